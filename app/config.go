@@ -1,13 +1,19 @@
 package main
 
 import (
+	accountingUC "github.com/QuickAmethyst/monosvc/module/accounting/usecase"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/grace"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/http"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/httpserver"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
 	"github.com/go-redis/redis/v9"
+	"time"
 )
 
+// attachmentURLSecretPlaceholder is the AttachmentURLSecret config.yml used to ship with.
+const attachmentURLSecretPlaceholder = "change-me"
+
 type Config struct {
 	Development        bool
 	Grace              grace.Options
@@ -17,4 +23,10 @@ type Config struct {
 	AccountingDatabase sql.PostgresSQLOptions
 	HttpServer         httpserver.Options
 	HttpCors           http.CorsOptions
+	Storage            storage.Options
+	// AttachmentURLSecret signs attachment download links, the server does not start without one.
+	AttachmentURLSecret string
+	// AmortizationInterval is how often due amortization entries are posted, zero turns the scheduled run off.
+	AmortizationInterval time.Duration
+	StandardAuditFile    accountingUC.StandardAuditFileOptions
 }
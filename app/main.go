@@ -8,6 +8,7 @@ import (
 	inventoryUC "github.com/QuickAmethyst/monosvc/module/inventory/usecase"
 	sdkAuth "github.com/QuickAmethyst/monosvc/stdlibgo/auth"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
 	"github.com/go-redis/redis/v9"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	sdkLogger "github.com/QuickAmethyst/monosvc/stdlibgo/logger"
 	"go.uber.org/zap"
 	"log"
+	"os"
 	"syscall"
 )
 
@@ -41,6 +43,7 @@ var (
 	accountingSqlClient sql.PostgresSQL
 	resolver            graph.Resolver
 	auth                sdkAuth.Auth
+	attachmentStorage   storage.Storage
 )
 
 func initConf() {
@@ -87,6 +90,12 @@ func initRedis() {
 	redisClient = redis.NewUniversalClient(&conf.Redis)
 }
 
+func initStorage() {
+	if attachmentStorage, err = storage.New(conf.Storage); err != nil {
+		logger.Fatal(err.Error())
+	}
+}
+
 func initResolver() {
 	accountSQLRepo := accountSql.New(&accountSql.Options{
 		MasterDB: accountSqlClient.Master(),
@@ -100,12 +109,6 @@ func initResolver() {
 		Logger:   logger,
 	})
 
-	accountingSQLRepo := accountingSql.New(&accountingSql.Options{
-		MasterDB: accountingSqlClient.Master(),
-		SlaveDB:  accountingSqlClient.Slave(),
-		Logger:   logger,
-	})
-
 	auth, err = sdkAuth.New(&sdkAuth.Options{
 		Redis:                redisClient,
 		PublicKeyPath:        "etc/rsa/public.pem",
@@ -118,17 +121,33 @@ func initResolver() {
 		logger.Fatal(err.Error())
 	}
 
+	accountUsecase := accountUC.New(&accountUC.Options{AccountSQL: accountSQLRepo, Auth: auth})
+
+	accountingSQLRepo := accountingSql.New(&accountingSql.Options{
+		MasterDB:   accountingSqlClient.Master(),
+		SlaveDB:    accountingSqlClient.Slave(),
+		Logger:     logger,
+		Permission: accountUsecase,
+	})
+
 	resolver = graph.Resolver{
-		Logger:            logger,
-		InventoryUsecase:  inventoryUC.New(&inventoryUC.Options{InventorySQL: inventorySQLRepo}),
-		AccountUsecase:    accountUC.New(&accountUC.Options{AccountSQL: accountSQLRepo, Auth: auth}),
-		AccountingUsecase: accountingUC.New(&accountingUC.Options{AccountingSQL: accountingSQLRepo}),
+		Logger:           logger,
+		InventoryUsecase: inventoryUC.New(&inventoryUC.Options{InventorySQL: inventorySQLRepo}),
+		AccountUsecase:   accountUsecase,
+		AccountingUsecase: accountingUC.New(&accountingUC.Options{
+			AccountingSQL: accountingSQLRepo,
+			Storage:       attachmentStorage,
+			Signer:        storage.NewSigner(conf.AttachmentURLSecret),
+
+			StandardAuditFile: conf.StandardAuditFile,
+		}),
 	}
 }
 
 func initGraph() {
 	c := generated.Config{Resolvers: &resolver}
-	c.Directives.Authenticated = sdkGraphql.AuthenticatedDirective(auth)
+	c.Directives.Authenticated = resolver.CompanyDirective(sdkGraphql.AuthenticatedDirective(auth))
+	c.Directives.CompanyAgnostic = resolver.CompanyAgnosticDirective
 
 	graphES = generated.NewExecutableSchema(c)
 	rest = http.New(http.Options{Cors: &conf.HttpCors})
@@ -139,6 +158,36 @@ func initGraph() {
 	}
 
 	rest.Handle(http.MethodPost, "/graphql/query", graphqlH)
+	rest.Handle(http.MethodGet, accountingUC.AttachmentDownloadPath+":id", resolver.AttachmentDownloadHandler)
+	rest.Handle(http.MethodGet, accountingUC.StandardAuditFileDownloadPath, resolver.StandardAuditFileDownloadHandler(auth))
+}
+
+// runAmortization posts the due amortization entries of every company every AmortizationInterval until ctx is done.
+func runAmortization(ctx context.Context) {
+	if conf.AmortizationInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(conf.AmortizationInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := resolver.AccountingUsecase.PostDueAmortizationEntries(ctx, time.Now()); err != nil {
+			logger.Error(err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// commands run instead of the server when the first argument names one.
+var commands = map[string]func(args []string) error{
+	standardAuditFileCommand: runStandardAuditFileCommand,
+	verifyLedgerCommand:      runVerifyLedgerCommand,
 }
 
 func init() {
@@ -146,21 +195,41 @@ func init() {
 	initConf()
 	initRedis()
 	initDB()
+	initStorage()
 	initResolver()
 	initGraph()
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				logger.Fatal(err.Error())
+			}
+
+			return
+		}
+	}
+
+	// a signed link is all that protects an attachment download, so a guessable secret exposes every attachment
+	if conf.AttachmentURLSecret == "" || conf.AttachmentURLSecret == attachmentURLSecretPlaceholder {
+		logger.Fatal("AttachmentURLSecret must be set to a secret value")
+	}
+
 	server := httpserver.New(conf.HttpServer, rest.Handler(), stdLog.Writer())
 	grace, err := sdkGrace.New(logger, conf.Grace)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	defer func() {
+		cancel()
 		grace.Stop()
 	}()
 
+	go runAmortization(ctx)
+
 	go func() {
 		grace.ListenForUpgrade(syscall.SIGHUP)
 	}()
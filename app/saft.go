@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	"io"
+	"os"
+	"time"
+)
+
+// standardAuditFileCommand is the subcommand that exports a SAF-T file without going through the HTTP API, e.g.
+//
+//	app saft -company 1 -from 2024-01-01 -to 2024-12-31 -out saft.xml
+//
+// The file is validated against StandardAuditFile.schemaPath of config.yml like the ones served over HTTP.
+const standardAuditFileCommand = "saft"
+
+func runStandardAuditFileCommand(args []string) (err error) {
+	var (
+		companyID int64
+		from, to  string
+		out       string
+	)
+
+	flags := flag.NewFlagSet(standardAuditFileCommand, flag.ContinueOnError)
+	flags.Int64Var(&companyID, "company", sql.DefaultCompanyID, "id of the company to export")
+	flags.StringVar(&from, "from", "", "first date of the export, YYYY-MM-DD")
+	flags.StringVar(&to, "to", "", "last date of the export, YYYY-MM-DD")
+	flags.StringVar(&out, "out", "", "file to write, standard output when empty")
+
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return fmt.Errorf("-from must be a date formatted YYYY-MM-DD: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return fmt.Errorf("-to must be a date formatted YYYY-MM-DD: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+		w = file
+	}
+
+	ctx := appcontext.SetCompanyID(context.Background(), companyID)
+	return resolver.AccountingUsecase.ExportStandardAuditFile(ctx, startDate, endDate, w)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/graph/model"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	"os"
+)
+
+// verifyLedgerCommand is the subcommand that runs the ledger integrity checks, e.g.
+//
+//	app verify-ledger -company 1
+//
+// It writes the report of every company checked to standard output as JSON and fails when any of them has issues,
+// so it can be run on a schedule.
+const verifyLedgerCommand = "verify-ledger"
+
+func runVerifyLedgerCommand(args []string) (err error) {
+	var (
+		companyID int64
+		companies []domain.Company
+		issues    int
+	)
+
+	flags := flag.NewFlagSet(verifyLedgerCommand, flag.ContinueOnError)
+	flags.Int64Var(&companyID, "company", 0, "id of the company to check, every company when zero")
+
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	if companyID != 0 {
+		companies = []domain.Company{{ID: companyID}}
+	} else if companies, err = resolver.AccountingUsecase.GetAllCompanies(ctx, sql.CompanyStatement{}); err != nil {
+		return
+	}
+
+	reports := make([]*model.LedgerVerification, len(companies))
+	for i, company := range companies {
+		verification, err := resolver.AccountingUsecase.VerifyLedger(appcontext.SetCompanyID(ctx, company.ID))
+		if err != nil {
+			return err
+		}
+
+		reports[i] = model.NewLedgerVerification(verification)
+		issues += len(verification.Issues)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(reports); err != nil {
+		return
+	}
+
+	if issues > 0 {
+		return fmt.Errorf("ledger verification found %d issues", issues)
+	}
+
+	return
+}
@@ -4,8 +4,15 @@ package graph
 // will be copied through when generating and any unknown code will be moved to the end.
 
 import (
+	"bytes"
 	"context"
+	goSql "database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/QuickAmethyst/monosvc/graph/generated"
 	"github.com/QuickAmethyst/monosvc/graph/model"
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
@@ -14,6 +21,7 @@ import (
 	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
 	sdkGraphql "github.com/QuickAmethyst/monosvc/stdlibgo/graphql"
 	qb "github.com/QuickAmethyst/monosvc/stdlibgo/querybuilder/sql"
+	"github.com/google/uuid"
 )
 
 // Group is the resolver for the group field.
@@ -28,13 +36,7 @@ func (r *accountResolver) Group(ctx context.Context, obj *model.Account) (*model
 		return nil, sdkGraphql.NewError(err, "Failed on get account group", libErr.GetCode(err))
 	}
 
-	return &model.AccountGroup{
-		ID:       accountGroup.ID,
-		Name:     accountGroup.Name,
-		ClassID:  accountGroup.ClassID,
-		ParentID: accountGroup.ParentID.Int64,
-		Inactive: accountGroup.Inactive,
-	}, nil
+	return model.NewAccountGroup(accountGroup), nil
 }
 
 // Balance is the resolver for the balance field.
@@ -52,12 +54,47 @@ func (r *accountResolver) Balance(ctx context.Context, obj *model.Account) (floa
 	return balance, nil
 }
 
+// NormalBalance is the resolver for the normalBalance field.
+func (r *accountResolver) NormalBalance(ctx context.Context, obj *model.Account) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	normalBalance, err := r.AccountingUsecase.GetAccountNormalBalanceByID(ctx, obj.ID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return "", sdkGraphql.NewError(err, "Failed on get account normal balance", libErr.GetCode(err))
+	}
+
+	return normalBalance, nil
+}
+
+// PresentationBalance is the resolver for the presentationBalance field.
+func (r *accountResolver) PresentationBalance(ctx context.Context, obj *model.Account) (float64, error) {
+	if obj == nil {
+		return 0, nil
+	}
+
+	balance, err := r.Balance(ctx, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	normalBalance, err := r.NormalBalance(ctx, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	return sql.PresentationAmount(balance, normalBalance), nil
+}
+
 // Type is the resolver for the type field.
 func (r *accountClassResolver) Type(ctx context.Context, obj *model.AccountClass) (*model.AccountClassType, error) {
 	accountClassType := r.AccountingUsecase.GetAccountClassTypeByID(ctx, obj.TypeID)
 	return &model.AccountClassType{
-		ID:   accountClassType.ID,
-		Name: accountClassType.Name,
+		ID:            accountClassType.ID,
+		Name:          accountClassType.Name,
+		NormalBalance: accountClassType.NormalBalance,
 	}, nil
 }
 
@@ -76,6 +113,20 @@ func (r *accountClassResolver) Balance(ctx context.Context, obj *model.AccountCl
 	return balance, nil
 }
 
+// PresentationBalance is the resolver for the presentationBalance field.
+func (r *accountClassResolver) PresentationBalance(ctx context.Context, obj *model.AccountClass) (float64, error) {
+	if obj == nil {
+		return 0, nil
+	}
+
+	balance, err := r.Balance(ctx, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	return sql.PresentationAmount(balance, sql.ClassTypeNormalBalance(obj.TypeID)), nil
+}
+
 // Accounts is the resolver for the accounts field.
 func (r *accountClassResolver) Accounts(ctx context.Context, obj *model.AccountClass) ([]*model.Account, error) {
 	if obj == nil {
@@ -90,12 +141,7 @@ func (r *accountClassResolver) Accounts(ctx context.Context, obj *model.AccountC
 
 	result := make([]*model.Account, len(accounts))
 	for i, account := range accounts {
-		result[i] = &model.Account{
-			ID:       account.ID,
-			Name:     account.Name,
-			GroupID:  account.GroupID,
-			Inactive: account.Inactive,
-		}
+		result[i] = model.NewAccount(account)
 	}
 
 	return result, nil
@@ -113,13 +159,7 @@ func (r *accountGroupResolver) Parent(ctx context.Context, obj *model.AccountGro
 		return nil, sdkGraphql.NewError(err, "Failed on get account group parent", libErr.GetCode(err))
 	}
 
-	return &model.AccountGroup{
-		ID:       accountGroup.ID,
-		Name:     accountGroup.Name,
-		ClassID:  accountGroup.ClassID,
-		ParentID: accountGroup.ParentID.Int64,
-		Inactive: accountGroup.Inactive,
-	}, nil
+	return model.NewAccountGroup(accountGroup), nil
 }
 
 // Class is the resolver for the class field.
@@ -134,17 +174,12 @@ func (r *accountGroupResolver) Class(ctx context.Context, obj *model.AccountGrou
 		return nil, sdkGraphql.NewError(err, "Failed on get account group class", libErr.GetCode(err))
 	}
 
-	return &model.AccountClass{
-		ID:       accountClass.ID,
-		Name:     accountClass.Name,
-		TypeID:   accountClass.TypeID,
-		Inactive: accountClass.Inactive,
-	}, nil
+	return model.NewAccountClass(accountClass), nil
 }
 
 // Child is the resolver for the child field.
 func (r *accountGroupResolver) Child(ctx context.Context, obj *model.AccountGroup) ([]*model.AccountGroup, error) {
-	accountGroups, err := r.AccountingUsecase.GetAllTopLevelAccountGroup(ctx, sql.AccountGroupStatement{
+	accountGroups, err := r.AccountingUsecase.GetAllAccountGroups(ctx, sql.AccountGroupStatement{
 		ParentID: obj.ID,
 	})
 
@@ -155,18 +190,161 @@ func (r *accountGroupResolver) Child(ctx context.Context, obj *model.AccountGrou
 
 	result := make([]*model.AccountGroup, len(accountGroups))
 	for i, accountGroup := range accountGroups {
-		result[i] = &model.AccountGroup{
-			ID:       accountGroup.ID,
-			Name:     accountGroup.Name,
-			ClassID:  accountGroup.ClassID,
-			ParentID: accountGroup.ParentID.Int64,
-			Inactive: false,
-		}
+		result[i] = model.NewAccountGroup(accountGroup)
+	}
+
+	return result, nil
+}
+
+// PresentationBalance is the resolver for the presentationBalance field.
+func (r *accountTreeAccountResolver) PresentationBalance(ctx context.Context, obj *model.AccountTreeAccount) (*float64, error) {
+	if obj.Balance == nil {
+		return nil, nil
+	}
+
+	balance := sql.PresentationAmount(*obj.Balance, obj.NormalBalance)
+	return &balance, nil
+}
+
+// PresentationBalance is the resolver for the presentationBalance field.
+func (r *accountTreeClassResolver) PresentationBalance(ctx context.Context, obj *model.AccountTreeClass) (*float64, error) {
+	if obj.Balance == nil {
+		return nil, nil
+	}
+
+	balance := sql.PresentationAmount(*obj.Balance, obj.NormalBalance)
+	return &balance, nil
+}
+
+// PresentationBalance is the resolver for the presentationBalance field.
+func (r *accountTreeGroupResolver) PresentationBalance(ctx context.Context, obj *model.AccountTreeGroup) (*float64, error) {
+	if obj.Balance == nil {
+		return nil, nil
+	}
+
+	balance := sql.PresentationAmount(*obj.Balance, obj.NormalBalance)
+	return &balance, nil
+}
+
+// Journal is the resolver for the journal field.
+func (r *amortizationEntryResolver) Journal(ctx context.Context, obj *model.AmortizationEntry) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
+}
+
+// SourceAccount is the resolver for the sourceAccount field.
+func (r *amortizationScheduleResolver) SourceAccount(ctx context.Context, obj *model.AmortizationSchedule) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.SourceAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get source account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// TargetAccount is the resolver for the targetAccount field.
+func (r *amortizationScheduleResolver) TargetAccount(ctx context.Context, obj *model.AmortizationSchedule) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.TargetAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get target account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// Entries is the resolver for the entries field.
+func (r *amortizationScheduleResolver) Entries(ctx context.Context, obj *model.AmortizationSchedule) ([]*model.AmortizationEntry, error) {
+	entries, err := r.AccountingUsecase.GetAllAmortizationEntries(ctx, sql.AmortizationEntryStatement{ScheduleID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get amortization entries", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AmortizationEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = model.NewAmortizationEntry(entry)
 	}
 
 	return result, nil
 }
 
+// Plan is the resolver for the plan field.
+func (r *amortizationScheduleResolver) Plan(ctx context.Context, obj *model.AmortizationSchedule) ([]*model.AmortizationPlanLine, error) {
+	plan := r.AccountingUsecase.GetAmortizationPlan(domain.AmortizationSchedule{
+		TotalAmount: obj.TotalAmount,
+		StartDate:   obj.StartDate,
+		EndDate:     obj.EndDate,
+		MethodID:    obj.MethodID,
+	})
+
+	result := make([]*model.AmortizationPlanLine, len(plan))
+	for i, line := range plan {
+		result[i] = &model.AmortizationPlanLine{PeriodStart: line.PeriodStart, PeriodEnd: line.PeriodEnd, Amount: line.Amount}
+	}
+
+	return result, nil
+}
+
+// AccountClass is the resolver for the accountClass field.
+func (r *approvalRuleResolver) AccountClass(ctx context.Context, obj *model.ApprovalRule) (*model.AccountClass, error) {
+	if obj == nil || obj.AccountClassID == nil {
+		return nil, nil
+	}
+
+	accountClass, err := r.AccountingUsecase.GetAccountClassByID(ctx, *obj.AccountClassID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get approval rule account class", libErr.GetCode(err))
+	}
+
+	return model.NewAccountClass(accountClass), nil
+}
+
+// AssetAccount is the resolver for the assetAccount field.
+func (r *assetCategoryResolver) AssetAccount(ctx context.Context, obj *model.AssetCategory) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AssetAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get asset account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// AccumulatedDepreciationAccount is the resolver for the accumulatedDepreciationAccount field.
+func (r *assetCategoryResolver) AccumulatedDepreciationAccount(ctx context.Context, obj *model.AssetCategory) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccumulatedDepreciationAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get accumulated depreciation account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// DepreciationExpenseAccount is the resolver for the depreciationExpenseAccount field.
+func (r *assetCategoryResolver) DepreciationExpenseAccount(ctx context.Context, obj *model.AssetCategory) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.DepreciationExpenseAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get depreciation expense account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
 // Account is the resolver for the account field.
 func (r *bankAccountResolver) Account(ctx context.Context, obj *model.BankAccount) (*model.Account, error) {
 	if obj == nil || obj.AccountID <= 0 {
@@ -179,12 +357,7 @@ func (r *bankAccountResolver) Account(ctx context.Context, obj *model.BankAccoun
 		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
 	}
 
-	return &model.Account{
-		ID:       account.ID,
-		Name:     account.Name,
-		GroupID:  account.GroupID,
-		Inactive: account.Inactive,
-	}, nil
+	return model.NewAccount(account), nil
 }
 
 // Type is the resolver for the type field.
@@ -206,8 +379,59 @@ func (r *bankAccountResolver) Type(ctx context.Context, obj *model.BankAccount)
 	return nil, nil
 }
 
+// Attachments is the resolver for the attachments field.
+func (r *bankTransactionResolver) Attachments(ctx context.Context, obj *model.BankTransaction) ([]*model.Attachment, error) {
+	return r.getAllAttachments(ctx, sql.AttachmentStatement{BankTransactionID: obj.ID})
+}
+
+// Lines is the resolver for the lines field.
+func (r *budgetResolver) Lines(ctx context.Context, obj *model.Budget, dimension *string) ([]*model.BudgetLine, error) {
+	stmt := sql.BudgetLineStatement{BudgetID: obj.ID}
+	if dimension != nil {
+		stmt.Dimension = *dimension
+	}
+
+	lines, err := r.AccountingUsecase.GetAllBudgetLines(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get budget lines", libErr.GetCode(err))
+	}
+
+	result := make([]*model.BudgetLine, len(lines))
+	for i, line := range lines {
+		result[i] = model.NewBudgetLine(line)
+	}
+
+	return result, nil
+}
+
+// PresentationBudget is the resolver for the presentationBudget field.
+func (r *budgetVsActualRowResolver) PresentationBudget(ctx context.Context, obj *model.BudgetVsActualRow) (float64, error) {
+	return sql.PresentationAmount(obj.Budget, obj.NormalBalance), nil
+}
+
+// PresentationActual is the resolver for the presentationActual field.
+func (r *budgetVsActualRowResolver) PresentationActual(ctx context.Context, obj *model.BudgetVsActualRow) (float64, error) {
+	return sql.PresentationAmount(obj.Actual, obj.NormalBalance), nil
+}
+
+// PresentationVariance is the resolver for the presentationVariance field.
+func (r *budgetVsActualRowResolver) PresentationVariance(ctx context.Context, obj *model.BudgetVsActualRow) (float64, error) {
+	return sql.PresentationAmount(obj.Variance, obj.NormalBalance), nil
+}
+
+// PresentationVariancePercent is the resolver for the presentationVariancePercent field.
+func (r *budgetVsActualRowResolver) PresentationVariancePercent(ctx context.Context, obj *model.BudgetVsActualRow) (*float64, error) {
+	if obj.VariancePercent == nil {
+		return nil, nil
+	}
+
+	variancePercent := sql.PresentationAmount(*obj.VariancePercent, obj.NormalBalance)
+	return &variancePercent, nil
+}
+
 // Account is the resolver for the account field.
-func (r *generalLedgerPreferenceResolver) Account(ctx context.Context, obj *model.GeneralLedgerPreference) (*model.Account, error) {
+func (r *closingJournalLineResolver) Account(ctx context.Context, obj *model.ClosingJournalLine) (*model.Account, error) {
 	if obj == nil || obj.AccountID == 0 {
 		return nil, nil
 	}
@@ -218,594 +442,3064 @@ func (r *generalLedgerPreferenceResolver) Account(ctx context.Context, obj *mode
 		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
 	}
 
-	return &model.Account{
-		ID:       account.ID,
-		Name:     account.Name,
-		GroupID:  account.GroupID,
-		Inactive: account.Inactive,
-	}, nil
+	return model.NewAccount(account), nil
 }
 
-// StoreAccountClass is the resolver for the storeAccountClass field.
-func (r *mutationResolver) StoreAccountClass(ctx context.Context, input model.WriteAccountClassInput) (*model.AccountClass, error) {
-	accountClass := input.Domain()
+// PresentationElimination is the resolver for the presentationElimination field.
+func (r *consolidationRowResolver) PresentationElimination(ctx context.Context, obj *model.ConsolidationRow) (float64, error) {
+	return sql.PresentationAmount(obj.Elimination, obj.NormalBalance), nil
+}
 
-	if err := r.Resolver.AccountingUsecase.StoreAccountClass(ctx, &accountClass); err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on create account class", libErr.GetCode(err))
-	}
+// PresentationAmount is the resolver for the presentationAmount field.
+func (r *consolidationRowResolver) PresentationAmount(ctx context.Context, obj *model.ConsolidationRow) (float64, error) {
+	return sql.PresentationAmount(obj.Amount, obj.NormalBalance), nil
+}
 
-	return &model.AccountClass{
-		ID:       accountClass.ID,
-		Name:     accountClass.Name,
-		TypeID:   accountClass.TypeID,
-		Inactive: accountClass.Inactive,
-	}, nil
+// Invoice is the resolver for the invoice field.
+func (r *creditNoteResolver) Invoice(ctx context.Context, obj *model.CreditNote) (*model.SalesInvoice, error) {
+	return r.Query().SalesInvoice(ctx, int(obj.InvoiceID))
 }
 
-// UpdateAccountClassByID is the resolver for the updateAccountClassByID field.
-func (r *mutationResolver) UpdateAccountClassByID(ctx context.Context, id int, input model.WriteAccountClassInput) (*model.AccountClass, error) {
-	accountClass := input.Domain()
+// Journal is the resolver for the journal field.
+func (r *creditNoteResolver) Journal(ctx context.Context, obj *model.CreditNote) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
 
-	if err := r.Resolver.AccountingUsecase.UpdateAccountClassByID(ctx, int64(id), &accountClass); err != nil {
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on update account class", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return &model.AccountClass{
-		ID:       int64(id),
-		Name:     accountClass.Name,
-		TypeID:   accountClass.TypeID,
-		Inactive: accountClass.Inactive,
-	}, nil
+	return model.NewJournal(journal), nil
 }
 
-// DeleteAccountClassByID is the resolver for the deleteAccountClassByID field.
-func (r *mutationResolver) DeleteAccountClassByID(ctx context.Context, id int) (int, error) {
-	if err := r.AccountingUsecase.DeleteAccountClassByID(ctx, int64(id)); err != nil {
-		r.Logger.Error(err.Error())
-		return id, sdkGraphql.NewError(err, "Failed to delete account class", libErr.GetCode(err))
-	}
-
-	return id, nil
+// Customer is the resolver for the customer field.
+func (r *customerReceiptResolver) Customer(ctx context.Context, obj *model.CustomerReceipt) (*model.Customer, error) {
+	return r.Query().Customer(ctx, int(obj.CustomerID))
 }
 
-// StoreAccountGroup is the resolver for the storeAccountGroup field.
-func (r *mutationResolver) StoreAccountGroup(ctx context.Context, input model.WriteAccountGroupInput) (*model.AccountGroup, error) {
-	accountGroup, err := input.Domain()
+// Allocations is the resolver for the allocations field.
+func (r *customerReceiptResolver) Allocations(ctx context.Context, obj *model.CustomerReceipt) ([]*model.ReceiptAllocation, error) {
+	allocations, err := r.AccountingUsecase.GetAllReceiptAllocations(ctx, sql.ReceiptAllocationStatement{ReceiptID: obj.ID})
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed to create account group", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get receipt allocations", libErr.GetCode(err))
 	}
 
-	if err = r.Resolver.AccountingUsecase.StoreAccountGroup(ctx, &accountGroup); err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed to create account group", libErr.GetCode(err))
+	result := make([]*model.ReceiptAllocation, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = model.NewReceiptAllocation(allocation)
 	}
 
-	return &model.AccountGroup{
-		ID:       accountGroup.ID,
-		Name:     accountGroup.Name,
-		ClassID:  accountGroup.ClassID,
-		ParentID: accountGroup.ParentID.Int64,
-		Inactive: accountGroup.Inactive,
-	}, nil
+	return result, nil
 }
 
-// UpdateAccountGroupByID is the resolver for the updateAccountGroupByID field.
-func (r *mutationResolver) UpdateAccountGroupByID(ctx context.Context, id int, input model.WriteAccountGroupInput) (*model.AccountGroup, error) {
-	accountGroup, err := input.Domain()
+// Journal is the resolver for the journal field.
+func (r *customerReceiptResolver) Journal(ctx context.Context, obj *model.CustomerReceipt) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
 	if err != nil {
-		return nil, sdkGraphql.NewError(err, "Failed to update account group", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	if err := r.Resolver.AccountingUsecase.UpdateAccountGroupByID(ctx, int64(id), &accountGroup); err != nil {
-		return nil, sdkGraphql.NewError(err, "Failed to update account group", libErr.GetCode(err))
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return &model.AccountGroup{
-		ID:       int64(id),
-		Name:     accountGroup.Name,
-		ClassID:  accountGroup.ClassID,
-		ParentID: accountGroup.ParentID.Int64,
-		Inactive: accountGroup.Inactive,
-	}, nil
+	return model.NewJournal(journal), nil
 }
 
-// DeleteAccountGroupByID is the resolver for the deleteAccountGroupByID field.
-func (r *mutationResolver) DeleteAccountGroupByID(ctx context.Context, id int) (int, error) {
-	if err := r.AccountingUsecase.DeleteAccountGroupByID(ctx, int64(id)); err != nil {
+// Bill is the resolver for the bill field.
+func (r *debitNoteResolver) Bill(ctx context.Context, obj *model.DebitNote) (*model.PurchaseBill, error) {
+	return r.Query().PurchaseBill(ctx, int(obj.BillID))
+}
+
+// Journal is the resolver for the journal field.
+func (r *debitNoteResolver) Journal(ctx context.Context, obj *model.DebitNote) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return id, sdkGraphql.NewError(err, "Failed to delete account group", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return id, nil
+	return model.NewJournal(journal), nil
 }
 
-// StoreAccount is the resolver for the storeAccount field.
-func (r *mutationResolver) StoreAccount(ctx context.Context, input model.WriteAccountInput) (*model.Account, error) {
-	account := input.Domain()
+// Asset is the resolver for the asset field.
+func (r *depreciationEntryResolver) Asset(ctx context.Context, obj *model.DepreciationEntry) (*model.FixedAsset, error) {
+	return r.Query().FixedAsset(ctx, int(obj.AssetID))
+}
 
-	if err := r.AccountingUsecase.StoreAccount(ctx, &account); err != nil {
+// Entries is the resolver for the entries field.
+func (r *depreciationRunResolver) Entries(ctx context.Context, obj *model.DepreciationRun) ([]*model.DepreciationEntry, error) {
+	entries, err := r.AccountingUsecase.GetAllDepreciationEntries(ctx, sql.DepreciationEntryStatement{RunID: obj.ID})
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on store account", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get depreciation entries", libErr.GetCode(err))
 	}
 
-	return &model.Account{
-		ID:       account.ID,
-		Name:     account.Name,
-		GroupID:  account.GroupID,
-		Inactive: account.Inactive,
-	}, nil
+	result := make([]*model.DepreciationEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = model.NewDepreciationEntry(entry)
+	}
+
+	return result, nil
 }
 
-// UpdateAccountByID is the resolver for the updateAccountByID field.
-func (r *mutationResolver) UpdateAccountByID(ctx context.Context, id int, input model.WriteAccountInput) (*model.Account, error) {
-	account := input.Domain()
+// Journal is the resolver for the journal field.
+func (r *depreciationRunResolver) Journal(ctx context.Context, obj *model.DepreciationRun) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
 
-	if err := r.AccountingUsecase.UpdateAccountByID(ctx, int64(id), &account); err != nil {
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on update account by id", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return &model.Account{
-		ID:       int64(id),
-		Name:     account.Name,
-		GroupID:  account.GroupID,
-		Inactive: account.Inactive,
-	}, nil
+	return model.NewJournal(journal), nil
 }
 
-// DeleteAccountByID is the resolver for the deleteAccountByID field.
-func (r *mutationResolver) DeleteAccountByID(ctx context.Context, id int) (int, error) {
-	if err := r.AccountingUsecase.DeleteAccountByID(ctx, int64(id)); err != nil {
+// Histories is the resolver for the histories field.
+func (r *fiscalPeriodResolver) Histories(ctx context.Context, obj *model.FiscalPeriod) ([]*model.FiscalPeriodHistory, error) {
+	histories, err := r.AccountingUsecase.GetAllFiscalPeriodHistoriesByPeriodID(ctx, obj.ID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return id, sdkGraphql.NewError(err, "Failed on delete account by id", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal period histories", libErr.GetCode(err))
 	}
 
-	return id, nil
-}
-
-// StoreTransaction is the resolver for the storeTransaction field.
-func (r *mutationResolver) StoreTransaction(ctx context.Context, input model.WriteTransactionInput) (*model.Journal, error) {
-	userID := appcontext.GetUserID(ctx)
+	result := make([]*model.FiscalPeriodHistory, len(histories))
+	for i, history := range histories {
+		result[i] = &model.FiscalPeriodHistory{
+			ID:           history.ID,
+			FromStatusID: history.FromStatusID,
+			ToStatusID:   history.ToStatusID,
+			CreatedBy:    history.CreatedBy.String(),
+			CreatedAt:    history.CreatedAt,
+		}
 
-	transactions := make([]sql.TransactionRow, len(input.Data))
-	for i, item := range input.Data {
-		transactions[i] = sql.TransactionRow{
-			AccountID: item.AccountID,
-			Amount:    item.Amount,
+		if history.Reason.Valid {
+			result[i].Reason = &history.Reason.String
 		}
 	}
 
-	journal, err := r.AccountingUsecase.StoreTransaction(ctx, userID, sql.Transaction{
-		Date: input.TransDate,
-		Memo: input.Memo,
-		Data: transactions,
-	})
+	return result, nil
+}
 
+// Periods is the resolver for the periods field.
+func (r *fiscalYearResolver) Periods(ctx context.Context, obj *model.FiscalYear) ([]*model.FiscalPeriod, error) {
+	periods, err := r.AccountingUsecase.GetAllFiscalPeriods(ctx, sql.FiscalPeriodStatement{FiscalYearID: obj.ID})
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on create transactions", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal periods", libErr.GetCode(err))
 	}
 
-	if journal == nil {
-		return nil, nil
+	result := make([]*model.FiscalPeriod, len(periods))
+	for i, period := range periods {
+		result[i] = model.NewFiscalPeriod(period)
 	}
 
-	return &model.Journal{
-		ID:        journal.ID.String(),
-		Amount:    journal.Amount,
-		TransDate: journal.TransDate,
-		CreatedAt: journal.CreatedAt,
-	}, nil
+	return result, nil
 }
 
-// UpdateGeneralLedgerPreferences is the resolver for the updateGeneralLedgerPreferences field.
-func (r *mutationResolver) UpdateGeneralLedgerPreferences(ctx context.Context, input []*model.WriteGeneralLedgerPreferenceInput) ([]*model.GeneralLedgerPreference, error) {
-	preferences := make([]domain.GeneralLedgerPreference, len(input))
+// Histories is the resolver for the histories field.
+func (r *fiscalYearResolver) Histories(ctx context.Context, obj *model.FiscalYear) ([]*model.FiscalYearHistory, error) {
+	histories, err := r.AccountingUsecase.GetAllFiscalYearHistoriesByFiscalYearID(ctx, obj.ID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal year histories", libErr.GetCode(err))
+	}
 
-	for i, o := range input {
-		d, err := o.Domain()
-		if err != nil {
-			r.Logger.Error(err.Error())
-			return nil, sdkGraphql.NewError(err, "Failed on update generate ledger preferences", libErr.GetCode(err))
+	result := make([]*model.FiscalYearHistory, len(histories))
+	for i, history := range histories {
+		result[i] = &model.FiscalYearHistory{
+			ID:        history.ID,
+			ActionID:  history.ActionID,
+			CreatedBy: history.CreatedBy.String(),
+			CreatedAt: history.CreatedAt,
 		}
 
-		preferences[i] = d
-	}
-
-	if err := r.AccountingUsecase.UpdateGeneralLedgerPreferences(ctx, preferences); err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on update general ledger preferences", libErr.GetCode(err))
-	}
+		if history.JournalID.Valid {
+			journalID := history.JournalID.UUID.String()
+			result[i].JournalID = &journalID
+		}
 
-	result := make([]*model.GeneralLedgerPreference, len(preferences))
-	for i, preference := range preferences {
-		result[i] = &model.GeneralLedgerPreference{
-			ID:        preference.ID,
-			AccountID: preference.AccountID.Int64,
+		if history.Reason.Valid {
+			result[i].Reason = &history.Reason.String
 		}
 	}
 
 	return result, nil
 }
 
-// StoreBankAccount is the resolver for the storeBankAccount field.
-func (r *mutationResolver) StoreBankAccount(ctx context.Context, input model.WriteBankAccountInput) (*model.BankAccount, error) {
-	bankAccount, err := input.Domain()
+// Category is the resolver for the category field.
+func (r *fixedAssetResolver) Category(ctx context.Context, obj *model.FixedAsset) (*model.AssetCategory, error) {
+	category, err := r.AccountingUsecase.GetAssetCategoryByID(ctx, obj.CategoryID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on store bank account", libErr.GetCode(err))
-	}
-
-	if err = r.AccountingUsecase.StoreBankAccount(ctx, &bankAccount); err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on store bank account", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get asset category", libErr.GetCode(err))
 	}
 
-	return &model.BankAccount{
-		ID:         bankAccount.ID,
-		AccountID:  bankAccount.AccountID,
-		TypeID:     bankAccount.TypeID,
-		BankNumber: bankAccount.BankNumber.String,
-		Inactive:   bankAccount.Inactive,
-	}, nil
+	return model.NewAssetCategory(category), nil
 }
 
-// UpdateBankAccountByID is the resolver for the updateBankAccountByID field.
-func (r *mutationResolver) UpdateBankAccountByID(ctx context.Context, id int, input model.WriteBankAccountInput) (*model.BankAccount, error) {
-	bankAccount, err := input.Domain()
+// DisposalJournal is the resolver for the disposalJournal field.
+func (r *fixedAssetResolver) DisposalJournal(ctx context.Context, obj *model.FixedAsset) (*model.Journal, error) {
+	if obj.DisposalJournalID == nil {
+		return nil, nil
+	}
+
+	journalID, err := uuid.Parse(*obj.DisposalJournalID)
 	if err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on update bank account by id", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	if err = r.AccountingUsecase.UpdateBankAccountByID(ctx, int64(id), &bankAccount); err != nil {
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on update bank account by id", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return &model.BankAccount{
-		ID:         bankAccount.ID,
-		AccountID:  bankAccount.AccountID,
-		TypeID:     bankAccount.TypeID,
-		BankNumber: bankAccount.BankNumber.String,
-		Inactive:   bankAccount.Inactive,
-	}, nil
+	return model.NewJournal(journal), nil
 }
 
-// StoreBankDepositTransaction is the resolver for the storeBankDepositTransaction field.
-func (r *mutationResolver) StoreBankDepositTransaction(ctx context.Context, input model.WriteBankTransactionInput) (*model.BankTransaction, error) {
-	userID := appcontext.GetUserID(ctx)
-
-	transactions := make([]sql.TransactionRow, len(input.Data))
-	for i, item := range input.Data {
-		transactions[i] = sql.TransactionRow{
-			AccountID: item.AccountID,
-			Amount:    item.Amount,
-		}
+// Journal is the resolver for the journal field.
+func (r *generalLedgerResolver) Journal(ctx context.Context, obj *model.GeneralLedger) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	bankTransaction, err := r.AccountingUsecase.StoreBankDepositTransaction(ctx, userID, sql.BankTransaction{
-		BankAccountID: input.BankAccountID,
-		Transaction: sql.Transaction{
-			Date: input.TransDate,
-			Memo: input.Memo,
-			Data: transactions,
-		},
-	})
-
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, libErr.RootCause(err).Error(), libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
 	}
 
-	return &model.BankTransaction{
-		ID:            bankTransaction.ID,
-		JournalID:     bankTransaction.JournalID.String(),
-		BankAccountID: bankTransaction.BankAccountID,
-		Amount:        bankTransaction.Amount,
-		CreatedAt:     bankTransaction.CreatedAt,
-	}, nil
+	return model.NewJournal(journal), nil
 }
 
-// StoreFiscalYear is the resolver for the storeFiscalYear field.
-func (r *mutationResolver) StoreFiscalYear(ctx context.Context, input model.WriteFiscalYearInput) (*model.FiscalYear, error) {
-	fiscalYear := input.Domain()
-
-	if err := r.AccountingUsecase.StoreFiscalYear(ctx, &fiscalYear); err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on store fiscal year", libErr.GetCode(err))
+// Account is the resolver for the account field.
+func (r *generalLedgerResolver) Account(ctx context.Context, obj *model.GeneralLedger) (*model.Account, error) {
+	if obj == nil || obj.AccountID == 0 {
+		return nil, nil
 	}
 
-	return &model.FiscalYear{
-		ID:        fiscalYear.ID,
-		StartDate: fiscalYear.StartDate,
-		EndDate:   fiscalYear.EndDate,
-		Closed:    fiscalYear.Closed,
-	}, nil
-}
-
-// CloseFiscalYear is the resolver for the closeFiscalYear field.
-func (r *mutationResolver) CloseFiscalYear(ctx context.Context, id int) (int, error) {
-	userID := appcontext.GetUserID(ctx)
-	if err := r.AccountingUsecase.CloseFiscalYear(ctx, int64(id), userID); err != nil {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccountID)
+	if err != nil {
 		r.Logger.Error(err.Error())
-		return id, sdkGraphql.NewError(err, "Failed on close fiscal year", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
 	}
 
-	return id, nil
+	return model.NewAccount(account), nil
 }
 
-// AccountClasses is the resolver for the accountClasses field.
-func (r *queryResolver) AccountClasses(ctx context.Context) ([]*model.AccountClass, error) {
-	accountClasses, err := r.AccountingUsecase.GetAllAccountClasses(ctx, sql.AccountClassStatement{})
+// Attachments is the resolver for the attachments field.
+func (r *generalLedgerResolver) Attachments(ctx context.Context, obj *model.GeneralLedger) ([]*model.Attachment, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
 	if err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get account classes", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	result := make([]*model.AccountClass, len(accountClasses))
-	for i, accountClass := range accountClasses {
-		result[i] = &model.AccountClass{
-			ID:       accountClass.ID,
-			Name:     accountClass.Name,
-			TypeID:   accountClass.TypeID,
-			Inactive: accountClass.Inactive,
+	return r.getAllAttachments(ctx, sql.AttachmentStatement{JournalID: journalID})
+}
+
+// ClassTypes is the resolver for the classTypes field.
+func (r *generalLedgerPreferenceResolver) ClassTypes(ctx context.Context, obj *model.GeneralLedgerPreference) ([]*model.AccountClassType, error) {
+	result := make([]*model.AccountClassType, len(obj.ClassTypeIDs))
+	for i, classTypeID := range obj.ClassTypeIDs {
+		accountClassType := r.AccountingUsecase.GetAccountClassTypeByID(ctx, classTypeID)
+		result[i] = &model.AccountClassType{
+			ID:            accountClassType.ID,
+			Name:          accountClassType.Name,
+			NormalBalance: accountClassType.NormalBalance,
 		}
 	}
 
 	return result, nil
 }
 
-// AccountClass is the resolver for the accountClass field.
-func (r *queryResolver) AccountClass(ctx context.Context, input model.AccountClassInput) (*model.AccountClass, error) {
-	accountClass, err := r.AccountingUsecase.GetAccountClass(ctx, sql.AccountClassStatement{
-		ID: int64(input.ID),
-	})
+// Account is the resolver for the account field.
+func (r *generalLedgerPreferenceResolver) Account(ctx context.Context, obj *model.GeneralLedgerPreference) (*model.Account, error) {
+	if obj == nil || obj.AccountID == 0 {
+		return nil, nil
+	}
 
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccountID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get account class", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
 	}
 
-	return &model.AccountClass{
-		ID:       accountClass.ID,
-		Name:     accountClass.Name,
-		TypeID:   accountClass.TypeID,
-		Inactive: accountClass.Inactive,
-	}, nil
+	return model.NewAccount(account), nil
 }
 
-// AccountClassTypes is the resolver for the accountClassTypes field.
-func (r *queryResolver) AccountClassTypes(ctx context.Context) (*model.AccountClassTypesResult, error) {
-	result := make([]model.AccountClassType, 0)
-	classTypes := r.AccountingUsecase.GetAllAccountTypes(ctx)
-
-	for _, classType := range classTypes {
-		result = append(result, model.AccountClassType{
-			ID:   classType.ID,
-			Name: classType.Name,
-		})
+// Class is the resolver for the class field.
+func (r *groupAccountResolver) Class(ctx context.Context, obj *model.GroupAccount) (*model.AccountClass, error) {
+	// the group chart is laid on the account classes of the default company
+	accountClass, err := r.AccountingUsecase.GetAccountClassByID(appcontext.SetCompanyID(ctx, sql.DefaultCompanyID), obj.ClassID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get group account class", libErr.GetCode(err))
 	}
 
-	return &model.AccountClassTypesResult{Data: result}, nil
-}
-
-// AccountClassType is the resolver for the accountClassType field.
-func (r *queryResolver) AccountClassType(ctx context.Context, input model.AccountClassTypeInput) (*model.AccountClassType, error) {
-	classType := r.AccountingUsecase.GetAccountClassTypeByID(ctx, input.ID)
-	return &model.AccountClassType{
-		ID:   classType.ID,
-		Name: classType.Name,
-	}, nil
+	return model.NewAccountClass(accountClass), nil
 }
 
-// AccountGroups is the resolver for the accountGroups field.
-func (r *queryResolver) AccountGroups(ctx context.Context, input *model.AccountGroupInput) ([]*model.AccountGroup, error) {
-	var statement sql.AccountGroupStatement
-	if input != nil {
-		statement = sql.AccountGroupStatement{ParentIDIsNULL: input.ParentIDIsNULL}
+// FromCompany is the resolver for the fromCompany field.
+func (r *intercompanyTransactionResolver) FromCompany(ctx context.Context, obj *model.IntercompanyTransaction) (*model.Company, error) {
+	company, err := r.AccountingUsecase.GetCompanyByID(ctx, obj.FromCompanyID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get company", libErr.GetCode(err))
 	}
 
-	accountGroups, err := r.AccountingUsecase.GetAllAccountGroups(ctx, statement)
+	return model.NewCompany(company), nil
+}
 
+// ToCompany is the resolver for the toCompany field.
+func (r *intercompanyTransactionResolver) ToCompany(ctx context.Context, obj *model.IntercompanyTransaction) (*model.Company, error) {
+	company, err := r.AccountingUsecase.GetCompanyByID(ctx, obj.ToCompanyID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get account groups", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get company", libErr.GetCode(err))
 	}
 
-	result := make([]*model.AccountGroup, len(accountGroups))
-	for i, accountGroup := range accountGroups {
-		result[i] = &model.AccountGroup{
-			ID:       accountGroup.ID,
-			Name:     accountGroup.Name,
-			ClassID:  accountGroup.ClassID,
-			ParentID: accountGroup.ParentID.Int64,
-			Inactive: accountGroup.Inactive,
-		}
-	}
+	return model.NewCompany(company), nil
+}
 
-	return result, nil
+// FromJournal is the resolver for the fromJournal field.
+func (r *intercompanyTransactionResolver) FromJournal(ctx context.Context, obj *model.IntercompanyTransaction) (*model.Journal, error) {
+	return r.intercompanyJournal(ctx, obj.FromCompanyID, obj.FromJournalID)
 }
 
-// AccountGroup is the resolver for the accountGroup field.
-func (r *queryResolver) AccountGroup(ctx context.Context, input model.AccountGroupInput) (*model.AccountGroup, error) {
-	accountGroup, err := r.AccountingUsecase.GetAccountGroup(ctx, sql.AccountGroupStatement{
-		ID: int64(input.ID),
-	})
+// ToJournal is the resolver for the toJournal field.
+func (r *intercompanyTransactionResolver) ToJournal(ctx context.Context, obj *model.IntercompanyTransaction) (*model.Journal, error) {
+	return r.intercompanyJournal(ctx, obj.ToCompanyID, obj.ToJournalID)
+}
 
+// Attachments is the resolver for the attachments field.
+func (r *journalResolver) Attachments(ctx context.Context, obj *model.Journal) ([]*model.Attachment, error) {
+	journalID, err := uuid.Parse(obj.ID)
 	if err != nil {
-		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get account group", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	return &model.AccountGroup{
-		ID:       accountGroup.ID,
-		Name:     accountGroup.Name,
-		ParentID: accountGroup.ParentID.Int64,
-		ClassID:  accountGroup.ClassID,
-		Inactive: accountGroup.Inactive,
-	}, nil
+	return r.getAllAttachments(ctx, sql.AttachmentStatement{JournalID: journalID})
 }
 
-// Accounts is the resolver for the accounts field.
-func (r *queryResolver) Accounts(ctx context.Context, input *model.AccountInput) ([]*model.Account, error) {
-	var stmt sql.AccountStatement
-	if input != nil {
-		stmt.ID = input.ID
-		stmt.ClassType = input.ClassType
+// Versions is the resolver for the versions field.
+func (r *journalResolver) Versions(ctx context.Context, obj *model.Journal) ([]*model.JournalVersion, error) {
+	journalID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
 	}
 
-	accounts, err := r.AccountingUsecase.GetAllAccounts(ctx, stmt)
+	versions, err := r.AccountingUsecase.GetAllJournalVersions(ctx, journalID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get accounts", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal versions", libErr.GetCode(err))
 	}
 
-	var result = make([]*model.Account, len(accounts))
-	for i, account := range accounts {
-		result[i] = &model.Account{
-			ID:       account.ID,
-			Name:     account.Name,
-			GroupID:  account.GroupID,
-			Inactive: account.Inactive,
-		}
+	result := make([]*model.JournalVersion, len(versions))
+	for i, version := range versions {
+		result[i] = model.NewJournalVersion(version)
 	}
 
 	return result, nil
 }
 
-// Account is the resolver for the account field.
-func (r *queryResolver) Account(ctx context.Context, input model.AccountInput) (*model.Account, error) {
-	account, err := r.AccountingUsecase.GetAccount(ctx, sql.AccountStatement{
-		ID: input.ID,
-	})
+// RequiredApprovals is the resolver for the requiredApprovals field.
+func (r *journalDraftResolver) RequiredApprovals(ctx context.Context, obj *model.JournalDraft) (int, error) {
+	draftID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return 0, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
 
+	requiredApprovals, err := r.AccountingUsecase.GetRequiredApprovalsByDraftID(ctx, draftID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get account group", libErr.GetCode(err))
+		return 0, sdkGraphql.NewError(err, "Failed on get required approvals", libErr.GetCode(err))
 	}
 
-	return &model.Account{
-		ID:       account.ID,
-		Name:     account.Name,
-		GroupID:  account.GroupID,
-		Inactive: account.Inactive,
-	}, nil
+	return int(requiredApprovals), nil
 }
 
-// GeneralLedgerPreferences is the resolver for the generalLedgerPreferences field.
-func (r *queryResolver) GeneralLedgerPreferences(ctx context.Context, input *model.GeneralLedgerPreferenceInput) ([]*model.GeneralLedgerPreference, error) {
-	var statement sql.GeneralLedgerPreferenceStatement
-	if input != nil {
-		statement = sql.GeneralLedgerPreferenceStatement{ID: input.ID}
+// Lines is the resolver for the lines field.
+func (r *journalDraftResolver) Lines(ctx context.Context, obj *model.JournalDraft) ([]*model.JournalDraftLine, error) {
+	draftID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
 	}
 
-	preferences, err := r.AccountingUsecase.GetAllGeneralLedgerPreferences(ctx, statement)
+	lines, err := r.AccountingUsecase.GetAllJournalDraftLinesByDraftID(ctx, draftID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get general ledger preferences", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal draft lines", libErr.GetCode(err))
 	}
 
-	result := make([]*model.GeneralLedgerPreference, len(preferences))
-	for i, preference := range preferences {
-		result[i] = &model.GeneralLedgerPreference{
-			ID:        preference.ID,
-			AccountID: preference.AccountID.Int64,
+	result := make([]*model.JournalDraftLine, len(lines))
+	for i, line := range lines {
+		result[i] = &model.JournalDraftLine{
+			ID:        line.ID.String(),
+			AccountID: line.AccountID,
+			Amount:    line.Amount,
+		}
+
+		if line.Memo.Valid {
+			result[i].Memo = &line.Memo.String
+		}
+
+		if line.ExternalReference.Valid {
+			result[i].ExternalReference = &line.ExternalReference.String
 		}
 	}
 
 	return result, nil
 }
 
-// FiscalYears is the resolver for the fiscalYears field.
-func (r *queryResolver) FiscalYears(ctx context.Context, input *model.FiscalYearsInput) (*model.FiscalYearsResult, error) {
-	var (
-		paging qb.Paging
-	)
-
-	if input != nil {
-		paging = qb.Paging{
-			CurrentPage: input.Paging.CurrentPage,
-			PageSize:    input.Paging.PageSize,
-		}
+// Reviews is the resolver for the reviews field.
+func (r *journalDraftResolver) Reviews(ctx context.Context, obj *model.JournalDraft) ([]*model.JournalDraftReview, error) {
+	draftID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
 	}
 
-	fiscalYears, paging, err := r.AccountingUsecase.GetFiscalYearList(ctx, sql.FiscalYearStatement{}, paging)
+	reviews, err := r.AccountingUsecase.GetAllJournalDraftReviewsByDraftID(ctx, draftID)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get fiscal year list", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal draft reviews", libErr.GetCode(err))
 	}
 
-	resultData := make([]model.FiscalYear, len(fiscalYears))
-	for i, fiscalYear := range fiscalYears {
-		resultData[i] = model.FiscalYear{
-			ID:        fiscalYear.ID,
-			StartDate: fiscalYear.StartDate,
-			EndDate:   fiscalYear.EndDate,
-			Closed:    fiscalYear.Closed,
+	result := make([]*model.JournalDraftReview, len(reviews))
+	for i, review := range reviews {
+		result[i] = &model.JournalDraftReview{
+			ID:        review.ID,
+			UserID:    review.UserID.String(),
+			Approved:  review.Approved,
+			CreatedAt: review.CreatedAt,
+		}
+
+		if review.Comment.Valid {
+			result[i].Comment = &reviews[i].Comment.String
 		}
 	}
 
-	return &model.FiscalYearsResult{
-		Data: resultData,
-		Paging: model.Paging{
-			CurrentPage: paging.CurrentPage,
-			PageSize:    paging.PageSize,
-			Total:       paging.Total,
-		},
-	}, nil
+	return result, nil
 }
 
-// BankAccountTypes is the resolver for the bankAccountTypes field.
-func (r *queryResolver) BankAccountTypes(ctx context.Context) (*model.BankAccountTypesResult, error) {
-	bankAccountTypes := r.AccountingUsecase.GetAllBankAccountTypes(ctx)
+// Account is the resolver for the account field.
+func (r *journalDraftLineResolver) Account(ctx context.Context, obj *model.JournalDraftLine) (*model.Account, error) {
+	if obj == nil || obj.AccountID == 0 {
+		return nil, nil
+	}
 
-	data := make([]model.BankAccountType, len(bankAccountTypes))
-	for i, bankAccountType := range bankAccountTypes {
-		data[i] = model.BankAccountType{ID: bankAccountType.ID, Name: bankAccountType.Name}
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
 	}
 
-	return &model.BankAccountTypesResult{Data: data}, nil
+	return model.NewAccount(account), nil
 }
 
-// BankAccounts is the resolver for the bankAccounts field.
-func (r *queryResolver) BankAccounts(ctx context.Context, input *model.BankAccountsInput) (*model.BankAccountsResult, error) {
-	var (
-		paging qb.Paging
-		stmt   sql.BankAccountStatement
-	)
-
-	if input != nil {
-		paging = qb.Paging{
-			CurrentPage: input.Paging.CurrentPage,
-			PageSize:    input.Paging.PageSize,
-		}
-
-		stmt = sql.BankAccountStatement{ID: input.Scope.ID}
+// Journal is the resolver for the journal field.
+func (r *journalImportJournalResolver) Journal(ctx context.Context, obj *model.JournalImportJournal) (*model.Journal, error) {
+	if obj == nil || obj.JournalID == nil {
+		return nil, nil
+	}
+
+	journalID, err := uuid.Parse(*obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
+}
+
+// StoreAccountClass is the resolver for the storeAccountClass field.
+func (r *mutationResolver) StoreAccountClass(ctx context.Context, input model.WriteAccountClassInput) (*model.AccountClass, error) {
+	accountClass := input.Domain()
+
+	if err := r.Resolver.AccountingUsecase.StoreAccountClass(ctx, &accountClass); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on create account class", libErr.GetCode(err))
+	}
+
+	return model.NewAccountClass(accountClass), nil
+}
+
+// UpdateAccountClassByID is the resolver for the updateAccountClassByID field.
+func (r *mutationResolver) UpdateAccountClassByID(ctx context.Context, id int, input model.WriteAccountClassInput) (*model.AccountClass, error) {
+	accountClass := input.Domain()
+
+	if err := r.Resolver.AccountingUsecase.UpdateAccountClassByID(ctx, int64(id), &accountClass); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update account class", libErr.GetCode(err))
+	}
+
+	accountClass.ID = int64(id)
+
+	return model.NewAccountClass(accountClass), nil
+}
+
+// DeleteAccountClassByID is the resolver for the deleteAccountClassByID field.
+func (r *mutationResolver) DeleteAccountClassByID(ctx context.Context, id int) (int, error) {
+	if err := r.AccountingUsecase.DeleteAccountClassByID(ctx, int64(id)); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed to delete account class", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// StoreAccountGroup is the resolver for the storeAccountGroup field.
+func (r *mutationResolver) StoreAccountGroup(ctx context.Context, input model.WriteAccountGroupInput) (*model.AccountGroup, error) {
+	accountGroup, err := input.Domain()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed to create account group", libErr.GetCode(err))
+	}
+
+	if err = r.Resolver.AccountingUsecase.StoreAccountGroup(ctx, &accountGroup); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed to create account group", libErr.GetCode(err))
+	}
+
+	return model.NewAccountGroup(accountGroup), nil
+}
+
+// UpdateAccountGroupByID is the resolver for the updateAccountGroupByID field.
+func (r *mutationResolver) UpdateAccountGroupByID(ctx context.Context, id int, input model.WriteAccountGroupInput) (*model.AccountGroup, error) {
+	accountGroup, err := input.Domain()
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Failed to update account group", libErr.GetCode(err))
+	}
+
+	if err := r.Resolver.AccountingUsecase.UpdateAccountGroupByID(ctx, int64(id), &accountGroup); err != nil {
+		return nil, sdkGraphql.NewError(err, "Failed to update account group", libErr.GetCode(err))
+	}
+
+	accountGroup.ID = int64(id)
+
+	return model.NewAccountGroup(accountGroup), nil
+}
+
+// DeleteAccountGroupByID is the resolver for the deleteAccountGroupByID field.
+func (r *mutationResolver) DeleteAccountGroupByID(ctx context.Context, id int) (int, error) {
+	if err := r.AccountingUsecase.DeleteAccountGroupByID(ctx, int64(id)); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed to delete account group", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// StoreAccount is the resolver for the storeAccount field.
+func (r *mutationResolver) StoreAccount(ctx context.Context, input model.WriteAccountInput) (*model.Account, error) {
+	account := input.Domain()
+
+	if err := r.AccountingUsecase.StoreAccount(ctx, &account); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// UpdateAccountByID is the resolver for the updateAccountByID field.
+func (r *mutationResolver) UpdateAccountByID(ctx context.Context, id int, input model.WriteAccountInput) (*model.Account, error) {
+	account := input.Domain()
+
+	if err := r.AccountingUsecase.UpdateAccountByID(ctx, int64(id), &account); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update account by id", libErr.GetCode(err))
+	}
+
+	account.ID = int64(id)
+
+	return model.NewAccount(account), nil
+}
+
+// DeleteAccountByID is the resolver for the deleteAccountByID field.
+func (r *mutationResolver) DeleteAccountByID(ctx context.Context, id int) (int, error) {
+	if err := r.AccountingUsecase.DeleteAccountByID(ctx, int64(id)); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed on delete account by id", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// UpdateAccountCodeFormat is the resolver for the updateAccountCodeFormat field.
+func (r *mutationResolver) UpdateAccountCodeFormat(ctx context.Context, classTypeID int, pattern string) (*model.AccountCodeFormat, error) {
+	if err := r.AccountingUsecase.UpdateAccountCodeFormatByClassTypeID(ctx, int64(classTypeID), pattern); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update account code format", libErr.GetCode(err))
+	}
+
+	return &model.AccountCodeFormat{
+		ClassTypeID: int64(classTypeID),
+		Pattern:     pattern,
+	}, nil
+}
+
+// ImportChartOfAccounts is the resolver for the importChartOfAccounts field.
+func (r *mutationResolver) ImportChartOfAccounts(ctx context.Context, input model.ImportChartOfAccountsInput) (*model.ChartOfAccountsImportResult, error) {
+	var (
+		file   io.Reader
+		dryRun = input.DryRun != nil && *input.DryRun
+	)
+
+	switch {
+	case input.File != nil:
+		file = input.File.File
+	case input.Data != nil:
+		file = strings.NewReader(*input.Data)
+	default:
+		err := fmt.Errorf("data or file is required")
+		return nil, sdkGraphql.NewError(err, "Data or file is required", sql.EcodeChartOfAccountsInvalid)
+	}
+
+	changes, err := r.AccountingUsecase.ImportChartOfAccounts(ctx, strings.ToLower(input.Format), file, dryRun)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on import chart of accounts", libErr.GetCode(err))
+	}
+
+	return model.NewChartOfAccountsImportResult(changes, dryRun), nil
+}
+
+// ApplyChartOfAccountsTemplate is the resolver for the applyChartOfAccountsTemplate field.
+func (r *mutationResolver) ApplyChartOfAccountsTemplate(ctx context.Context, name string) (*model.ChartOfAccountsImportResult, error) {
+	changes, err := r.AccountingUsecase.ApplyChartOfAccountsTemplate(ctx, name)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on apply chart of accounts template", libErr.GetCode(err))
+	}
+
+	return model.NewChartOfAccountsImportResult(changes, false), nil
+}
+
+// StoreTransaction is the resolver for the storeTransaction field.
+func (r *mutationResolver) StoreTransaction(ctx context.Context, input model.WriteTransactionInput) (*model.Journal, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	transactions, err := newTransactionRows(input.Data)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.StoreTransaction(ctx, userID, sql.Transaction{
+		Date: input.TransDate,
+		Memo: input.Memo,
+		Data: transactions,
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on create transactions", libErr.GetCode(err))
+	}
+
+	if journal == nil {
+		return nil, nil
+	}
+
+	return model.NewJournal(*journal), nil
+}
+
+// AmendJournal is the resolver for the amendJournal field.
+func (r *mutationResolver) AmendJournal(ctx context.Context, id string, input model.WriteTransactionInput, reason *string) (*model.Journal, error) {
+	journalID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	transactions, err := newTransactionRows(input.Data)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+	}
+
+	var amendReason string
+	if reason != nil {
+		amendReason = *reason
+	}
+
+	journal, err := r.AccountingUsecase.AmendJournalByID(ctx, journalID, appcontext.GetUserID(ctx), sql.Transaction{
+		Date: input.TransDate,
+		Memo: input.Memo,
+		Data: transactions,
+	}, amendReason)
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on amend journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(*journal), nil
+}
+
+// ImportOpeningBalances is the resolver for the importOpeningBalances field.
+func (r *mutationResolver) ImportOpeningBalances(ctx context.Context, input model.ImportOpeningBalancesInput) (*model.Journal, error) {
+	var (
+		journal *domain.Journal
+		err     error
+	)
+
+	userID := appcontext.GetUserID(ctx)
+
+	if input.File != nil {
+		journal, err = r.AccountingUsecase.ImportOpeningBalancesCSV(ctx, userID, input.File.File)
+	} else {
+		var rows []sql.TransactionRow
+		if rows, err = newTransactionRows(input.Data); err != nil {
+			return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+		}
+
+		journal, err = r.AccountingUsecase.ImportOpeningBalances(ctx, userID, rows)
+	}
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on import opening balances", libErr.GetCode(err))
+	}
+
+	if journal == nil {
+		return nil, nil
+	}
+
+	return model.NewJournal(*journal), nil
+}
+
+// ImportJournals is the resolver for the importJournals field.
+func (r *mutationResolver) ImportJournals(ctx context.Context, input model.ImportJournalsInput) (*model.JournalImport, error) {
+	var file io.Reader
+
+	switch {
+	case input.File != nil:
+		file = input.File.File
+	case input.Data != nil:
+		file = strings.NewReader(*input.Data)
+	default:
+		err := fmt.Errorf("data or file is required")
+		return nil, sdkGraphql.NewError(err, "Data or file is required", sql.EcodeJournalImportInvalid)
+	}
+
+	journalImport, err := r.AccountingUsecase.ImportJournals(
+		ctx,
+		appcontext.GetUserID(ctx),
+		input.BatchID,
+		strings.ToLower(input.Format),
+		strings.ToLower(input.Mode),
+		file,
+	)
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on import journals", libErr.GetCode(err))
+	}
+
+	return model.NewJournalImport(journalImport), nil
+}
+
+// UpdateGeneralLedgerPreferences is the resolver for the updateGeneralLedgerPreferences field.
+func (r *mutationResolver) UpdateGeneralLedgerPreferences(ctx context.Context, input []*model.WriteGeneralLedgerPreferenceInput) ([]*model.GeneralLedgerPreference, error) {
+	preferences := make([]domain.GeneralLedgerPreference, len(input))
+
+	for i, o := range input {
+		d, err := o.Domain()
+		if err != nil {
+			r.Logger.Error(err.Error())
+			return nil, sdkGraphql.NewError(err, "Failed on update generate ledger preferences", libErr.GetCode(err))
+		}
+
+		preferences[i] = d
+	}
+
+	if err := r.AccountingUsecase.UpdateGeneralLedgerPreferences(ctx, preferences); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update general ledger preferences", libErr.GetCode(err))
+	}
+
+	result := make([]*model.GeneralLedgerPreference, len(preferences))
+	for i, preference := range preferences {
+		definition := r.AccountingUsecase.GetGeneralLedgerPreferenceDefinitionByID(ctx, preference.ID)
+		result[i] = model.NewGeneralLedgerPreference(preference, definition)
+	}
+
+	return result, nil
+}
+
+// StoreBankAccount is the resolver for the storeBankAccount field.
+func (r *mutationResolver) StoreBankAccount(ctx context.Context, input model.WriteBankAccountInput) (*model.BankAccount, error) {
+	bankAccount, err := input.Domain()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store bank account", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.StoreBankAccount(ctx, &bankAccount); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store bank account", libErr.GetCode(err))
+	}
+
+	return &model.BankAccount{
+		ID:         bankAccount.ID,
+		AccountID:  bankAccount.AccountID,
+		TypeID:     bankAccount.TypeID,
+		BankNumber: bankAccount.BankNumber.String,
+		Inactive:   bankAccount.Inactive,
+	}, nil
+}
+
+// UpdateBankAccountByID is the resolver for the updateBankAccountByID field.
+func (r *mutationResolver) UpdateBankAccountByID(ctx context.Context, id int, input model.WriteBankAccountInput) (*model.BankAccount, error) {
+	bankAccount, err := input.Domain()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update bank account by id", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.UpdateBankAccountByID(ctx, int64(id), &bankAccount); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update bank account by id", libErr.GetCode(err))
+	}
+
+	return &model.BankAccount{
+		ID:         bankAccount.ID,
+		AccountID:  bankAccount.AccountID,
+		TypeID:     bankAccount.TypeID,
+		BankNumber: bankAccount.BankNumber.String,
+		Inactive:   bankAccount.Inactive,
+	}, nil
+}
+
+// StoreBankDepositTransaction is the resolver for the storeBankDepositTransaction field.
+func (r *mutationResolver) StoreBankDepositTransaction(ctx context.Context, input model.WriteBankTransactionInput) (*model.BankTransaction, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	transactions, err := newTransactionRows(input.Data)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+	}
+
+	// deposit rows are entered as the positive amount credited against the bank account
+	for i, item := range input.Data {
+		if item.Amount == nil {
+			transactions[i].Amount = -transactions[i].Amount
+		}
+	}
+
+	bankTransaction, err := r.AccountingUsecase.StoreBankDepositTransaction(ctx, userID, sql.BankTransaction{
+		BankAccountID: input.BankAccountID,
+		Transaction: sql.Transaction{
+			Date: input.TransDate,
+			Memo: input.Memo,
+			Data: transactions,
+		},
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, libErr.RootCause(err).Error(), libErr.GetCode(err))
+	}
+
+	return &model.BankTransaction{
+		ID:            bankTransaction.ID,
+		JournalID:     bankTransaction.JournalID.String(),
+		BankAccountID: bankTransaction.BankAccountID,
+		Amount:        bankTransaction.Amount,
+		CreatedAt:     bankTransaction.CreatedAt,
+	}, nil
+}
+
+// StoreFiscalYear is the resolver for the storeFiscalYear field.
+func (r *mutationResolver) StoreFiscalYear(ctx context.Context, input model.WriteFiscalYearInput) (*model.FiscalYear, error) {
+	fiscalYear := input.Domain()
+
+	if err := r.AccountingUsecase.StoreFiscalYear(ctx, &fiscalYear, input.PeriodMonths); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store fiscal year", libErr.GetCode(err))
+	}
+
+	return &model.FiscalYear{
+		ID:        fiscalYear.ID,
+		StartDate: fiscalYear.StartDate,
+		EndDate:   fiscalYear.EndDate,
+		Closed:    fiscalYear.Closed,
+	}, nil
+}
+
+// CloseFiscalYear is the resolver for the closeFiscalYear field.
+func (r *mutationResolver) CloseFiscalYear(ctx context.Context, id int) (int, error) {
+	userID := appcontext.GetUserID(ctx)
+	if err := r.AccountingUsecase.CloseFiscalYear(ctx, int64(id), userID); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed on close fiscal year", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// ReopenFiscalYear is the resolver for the reopenFiscalYear field.
+func (r *mutationResolver) ReopenFiscalYear(ctx context.Context, id int, reason string) (*model.FiscalYear, error) {
+	userID := appcontext.GetUserID(ctx)
+	if err := r.AccountingUsecase.ReopenFiscalYear(ctx, int64(id), userID, reason); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on reopen fiscal year", libErr.GetCode(err))
+	}
+
+	fiscalYear, err := r.AccountingUsecase.GetFiscalYear(ctx, sql.FiscalYearStatement{ID: int64(id)})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal year", libErr.GetCode(err))
+	}
+
+	return &model.FiscalYear{
+		ID:        fiscalYear.ID,
+		StartDate: fiscalYear.StartDate,
+		EndDate:   fiscalYear.EndDate,
+		Closed:    fiscalYear.Closed,
+	}, nil
+}
+
+// GenerateFiscalPeriods is the resolver for the generateFiscalPeriods field.
+func (r *mutationResolver) GenerateFiscalPeriods(ctx context.Context, fiscalYearID int, periodMonths *int) ([]*model.FiscalPeriod, error) {
+	var months int
+	if periodMonths != nil {
+		months = *periodMonths
+	}
+
+	periods, err := r.AccountingUsecase.GenerateFiscalPeriods(ctx, int64(fiscalYearID), months)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on generate fiscal periods", libErr.GetCode(err))
+	}
+
+	result := make([]*model.FiscalPeriod, len(periods))
+	for i, period := range periods {
+		result[i] = model.NewFiscalPeriod(period)
+	}
+
+	return result, nil
+}
+
+// UpdateFiscalPeriodStatus is the resolver for the updateFiscalPeriodStatus field.
+func (r *mutationResolver) UpdateFiscalPeriodStatus(ctx context.Context, id int, input model.WriteFiscalPeriodStatusInput) (*model.FiscalPeriod, error) {
+	userID := appcontext.GetUserID(ctx)
+	if err := r.AccountingUsecase.UpdateFiscalPeriodStatusByID(ctx, int64(id), userID, input.StatusID, input.Reason); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update fiscal period status", libErr.GetCode(err))
+	}
+
+	period, err := r.AccountingUsecase.GetFiscalPeriodByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal period", libErr.GetCode(err))
+	}
+
+	return model.NewFiscalPeriod(period), nil
+}
+
+// StoreJournalDraft is the resolver for the storeJournalDraft field.
+func (r *mutationResolver) StoreJournalDraft(ctx context.Context, input model.WriteTransactionInput) (*model.JournalDraft, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	transactions, err := newTransactionRows(input.Data)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+	}
+
+	draft, err := r.AccountingUsecase.StoreJournalDraft(ctx, userID, sql.Transaction{
+		Date: input.TransDate,
+		Memo: input.Memo,
+		Data: transactions,
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store journal draft", libErr.GetCode(err))
+	}
+
+	return model.NewJournalDraft(*draft), nil
+}
+
+// UpdateJournalDraftByID is the resolver for the updateJournalDraftByID field.
+func (r *mutationResolver) UpdateJournalDraftByID(ctx context.Context, id string, input model.WriteTransactionInput) (*model.JournalDraft, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	draftID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
+
+	transactions, err := newTransactionRows(input.Data)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid transaction row", libErr.GetCode(err))
+	}
+
+	draft, err := r.AccountingUsecase.UpdateJournalDraftByID(ctx, draftID, userID, sql.Transaction{
+		Date: input.TransDate,
+		Memo: input.Memo,
+		Data: transactions,
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update journal draft", libErr.GetCode(err))
+	}
+
+	return model.NewJournalDraft(*draft), nil
+}
+
+// SubmitJournalDraft is the resolver for the submitJournalDraft field.
+func (r *mutationResolver) SubmitJournalDraft(ctx context.Context, id string) (*model.JournalDraft, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	draftID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.SubmitJournalDraftByID(ctx, draftID, userID); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, libErr.RootCause(err).Error(), libErr.GetCode(err))
+	}
+
+	return r.Query().JournalDraft(ctx, id)
+}
+
+// ApproveJournalDraft is the resolver for the approveJournalDraft field.
+func (r *mutationResolver) ApproveJournalDraft(ctx context.Context, id string, comment *string) (*model.JournalDraft, error) {
+	var reviewComment string
+
+	userID := appcontext.GetUserID(ctx)
+
+	draftID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
+
+	if comment != nil {
+		reviewComment = *comment
+	}
+
+	if err = r.AccountingUsecase.ApproveJournalDraftByID(ctx, draftID, userID, reviewComment); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, libErr.RootCause(err).Error(), libErr.GetCode(err))
+	}
+
+	return r.Query().JournalDraft(ctx, id)
+}
+
+// RejectJournalDraft is the resolver for the rejectJournalDraft field.
+func (r *mutationResolver) RejectJournalDraft(ctx context.Context, id string, comment string) (*model.JournalDraft, error) {
+	userID := appcontext.GetUserID(ctx)
+
+	draftID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.RejectJournalDraftByID(ctx, draftID, userID, comment); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, libErr.RootCause(err).Error(), libErr.GetCode(err))
+	}
+
+	return r.Query().JournalDraft(ctx, id)
+}
+
+// StoreApprovalRule is the resolver for the storeApprovalRule field.
+func (r *mutationResolver) StoreApprovalRule(ctx context.Context, input model.WriteApprovalRuleInput) (*model.ApprovalRule, error) {
+	rule, err := input.Domain()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store approval rule", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.StoreApprovalRule(ctx, appcontext.GetUserID(ctx), &rule); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store approval rule", libErr.GetCode(err))
+	}
+
+	return model.NewApprovalRule(rule), nil
+}
+
+// UpdateApprovalRuleByID is the resolver for the updateApprovalRuleByID field.
+func (r *mutationResolver) UpdateApprovalRuleByID(ctx context.Context, id int, input model.WriteApprovalRuleInput) (*model.ApprovalRule, error) {
+	rule, err := input.Domain()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update approval rule", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.UpdateApprovalRuleByID(ctx, int64(id), appcontext.GetUserID(ctx), &rule); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update approval rule", libErr.GetCode(err))
+	}
+
+	return model.NewApprovalRule(rule), nil
+}
+
+// DeleteApprovalRuleByID is the resolver for the deleteApprovalRuleByID field.
+func (r *mutationResolver) DeleteApprovalRuleByID(ctx context.Context, id int) (int, error) {
+	if err := r.AccountingUsecase.DeleteApprovalRuleByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed on delete approval rule", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// StoreBudget is the resolver for the storeBudget field.
+func (r *mutationResolver) StoreBudget(ctx context.Context, input model.WriteBudgetInput) (*model.Budget, error) {
+	budget := input.Domain()
+
+	if err := r.AccountingUsecase.StoreBudget(ctx, appcontext.GetUserID(ctx), &budget); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store budget", libErr.GetCode(err))
+	}
+
+	return model.NewBudget(budget), nil
+}
+
+// UpdateBudgetByID is the resolver for the updateBudgetByID field.
+func (r *mutationResolver) UpdateBudgetByID(ctx context.Context, id int, input model.WriteBudgetInput) (*model.Budget, error) {
+	budget := input.Domain()
+
+	if err := r.AccountingUsecase.UpdateBudgetByID(ctx, int64(id), &budget); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update budget", libErr.GetCode(err))
+	}
+
+	budget, err := r.AccountingUsecase.GetBudgetByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get budget", libErr.GetCode(err))
+	}
+
+	return model.NewBudget(budget), nil
+}
+
+// DeleteBudgetByID is the resolver for the deleteBudgetByID field.
+func (r *mutationResolver) DeleteBudgetByID(ctx context.Context, id int) (int, error) {
+	if err := r.AccountingUsecase.DeleteBudgetByID(ctx, int64(id)); err != nil {
+		r.Logger.Error(err.Error())
+		return id, sdkGraphql.NewError(err, "Failed on delete budget", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// StoreBudgetLines is the resolver for the storeBudgetLines field.
+func (r *mutationResolver) StoreBudgetLines(ctx context.Context, budgetID int, input []*model.WriteBudgetLineInput) ([]*model.BudgetLine, error) {
+	lines := make([]domain.BudgetLine, len(input))
+	for i, item := range input {
+		lines[i] = item.Domain()
+	}
+
+	if err := r.AccountingUsecase.StoreBudgetLines(ctx, int64(budgetID), lines); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store budget lines", libErr.GetCode(err))
+	}
+
+	return r.Budget().Lines(ctx, &model.Budget{ID: int64(budgetID)}, nil)
+}
+
+// ImportBudgetLines is the resolver for the importBudgetLines field.
+func (r *mutationResolver) ImportBudgetLines(ctx context.Context, budgetID int, file graphql.Upload) ([]*model.BudgetLine, error) {
+	lines, err := r.AccountingUsecase.ImportBudgetLinesCSV(ctx, int64(budgetID), file.File)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on import budget lines", libErr.GetCode(err))
+	}
+
+	result := make([]*model.BudgetLine, len(lines))
+	for i, line := range lines {
+		result[i] = model.NewBudgetLine(line)
+	}
+
+	return result, nil
+}
+
+// GenerateBudgetFromActuals is the resolver for the generateBudgetFromActuals field.
+func (r *mutationResolver) GenerateBudgetFromActuals(ctx context.Context, input model.GenerateBudgetFromActualsInput) (*model.Budget, error) {
+	var (
+		sourceFiscalYearID int64
+		percent            float64
+	)
+
+	budget := domain.Budget{FiscalYearID: input.FiscalYearID, Name: input.Name}
+	if input.Description != nil {
+		budget.Description = goSql.NullString{String: *input.Description, Valid: true}
+	}
+
+	if input.SourceFiscalYearID != nil {
+		sourceFiscalYearID = *input.SourceFiscalYearID
+	}
+
+	if input.Percent != nil {
+		percent = *input.Percent
+	}
+
+	err := r.AccountingUsecase.GenerateBudgetFromActuals(ctx, appcontext.GetUserID(ctx), &budget, sourceFiscalYearID, percent)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on generate budget", libErr.GetCode(err))
+	}
+
+	return model.NewBudget(budget), nil
+}
+
+// StoreAmortizationSchedule is the resolver for the storeAmortizationSchedule field.
+func (r *mutationResolver) StoreAmortizationSchedule(ctx context.Context, input model.WriteAmortizationScheduleInput) (*model.AmortizationSchedule, error) {
+	schedule := input.Domain()
+
+	if err := r.AccountingUsecase.StoreAmortizationSchedule(ctx, appcontext.GetUserID(ctx), &schedule); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store amortization schedule", libErr.GetCode(err))
+	}
+
+	return model.NewAmortizationSchedule(schedule), nil
+}
+
+// TerminateAmortizationSchedule is the resolver for the terminateAmortizationSchedule field.
+func (r *mutationResolver) TerminateAmortizationSchedule(ctx context.Context, id int, date time.Time, writeOffRemaining *bool) (*model.AmortizationSchedule, error) {
+	err := r.AccountingUsecase.TerminateAmortizationScheduleByID(ctx, int64(id), appcontext.GetUserID(ctx), date, writeOffRemaining != nil && *writeOffRemaining)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on terminate amortization schedule", libErr.GetCode(err))
+	}
+
+	return r.Query().AmortizationSchedule(ctx, id)
+}
+
+// PostDueAmortizationEntries is the resolver for the postDueAmortizationEntries field.
+func (r *mutationResolver) PostDueAmortizationEntries(ctx context.Context, asOf *time.Time) ([]*model.AmortizationEntry, error) {
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	// a request without a company works in the default one, only the background job posts for every company
+	if appcontext.GetCompanyID(ctx) == 0 {
+		ctx = appcontext.SetCompanyID(ctx, sql.DefaultCompanyID)
+	}
+
+	entries, err := r.AccountingUsecase.PostDueAmortizationEntries(ctx, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on post amortization entries", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AmortizationEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = model.NewAmortizationEntry(entry)
+	}
+
+	return result, nil
+}
+
+// StoreAssetCategory is the resolver for the storeAssetCategory field.
+func (r *mutationResolver) StoreAssetCategory(ctx context.Context, input model.WriteAssetCategoryInput) (*model.AssetCategory, error) {
+	category := input.Domain()
+	if err := r.AccountingUsecase.StoreAssetCategory(ctx, &category); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store asset category", libErr.GetCode(err))
+	}
+
+	return model.NewAssetCategory(category), nil
+}
+
+// UpdateAssetCategoryByID is the resolver for the updateAssetCategoryByID field.
+func (r *mutationResolver) UpdateAssetCategoryByID(ctx context.Context, id int, input model.WriteAssetCategoryInput) (*model.AssetCategory, error) {
+	category := input.Domain()
+	if err := r.AccountingUsecase.UpdateAssetCategoryByID(ctx, int64(id), &category); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update asset category", libErr.GetCode(err))
+	}
+
+	return model.NewAssetCategory(category), nil
+}
+
+// StoreFixedAsset is the resolver for the storeFixedAsset field.
+func (r *mutationResolver) StoreFixedAsset(ctx context.Context, input model.WriteFixedAssetInput) (*model.FixedAsset, error) {
+	asset := input.Domain()
+	if err := r.AccountingUsecase.StoreFixedAsset(ctx, appcontext.GetUserID(ctx), &asset); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store fixed asset", libErr.GetCode(err))
+	}
+
+	return model.NewFixedAsset(asset), nil
+}
+
+// UpdateFixedAssetByID is the resolver for the updateFixedAssetByID field.
+func (r *mutationResolver) UpdateFixedAssetByID(ctx context.Context, id int, input model.WriteFixedAssetInput) (*model.FixedAsset, error) {
+	asset := input.Domain()
+	if err := r.AccountingUsecase.UpdateFixedAssetByID(ctx, int64(id), &asset); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update fixed asset", libErr.GetCode(err))
+	}
+
+	return r.Query().FixedAsset(ctx, id)
+}
+
+// RunDepreciation is the resolver for the runDepreciation field.
+func (r *mutationResolver) RunDepreciation(ctx context.Context, date time.Time) (*model.DepreciationRun, error) {
+	run, err := r.AccountingUsecase.RunDepreciation(ctx, appcontext.GetUserID(ctx), date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on run depreciation", libErr.GetCode(err))
+	}
+
+	return model.NewDepreciationRun(run), nil
+}
+
+// DisposeFixedAsset is the resolver for the disposeFixedAsset field.
+func (r *mutationResolver) DisposeFixedAsset(ctx context.Context, id int, input model.DisposeFixedAssetInput) (*model.FixedAsset, error) {
+	var (
+		proceeds          float64
+		proceedsAccountID int64
+	)
+
+	if input.Proceeds != nil {
+		proceeds = *input.Proceeds
+	}
+
+	if input.ProceedsAccountID != nil {
+		proceedsAccountID = *input.ProceedsAccountID
+	}
+
+	err := r.AccountingUsecase.DisposeFixedAssetByID(ctx, int64(id), appcontext.GetUserID(ctx), input.Date, proceeds, proceedsAccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on dispose fixed asset", libErr.GetCode(err))
+	}
+
+	return r.Query().FixedAsset(ctx, id)
+}
+
+// StoreCustomer is the resolver for the storeCustomer field.
+func (r *mutationResolver) StoreCustomer(ctx context.Context, input model.WriteCustomerInput) (*model.Customer, error) {
+	customer := input.Domain()
+	if err := r.AccountingUsecase.StoreCustomer(ctx, &customer); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store customer", libErr.GetCode(err))
+	}
+
+	return model.NewCustomer(customer), nil
+}
+
+// UpdateCustomerByID is the resolver for the updateCustomerByID field.
+func (r *mutationResolver) UpdateCustomerByID(ctx context.Context, id int, input model.WriteCustomerInput) (*model.Customer, error) {
+	customer := input.Domain()
+	if err := r.AccountingUsecase.UpdateCustomerByID(ctx, int64(id), &customer); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update customer", libErr.GetCode(err))
+	}
+
+	return r.Query().Customer(ctx, id)
+}
+
+// StoreSalesInvoice is the resolver for the storeSalesInvoice field.
+func (r *mutationResolver) StoreSalesInvoice(ctx context.Context, input model.WriteSalesInvoiceInput) (*model.SalesInvoice, error) {
+	invoice := input.Domain()
+	if err := r.AccountingUsecase.StoreSalesInvoice(ctx, appcontext.GetUserID(ctx), &invoice); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store sales invoice", libErr.GetCode(err))
+	}
+
+	return model.NewSalesInvoice(invoice), nil
+}
+
+// VoidSalesInvoiceByID is the resolver for the voidSalesInvoiceByID field.
+func (r *mutationResolver) VoidSalesInvoiceByID(ctx context.Context, id int) (*model.SalesInvoice, error) {
+	if err := r.AccountingUsecase.VoidSalesInvoiceByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on void sales invoice", libErr.GetCode(err))
+	}
+
+	return r.Query().SalesInvoice(ctx, id)
+}
+
+// StoreCustomerReceipt is the resolver for the storeCustomerReceipt field.
+func (r *mutationResolver) StoreCustomerReceipt(ctx context.Context, input model.WriteCustomerReceiptInput) (*model.CustomerReceipt, error) {
+	receipt := input.Domain()
+	if err := r.AccountingUsecase.StoreCustomerReceipt(ctx, appcontext.GetUserID(ctx), &receipt); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store customer receipt", libErr.GetCode(err))
+	}
+
+	return model.NewCustomerReceipt(receipt), nil
+}
+
+// AllocateCustomerReceipt is the resolver for the allocateCustomerReceipt field.
+func (r *mutationResolver) AllocateCustomerReceipt(ctx context.Context, receiptID int, input []*model.WriteReceiptAllocationInput) (*model.CustomerReceipt, error) {
+	err := r.AccountingUsecase.AllocateCustomerReceipt(ctx, int64(receiptID), model.NewReceiptAllocationsDomain(input))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on allocate customer receipt", libErr.GetCode(err))
+	}
+
+	return r.Query().CustomerReceipt(ctx, receiptID)
+}
+
+// StoreVendor is the resolver for the storeVendor field.
+func (r *mutationResolver) StoreVendor(ctx context.Context, input model.WriteVendorInput) (*model.Vendor, error) {
+	vendor := input.Domain()
+	if err := r.AccountingUsecase.StoreVendor(ctx, &vendor); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store vendor", libErr.GetCode(err))
+	}
+
+	return model.NewVendor(vendor), nil
+}
+
+// UpdateVendorByID is the resolver for the updateVendorByID field.
+func (r *mutationResolver) UpdateVendorByID(ctx context.Context, id int, input model.WriteVendorInput) (*model.Vendor, error) {
+	vendor := input.Domain()
+	if err := r.AccountingUsecase.UpdateVendorByID(ctx, int64(id), &vendor); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update vendor", libErr.GetCode(err))
+	}
+
+	return r.Query().Vendor(ctx, id)
+}
+
+// StorePurchaseBill is the resolver for the storePurchaseBill field.
+func (r *mutationResolver) StorePurchaseBill(ctx context.Context, input model.WritePurchaseBillInput) (*model.PurchaseBill, error) {
+	bill := input.Domain()
+	if err := r.AccountingUsecase.StorePurchaseBill(ctx, appcontext.GetUserID(ctx), &bill); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store purchase bill", libErr.GetCode(err))
+	}
+
+	return model.NewPurchaseBill(bill), nil
+}
+
+// VoidPurchaseBillByID is the resolver for the voidPurchaseBillByID field.
+func (r *mutationResolver) VoidPurchaseBillByID(ctx context.Context, id int) (*model.PurchaseBill, error) {
+	if err := r.AccountingUsecase.VoidPurchaseBillByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on void purchase bill", libErr.GetCode(err))
+	}
+
+	return r.Query().PurchaseBill(ctx, id)
+}
+
+// StoreVendorPayment is the resolver for the storeVendorPayment field.
+func (r *mutationResolver) StoreVendorPayment(ctx context.Context, input model.WriteVendorPaymentInput) (*model.VendorPayment, error) {
+	payment := input.Domain()
+	if err := r.AccountingUsecase.StoreVendorPayment(ctx, appcontext.GetUserID(ctx), &payment); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store vendor payment", libErr.GetCode(err))
+	}
+
+	return model.NewVendorPayment(payment), nil
+}
+
+// AllocateVendorPayment is the resolver for the allocateVendorPayment field.
+func (r *mutationResolver) AllocateVendorPayment(ctx context.Context, paymentID int, input []*model.WritePaymentAllocationInput) (*model.VendorPayment, error) {
+	err := r.AccountingUsecase.AllocateVendorPayment(ctx, int64(paymentID), model.NewPaymentAllocationsDomain(input))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on allocate vendor payment", libErr.GetCode(err))
+	}
+
+	return r.Query().VendorPayment(ctx, paymentID)
+}
+
+// StoreCreditNote is the resolver for the storeCreditNote field.
+func (r *mutationResolver) StoreCreditNote(ctx context.Context, input model.WriteCreditNoteInput) (*model.CreditNote, error) {
+	note := input.Domain()
+	if err := r.AccountingUsecase.StoreCreditNote(ctx, appcontext.GetUserID(ctx), &note); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store credit note", libErr.GetCode(err))
+	}
+
+	return model.NewCreditNote(note), nil
+}
+
+// VoidCreditNoteByID is the resolver for the voidCreditNoteByID field.
+func (r *mutationResolver) VoidCreditNoteByID(ctx context.Context, id int) (*model.CreditNote, error) {
+	if err := r.AccountingUsecase.VoidCreditNoteByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on void credit note", libErr.GetCode(err))
+	}
+
+	return r.Query().CreditNote(ctx, id)
+}
+
+// StoreDebitNote is the resolver for the storeDebitNote field.
+func (r *mutationResolver) StoreDebitNote(ctx context.Context, input model.WriteDebitNoteInput) (*model.DebitNote, error) {
+	note := input.Domain()
+	if err := r.AccountingUsecase.StoreDebitNote(ctx, appcontext.GetUserID(ctx), &note); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store debit note", libErr.GetCode(err))
+	}
+
+	return model.NewDebitNote(note), nil
+}
+
+// VoidDebitNoteByID is the resolver for the voidDebitNoteByID field.
+func (r *mutationResolver) VoidDebitNoteByID(ctx context.Context, id int) (*model.DebitNote, error) {
+	if err := r.AccountingUsecase.VoidDebitNoteByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on void debit note", libErr.GetCode(err))
+	}
+
+	return r.Query().DebitNote(ctx, id)
+}
+
+// StoreCompany is the resolver for the storeCompany field.
+func (r *mutationResolver) StoreCompany(ctx context.Context, input model.WriteCompanyInput) (*model.Company, error) {
+	company := input.Domain()
+	if err := r.AccountingUsecase.StoreCompany(ctx, appcontext.GetUserID(ctx), &company); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store company", libErr.GetCode(err))
+	}
+
+	return model.NewCompany(company), nil
+}
+
+// UpdateCompanyByID is the resolver for the updateCompanyByID field.
+func (r *mutationResolver) UpdateCompanyByID(ctx context.Context, id int, input model.WriteCompanyInput) (*model.Company, error) {
+	if err := r.checkCompanyAccess(ctx, int64(id)); err != nil {
+		return nil, err
+	}
+
+	company := input.Domain()
+	if err := r.AccountingUsecase.UpdateCompanyByID(ctx, int64(id), &company); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update company", libErr.GetCode(err))
+	}
+
+	return r.Query().Company(ctx, id)
+}
+
+// AddCompanyUser is the resolver for the addCompanyUser field.
+func (r *mutationResolver) AddCompanyUser(ctx context.Context, companyID int, userID string) (*model.Company, error) {
+	if err := r.checkCompanyAccess(ctx, int64(companyID)); err != nil {
+		return nil, err
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid user id", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.AddCompanyUser(ctx, int64(companyID), user); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on add company user", libErr.GetCode(err))
+	}
+
+	return r.Query().Company(ctx, companyID)
+}
+
+// RemoveCompanyUser is the resolver for the removeCompanyUser field.
+func (r *mutationResolver) RemoveCompanyUser(ctx context.Context, companyID int, userID string) (*model.Company, error) {
+	if err := r.checkCompanyAccess(ctx, int64(companyID)); err != nil {
+		return nil, err
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid user id", libErr.GetCode(err))
+	}
+
+	company, err := r.AccountingUsecase.GetCompanyByID(ctx, int64(companyID))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get company", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.RemoveCompanyUser(ctx, int64(companyID), user); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on remove company user", libErr.GetCode(err))
+	}
+
+	return model.NewCompany(company), nil
+}
+
+// StoreGroupAccount is the resolver for the storeGroupAccount field.
+func (r *mutationResolver) StoreGroupAccount(ctx context.Context, input model.WriteGroupAccountInput) (*model.GroupAccount, error) {
+	groupAccount := input.Domain()
+	if err := r.AccountingUsecase.StoreGroupAccount(ctx, &groupAccount); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store group account", libErr.GetCode(err))
+	}
+
+	return model.NewGroupAccount(groupAccount), nil
+}
+
+// UpdateGroupAccountByID is the resolver for the updateGroupAccountByID field.
+func (r *mutationResolver) UpdateGroupAccountByID(ctx context.Context, id int, input model.WriteGroupAccountInput) (*model.GroupAccount, error) {
+	groupAccount := input.Domain()
+	if err := r.AccountingUsecase.UpdateGroupAccountByID(ctx, int64(id), &groupAccount); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update group account", libErr.GetCode(err))
+	}
+
+	return model.NewGroupAccount(groupAccount), nil
+}
+
+// MapAccountToGroupAccount is the resolver for the mapAccountToGroupAccount field.
+func (r *mutationResolver) MapAccountToGroupAccount(ctx context.Context, accountID int, groupAccountID *int) (*model.Account, error) {
+	var groupAccount int64
+	if groupAccountID != nil {
+		groupAccount = int64(*groupAccountID)
+	}
+
+	if err := r.AccountingUsecase.MapAccountToGroupAccount(ctx, int64(accountID), groupAccount); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on map account to group account", libErr.GetCode(err))
+	}
+
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, int64(accountID))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// StoreIntercompanyTransaction is the resolver for the storeIntercompanyTransaction field.
+func (r *mutationResolver) StoreIntercompanyTransaction(ctx context.Context, input model.WriteIntercompanyTransactionInput) (*model.IntercompanyTransaction, error) {
+	if err := r.checkCompanyAccess(ctx, input.ToCompanyID); err != nil {
+		return nil, err
+	}
+
+	transaction := input.Domain()
+	if err := r.AccountingUsecase.StoreIntercompanyTransaction(ctx, appcontext.GetUserID(ctx), &transaction); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store intercompany transaction", libErr.GetCode(err))
+	}
+
+	return model.NewIntercompanyTransaction(transaction), nil
+}
+
+// VoidIntercompanyTransactionByID is the resolver for the voidIntercompanyTransactionByID field.
+func (r *mutationResolver) VoidIntercompanyTransactionByID(ctx context.Context, id int) (*model.IntercompanyTransaction, error) {
+	transaction, err := r.AccountingUsecase.GetIntercompanyTransactionByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get intercompany transaction", libErr.GetCode(err))
+	}
+
+	for _, companyID := range []int64{transaction.FromCompanyID, transaction.ToCompanyID} {
+		if err = r.checkCompanyAccess(ctx, companyID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.AccountingUsecase.VoidIntercompanyTransactionByID(ctx, int64(id), appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on void intercompany transaction", libErr.GetCode(err))
+	}
+
+	return r.Query().IntercompanyTransaction(ctx, id)
+}
+
+// UpdateJournalNumberFormat is the resolver for the updateJournalNumberFormat field.
+func (r *mutationResolver) UpdateJournalNumberFormat(ctx context.Context, typeID int, format string) (*model.JournalNumberFormat, error) {
+	if err := r.AccountingUsecase.UpdateJournalNumberFormatByTypeID(ctx, int64(typeID), appcontext.GetUserID(ctx), format); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on update journal number format", libErr.GetCode(err))
+	}
+
+	journalNumberFormat, err := r.AccountingUsecase.GetJournalNumberFormat(ctx, sql.JournalNumberFormatStatement{TypeID: int64(typeID)})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal number format", libErr.GetCode(err))
+	}
+
+	return &model.JournalNumberFormat{
+		TypeID: journalNumberFormat.TypeID,
+		Format: journalNumberFormat.Format,
+	}, nil
+}
+
+// AttachToJournal is the resolver for the attachToJournal field.
+func (r *mutationResolver) AttachToJournal(ctx context.Context, journalID string, file graphql.Upload) (*model.Attachment, error) {
+	id, err := uuid.Parse(journalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	attachment := domain.Attachment{
+		JournalID:   uuid.NullUUID{UUID: id, Valid: true},
+		FileName:    file.Filename,
+		ContentType: file.ContentType,
+	}
+
+	if err = r.AccountingUsecase.StoreAttachment(ctx, appcontext.GetUserID(ctx), &attachment, file.File); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store attachment", libErr.GetCode(err))
+	}
+
+	return model.NewAttachment(attachment, r.AccountingUsecase.GetAttachmentDownloadURL(attachment)), nil
+}
+
+// AttachToBankTransaction is the resolver for the attachToBankTransaction field.
+func (r *mutationResolver) AttachToBankTransaction(ctx context.Context, bankTransactionID int, file graphql.Upload) (*model.Attachment, error) {
+	attachment := domain.Attachment{
+		BankTransactionID: goSql.NullInt64{Int64: int64(bankTransactionID), Valid: true},
+		FileName:          file.Filename,
+		ContentType:       file.ContentType,
+	}
+
+	if err := r.AccountingUsecase.StoreAttachment(ctx, appcontext.GetUserID(ctx), &attachment, file.File); err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on store attachment", libErr.GetCode(err))
+	}
+
+	return model.NewAttachment(attachment, r.AccountingUsecase.GetAttachmentDownloadURL(attachment)), nil
+}
+
+// DeleteAttachment is the resolver for the deleteAttachment field.
+func (r *mutationResolver) DeleteAttachment(ctx context.Context, id string) (string, error) {
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return "", sdkGraphql.NewError(err, "Invalid attachment id", libErr.GetCode(err))
+	}
+
+	if err = r.AccountingUsecase.DeleteAttachmentByID(ctx, attachmentID, appcontext.GetUserID(ctx)); err != nil {
+		r.Logger.Error(err.Error())
+		return "", sdkGraphql.NewError(err, "Failed on delete attachment", libErr.GetCode(err))
+	}
+
+	return id, nil
+}
+
+// Bill is the resolver for the bill field.
+func (r *paymentAllocationResolver) Bill(ctx context.Context, obj *model.PaymentAllocation) (*model.PurchaseBill, error) {
+	return r.Query().PurchaseBill(ctx, int(obj.BillID))
+}
+
+// Vendor is the resolver for the vendor field.
+func (r *purchaseBillResolver) Vendor(ctx context.Context, obj *model.PurchaseBill) (*model.Vendor, error) {
+	return r.Query().Vendor(ctx, int(obj.VendorID))
+}
+
+// Lines is the resolver for the lines field.
+func (r *purchaseBillResolver) Lines(ctx context.Context, obj *model.PurchaseBill) ([]*model.PurchaseBillLine, error) {
+	lines, err := r.AccountingUsecase.GetAllPurchaseBillLines(ctx, sql.PurchaseBillLineStatement{BillID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get purchase bill lines", libErr.GetCode(err))
+	}
+
+	result := make([]*model.PurchaseBillLine, len(lines))
+	for i, line := range lines {
+		result[i] = model.NewPurchaseBillLine(line)
+	}
+
+	return result, nil
+}
+
+// Allocations is the resolver for the allocations field.
+func (r *purchaseBillResolver) Allocations(ctx context.Context, obj *model.PurchaseBill) ([]*model.PaymentAllocation, error) {
+	allocations, err := r.AccountingUsecase.GetAllPaymentAllocations(ctx, sql.PaymentAllocationStatement{BillID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get payment allocations", libErr.GetCode(err))
+	}
+
+	result := make([]*model.PaymentAllocation, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = model.NewPaymentAllocation(allocation)
+	}
+
+	return result, nil
+}
+
+// DebitNotes is the resolver for the debitNotes field.
+func (r *purchaseBillResolver) DebitNotes(ctx context.Context, obj *model.PurchaseBill) ([]*model.DebitNote, error) {
+	notes, err := r.AccountingUsecase.GetAllDebitNotes(ctx, sql.DebitNoteStatement{BillID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get debit notes", libErr.GetCode(err))
+	}
+
+	result := make([]*model.DebitNote, len(notes))
+	for i, note := range notes {
+		result[i] = model.NewDebitNote(note)
+	}
+
+	return result, nil
+}
+
+// Journal is the resolver for the journal field.
+func (r *purchaseBillResolver) Journal(ctx context.Context, obj *model.PurchaseBill) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
+}
+
+// Account is the resolver for the account field.
+func (r *purchaseBillLineResolver) Account(ctx context.Context, obj *model.PurchaseBillLine) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// AccountClasses is the resolver for the accountClasses field.
+func (r *queryResolver) AccountClasses(ctx context.Context) ([]*model.AccountClass, error) {
+	accountClasses, err := r.AccountingUsecase.GetAllAccountClasses(ctx, sql.AccountClassStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account classes", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AccountClass, len(accountClasses))
+	for i, accountClass := range accountClasses {
+		result[i] = model.NewAccountClass(accountClass)
+	}
+
+	return result, nil
+}
+
+// AccountClass is the resolver for the accountClass field.
+func (r *queryResolver) AccountClass(ctx context.Context, input model.AccountClassInput) (*model.AccountClass, error) {
+	accountClass, err := r.AccountingUsecase.GetAccountClass(ctx, sql.AccountClassStatement{
+		ID: int64(input.ID),
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account class", libErr.GetCode(err))
+	}
+
+	return model.NewAccountClass(accountClass), nil
+}
+
+// ChartOfAccounts is the resolver for the chartOfAccounts field.
+func (r *queryResolver) ChartOfAccounts(ctx context.Context, withBalances *bool) ([]*model.AccountTreeClass, error) {
+	classes, err := r.AccountingUsecase.GetAccountTree(ctx, withBalances != nil && *withBalances)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get chart of accounts", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AccountTreeClass, len(classes))
+	for i, class := range classes {
+		result[i] = model.NewAccountTreeClass(class)
+	}
+
+	return result, nil
+}
+
+// ChartOfAccountsExport is the resolver for the chartOfAccountsExport field.
+func (r *queryResolver) ChartOfAccountsExport(ctx context.Context, format string) (string, error) {
+	var buf bytes.Buffer
+
+	if err := r.AccountingUsecase.ExportChartOfAccounts(ctx, strings.ToLower(format), &buf); err != nil {
+		r.Logger.Error(err.Error())
+		return "", sdkGraphql.NewError(err, "Failed on export chart of accounts", libErr.GetCode(err))
+	}
+
+	return buf.String(), nil
+}
+
+// ChartOfAccountsTemplates is the resolver for the chartOfAccountsTemplates field.
+func (r *queryResolver) ChartOfAccountsTemplates(ctx context.Context) ([]*model.ChartOfAccountsTemplate, error) {
+	templates, err := r.AccountingUsecase.GetAllChartOfAccountsTemplates()
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get chart of accounts templates", libErr.GetCode(err))
+	}
+
+	result := make([]*model.ChartOfAccountsTemplate, len(templates))
+	for i, template := range templates {
+		result[i] = model.NewChartOfAccountsTemplate(template)
+	}
+
+	return result, nil
+}
+
+// AccountClassTypes is the resolver for the accountClassTypes field.
+func (r *queryResolver) AccountClassTypes(ctx context.Context) (*model.AccountClassTypesResult, error) {
+	result := make([]model.AccountClassType, 0)
+	classTypes := r.AccountingUsecase.GetAllAccountTypes(ctx)
+
+	for _, classType := range classTypes {
+		result = append(result, model.AccountClassType{
+			ID:            classType.ID,
+			Name:          classType.Name,
+			NormalBalance: classType.NormalBalance,
+		})
+	}
+
+	return &model.AccountClassTypesResult{Data: result}, nil
+}
+
+// AccountClassType is the resolver for the accountClassType field.
+func (r *queryResolver) AccountClassType(ctx context.Context, input model.AccountClassTypeInput) (*model.AccountClassType, error) {
+	classType := r.AccountingUsecase.GetAccountClassTypeByID(ctx, input.ID)
+	return &model.AccountClassType{
+		ID:            classType.ID,
+		Name:          classType.Name,
+		NormalBalance: classType.NormalBalance,
+	}, nil
+}
+
+// AccountGroups is the resolver for the accountGroups field.
+func (r *queryResolver) AccountGroups(ctx context.Context, input *model.AccountGroupInput) ([]*model.AccountGroup, error) {
+	var statement sql.AccountGroupStatement
+	if input != nil {
+		statement = sql.AccountGroupStatement{ParentIDIsNULL: input.ParentIDIsNULL}
+	}
+
+	accountGroups, err := r.AccountingUsecase.GetAllAccountGroups(ctx, statement)
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account groups", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AccountGroup, len(accountGroups))
+	for i, accountGroup := range accountGroups {
+		result[i] = model.NewAccountGroup(accountGroup)
+	}
+
+	return result, nil
+}
+
+// AccountGroup is the resolver for the accountGroup field.
+func (r *queryResolver) AccountGroup(ctx context.Context, input model.AccountGroupInput) (*model.AccountGroup, error) {
+	accountGroup, err := r.AccountingUsecase.GetAccountGroup(ctx, sql.AccountGroupStatement{
+		ID: int64(input.ID),
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account group", libErr.GetCode(err))
+	}
+
+	return model.NewAccountGroup(accountGroup), nil
+}
+
+// Accounts is the resolver for the accounts field.
+func (r *queryResolver) Accounts(ctx context.Context, input *model.AccountInput) ([]*model.Account, error) {
+	var stmt sql.AccountStatement
+	if input != nil {
+		stmt.ID = input.ID
+		stmt.ClassType = input.ClassType
+	}
+
+	accounts, err := r.AccountingUsecase.GetAllAccounts(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get accounts", libErr.GetCode(err))
+	}
+
+	var result = make([]*model.Account, len(accounts))
+	for i, account := range accounts {
+		result[i] = model.NewAccount(account)
+	}
+
+	return result, nil
+}
+
+// Account is the resolver for the account field.
+func (r *queryResolver) Account(ctx context.Context, input model.AccountInput) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccount(ctx, sql.AccountStatement{
+		ID: input.ID,
+	})
+
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account group", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// SearchAccounts is the resolver for the searchAccounts field.
+func (r *queryResolver) SearchAccounts(ctx context.Context, query string, limit *int, includeInactive *bool) ([]*model.Account, error) {
+	size := 20
+	if limit != nil && *limit > 0 && *limit <= 100 {
+		size = *limit
+	}
+
+	accounts, err := r.AccountingUsecase.SearchAccounts(ctx, query, includeInactive != nil && *includeInactive, size)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on search accounts", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Account, len(accounts))
+	for i, account := range accounts {
+		result[i] = model.NewAccount(account)
+	}
+
+	return result, nil
+}
+
+// AccountCodeFormats is the resolver for the accountCodeFormats field.
+func (r *queryResolver) AccountCodeFormats(ctx context.Context) ([]*model.AccountCodeFormat, error) {
+	formats, err := r.AccountingUsecase.GetAllAccountCodeFormats(ctx)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account code formats", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AccountCodeFormat, len(formats))
+	for i, format := range formats {
+		result[i] = &model.AccountCodeFormat{
+			ClassTypeID: format.ClassTypeID,
+			Pattern:     format.Pattern,
+		}
+	}
+
+	return result, nil
+}
+
+// GeneralLedgerPreferences is the resolver for the generalLedgerPreferences field.
+func (r *queryResolver) GeneralLedgerPreferences(ctx context.Context, input *model.GeneralLedgerPreferenceInput) ([]*model.GeneralLedgerPreference, error) {
+	var statement sql.GeneralLedgerPreferenceStatement
+	if input != nil {
+		statement = sql.GeneralLedgerPreferenceStatement{ID: input.ID}
+	}
+
+	preferences, err := r.AccountingUsecase.GetAllGeneralLedgerPreferences(ctx, statement)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get general ledger preferences", libErr.GetCode(err))
+	}
+
+	result := make([]*model.GeneralLedgerPreference, len(preferences))
+	for i, preference := range preferences {
+		definition := r.AccountingUsecase.GetGeneralLedgerPreferenceDefinitionByID(ctx, preference.ID)
+		result[i] = model.NewGeneralLedgerPreference(preference, definition)
+	}
+
+	return result, nil
+}
+
+// FiscalYears is the resolver for the fiscalYears field.
+func (r *queryResolver) FiscalYears(ctx context.Context, input *model.FiscalYearsInput) (*model.FiscalYearsResult, error) {
+	var (
+		paging qb.Paging
+	)
+
+	if input != nil {
+		paging = qb.Paging{
+			CurrentPage: input.Paging.CurrentPage,
+			PageSize:    input.Paging.PageSize,
+		}
+	}
+
+	fiscalYears, paging, err := r.AccountingUsecase.GetFiscalYearList(ctx, sql.FiscalYearStatement{}, paging)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal year list", libErr.GetCode(err))
+	}
+
+	resultData := make([]model.FiscalYear, len(fiscalYears))
+	for i, fiscalYear := range fiscalYears {
+		resultData[i] = model.FiscalYear{
+			ID:        fiscalYear.ID,
+			StartDate: fiscalYear.StartDate,
+			EndDate:   fiscalYear.EndDate,
+			Closed:    fiscalYear.Closed,
+		}
+	}
+
+	return &model.FiscalYearsResult{
+		Data: resultData,
+		Paging: model.Paging{
+			CurrentPage: paging.CurrentPage,
+			PageSize:    paging.PageSize,
+			Total:       paging.Total,
+		},
+	}, nil
+}
+
+// FiscalPeriods is the resolver for the fiscalPeriods field.
+func (r *queryResolver) FiscalPeriods(ctx context.Context, input model.FiscalPeriodsInput) ([]*model.FiscalPeriod, error) {
+	periods, err := r.AccountingUsecase.GetAllFiscalPeriods(ctx, sql.FiscalPeriodStatement{FiscalYearID: input.FiscalYearID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fiscal periods", libErr.GetCode(err))
+	}
+
+	result := make([]*model.FiscalPeriod, len(periods))
+	for i, period := range periods {
+		result[i] = model.NewFiscalPeriod(period)
+	}
+
+	return result, nil
+}
+
+// ClosingJournal is the resolver for the closingJournal field.
+func (r *queryResolver) ClosingJournal(ctx context.Context, fiscalYearID int) (*model.ClosingJournal, error) {
+	closingJournal, err := r.AccountingUsecase.GetClosingJournal(ctx, int64(fiscalYearID))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get closing journal", libErr.GetCode(err))
+	}
+
+	return model.NewClosingJournal(closingJournal), nil
+}
+
+// BankAccountTypes is the resolver for the bankAccountTypes field.
+func (r *queryResolver) BankAccountTypes(ctx context.Context) (*model.BankAccountTypesResult, error) {
+	bankAccountTypes := r.AccountingUsecase.GetAllBankAccountTypes(ctx)
+
+	data := make([]model.BankAccountType, len(bankAccountTypes))
+	for i, bankAccountType := range bankAccountTypes {
+		data[i] = model.BankAccountType{ID: bankAccountType.ID, Name: bankAccountType.Name}
+	}
+
+	return &model.BankAccountTypesResult{Data: data}, nil
+}
+
+// BankAccounts is the resolver for the bankAccounts field.
+func (r *queryResolver) BankAccounts(ctx context.Context, input *model.BankAccountsInput) (*model.BankAccountsResult, error) {
+	var (
+		paging qb.Paging
+		stmt   sql.BankAccountStatement
+	)
+
+	if input != nil {
+		paging = qb.Paging{
+			CurrentPage: input.Paging.CurrentPage,
+			PageSize:    input.Paging.PageSize,
+		}
+
+		stmt = sql.BankAccountStatement{ID: input.Scope.ID}
 	}
 
 	bankAccounts, paging, err := r.AccountingUsecase.GetBankAccountList(ctx, stmt, paging)
 
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get bank account list", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get bank account list", libErr.GetCode(err))
+	}
+
+	data := make([]model.BankAccount, len(bankAccounts))
+	for i, bankAccount := range bankAccounts {
+		data[i] = model.BankAccount{
+			ID:         bankAccount.ID,
+			AccountID:  bankAccount.AccountID,
+			TypeID:     bankAccount.TypeID,
+			BankNumber: bankAccount.BankNumber.String,
+			Inactive:   bankAccount.Inactive,
+		}
+	}
+
+	return &model.BankAccountsResult{
+		Data: data,
+		Paging: model.Paging{
+			CurrentPage: paging.CurrentPage,
+			PageSize:    paging.PageSize,
+			Total:       paging.Total,
+		},
+	}, nil
+}
+
+// BankAccount is the resolver for the bankAccount field.
+func (r *queryResolver) BankAccount(ctx context.Context, input model.BankAccountInput) (*model.BankAccount, error) {
+	bankAccount, err := r.AccountingUsecase.GetBankAccount(ctx, sql.BankAccountStatement{ID: input.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get bank account", libErr.GetCode(err))
+	}
+
+	return &model.BankAccount{
+		ID:         bankAccount.ID,
+		AccountID:  bankAccount.AccountID,
+		TypeID:     bankAccount.TypeID,
+		BankNumber: bankAccount.BankNumber.String,
+		Inactive:   bankAccount.Inactive,
+	}, nil
+}
+
+// JournalDrafts is the resolver for the journalDrafts field.
+func (r *queryResolver) JournalDrafts(ctx context.Context, input *model.JournalDraftsInput) (*model.JournalDraftsResult, error) {
+	var (
+		paging qb.Paging
+		stmt   sql.JournalDraftStatement
+	)
+
+	if input != nil {
+		paging = qb.Paging{
+			CurrentPage: input.Paging.CurrentPage,
+			PageSize:    input.Paging.PageSize,
+		}
+
+		stmt.StatusID = input.Scope.StatusID
+		if input.Scope.CreatedBy != "" {
+			createdBy, err := uuid.Parse(input.Scope.CreatedBy)
+			if err != nil {
+				return nil, sdkGraphql.NewError(err, "Invalid creator id", libErr.GetCode(err))
+			}
+
+			stmt.CreatedBy = createdBy
+		}
+	}
+
+	drafts, paging, err := r.AccountingUsecase.GetJournalDraftList(ctx, stmt, paging)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal draft list", libErr.GetCode(err))
+	}
+
+	data := make([]*model.JournalDraft, len(drafts))
+	for i, draft := range drafts {
+		data[i] = model.NewJournalDraft(draft)
+	}
+
+	return &model.JournalDraftsResult{
+		Data: data,
+		Paging: model.Paging{
+			CurrentPage: paging.CurrentPage,
+			PageSize:    paging.PageSize,
+			Total:       paging.Total,
+		},
+	}, nil
+}
+
+// JournalDraft is the resolver for the journalDraft field.
+func (r *queryResolver) JournalDraft(ctx context.Context, id string) (*model.JournalDraft, error) {
+	draftID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal draft id", libErr.GetCode(err))
+	}
+
+	draft, err := r.AccountingUsecase.GetJournalDraftByID(ctx, draftID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal draft", libErr.GetCode(err))
+	}
+
+	return model.NewJournalDraft(draft), nil
+}
+
+// ApprovalRules is the resolver for the approvalRules field.
+func (r *queryResolver) ApprovalRules(ctx context.Context) ([]*model.ApprovalRule, error) {
+	rules, err := r.AccountingUsecase.GetAllApprovalRules(ctx, sql.ApprovalRuleStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get approval rules", libErr.GetCode(err))
+	}
+
+	result := make([]*model.ApprovalRule, len(rules))
+	for i, rule := range rules {
+		result[i] = model.NewApprovalRule(rule)
+	}
+
+	return result, nil
+}
+
+// Budgets is the resolver for the budgets field.
+func (r *queryResolver) Budgets(ctx context.Context, fiscalYearID *int) ([]*model.Budget, error) {
+	var stmt sql.BudgetStatement
+	if fiscalYearID != nil {
+		stmt.FiscalYearID = int64(*fiscalYearID)
+	}
+
+	budgets, err := r.AccountingUsecase.GetAllBudgets(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get budgets", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Budget, len(budgets))
+	for i, budget := range budgets {
+		result[i] = model.NewBudget(budget)
+	}
+
+	return result, nil
+}
+
+// Budget is the resolver for the budget field.
+func (r *queryResolver) Budget(ctx context.Context, id int) (*model.Budget, error) {
+	budget, err := r.AccountingUsecase.GetBudgetByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get budget", libErr.GetCode(err))
+	}
+
+	return model.NewBudget(budget), nil
+}
+
+// BudgetVsActual is the resolver for the budgetVsActual field.
+func (r *queryResolver) BudgetVsActual(ctx context.Context, input model.BudgetVsActualInput) (*model.BudgetVsActual, error) {
+	var fromPeriodID, toPeriodID int64
+	if input.FromPeriodID != nil {
+		fromPeriodID = *input.FromPeriodID
+	}
+
+	if input.ToPeriodID != nil {
+		toPeriodID = *input.ToPeriodID
+	}
+
+	report, err := r.AccountingUsecase.GetBudgetVsActual(ctx, input.BudgetID, fromPeriodID, toPeriodID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get budget vs actual", libErr.GetCode(err))
+	}
+
+	return model.NewBudgetVsActual(report), nil
+}
+
+// AmortizationSchedules is the resolver for the amortizationSchedules field.
+func (r *queryResolver) AmortizationSchedules(ctx context.Context, statusID *int) ([]*model.AmortizationSchedule, error) {
+	var stmt sql.AmortizationScheduleStatement
+	if statusID != nil {
+		stmt.StatusID = int64(*statusID)
+	}
+
+	schedules, err := r.AccountingUsecase.GetAllAmortizationSchedules(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get amortization schedules", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AmortizationSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = model.NewAmortizationSchedule(schedule)
+	}
+
+	return result, nil
+}
+
+// AmortizationSchedule is the resolver for the amortizationSchedule field.
+func (r *queryResolver) AmortizationSchedule(ctx context.Context, id int) (*model.AmortizationSchedule, error) {
+	schedule, err := r.AccountingUsecase.GetAmortizationScheduleByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get amortization schedule", libErr.GetCode(err))
+	}
+
+	return model.NewAmortizationSchedule(schedule), nil
+}
+
+// AssetCategories is the resolver for the assetCategories field.
+func (r *queryResolver) AssetCategories(ctx context.Context) ([]*model.AssetCategory, error) {
+	categories, err := r.AccountingUsecase.GetAllAssetCategories(ctx, sql.AssetCategoryStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get asset categories", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AssetCategory, len(categories))
+	for i, category := range categories {
+		result[i] = model.NewAssetCategory(category)
+	}
+
+	return result, nil
+}
+
+// FixedAssets is the resolver for the fixedAssets field.
+func (r *queryResolver) FixedAssets(ctx context.Context, categoryID *int, statusID *int) ([]*model.FixedAsset, error) {
+	var stmt sql.FixedAssetStatement
+	if categoryID != nil {
+		stmt.CategoryID = int64(*categoryID)
+	}
+
+	if statusID != nil {
+		stmt.StatusID = int64(*statusID)
+	}
+
+	assets, err := r.AccountingUsecase.GetAllFixedAssets(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fixed assets", libErr.GetCode(err))
+	}
+
+	result := make([]*model.FixedAsset, len(assets))
+	for i, asset := range assets {
+		result[i] = model.NewFixedAsset(asset)
+	}
+
+	return result, nil
+}
+
+// FixedAsset is the resolver for the fixedAsset field.
+func (r *queryResolver) FixedAsset(ctx context.Context, id int) (*model.FixedAsset, error) {
+	asset, err := r.AccountingUsecase.GetFixedAssetByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fixed asset", libErr.GetCode(err))
+	}
+
+	return model.NewFixedAsset(asset), nil
+}
+
+// DepreciationRuns is the resolver for the depreciationRuns field.
+func (r *queryResolver) DepreciationRuns(ctx context.Context) ([]*model.DepreciationRun, error) {
+	runs, err := r.AccountingUsecase.GetAllDepreciationRuns(ctx, sql.DepreciationRunStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get depreciation runs", libErr.GetCode(err))
+	}
+
+	result := make([]*model.DepreciationRun, len(runs))
+	for i, run := range runs {
+		result[i] = model.NewDepreciationRun(run)
+	}
+
+	return result, nil
+}
+
+// FixedAssetRegister is the resolver for the fixedAssetRegister field.
+func (r *queryResolver) FixedAssetRegister(ctx context.Context, asOf *time.Time) ([]*model.FixedAssetRegisterRow, error) {
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	rows, err := r.AccountingUsecase.GetFixedAssetRegister(ctx, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get fixed asset register", libErr.GetCode(err))
+	}
+
+	result := make([]*model.FixedAssetRegisterRow, len(rows))
+	for i, row := range rows {
+		result[i] = model.NewFixedAssetRegisterRow(row)
+	}
+
+	return result, nil
+}
+
+// Customers is the resolver for the customers field.
+func (r *queryResolver) Customers(ctx context.Context) ([]*model.Customer, error) {
+	customers, err := r.AccountingUsecase.GetAllCustomers(ctx, sql.CustomerStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get customers", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Customer, len(customers))
+	for i, customer := range customers {
+		result[i] = model.NewCustomer(customer)
+	}
+
+	return result, nil
+}
+
+// Customer is the resolver for the customer field.
+func (r *queryResolver) Customer(ctx context.Context, id int) (*model.Customer, error) {
+	customer, err := r.AccountingUsecase.GetCustomerByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get customer", libErr.GetCode(err))
+	}
+
+	return model.NewCustomer(customer), nil
+}
+
+// SalesInvoices is the resolver for the salesInvoices field.
+func (r *queryResolver) SalesInvoices(ctx context.Context, customerID *int) ([]*model.SalesInvoice, error) {
+	var stmt sql.SalesInvoiceStatement
+	if customerID != nil {
+		stmt.CustomerID = int64(*customerID)
+	}
+
+	invoices, err := r.AccountingUsecase.GetAllSalesInvoices(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get sales invoices", libErr.GetCode(err))
+	}
+
+	result := make([]*model.SalesInvoice, len(invoices))
+	for i, invoice := range invoices {
+		result[i] = model.NewSalesInvoice(invoice)
+	}
+
+	return result, nil
+}
+
+// SalesInvoice is the resolver for the salesInvoice field.
+func (r *queryResolver) SalesInvoice(ctx context.Context, id int) (*model.SalesInvoice, error) {
+	invoice, err := r.AccountingUsecase.GetSalesInvoiceByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get sales invoice", libErr.GetCode(err))
+	}
+
+	return model.NewSalesInvoice(invoice), nil
+}
+
+// CustomerReceipts is the resolver for the customerReceipts field.
+func (r *queryResolver) CustomerReceipts(ctx context.Context, customerID *int) ([]*model.CustomerReceipt, error) {
+	var stmt sql.CustomerReceiptStatement
+	if customerID != nil {
+		stmt.CustomerID = int64(*customerID)
+	}
+
+	receipts, err := r.AccountingUsecase.GetAllCustomerReceipts(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get customer receipts", libErr.GetCode(err))
+	}
+
+	result := make([]*model.CustomerReceipt, len(receipts))
+	for i, receipt := range receipts {
+		result[i] = model.NewCustomerReceipt(receipt)
+	}
+
+	return result, nil
+}
+
+// CustomerReceipt is the resolver for the customerReceipt field.
+func (r *queryResolver) CustomerReceipt(ctx context.Context, id int) (*model.CustomerReceipt, error) {
+	receipt, err := r.AccountingUsecase.GetCustomerReceiptByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get customer receipt", libErr.GetCode(err))
+	}
+
+	return model.NewCustomerReceipt(receipt), nil
+}
+
+// ReceivableAging is the resolver for the receivableAging field.
+func (r *queryResolver) ReceivableAging(ctx context.Context, asOf *time.Time) (*model.AgingReport, error) {
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	report, err := r.AccountingUsecase.GetReceivableAging(ctx, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get receivable aging", libErr.GetCode(err))
+	}
+
+	return model.NewAgingReport(report), nil
+}
+
+// CustomerStatement is the resolver for the customerStatement field.
+func (r *queryResolver) CustomerStatement(ctx context.Context, customerID int, startDate time.Time, endDate time.Time) (*model.PartyStatement, error) {
+	statement, err := r.AccountingUsecase.GetCustomerAccountStatement(ctx, int64(customerID), startDate, endDate)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get customer statement", libErr.GetCode(err))
+	}
+
+	return model.NewPartyStatement(statement), nil
+}
+
+// Vendors is the resolver for the vendors field.
+func (r *queryResolver) Vendors(ctx context.Context) ([]*model.Vendor, error) {
+	vendors, err := r.AccountingUsecase.GetAllVendors(ctx, sql.VendorStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get vendors", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Vendor, len(vendors))
+	for i, vendor := range vendors {
+		result[i] = model.NewVendor(vendor)
+	}
+
+	return result, nil
+}
+
+// Vendor is the resolver for the vendor field.
+func (r *queryResolver) Vendor(ctx context.Context, id int) (*model.Vendor, error) {
+	vendor, err := r.AccountingUsecase.GetVendorByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get vendor", libErr.GetCode(err))
+	}
+
+	return model.NewVendor(vendor), nil
+}
+
+// PurchaseBills is the resolver for the purchaseBills field.
+func (r *queryResolver) PurchaseBills(ctx context.Context, vendorID *int) ([]*model.PurchaseBill, error) {
+	var stmt sql.PurchaseBillStatement
+	if vendorID != nil {
+		stmt.VendorID = int64(*vendorID)
+	}
+
+	bills, err := r.AccountingUsecase.GetAllPurchaseBills(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get purchase bills", libErr.GetCode(err))
+	}
+
+	result := make([]*model.PurchaseBill, len(bills))
+	for i, bill := range bills {
+		result[i] = model.NewPurchaseBill(bill)
+	}
+
+	return result, nil
+}
+
+// PurchaseBill is the resolver for the purchaseBill field.
+func (r *queryResolver) PurchaseBill(ctx context.Context, id int) (*model.PurchaseBill, error) {
+	bill, err := r.AccountingUsecase.GetPurchaseBillByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get purchase bill", libErr.GetCode(err))
+	}
+
+	return model.NewPurchaseBill(bill), nil
+}
+
+// VendorPayments is the resolver for the vendorPayments field.
+func (r *queryResolver) VendorPayments(ctx context.Context, vendorID *int) ([]*model.VendorPayment, error) {
+	var stmt sql.VendorPaymentStatement
+	if vendorID != nil {
+		stmt.VendorID = int64(*vendorID)
+	}
+
+	payments, err := r.AccountingUsecase.GetAllVendorPayments(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get vendor payments", libErr.GetCode(err))
+	}
+
+	result := make([]*model.VendorPayment, len(payments))
+	for i, payment := range payments {
+		result[i] = model.NewVendorPayment(payment)
+	}
+
+	return result, nil
+}
+
+// VendorPayment is the resolver for the vendorPayment field.
+func (r *queryResolver) VendorPayment(ctx context.Context, id int) (*model.VendorPayment, error) {
+	payment, err := r.AccountingUsecase.GetVendorPaymentByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get vendor payment", libErr.GetCode(err))
+	}
+
+	return model.NewVendorPayment(payment), nil
+}
+
+// PayableAging is the resolver for the payableAging field.
+func (r *queryResolver) PayableAging(ctx context.Context, asOf *time.Time) (*model.AgingReport, error) {
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	report, err := r.AccountingUsecase.GetPayableAging(ctx, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get payable aging", libErr.GetCode(err))
+	}
+
+	return model.NewAgingReport(report), nil
+}
+
+// VendorStatement is the resolver for the vendorStatement field.
+func (r *queryResolver) VendorStatement(ctx context.Context, vendorID int, startDate time.Time, endDate time.Time) (*model.PartyStatement, error) {
+	statement, err := r.AccountingUsecase.GetVendorAccountStatement(ctx, int64(vendorID), startDate, endDate)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get vendor statement", libErr.GetCode(err))
+	}
+
+	return model.NewPartyStatement(statement), nil
+}
+
+// BillsDue is the resolver for the billsDue field.
+func (r *queryResolver) BillsDue(ctx context.Context, through *time.Time) ([]*model.PurchaseBill, error) {
+	// a payment run covers the bills due through the coming Sunday unless told otherwise
+	date := time.Now()
+	date = date.AddDate(0, 0, (7-int(date.Weekday()))%7)
+	if through != nil {
+		date = *through
+	}
+
+	bills, err := r.AccountingUsecase.GetAllPurchaseBillsDue(ctx, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get bills due", libErr.GetCode(err))
+	}
+
+	result := make([]*model.PurchaseBill, len(bills))
+	for i, bill := range bills {
+		result[i] = model.NewPurchaseBill(bill)
+	}
+
+	return result, nil
+}
+
+// CreditNotes is the resolver for the creditNotes field.
+func (r *queryResolver) CreditNotes(ctx context.Context, invoiceID *int) ([]*model.CreditNote, error) {
+	var stmt sql.CreditNoteStatement
+	if invoiceID != nil {
+		stmt.InvoiceID = int64(*invoiceID)
+	}
+
+	notes, err := r.AccountingUsecase.GetAllCreditNotes(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get credit notes", libErr.GetCode(err))
+	}
+
+	result := make([]*model.CreditNote, len(notes))
+	for i, note := range notes {
+		result[i] = model.NewCreditNote(note)
+	}
+
+	return result, nil
+}
+
+// CreditNote is the resolver for the creditNote field.
+func (r *queryResolver) CreditNote(ctx context.Context, id int) (*model.CreditNote, error) {
+	note, err := r.AccountingUsecase.GetCreditNoteByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get credit note", libErr.GetCode(err))
+	}
+
+	return model.NewCreditNote(note), nil
+}
+
+// DebitNotes is the resolver for the debitNotes field.
+func (r *queryResolver) DebitNotes(ctx context.Context, billID *int) ([]*model.DebitNote, error) {
+	var stmt sql.DebitNoteStatement
+	if billID != nil {
+		stmt.BillID = int64(*billID)
+	}
+
+	notes, err := r.AccountingUsecase.GetAllDebitNotes(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get debit notes", libErr.GetCode(err))
+	}
+
+	result := make([]*model.DebitNote, len(notes))
+	for i, note := range notes {
+		result[i] = model.NewDebitNote(note)
+	}
+
+	return result, nil
+}
+
+// DebitNote is the resolver for the debitNote field.
+func (r *queryResolver) DebitNote(ctx context.Context, id int) (*model.DebitNote, error) {
+	note, err := r.AccountingUsecase.GetDebitNoteByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get debit note", libErr.GetCode(err))
+	}
+
+	return model.NewDebitNote(note), nil
+}
+
+// Companies is the resolver for the companies field.
+func (r *queryResolver) Companies(ctx context.Context) ([]*model.Company, error) {
+	companies, err := r.AccountingUsecase.GetAllCompaniesByUserID(ctx, appcontext.GetUserID(ctx))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get companies", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Company, len(companies))
+	for i, company := range companies {
+		result[i] = model.NewCompany(company)
+	}
+
+	return result, nil
+}
+
+// Company is the resolver for the company field.
+func (r *queryResolver) Company(ctx context.Context, id int) (*model.Company, error) {
+	if err := r.checkCompanyAccess(ctx, int64(id)); err != nil {
+		return nil, err
+	}
+
+	company, err := r.AccountingUsecase.GetCompanyByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get company", libErr.GetCode(err))
+	}
+
+	return model.NewCompany(company), nil
+}
+
+// GroupAccounts is the resolver for the groupAccounts field.
+func (r *queryResolver) GroupAccounts(ctx context.Context) ([]*model.GroupAccount, error) {
+	groupAccounts, err := r.AccountingUsecase.GetAllGroupAccounts(ctx, sql.GroupAccountStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get group accounts", libErr.GetCode(err))
+	}
+
+	result := make([]*model.GroupAccount, len(groupAccounts))
+	for i, groupAccount := range groupAccounts {
+		result[i] = model.NewGroupAccount(groupAccount)
+	}
+
+	return result, nil
+}
+
+// GroupAccount is the resolver for the groupAccount field.
+func (r *queryResolver) GroupAccount(ctx context.Context, id int) (*model.GroupAccount, error) {
+	groupAccount, err := r.AccountingUsecase.GetGroupAccountByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get group account", libErr.GetCode(err))
+	}
+
+	return model.NewGroupAccount(groupAccount), nil
+}
+
+// ConsolidatedTrialBalance is the resolver for the consolidatedTrialBalance field.
+func (r *queryResolver) ConsolidatedTrialBalance(ctx context.Context, companyIDs []int, asOf *time.Time) (*model.ConsolidatedTrialBalance, error) {
+	ids, err := r.consolidatedCompanyIDs(ctx, companyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	report, err := r.AccountingUsecase.GetConsolidatedTrialBalance(ctx, ids, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get consolidated trial balance", libErr.GetCode(err))
 	}
 
-	data := make([]model.BankAccount, len(bankAccounts))
-	for i, bankAccount := range bankAccounts {
-		data[i] = model.BankAccount{
-			ID:         bankAccount.ID,
-			AccountID:  bankAccount.AccountID,
-			TypeID:     bankAccount.TypeID,
-			BankNumber: bankAccount.BankNumber.String,
-			Inactive:   bankAccount.Inactive,
+	return model.NewConsolidatedTrialBalance(report), nil
+}
+
+// ConsolidatedBalanceSheet is the resolver for the consolidatedBalanceSheet field.
+func (r *queryResolver) ConsolidatedBalanceSheet(ctx context.Context, companyIDs []int, asOf *time.Time) (*model.ConsolidatedBalanceSheet, error) {
+	ids, err := r.consolidatedCompanyIDs(ctx, companyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	report, err := r.AccountingUsecase.GetConsolidatedBalanceSheet(ctx, ids, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get consolidated balance sheet", libErr.GetCode(err))
+	}
+
+	return model.NewConsolidatedBalanceSheet(report), nil
+}
+
+// IntercompanyTransactions is the resolver for the intercompanyTransactions field.
+func (r *queryResolver) IntercompanyTransactions(ctx context.Context) ([]*model.IntercompanyTransaction, error) {
+	transactions, err := r.AccountingUsecase.GetAllIntercompanyTransactions(ctx, sql.IntercompanyTransactionStatement{})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get intercompany transactions", libErr.GetCode(err))
+	}
+
+	result := make([]*model.IntercompanyTransaction, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = model.NewIntercompanyTransaction(transaction)
+	}
+
+	return result, nil
+}
+
+// IntercompanyTransaction is the resolver for the intercompanyTransaction field.
+func (r *queryResolver) IntercompanyTransaction(ctx context.Context, id int) (*model.IntercompanyTransaction, error) {
+	transaction, err := r.AccountingUsecase.GetIntercompanyTransactionByID(ctx, int64(id))
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get intercompany transaction", libErr.GetCode(err))
+	}
+
+	return model.NewIntercompanyTransaction(transaction), nil
+}
+
+// IntercompanyReconciliation is the resolver for the intercompanyReconciliation field.
+func (r *queryResolver) IntercompanyReconciliation(ctx context.Context, companyIDs []int, asOf *time.Time) ([]*model.IntercompanyBalance, error) {
+	var ids []int64
+
+	if companyIDs == nil {
+		companies, err := r.AccountingUsecase.GetAllCompaniesByUserID(ctx, appcontext.GetUserID(ctx))
+		if err != nil {
+			r.Logger.Error(err.Error())
+			return nil, sdkGraphql.NewError(err, "Failed on get companies", libErr.GetCode(err))
+		}
+
+		for _, company := range companies {
+			ids = append(ids, company.ID)
+		}
+	} else {
+		var err error
+		if ids, err = r.consolidatedCompanyIDs(ctx, companyIDs); err != nil {
+			return nil, err
 		}
 	}
 
-	return &model.BankAccountsResult{
+	date := time.Now()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	balances, err := r.AccountingUsecase.GetIntercompanyReconciliation(ctx, ids, date)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get intercompany reconciliation", libErr.GetCode(err))
+	}
+
+	result := make([]*model.IntercompanyBalance, len(balances))
+	for i, balance := range balances {
+		result[i] = model.NewIntercompanyBalance(balance)
+	}
+
+	return result, nil
+}
+
+// AuditLog is the resolver for the auditLog field.
+func (r *queryResolver) AuditLog(ctx context.Context, entity *string, entityID *string, from *time.Time, to *time.Time, user *string) ([]*model.AuditLog, error) {
+	var stmt sql.AuditLogStatement
+	if entity != nil {
+		stmt.Entity = *entity
+	}
+
+	if entityID != nil {
+		stmt.EntityID = *entityID
+	}
+
+	if from != nil {
+		stmt.CreatedAtGTE = *from
+	}
+
+	if to != nil {
+		stmt.CreatedAtLTE = *to
+	}
+
+	if user != nil {
+		userID, err := uuid.Parse(*user)
+		if err != nil {
+			return nil, sdkGraphql.NewError(err, "Invalid user id", libErr.GetCode(err))
+		}
+
+		stmt.UserID = userID
+	}
+
+	logs, err := r.AccountingUsecase.GetAllAuditLogs(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get audit log", libErr.GetCode(err))
+	}
+
+	result := make([]*model.AuditLog, len(logs))
+	for i, log := range logs {
+		result[i] = model.NewAuditLog(log)
+	}
+
+	return result, nil
+}
+
+// VerifyAuditLog is the resolver for the verifyAuditLog field.
+func (r *queryResolver) VerifyAuditLog(ctx context.Context) (*model.AuditLogVerification, error) {
+	verification, err := r.AccountingUsecase.VerifyAuditLog(ctx)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on verify audit log", libErr.GetCode(err))
+	}
+
+	return model.NewAuditLogVerification(verification), nil
+}
+
+// VerifyLedger is the resolver for the verifyLedger field.
+func (r *queryResolver) VerifyLedger(ctx context.Context) (*model.LedgerVerification, error) {
+	verification, err := r.AccountingUsecase.VerifyLedger(ctx)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on verify ledger", libErr.GetCode(err))
+	}
+
+	return model.NewLedgerVerification(verification), nil
+}
+
+// JournalImport is the resolver for the journalImport field.
+func (r *queryResolver) JournalImport(ctx context.Context, batchID string) (*model.JournalImport, error) {
+	journalImport, err := r.AccountingUsecase.GetJournalImportByBatchID(ctx, batchID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal import", libErr.GetCode(err))
+	}
+
+	return model.NewJournalImport(journalImport), nil
+}
+
+// GeneralLedgers is the resolver for the generalLedgers field.
+func (r *queryResolver) GeneralLedgers(ctx context.Context, input *model.GeneralLedgersInput) (*model.GeneralLedgersResult, error) {
+	var (
+		paging qb.Paging
+		stmt   sql.GeneralLedgerStatement
+		search string
+	)
+
+	if input != nil {
+		paging = qb.Paging{
+			CurrentPage: input.Paging.CurrentPage,
+			PageSize:    input.Paging.PageSize,
+		}
+
+		search = input.Search
+		stmt.AccountID = input.Scope.AccountID
+		if input.Scope.JournalID != "" {
+			journalID, err := uuid.Parse(input.Scope.JournalID)
+			if err != nil {
+				return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+			}
+
+			stmt.JournalID = journalID
+		}
+	}
+
+	gls, paging, err := r.AccountingUsecase.SearchGeneralLedgers(ctx, stmt, search, paging)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on search general ledgers", libErr.GetCode(err))
+	}
+
+	data := make([]*model.GeneralLedger, len(gls))
+	for i, gl := range gls {
+		data[i] = model.NewGeneralLedger(gl)
+	}
+
+	return &model.GeneralLedgersResult{
 		Data: data,
 		Paging: model.Paging{
 			CurrentPage: paging.CurrentPage,
@@ -815,21 +3509,145 @@ func (r *queryResolver) BankAccounts(ctx context.Context, input *model.BankAccou
 	}, nil
 }
 
-// BankAccount is the resolver for the bankAccount field.
-func (r *queryResolver) BankAccount(ctx context.Context, input model.BankAccountInput) (*model.BankAccount, error) {
-	bankAccount, err := r.AccountingUsecase.GetBankAccount(ctx, sql.BankAccountStatement{ID: input.ID})
+// JournalNumberFormats is the resolver for the journalNumberFormats field.
+func (r *queryResolver) JournalNumberFormats(ctx context.Context) ([]*model.JournalNumberFormat, error) {
+	formats, err := r.AccountingUsecase.GetAllJournalNumberFormats(ctx)
 	if err != nil {
 		r.Logger.Error(err.Error())
-		return nil, sdkGraphql.NewError(err, "Failed on get bank account", libErr.GetCode(err))
+		return nil, sdkGraphql.NewError(err, "Failed on get journal number formats", libErr.GetCode(err))
 	}
 
-	return &model.BankAccount{
-		ID:         bankAccount.ID,
-		AccountID:  bankAccount.AccountID,
-		TypeID:     bankAccount.TypeID,
-		BankNumber: bankAccount.BankNumber.String,
-		Inactive:   bankAccount.Inactive,
-	}, nil
+	result := make([]*model.JournalNumberFormat, len(formats))
+	for i, format := range formats {
+		result[i] = &model.JournalNumberFormat{
+			TypeID: format.TypeID,
+			Format: format.Format,
+		}
+	}
+
+	return result, nil
+}
+
+// Invoice is the resolver for the invoice field.
+func (r *receiptAllocationResolver) Invoice(ctx context.Context, obj *model.ReceiptAllocation) (*model.SalesInvoice, error) {
+	return r.Query().SalesInvoice(ctx, int(obj.InvoiceID))
+}
+
+// Customer is the resolver for the customer field.
+func (r *salesInvoiceResolver) Customer(ctx context.Context, obj *model.SalesInvoice) (*model.Customer, error) {
+	return r.Query().Customer(ctx, int(obj.CustomerID))
+}
+
+// Lines is the resolver for the lines field.
+func (r *salesInvoiceResolver) Lines(ctx context.Context, obj *model.SalesInvoice) ([]*model.SalesInvoiceLine, error) {
+	lines, err := r.AccountingUsecase.GetAllSalesInvoiceLines(ctx, sql.SalesInvoiceLineStatement{InvoiceID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get sales invoice lines", libErr.GetCode(err))
+	}
+
+	result := make([]*model.SalesInvoiceLine, len(lines))
+	for i, line := range lines {
+		result[i] = model.NewSalesInvoiceLine(line)
+	}
+
+	return result, nil
+}
+
+// Allocations is the resolver for the allocations field.
+func (r *salesInvoiceResolver) Allocations(ctx context.Context, obj *model.SalesInvoice) ([]*model.ReceiptAllocation, error) {
+	allocations, err := r.AccountingUsecase.GetAllReceiptAllocations(ctx, sql.ReceiptAllocationStatement{InvoiceID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get receipt allocations", libErr.GetCode(err))
+	}
+
+	result := make([]*model.ReceiptAllocation, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = model.NewReceiptAllocation(allocation)
+	}
+
+	return result, nil
+}
+
+// CreditNotes is the resolver for the creditNotes field.
+func (r *salesInvoiceResolver) CreditNotes(ctx context.Context, obj *model.SalesInvoice) ([]*model.CreditNote, error) {
+	notes, err := r.AccountingUsecase.GetAllCreditNotes(ctx, sql.CreditNoteStatement{InvoiceID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get credit notes", libErr.GetCode(err))
+	}
+
+	result := make([]*model.CreditNote, len(notes))
+	for i, note := range notes {
+		result[i] = model.NewCreditNote(note)
+	}
+
+	return result, nil
+}
+
+// Journal is the resolver for the journal field.
+func (r *salesInvoiceResolver) Journal(ctx context.Context, obj *model.SalesInvoice) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
+}
+
+// Account is the resolver for the account field.
+func (r *salesInvoiceLineResolver) Account(ctx context.Context, obj *model.SalesInvoiceLine) (*model.Account, error) {
+	account, err := r.AccountingUsecase.GetAccountByID(ctx, obj.AccountID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get account", libErr.GetCode(err))
+	}
+
+	return model.NewAccount(account), nil
+}
+
+// Vendor is the resolver for the vendor field.
+func (r *vendorPaymentResolver) Vendor(ctx context.Context, obj *model.VendorPayment) (*model.Vendor, error) {
+	return r.Query().Vendor(ctx, int(obj.VendorID))
+}
+
+// Allocations is the resolver for the allocations field.
+func (r *vendorPaymentResolver) Allocations(ctx context.Context, obj *model.VendorPayment) ([]*model.PaymentAllocation, error) {
+	allocations, err := r.AccountingUsecase.GetAllPaymentAllocations(ctx, sql.PaymentAllocationStatement{PaymentID: obj.ID})
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get payment allocations", libErr.GetCode(err))
+	}
+
+	result := make([]*model.PaymentAllocation, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = model.NewPaymentAllocation(allocation)
+	}
+
+	return result, nil
+}
+
+// Journal is the resolver for the journal field.
+func (r *vendorPaymentResolver) Journal(ctx context.Context, obj *model.VendorPayment) (*model.Journal, error) {
+	journalID, err := uuid.Parse(obj.JournalID)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
 }
 
 // Account returns generated.AccountResolver implementation.
@@ -841,16 +3659,190 @@ func (r *Resolver) AccountClass() generated.AccountClassResolver { return &accou
 // AccountGroup returns generated.AccountGroupResolver implementation.
 func (r *Resolver) AccountGroup() generated.AccountGroupResolver { return &accountGroupResolver{r} }
 
+// AccountTreeAccount returns generated.AccountTreeAccountResolver implementation.
+func (r *Resolver) AccountTreeAccount() generated.AccountTreeAccountResolver {
+	return &accountTreeAccountResolver{r}
+}
+
+// AccountTreeClass returns generated.AccountTreeClassResolver implementation.
+func (r *Resolver) AccountTreeClass() generated.AccountTreeClassResolver {
+	return &accountTreeClassResolver{r}
+}
+
+// AccountTreeGroup returns generated.AccountTreeGroupResolver implementation.
+func (r *Resolver) AccountTreeGroup() generated.AccountTreeGroupResolver {
+	return &accountTreeGroupResolver{r}
+}
+
+// AmortizationEntry returns generated.AmortizationEntryResolver implementation.
+func (r *Resolver) AmortizationEntry() generated.AmortizationEntryResolver {
+	return &amortizationEntryResolver{r}
+}
+
+// AmortizationSchedule returns generated.AmortizationScheduleResolver implementation.
+func (r *Resolver) AmortizationSchedule() generated.AmortizationScheduleResolver {
+	return &amortizationScheduleResolver{r}
+}
+
+// ApprovalRule returns generated.ApprovalRuleResolver implementation.
+func (r *Resolver) ApprovalRule() generated.ApprovalRuleResolver { return &approvalRuleResolver{r} }
+
+// AssetCategory returns generated.AssetCategoryResolver implementation.
+func (r *Resolver) AssetCategory() generated.AssetCategoryResolver { return &assetCategoryResolver{r} }
+
 // BankAccount returns generated.BankAccountResolver implementation.
 func (r *Resolver) BankAccount() generated.BankAccountResolver { return &bankAccountResolver{r} }
 
+// BankTransaction returns generated.BankTransactionResolver implementation.
+func (r *Resolver) BankTransaction() generated.BankTransactionResolver {
+	return &bankTransactionResolver{r}
+}
+
+// Budget returns generated.BudgetResolver implementation.
+func (r *Resolver) Budget() generated.BudgetResolver { return &budgetResolver{r} }
+
+// BudgetVsActualRow returns generated.BudgetVsActualRowResolver implementation.
+func (r *Resolver) BudgetVsActualRow() generated.BudgetVsActualRowResolver {
+	return &budgetVsActualRowResolver{r}
+}
+
+// ClosingJournalLine returns generated.ClosingJournalLineResolver implementation.
+func (r *Resolver) ClosingJournalLine() generated.ClosingJournalLineResolver {
+	return &closingJournalLineResolver{r}
+}
+
+// ConsolidationRow returns generated.ConsolidationRowResolver implementation.
+func (r *Resolver) ConsolidationRow() generated.ConsolidationRowResolver {
+	return &consolidationRowResolver{r}
+}
+
+// CreditNote returns generated.CreditNoteResolver implementation.
+func (r *Resolver) CreditNote() generated.CreditNoteResolver { return &creditNoteResolver{r} }
+
+// CustomerReceipt returns generated.CustomerReceiptResolver implementation.
+func (r *Resolver) CustomerReceipt() generated.CustomerReceiptResolver {
+	return &customerReceiptResolver{r}
+}
+
+// DebitNote returns generated.DebitNoteResolver implementation.
+func (r *Resolver) DebitNote() generated.DebitNoteResolver { return &debitNoteResolver{r} }
+
+// DepreciationEntry returns generated.DepreciationEntryResolver implementation.
+func (r *Resolver) DepreciationEntry() generated.DepreciationEntryResolver {
+	return &depreciationEntryResolver{r}
+}
+
+// DepreciationRun returns generated.DepreciationRunResolver implementation.
+func (r *Resolver) DepreciationRun() generated.DepreciationRunResolver {
+	return &depreciationRunResolver{r}
+}
+
+// FiscalPeriod returns generated.FiscalPeriodResolver implementation.
+func (r *Resolver) FiscalPeriod() generated.FiscalPeriodResolver { return &fiscalPeriodResolver{r} }
+
+// FiscalYear returns generated.FiscalYearResolver implementation.
+func (r *Resolver) FiscalYear() generated.FiscalYearResolver { return &fiscalYearResolver{r} }
+
+// FixedAsset returns generated.FixedAssetResolver implementation.
+func (r *Resolver) FixedAsset() generated.FixedAssetResolver { return &fixedAssetResolver{r} }
+
+// GeneralLedger returns generated.GeneralLedgerResolver implementation.
+func (r *Resolver) GeneralLedger() generated.GeneralLedgerResolver { return &generalLedgerResolver{r} }
+
 // GeneralLedgerPreference returns generated.GeneralLedgerPreferenceResolver implementation.
 func (r *Resolver) GeneralLedgerPreference() generated.GeneralLedgerPreferenceResolver {
 	return &generalLedgerPreferenceResolver{r}
 }
 
+// GroupAccount returns generated.GroupAccountResolver implementation.
+func (r *Resolver) GroupAccount() generated.GroupAccountResolver { return &groupAccountResolver{r} }
+
+// IntercompanyTransaction returns generated.IntercompanyTransactionResolver implementation.
+func (r *Resolver) IntercompanyTransaction() generated.IntercompanyTransactionResolver {
+	return &intercompanyTransactionResolver{r}
+}
+
+// Journal returns generated.JournalResolver implementation.
+func (r *Resolver) Journal() generated.JournalResolver { return &journalResolver{r} }
+
+// JournalDraft returns generated.JournalDraftResolver implementation.
+func (r *Resolver) JournalDraft() generated.JournalDraftResolver { return &journalDraftResolver{r} }
+
+// JournalDraftLine returns generated.JournalDraftLineResolver implementation.
+func (r *Resolver) JournalDraftLine() generated.JournalDraftLineResolver {
+	return &journalDraftLineResolver{r}
+}
+
+// JournalImportJournal returns generated.JournalImportJournalResolver implementation.
+func (r *Resolver) JournalImportJournal() generated.JournalImportJournalResolver {
+	return &journalImportJournalResolver{r}
+}
+
+// PaymentAllocation returns generated.PaymentAllocationResolver implementation.
+func (r *Resolver) PaymentAllocation() generated.PaymentAllocationResolver {
+	return &paymentAllocationResolver{r}
+}
+
+// PurchaseBill returns generated.PurchaseBillResolver implementation.
+func (r *Resolver) PurchaseBill() generated.PurchaseBillResolver { return &purchaseBillResolver{r} }
+
+// PurchaseBillLine returns generated.PurchaseBillLineResolver implementation.
+func (r *Resolver) PurchaseBillLine() generated.PurchaseBillLineResolver {
+	return &purchaseBillLineResolver{r}
+}
+
+// ReceiptAllocation returns generated.ReceiptAllocationResolver implementation.
+func (r *Resolver) ReceiptAllocation() generated.ReceiptAllocationResolver {
+	return &receiptAllocationResolver{r}
+}
+
+// SalesInvoice returns generated.SalesInvoiceResolver implementation.
+func (r *Resolver) SalesInvoice() generated.SalesInvoiceResolver { return &salesInvoiceResolver{r} }
+
+// SalesInvoiceLine returns generated.SalesInvoiceLineResolver implementation.
+func (r *Resolver) SalesInvoiceLine() generated.SalesInvoiceLineResolver {
+	return &salesInvoiceLineResolver{r}
+}
+
+// VendorPayment returns generated.VendorPaymentResolver implementation.
+func (r *Resolver) VendorPayment() generated.VendorPaymentResolver { return &vendorPaymentResolver{r} }
+
 type accountResolver struct{ *Resolver }
 type accountClassResolver struct{ *Resolver }
 type accountGroupResolver struct{ *Resolver }
+type accountTreeAccountResolver struct{ *Resolver }
+type accountTreeClassResolver struct{ *Resolver }
+type accountTreeGroupResolver struct{ *Resolver }
+type amortizationEntryResolver struct{ *Resolver }
+type amortizationScheduleResolver struct{ *Resolver }
+type approvalRuleResolver struct{ *Resolver }
+type assetCategoryResolver struct{ *Resolver }
 type bankAccountResolver struct{ *Resolver }
+type bankTransactionResolver struct{ *Resolver }
+type budgetResolver struct{ *Resolver }
+type budgetVsActualRowResolver struct{ *Resolver }
+type closingJournalLineResolver struct{ *Resolver }
+type consolidationRowResolver struct{ *Resolver }
+type creditNoteResolver struct{ *Resolver }
+type customerReceiptResolver struct{ *Resolver }
+type debitNoteResolver struct{ *Resolver }
+type depreciationEntryResolver struct{ *Resolver }
+type depreciationRunResolver struct{ *Resolver }
+type fiscalPeriodResolver struct{ *Resolver }
+type fiscalYearResolver struct{ *Resolver }
+type fixedAssetResolver struct{ *Resolver }
+type generalLedgerResolver struct{ *Resolver }
 type generalLedgerPreferenceResolver struct{ *Resolver }
+type groupAccountResolver struct{ *Resolver }
+type intercompanyTransactionResolver struct{ *Resolver }
+type journalResolver struct{ *Resolver }
+type journalDraftResolver struct{ *Resolver }
+type journalDraftLineResolver struct{ *Resolver }
+type journalImportJournalResolver struct{ *Resolver }
+type paymentAllocationResolver struct{ *Resolver }
+type purchaseBillResolver struct{ *Resolver }
+type purchaseBillLineResolver struct{ *Resolver }
+type receiptAllocationResolver struct{ *Resolver }
+type salesInvoiceResolver struct{ *Resolver }
+type salesInvoiceLineResolver struct{ *Resolver }
+type vendorPaymentResolver struct{ *Resolver }
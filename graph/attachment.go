@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/graph/model"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	sdkGraphql "github.com/QuickAmethyst/monosvc/stdlibgo/graphql"
+	"github.com/google/uuid"
+	"io"
+	"mime"
+	"net/http"
+)
+
+func (r *Resolver) getAllAttachments(ctx context.Context, stmt sql.AttachmentStatement) ([]*model.Attachment, error) {
+	attachments, err := r.AccountingUsecase.GetAllAttachments(ctx, stmt)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get attachments", libErr.GetCode(err))
+	}
+
+	result := make([]*model.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		result[i] = model.NewAttachment(attachment, r.AccountingUsecase.GetAttachmentDownloadURL(attachment))
+	}
+
+	return result, nil
+}
+
+// AttachmentDownloadHandler streams an attachment addressed by a signed download URL, so a link taken from
+// the GraphQL API can be opened directly in the browser.
+func (r *Resolver) AttachmentDownloadHandler(w http.ResponseWriter, req *http.Request) {
+	id, err := uuid.Parse(req.URL.Query().Get(":id"))
+	if err != nil {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+
+	attachment, body, err := r.AccountingUsecase.OpenAttachment(req.Context(), id, req.URL.Query())
+	if err != nil {
+		switch libErr.GetCode(err) {
+		case sql.EcodeAttachmentURLInvalid:
+			http.Error(w, "Download link is invalid or has expired", http.StatusForbidden)
+		case sql.EcodeNotFound:
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+		default:
+			r.Logger.Error(err.Error())
+			http.Error(w, "Failed on open attachment", http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", fmt.Sprint(attachment.Size))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if _, err = io.Copy(w, body); err != nil {
+		r.Logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/QuickAmethyst/monosvc/graph/model"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	sdkGraphql "github.com/QuickAmethyst/monosvc/stdlibgo/graphql"
+	"github.com/google/uuid"
+)
+
+// CompanyDirective runs authenticated and then checks that the user may work in the company the request picks,
+// the default company when it picks none. Only the query and mutation fields are checked, the fields below them
+// are resolved within a field that already was, and fields marked @companyAgnostic are left alone.
+func (r *Resolver) CompanyDirective(authenticated sdkGraphql.Directive) sdkGraphql.Directive {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+		return authenticated(ctx, obj, func(ctx context.Context) (interface{}, error) {
+			fieldContext := graphql.GetFieldContext(ctx)
+			if fieldContext == nil || (fieldContext.Object != "Query" && fieldContext.Object != "Mutation") {
+				return next(ctx)
+			}
+
+			if fieldContext.Field.Definition != nil && fieldContext.Field.Definition.Directives.ForName("companyAgnostic") != nil {
+				return next(ctx)
+			}
+
+			companyID := appcontext.GetCompanyID(ctx)
+			if companyID == 0 {
+				companyID = sql.DefaultCompanyID
+			}
+
+			if err := r.checkCompanyAccess(ctx, companyID); err != nil {
+				return nil, err
+			}
+
+			return next(ctx)
+		})
+	}
+}
+
+// CompanyAgnosticDirective only marks a field for CompanyDirective.
+func (r *Resolver) CompanyAgnosticDirective(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	return next(ctx)
+}
+
+func (r *Resolver) checkCompanyAccess(ctx context.Context, companyID int64) error {
+	hasAccess, err := r.AccountingUsecase.HasCompanyAccess(ctx, appcontext.GetUserID(ctx), companyID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return sdkGraphql.NewError(err, "Failed on check company access", libErr.GetCode(err))
+	}
+
+	if !hasAccess {
+		return sdkGraphql.NewError(fmt.Errorf("no access to company %d", companyID), "Company access denied", sql.EcodeCompanyAccessDenied)
+	}
+
+	return nil
+}
+
+// consolidatedCompanyIDs checks that the user may work in every company of a consolidation.
+func (r *Resolver) consolidatedCompanyIDs(ctx context.Context, companyIDs []int) ([]int64, error) {
+	ids := make([]int64, len(companyIDs))
+	for i, id := range companyIDs {
+		if err := r.checkCompanyAccess(ctx, int64(id)); err != nil {
+			return nil, err
+		}
+
+		ids[i] = int64(id)
+	}
+
+	return ids, nil
+}
+
+// companyContext checks that the user may work in a company and picks it for the rest of the request,
+// for the intercompany fields that reach into the ledger of another company.
+func (r *Resolver) companyContext(ctx context.Context, companyID int64) (context.Context, error) {
+	if err := r.checkCompanyAccess(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	return appcontext.SetCompanyID(ctx, companyID), nil
+}
+
+// intercompanyJournal gets one side of an intercompany transaction from the ledger of its company.
+func (r *Resolver) intercompanyJournal(ctx context.Context, companyID int64, id string) (*model.Journal, error) {
+	journalID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, sdkGraphql.NewError(err, "Invalid journal id", libErr.GetCode(err))
+	}
+
+	ctx, err = r.companyContext(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	journal, err := r.AccountingUsecase.GetJournalByID(ctx, journalID)
+	if err != nil {
+		r.Logger.Error(err.Error())
+		return nil, sdkGraphql.NewError(err, "Failed on get journal", libErr.GetCode(err))
+	}
+
+	return model.NewJournal(journal), nil
+}
@@ -1,24 +1,44 @@
 package model
 
 import (
+	"database/sql"
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
 	"time"
 )
 
 type AccountClass struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	TypeID   int64  `json:"typeID"`
-	Inactive bool   `json:"inactive"`
+	ID       int64   `json:"id"`
+	Code     *string `json:"code"`
+	Name     string  `json:"name"`
+	TypeID   int64   `json:"typeID"`
+	Inactive bool    `json:"inactive"`
+}
+
+func NewAccountClass(accountClass domain.AccountClass) *AccountClass {
+	result := &AccountClass{
+		ID:       accountClass.ID,
+		Name:     accountClass.Name,
+		TypeID:   accountClass.TypeID,
+		Inactive: accountClass.Inactive,
+	}
+
+	if accountClass.Code.Valid {
+		result.Code = &accountClass.Code.String
+	}
+
+	return result
 }
 
 type WriteAccountClassInput struct {
+	Code     string `json:"code"`
 	Name     string `json:"name"`
 	TypeID   int64  `json:"typeID"`
 	Inactive bool   `json:"inactive"`
 }
 
 func (w *WriteAccountClassInput) Domain() (accountClass domain.AccountClass) {
+	accountClass.Code = sql.NullString{String: w.Code, Valid: w.Code != ""}
 	accountClass.Name = w.Name
 	accountClass.TypeID = w.TypeID
 	accountClass.Inactive = w.Inactive
@@ -30,8 +50,9 @@ type AccountClassInput struct {
 }
 
 type AccountClassType struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	NormalBalance string `json:"normalBalance"`
 }
 
 type AccountClassesInput struct {
@@ -47,14 +68,32 @@ type AccountClassTypesResult struct {
 }
 
 type AccountGroup struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	ClassID  int64  `json:"classID"`
-	ParentID int64  `json:"parentID"`
-	Inactive bool   `json:"inactive"`
+	ID       int64   `json:"id"`
+	Code     *string `json:"code"`
+	Name     string  `json:"name"`
+	ClassID  int64   `json:"classID"`
+	ParentID int64   `json:"parentID"`
+	Inactive bool    `json:"inactive"`
+}
+
+func NewAccountGroup(accountGroup domain.AccountGroup) *AccountGroup {
+	result := &AccountGroup{
+		ID:       accountGroup.ID,
+		Name:     accountGroup.Name,
+		ClassID:  accountGroup.ClassID,
+		ParentID: accountGroup.ParentID.Int64,
+		Inactive: accountGroup.Inactive,
+	}
+
+	if accountGroup.Code.Valid {
+		result.Code = &accountGroup.Code.String
+	}
+
+	return result
 }
 
 type WriteAccountGroupInput struct {
+	Code     string `json:"code"`
 	Name     string `json:"name"`
 	ClassID  int64  `json:"classID"`
 	ParentID *int64 `json:"parentID"`
@@ -62,6 +101,7 @@ type WriteAccountGroupInput struct {
 }
 
 func (w *WriteAccountGroupInput) Domain() (accountGroup domain.AccountGroup, err error) {
+	accountGroup.Code = sql.NullString{String: w.Code, Valid: w.Code != ""}
 	accountGroup.Name = w.Name
 	accountGroup.ClassID = w.ClassID
 	accountGroup.Inactive = w.Inactive
@@ -82,22 +122,51 @@ type AccountGroupInput struct {
 }
 
 type Account struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	GroupID  int64  `json:"groupID"`
-	Inactive bool   `json:"inactive"`
+	ID             int64   `json:"id"`
+	Code           *string `json:"code"`
+	Name           string  `json:"name"`
+	GroupID        int64   `json:"groupID"`
+	Inactive       bool    `json:"inactive"`
+	GroupAccountID *int64  `json:"groupAccountID"`
+
+	NormalBalanceOverride *string `json:"normalBalanceOverride"`
+}
+
+func NewAccount(account domain.Account) *Account {
+	result := &Account{
+		ID:       account.ID,
+		Name:     account.Name,
+		GroupID:  account.GroupID,
+		Inactive: account.Inactive,
+	}
+
+	if account.Code.Valid {
+		result.Code = &account.Code.String
+	}
+
+	if account.GroupAccountID.Valid {
+		result.GroupAccountID = &account.GroupAccountID.Int64
+	}
+
+	result.NormalBalanceOverride = nullString(account.NormalBalance)
+
+	return result
 }
 
 type WriteAccountInput struct {
-	Name     string `json:"name"`
-	GroupID  int64  `json:"groupID"`
-	Inactive bool   `json:"inactive"`
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	GroupID       int64  `json:"groupID"`
+	Inactive      bool   `json:"inactive"`
+	NormalBalance string `json:"normalBalance"`
 }
 
 func (w *WriteAccountInput) Domain() (account domain.Account) {
+	account.Code = sql.NullString{String: w.Code, Valid: w.Code != ""}
 	account.Name = w.Name
 	account.GroupID = w.GroupID
 	account.Inactive = w.Inactive
+	account.NormalBalance = sql.NullString{String: w.NormalBalance, Valid: w.NormalBalance != ""}
 	return
 }
 
@@ -106,16 +175,362 @@ type AccountInput struct {
 	ClassType int64 `json:"classType"`
 }
 
+type AccountTreeClass struct {
+	ID            int64               `json:"id"`
+	Code          *string             `json:"code"`
+	Name          string              `json:"name"`
+	TypeID        int64               `json:"typeID"`
+	Inactive      bool                `json:"inactive"`
+	Balance       *float64            `json:"balance"`
+	NormalBalance string              `json:"normalBalance"`
+	Groups        []*AccountTreeGroup `json:"groups"`
+}
+
+func NewAccountTreeClass(class domain.AccountTreeClass) *AccountTreeClass {
+	return &AccountTreeClass{
+		ID:       class.ID,
+		Code:     nullString(class.Code),
+		Name:     class.Name,
+		TypeID:   class.TypeID,
+		Inactive: class.Inactive,
+		Balance:  nullFloat64(class.Balance),
+		Groups:   newAccountTreeGroups(class.Groups),
+
+		NormalBalance: class.NormalBalance,
+	}
+}
+
+type AccountTreeGroup struct {
+	ID            int64                 `json:"id"`
+	Code          *string               `json:"code"`
+	Name          string                `json:"name"`
+	ParentID      *int64                `json:"parentID"`
+	Inactive      bool                  `json:"inactive"`
+	Balance       *float64              `json:"balance"`
+	NormalBalance string                `json:"normalBalance"`
+	Groups        []*AccountTreeGroup   `json:"groups"`
+	Accounts      []*AccountTreeAccount `json:"accounts"`
+}
+
+func newAccountTreeGroups(groups []domain.AccountTreeGroup) []*AccountTreeGroup {
+	result := make([]*AccountTreeGroup, len(groups))
+	for i, group := range groups {
+		result[i] = &AccountTreeGroup{
+			ID:       group.ID,
+			Code:     nullString(group.Code),
+			Name:     group.Name,
+			Inactive: group.Inactive,
+			Balance:  nullFloat64(group.Balance),
+			Groups:   newAccountTreeGroups(group.Groups),
+			Accounts: make([]*AccountTreeAccount, len(group.Accounts)),
+
+			NormalBalance: group.NormalBalance,
+		}
+
+		if group.ParentID.Valid {
+			result[i].ParentID = &group.ParentID.Int64
+		}
+
+		for j, account := range group.Accounts {
+			result[i].Accounts[j] = &AccountTreeAccount{
+				ID:       account.ID,
+				Code:     nullString(account.Code),
+				Name:     account.Name,
+				Inactive: account.Inactive,
+				Balance:  nullFloat64(account.Balance),
+
+				NormalBalance: account.NormalBalance,
+			}
+		}
+	}
+
+	return result
+}
+
+type AccountTreeAccount struct {
+	ID            int64    `json:"id"`
+	Code          *string  `json:"code"`
+	Name          string   `json:"name"`
+	Inactive      bool     `json:"inactive"`
+	Balance       *float64 `json:"balance"`
+	NormalBalance string   `json:"normalBalance"`
+}
+
+func nullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.String
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func nullFloat64(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Float64
+}
+
+// debit is the debit side of a debit positive and credit negative amount, zero for a credit.
+func debit(amount float64) float64 {
+	if amount > 0 {
+		return amount
+	}
+
+	return 0
+}
+
+// credit is the credit side of a debit positive and credit negative amount, zero for a debit.
+func credit(amount float64) float64 {
+	if amount < 0 {
+		return -amount
+	}
+
+	return 0
+}
+
+type ChartOfAccountsTemplate struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ClassCount   int    `json:"classCount"`
+	AccountCount int    `json:"accountCount"`
+}
+
+func NewChartOfAccountsTemplate(template domain.ChartOfAccountsTemplate) *ChartOfAccountsTemplate {
+	var countAccounts func(groups []domain.ChartOfAccountsGroup) int
+	countAccounts = func(groups []domain.ChartOfAccountsGroup) (count int) {
+		for _, group := range groups {
+			count += len(group.Accounts) + countAccounts(group.Groups)
+		}
+
+		return
+	}
+
+	result := &ChartOfAccountsTemplate{
+		Name:        template.Name,
+		Description: template.Description,
+		ClassCount:  len(template.Classes),
+	}
+
+	for _, class := range template.Classes {
+		result.AccountCount += countAccounts(class.Groups)
+	}
+
+	return result
+}
+
+type ChartOfAccountsChange struct {
+	Kind   string   `json:"kind"`
+	Action string   `json:"action"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+type ChartOfAccountsImportResult struct {
+	DryRun  bool                     `json:"dryRun"`
+	Changes []*ChartOfAccountsChange `json:"changes"`
+}
+
+func NewChartOfAccountsImportResult(changes []domain.ChartOfAccountsChange, dryRun bool) *ChartOfAccountsImportResult {
+	result := &ChartOfAccountsImportResult{DryRun: dryRun, Changes: make([]*ChartOfAccountsChange, len(changes))}
+	for i, change := range changes {
+		fields := change.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+
+		result.Changes[i] = &ChartOfAccountsChange{Kind: change.Kind, Action: change.Action, Name: change.Name, Fields: fields}
+	}
+
+	return result
+}
+
+type ImportChartOfAccountsInput struct {
+	Format string          `json:"format"`
+	Data   *string         `json:"data"`
+	File   *graphql.Upload `json:"file"`
+	DryRun *bool           `json:"dryRun"`
+}
+
 type Journal struct {
 	ID        string    `json:"id"`
+	TypeID    int64     `json:"typeID"`
+	Number    *string   `json:"number"`
 	Amount    float64   `json:"amount"`
 	TransDate time.Time `json:"transDate"`
 	CreatedAt time.Time `json:"createdAt"`
+	Closing   bool      `json:"closing"`
+	Opening   bool      `json:"opening"`
 }
 
-type WriteTransactionRow struct {
+func NewJournal(journal domain.Journal) *Journal {
+	result := &Journal{
+		ID:        journal.ID.String(),
+		TypeID:    journal.TypeID,
+		Amount:    journal.Amount,
+		TransDate: journal.TransDate,
+		CreatedAt: journal.CreatedAt,
+		Closing:   journal.Closing,
+		Opening:   journal.Opening,
+	}
+
+	if journal.Number.Valid {
+		result.Number = &journal.Number.String
+	}
+
+	return result
+}
+
+type JournalVersion struct {
+	Version   int64                 `json:"version"`
+	TransDate time.Time             `json:"transDate"`
+	Memo      *string               `json:"memo"`
+	Amount    float64               `json:"amount"`
+	CreatedBy string                `json:"createdBy"`
+	CreatedAt time.Time             `json:"createdAt"`
+	Reason    *string               `json:"reason"`
+	Lines     []*JournalVersionLine `json:"lines"`
+	Changes   []*JournalLineChange  `json:"changes"`
+}
+
+func NewJournalVersion(version domain.JournalVersion) *JournalVersion {
+	result := &JournalVersion{
+		Version:   version.Version,
+		TransDate: version.TransDate,
+		Memo:      nullString(version.Memo),
+		Amount:    version.Amount,
+		CreatedBy: version.CreatedBy.String(),
+		CreatedAt: version.CreatedAt,
+		Reason:    nullString(version.Reason),
+		Lines:     make([]*JournalVersionLine, len(version.Lines)),
+		Changes:   make([]*JournalLineChange, len(version.Changes)),
+	}
+
+	for i, line := range version.Lines {
+		result.Lines[i] = &JournalVersionLine{
+			AccountID:         line.AccountID,
+			Amount:            line.Amount,
+			Debit:             debit(line.Amount),
+			Credit:            credit(line.Amount),
+			Memo:              nullString(line.Memo),
+			ExternalReference: nullString(line.ExternalReference),
+		}
+
+		if line.CounterpartyCompanyID.Valid {
+			result.Lines[i].CounterpartyCompanyID = &version.Lines[i].CounterpartyCompanyID.Int64
+		}
+	}
+
+	for i, change := range version.Changes {
+		result.Changes[i] = &JournalLineChange{AccountID: change.AccountID, Before: change.Before, After: change.After}
+	}
+
+	return result
+}
+
+type JournalVersionLine struct {
+	AccountID             int64   `json:"accountID"`
+	Amount                float64 `json:"amount"`
+	Debit                 float64 `json:"debit"`
+	Credit                float64 `json:"credit"`
+	Memo                  *string `json:"memo"`
+	ExternalReference     *string `json:"externalReference"`
+	CounterpartyCompanyID *int64  `json:"counterpartyCompanyID"`
+}
+
+type JournalLineChange struct {
 	AccountID int64   `json:"accountID"`
-	Amount    float64 `json:"amount"`
+	Before    float64 `json:"before"`
+	After     float64 `json:"after"`
+}
+
+type ImportOpeningBalancesInput struct {
+	Data []WriteTransactionRow `json:"data"`
+	File *graphql.Upload       `json:"file"`
+}
+
+type WriteTransactionRow struct {
+	AccountID             int64    `json:"accountID"`
+	Amount                *float64 `json:"amount"`
+	Debit                 *float64 `json:"debit"`
+	Credit                *float64 `json:"credit"`
+	Memo                  string   `json:"memo"`
+	ExternalReference     string   `json:"externalReference"`
+	CounterpartyCompanyID int64    `json:"counterpartyCompanyID"`
+}
+
+type GeneralLedger struct {
+	ID                    string  `json:"id"`
+	JournalID             string  `json:"journalID"`
+	AccountID             int64   `json:"accountID"`
+	Amount                float64 `json:"amount"`
+	Debit                 float64 `json:"debit"`
+	Credit                float64 `json:"credit"`
+	Memo                  *string `json:"memo"`
+	ExternalReference     *string `json:"externalReference"`
+	CreatedBy             string  `json:"createdBy"`
+	CounterpartyCompanyID *int64  `json:"counterpartyCompanyID"`
+}
+
+func NewGeneralLedger(gl domain.GeneralLedger) *GeneralLedger {
+	result := &GeneralLedger{
+		ID:        gl.ID.String(),
+		JournalID: gl.JournalID.String(),
+		AccountID: gl.AccountID,
+		Amount:    gl.Amount,
+		Debit:     debit(gl.Amount),
+		Credit:    credit(gl.Amount),
+		CreatedBy: gl.CreatedBy.String(),
+	}
+
+	if gl.Memo.Valid {
+		result.Memo = &gl.Memo.String
+	}
+
+	if gl.ExternalReference.Valid {
+		result.ExternalReference = &gl.ExternalReference.String
+	}
+
+	if gl.CounterpartyCompanyID.Valid {
+		result.CounterpartyCompanyID = &gl.CounterpartyCompanyID.Int64
+	}
+
+	return result
+}
+
+type GeneralLedgersResult struct {
+	Data   []*GeneralLedger `json:"data"`
+	Paging Paging           `json:"paging"`
+}
+
+type GeneralLedgersInputScope struct {
+	AccountID int64  `json:"accountID"`
+	JournalID string `json:"journalID"`
+}
+
+type GeneralLedgersInput struct {
+	Scope  GeneralLedgersInputScope `json:"scope"`
+	Search string                   `json:"search"`
+	Paging PagingInput              `json:"paging"`
+}
+
+type AccountCodeFormat struct {
+	ClassTypeID int64  `json:"classTypeID"`
+	Pattern     string `json:"pattern"`
+}
+
+type JournalNumberFormat struct {
+	TypeID int64  `json:"typeID"`
+	Format string `json:"format"`
 }
 
 type WriteTransactionInput struct {
@@ -125,8 +540,25 @@ type WriteTransactionInput struct {
 }
 
 type GeneralLedgerPreference struct {
-	ID        int64 `json:"id"`
-	AccountID int64 `json:"accountID"`
+	ID           int64   `json:"id"`
+	Key          string  `json:"key"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Required     bool    `json:"required"`
+	ClassTypeIDs []int64 `json:"-"`
+	AccountID    int64   `json:"accountID"`
+}
+
+func NewGeneralLedgerPreference(preference domain.GeneralLedgerPreference, definition domain.GeneralLedgerPreferenceDefinition) *GeneralLedgerPreference {
+	return &GeneralLedgerPreference{
+		ID:           preference.ID,
+		Key:          definition.Key,
+		Name:         definition.Name,
+		Description:  definition.Description,
+		Required:     definition.Required,
+		ClassTypeIDs: definition.ClassTypeIDs,
+		AccountID:    preference.AccountID.Int64,
+	}
 }
 
 type WriteGeneralLedgerPreferenceInput struct {
@@ -155,9 +587,10 @@ type FiscalYear struct {
 }
 
 type WriteFiscalYearInput struct {
-	StartDate time.Time `json:"startDate"`
-	EndDate   time.Time `json:"endDate"`
-	Closed    bool      `json:"closed"`
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+	Closed       bool      `json:"closed"`
+	PeriodMonths int       `json:"periodMonths"`
 }
 
 func (w *WriteFiscalYearInput) Domain() (fiscalYear domain.FiscalYear) {
@@ -176,6 +609,81 @@ type FiscalYearsResult struct {
 	Paging Paging       `json:"paging"`
 }
 
+type FiscalYearHistory struct {
+	ID        int64     `json:"id"`
+	ActionID  int64     `json:"actionID"`
+	JournalID *string   `json:"journalID"`
+	Reason    *string   `json:"reason"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type FiscalPeriod struct {
+	ID           int64     `json:"id"`
+	FiscalYearID int64     `json:"fiscalYearID"`
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+	StatusID     int64     `json:"statusID"`
+}
+
+func NewFiscalPeriod(period domain.FiscalPeriod) *FiscalPeriod {
+	return &FiscalPeriod{
+		ID:           period.ID,
+		FiscalYearID: period.FiscalYearID,
+		StartDate:    period.StartDate,
+		EndDate:      period.EndDate,
+		StatusID:     period.StatusID,
+	}
+}
+
+type FiscalPeriodHistory struct {
+	ID           int64     `json:"id"`
+	FromStatusID int64     `json:"fromStatusID"`
+	ToStatusID   int64     `json:"toStatusID"`
+	Reason       *string   `json:"reason"`
+	CreatedBy    string    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type ClosingJournal struct {
+	FiscalYearID int64                `json:"fiscalYearID"`
+	TransDate    time.Time            `json:"transDate"`
+	NetIncome    float64              `json:"netIncome"`
+	Lines        []ClosingJournalLine `json:"lines"`
+}
+
+func NewClosingJournal(closingJournal domain.ClosingJournal) *ClosingJournal {
+	result := &ClosingJournal{
+		FiscalYearID: closingJournal.FiscalYearID,
+		TransDate:    closingJournal.TransDate,
+		NetIncome:    closingJournal.NetIncome,
+		Lines:        make([]ClosingJournalLine, len(closingJournal.Lines)),
+	}
+
+	for i, line := range closingJournal.Lines {
+		result.Lines[i] = ClosingJournalLine{
+			AccountID: line.AccountID,
+			Amount:    line.Amount,
+		}
+	}
+
+	return result
+}
+
+type ClosingJournalLine struct {
+	AccountID int64   `json:"accountID"`
+	Amount    float64 `json:"amount"`
+}
+
+type FiscalPeriodsInput struct {
+	FiscalYearID int64 `json:"fiscalYearID"`
+}
+
+type WriteFiscalPeriodStatusInput struct {
+	StatusID int64  `json:"statusID"`
+	Reason   string `json:"reason"`
+}
+
 type BankAccount struct {
 	ID         int64  `json:"id"`
 	AccountID  int64  `json:"accountID"`
@@ -240,6 +748,43 @@ type BankTransaction struct {
 	CreatedAt     time.Time `json:"createdAt"`
 }
 
+type Attachment struct {
+	ID                string    `json:"id"`
+	JournalID         *string   `json:"journalID"`
+	BankTransactionID *int64    `json:"bankTransactionID"`
+	FileName          string    `json:"fileName"`
+	ContentType       string    `json:"contentType"`
+	Size              int64     `json:"size"`
+	Sha256            string    `json:"sha256"`
+	CreatedBy         string    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+	DownloadURL       string    `json:"downloadURL"`
+}
+
+func NewAttachment(attachment domain.Attachment, downloadURL string) *Attachment {
+	result := &Attachment{
+		ID:          attachment.ID.String(),
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Sha256:      attachment.SHA256,
+		CreatedBy:   attachment.CreatedBy.String(),
+		CreatedAt:   attachment.CreatedAt,
+		DownloadURL: downloadURL,
+	}
+
+	if attachment.JournalID.Valid {
+		journalID := attachment.JournalID.UUID.String()
+		result.JournalID = &journalID
+	}
+
+	if attachment.BankTransactionID.Valid {
+		result.BankTransactionID = &attachment.BankTransactionID.Int64
+	}
+
+	return result
+}
+
 type WriteBankTransactionInput struct {
 	BankAccountID int64                 `json:"bankAccountID"`
 	TransDate     time.Time             `json:"transDate"`
@@ -250,3 +795,1711 @@ type WriteBankTransactionInput struct {
 type AccountClassTransactionIDInput struct {
 	Paging *PagingInput `json:"paging"`
 }
+
+type JournalDraft struct {
+	ID          string     `json:"id"`
+	Amount      float64    `json:"amount"`
+	TransDate   time.Time  `json:"transDate"`
+	Memo        *string    `json:"memo"`
+	StatusID    int64      `json:"statusID"`
+	JournalID   *string    `json:"journalID"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	SubmittedAt *time.Time `json:"submittedAt"`
+}
+
+func NewJournalDraft(draft domain.JournalDraft) *JournalDraft {
+	result := &JournalDraft{
+		ID:        draft.ID.String(),
+		Amount:    draft.Amount,
+		TransDate: draft.TransDate,
+		StatusID:  draft.StatusID,
+		CreatedBy: draft.CreatedBy.String(),
+		CreatedAt: draft.CreatedAt,
+	}
+
+	if draft.Memo.Valid {
+		result.Memo = &draft.Memo.String
+	}
+
+	if draft.JournalID.Valid {
+		journalID := draft.JournalID.UUID.String()
+		result.JournalID = &journalID
+	}
+
+	if draft.SubmittedAt.Valid {
+		result.SubmittedAt = &draft.SubmittedAt.Time
+	}
+
+	return result
+}
+
+type JournalDraftLine struct {
+	ID                string  `json:"id"`
+	AccountID         int64   `json:"accountID"`
+	Amount            float64 `json:"amount"`
+	Memo              *string `json:"memo"`
+	ExternalReference *string `json:"externalReference"`
+}
+
+type JournalDraftReview struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"userID"`
+	Approved  bool      `json:"approved"`
+	Comment   *string   `json:"comment"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type JournalDraftsResult struct {
+	Data   []*JournalDraft `json:"data"`
+	Paging Paging          `json:"paging"`
+}
+
+type JournalDraftsInputScope struct {
+	StatusID  int64  `json:"statusID"`
+	CreatedBy string `json:"createdBy"`
+}
+
+type JournalDraftsInput struct {
+	Scope  JournalDraftsInputScope `json:"scope"`
+	Paging PagingInput             `json:"paging"`
+}
+
+type Budget struct {
+	ID           int64     `json:"id"`
+	FiscalYearID int64     `json:"fiscalYearID"`
+	Name         string    `json:"name"`
+	Description  *string   `json:"description"`
+	CreatedBy    string    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func NewBudget(budget domain.Budget) *Budget {
+	return &Budget{
+		ID:           budget.ID,
+		FiscalYearID: budget.FiscalYearID,
+		Name:         budget.Name,
+		Description:  nullString(budget.Description),
+		CreatedBy:    budget.CreatedBy.String(),
+		CreatedAt:    budget.CreatedAt,
+	}
+}
+
+type WriteBudgetInput struct {
+	FiscalYearID int64   `json:"fiscalYearID"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+}
+
+func (w *WriteBudgetInput) Domain() domain.Budget {
+	budget := domain.Budget{FiscalYearID: w.FiscalYearID, Name: w.Name}
+	if w.Description != nil {
+		budget.Description = sql.NullString{String: *w.Description, Valid: true}
+	}
+
+	return budget
+}
+
+type BudgetLine struct {
+	ID             int64   `json:"id"`
+	BudgetID       int64   `json:"budgetID"`
+	AccountID      int64   `json:"accountID"`
+	FiscalPeriodID int64   `json:"fiscalPeriodID"`
+	Dimension      string  `json:"dimension"`
+	Amount         float64 `json:"amount"`
+}
+
+func NewBudgetLine(line domain.BudgetLine) *BudgetLine {
+	return &BudgetLine{
+		ID:             line.ID,
+		BudgetID:       line.BudgetID,
+		AccountID:      line.AccountID,
+		FiscalPeriodID: line.FiscalPeriodID,
+		Dimension:      line.Dimension,
+		Amount:         line.Amount,
+	}
+}
+
+type WriteBudgetLineInput struct {
+	AccountID      int64   `json:"accountID"`
+	FiscalPeriodID int64   `json:"fiscalPeriodID"`
+	Dimension      *string `json:"dimension"`
+	Amount         float64 `json:"amount"`
+}
+
+func (w *WriteBudgetLineInput) Domain() domain.BudgetLine {
+	line := domain.BudgetLine{AccountID: w.AccountID, FiscalPeriodID: w.FiscalPeriodID, Amount: w.Amount}
+	if w.Dimension != nil {
+		line.Dimension = *w.Dimension
+	}
+
+	return line
+}
+
+type GenerateBudgetFromActualsInput struct {
+	FiscalYearID       int64    `json:"fiscalYearID"`
+	Name               string   `json:"name"`
+	Description        *string  `json:"description"`
+	SourceFiscalYearID *int64   `json:"sourceFiscalYearID"`
+	Percent            *float64 `json:"percent"`
+}
+
+type BudgetVsActual struct {
+	BudgetID  int64                `json:"budgetID"`
+	StartDate time.Time            `json:"startDate"`
+	EndDate   time.Time            `json:"endDate"`
+	Rows      []*BudgetVsActualRow `json:"rows"`
+}
+
+func NewBudgetVsActual(report domain.BudgetVsActual) *BudgetVsActual {
+	result := &BudgetVsActual{
+		BudgetID:  report.BudgetID,
+		StartDate: report.StartDate,
+		EndDate:   report.EndDate,
+		Rows:      make([]*BudgetVsActualRow, len(report.Rows)),
+	}
+
+	for i, row := range report.Rows {
+		result.Rows[i] = &BudgetVsActualRow{
+			Kind:            row.Kind,
+			ID:              row.ID,
+			Code:            nullString(row.Code),
+			Name:            row.Name,
+			Depth:           row.Depth,
+			Budget:          row.Budget,
+			Actual:          row.Actual,
+			Variance:        row.Variance,
+			VariancePercent: nullFloat64(row.VariancePercent),
+			NormalBalance:   row.NormalBalance,
+		}
+	}
+
+	return result
+}
+
+type BudgetVsActualRow struct {
+	Kind            string   `json:"kind"`
+	ID              int64    `json:"id"`
+	Code            *string  `json:"code"`
+	Name            string   `json:"name"`
+	Depth           int      `json:"depth"`
+	Budget          float64  `json:"budget"`
+	Actual          float64  `json:"actual"`
+	Variance        float64  `json:"variance"`
+	VariancePercent *float64 `json:"variancePercent"`
+	NormalBalance   string   `json:"normalBalance"`
+}
+
+type BudgetVsActualInput struct {
+	BudgetID     int64  `json:"budgetID"`
+	FromPeriodID *int64 `json:"fromPeriodID"`
+	ToPeriodID   *int64 `json:"toPeriodID"`
+}
+
+type AmortizationSchedule struct {
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	TotalAmount     float64    `json:"totalAmount"`
+	StartDate       time.Time  `json:"startDate"`
+	EndDate         time.Time  `json:"endDate"`
+	SourceAccountID int64      `json:"sourceAccountID"`
+	TargetAccountID int64      `json:"targetAccountID"`
+	MethodID        int64      `json:"methodID"`
+	StatusID        int64      `json:"statusID"`
+	TerminatedAt    *time.Time `json:"terminatedAt"`
+	CreatedBy       string     `json:"createdBy"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	PostedAmount    float64    `json:"postedAmount"`
+	RemainingAmount float64    `json:"remainingAmount"`
+}
+
+func NewAmortizationSchedule(schedule domain.AmortizationSchedule) *AmortizationSchedule {
+	result := &AmortizationSchedule{
+		ID:              schedule.ID,
+		Name:            schedule.Name,
+		TotalAmount:     schedule.TotalAmount,
+		StartDate:       schedule.StartDate,
+		EndDate:         schedule.EndDate,
+		SourceAccountID: schedule.SourceAccountID,
+		TargetAccountID: schedule.TargetAccountID,
+		MethodID:        schedule.MethodID,
+		StatusID:        schedule.StatusID,
+		CreatedBy:       schedule.CreatedBy.String(),
+		CreatedAt:       schedule.CreatedAt,
+		PostedAmount:    schedule.PostedAmount,
+		RemainingAmount: schedule.TotalAmount - schedule.PostedAmount,
+	}
+
+	if schedule.TerminatedAt.Valid {
+		result.TerminatedAt = &schedule.TerminatedAt.Time
+	}
+
+	return result
+}
+
+type WriteAmortizationScheduleInput struct {
+	Name            string    `json:"name"`
+	TotalAmount     float64   `json:"totalAmount"`
+	StartDate       time.Time `json:"startDate"`
+	EndDate         time.Time `json:"endDate"`
+	SourceAccountID int64     `json:"sourceAccountID"`
+	TargetAccountID int64     `json:"targetAccountID"`
+	MethodID        *int64    `json:"methodID"`
+}
+
+func (w *WriteAmortizationScheduleInput) Domain() domain.AmortizationSchedule {
+	schedule := domain.AmortizationSchedule{
+		Name:            w.Name,
+		TotalAmount:     w.TotalAmount,
+		StartDate:       w.StartDate,
+		EndDate:         w.EndDate,
+		SourceAccountID: w.SourceAccountID,
+		TargetAccountID: w.TargetAccountID,
+	}
+
+	if w.MethodID != nil {
+		schedule.MethodID = *w.MethodID
+	}
+
+	return schedule
+}
+
+type AmortizationEntry struct {
+	ID          int64     `json:"id"`
+	ScheduleID  int64     `json:"scheduleID"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	Amount      float64   `json:"amount"`
+	JournalID   string    `json:"journalID"`
+	CatchUp     bool      `json:"catchUp"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewAmortizationEntry(entry domain.AmortizationEntry) *AmortizationEntry {
+	return &AmortizationEntry{
+		ID:          entry.ID,
+		ScheduleID:  entry.ScheduleID,
+		PeriodStart: entry.PeriodStart,
+		PeriodEnd:   entry.PeriodEnd,
+		Amount:      entry.Amount,
+		JournalID:   entry.JournalID.String(),
+		CatchUp:     entry.CatchUp,
+		CreatedAt:   entry.CreatedAt,
+	}
+}
+
+type AmortizationPlanLine struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	Amount      float64   `json:"amount"`
+}
+
+type AssetCategory struct {
+	ID                               int64   `json:"id"`
+	Name                             string  `json:"name"`
+	AssetAccountID                   int64   `json:"assetAccountID"`
+	AccumulatedDepreciationAccountID int64   `json:"accumulatedDepreciationAccountID"`
+	DepreciationExpenseAccountID     int64   `json:"depreciationExpenseAccountID"`
+	UsefulLifeMonths                 int64   `json:"usefulLifeMonths"`
+	MethodID                         int64   `json:"methodID"`
+	DecliningFactor                  float64 `json:"decliningFactor"`
+}
+
+func NewAssetCategory(category domain.AssetCategory) *AssetCategory {
+	return &AssetCategory{
+		ID:                               category.ID,
+		Name:                             category.Name,
+		AssetAccountID:                   category.AssetAccountID,
+		AccumulatedDepreciationAccountID: category.AccumulatedDepreciationAccountID,
+		DepreciationExpenseAccountID:     category.DepreciationExpenseAccountID,
+		UsefulLifeMonths:                 category.UsefulLifeMonths,
+		MethodID:                         category.MethodID,
+		DecliningFactor:                  category.DecliningFactor,
+	}
+}
+
+type WriteAssetCategoryInput struct {
+	Name                             string   `json:"name"`
+	AssetAccountID                   int64    `json:"assetAccountID"`
+	AccumulatedDepreciationAccountID int64    `json:"accumulatedDepreciationAccountID"`
+	DepreciationExpenseAccountID     int64    `json:"depreciationExpenseAccountID"`
+	UsefulLifeMonths                 int64    `json:"usefulLifeMonths"`
+	MethodID                         *int64   `json:"methodID"`
+	DecliningFactor                  *float64 `json:"decliningFactor"`
+}
+
+func (w *WriteAssetCategoryInput) Domain() domain.AssetCategory {
+	category := domain.AssetCategory{
+		Name:                             w.Name,
+		AssetAccountID:                   w.AssetAccountID,
+		AccumulatedDepreciationAccountID: w.AccumulatedDepreciationAccountID,
+		DepreciationExpenseAccountID:     w.DepreciationExpenseAccountID,
+		UsefulLifeMonths:                 w.UsefulLifeMonths,
+	}
+
+	if w.MethodID != nil {
+		category.MethodID = *w.MethodID
+	}
+
+	if w.DecliningFactor != nil {
+		category.DecliningFactor = *w.DecliningFactor
+	}
+
+	return category
+}
+
+type FixedAsset struct {
+	ID                      int64      `json:"id"`
+	Name                    string     `json:"name"`
+	CategoryID              int64      `json:"categoryID"`
+	AcquisitionCost         float64    `json:"acquisitionCost"`
+	InServiceDate           time.Time  `json:"inServiceDate"`
+	SalvageValue            float64    `json:"salvageValue"`
+	UsefulLifeMonths        int64      `json:"usefulLifeMonths"`
+	MethodID                int64      `json:"methodID"`
+	DecliningFactor         float64    `json:"decliningFactor"`
+	StatusID                int64      `json:"statusID"`
+	DisposedAt              *time.Time `json:"disposedAt"`
+	DisposalProceeds        *float64   `json:"disposalProceeds"`
+	DisposalJournalID       *string    `json:"disposalJournalID"`
+	CreatedBy               string     `json:"createdBy"`
+	CreatedAt               time.Time  `json:"createdAt"`
+	AccumulatedDepreciation float64    `json:"accumulatedDepreciation"`
+	NetBookValue            float64    `json:"netBookValue"`
+}
+
+func NewFixedAsset(asset domain.FixedAsset) *FixedAsset {
+	result := &FixedAsset{
+		ID:                      asset.ID,
+		Name:                    asset.Name,
+		CategoryID:              asset.CategoryID,
+		AcquisitionCost:         asset.AcquisitionCost,
+		InServiceDate:           asset.InServiceDate,
+		SalvageValue:            asset.SalvageValue,
+		UsefulLifeMonths:        asset.UsefulLifeMonths,
+		MethodID:                asset.MethodID,
+		DecliningFactor:         asset.DecliningFactor,
+		StatusID:                asset.StatusID,
+		DisposalProceeds:        nullFloat64(asset.DisposalProceeds),
+		CreatedBy:               asset.CreatedBy.String(),
+		CreatedAt:               asset.CreatedAt,
+		AccumulatedDepreciation: asset.AccumulatedDepreciation,
+		NetBookValue:            asset.AcquisitionCost - asset.AccumulatedDepreciation,
+	}
+
+	if asset.DisposedAt.Valid {
+		result.DisposedAt = &asset.DisposedAt.Time
+		result.NetBookValue = 0
+	}
+
+	if asset.DisposalJournalID.Valid {
+		journalID := asset.DisposalJournalID.UUID.String()
+		result.DisposalJournalID = &journalID
+	}
+
+	return result
+}
+
+type WriteFixedAssetInput struct {
+	Name             string    `json:"name"`
+	CategoryID       int64     `json:"categoryID"`
+	AcquisitionCost  float64   `json:"acquisitionCost"`
+	InServiceDate    time.Time `json:"inServiceDate"`
+	SalvageValue     *float64  `json:"salvageValue"`
+	UsefulLifeMonths *int64    `json:"usefulLifeMonths"`
+	MethodID         *int64    `json:"methodID"`
+	DecliningFactor  *float64  `json:"decliningFactor"`
+}
+
+func (w *WriteFixedAssetInput) Domain() domain.FixedAsset {
+	asset := domain.FixedAsset{
+		Name:            w.Name,
+		CategoryID:      w.CategoryID,
+		AcquisitionCost: w.AcquisitionCost,
+		InServiceDate:   w.InServiceDate,
+	}
+
+	if w.SalvageValue != nil {
+		asset.SalvageValue = *w.SalvageValue
+	}
+
+	if w.UsefulLifeMonths != nil {
+		asset.UsefulLifeMonths = *w.UsefulLifeMonths
+	}
+
+	if w.MethodID != nil {
+		asset.MethodID = *w.MethodID
+	}
+
+	if w.DecliningFactor != nil {
+		asset.DecliningFactor = *w.DecliningFactor
+	}
+
+	return asset
+}
+
+type DisposeFixedAssetInput struct {
+	Date              time.Time `json:"date"`
+	Proceeds          *float64  `json:"proceeds"`
+	ProceedsAccountID *int64    `json:"proceedsAccountID"`
+}
+
+type DepreciationRun struct {
+	ID        int64     `json:"id"`
+	PeriodEnd time.Time `json:"periodEnd"`
+	JournalID string    `json:"journalID"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewDepreciationRun(run domain.DepreciationRun) *DepreciationRun {
+	return &DepreciationRun{
+		ID:        run.ID,
+		PeriodEnd: run.PeriodEnd,
+		JournalID: run.JournalID.String(),
+		CreatedBy: run.CreatedBy.String(),
+		CreatedAt: run.CreatedAt,
+	}
+}
+
+type DepreciationEntry struct {
+	ID      int64   `json:"id"`
+	RunID   int64   `json:"runID"`
+	AssetID int64   `json:"assetID"`
+	Amount  float64 `json:"amount"`
+}
+
+func NewDepreciationEntry(entry domain.DepreciationEntry) *DepreciationEntry {
+	return &DepreciationEntry{
+		ID:      entry.ID,
+		RunID:   entry.RunID,
+		AssetID: entry.AssetID,
+		Amount:  entry.Amount,
+	}
+}
+
+type FixedAssetRegisterRow struct {
+	Asset                   *FixedAsset `json:"asset"`
+	AccumulatedDepreciation float64     `json:"accumulatedDepreciation"`
+	NetBookValue            float64     `json:"netBookValue"`
+}
+
+func NewFixedAssetRegisterRow(row domain.FixedAssetRegisterRow) *FixedAssetRegisterRow {
+	return &FixedAssetRegisterRow{
+		Asset:                   NewFixedAsset(row.Asset),
+		AccumulatedDepreciation: row.AccumulatedDepreciation,
+		NetBookValue:            row.NetBookValue,
+	}
+}
+
+type Customer struct {
+	ID               int64     `json:"id"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Email            *string   `json:"email"`
+	Phone            *string   `json:"phone"`
+	Address          *string   `json:"address"`
+	TaxNumber        *string   `json:"taxNumber"`
+	PaymentTermsDays int64     `json:"paymentTermsDays"`
+	Inactive         bool      `json:"inactive"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func NewCustomer(customer domain.Customer) *Customer {
+	return &Customer{
+		ID:               customer.ID,
+		Code:             customer.Code,
+		Name:             customer.Name,
+		Email:            nullString(customer.Email),
+		Phone:            nullString(customer.Phone),
+		Address:          nullString(customer.Address),
+		TaxNumber:        nullString(customer.TaxNumber),
+		PaymentTermsDays: customer.PaymentTermsDays,
+		Inactive:         customer.Inactive,
+		CreatedAt:        customer.CreatedAt,
+	}
+}
+
+type WriteCustomerInput struct {
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Email            *string `json:"email"`
+	Phone            *string `json:"phone"`
+	Address          *string `json:"address"`
+	TaxNumber        *string `json:"taxNumber"`
+	PaymentTermsDays *int64  `json:"paymentTermsDays"`
+	Inactive         *bool   `json:"inactive"`
+}
+
+func (w *WriteCustomerInput) Domain() domain.Customer {
+	customer := domain.Customer{
+		Code:             w.Code,
+		Name:             w.Name,
+		Email:            toNullString(w.Email),
+		Phone:            toNullString(w.Phone),
+		Address:          toNullString(w.Address),
+		TaxNumber:        toNullString(w.TaxNumber),
+		PaymentTermsDays: 30,
+	}
+
+	if w.PaymentTermsDays != nil {
+		customer.PaymentTermsDays = *w.PaymentTermsDays
+	}
+
+	if w.Inactive != nil {
+		customer.Inactive = *w.Inactive
+	}
+
+	return customer
+}
+
+type SalesInvoice struct {
+	ID                int64     `json:"id"`
+	Number            string    `json:"number"`
+	CustomerID        int64     `json:"customerID"`
+	InvoiceDate       time.Time `json:"invoiceDate"`
+	DueDate           time.Time `json:"dueDate"`
+	Memo              *string   `json:"memo"`
+	Subtotal          float64   `json:"subtotal"`
+	TaxAmount         float64   `json:"taxAmount"`
+	Total             float64   `json:"total"`
+	JournalID         string    `json:"journalID"`
+	CreatedBy         string    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+	PaidAmount        float64   `json:"paidAmount"`
+	CreditedAmount    float64   `json:"creditedAmount"`
+	OutstandingAmount float64   `json:"outstandingAmount"`
+	Voided            bool      `json:"voided"`
+}
+
+func NewSalesInvoice(invoice domain.SalesInvoice) *SalesInvoice {
+	result := &SalesInvoice{
+		ID:                invoice.ID,
+		Number:            invoice.Number,
+		CustomerID:        invoice.CustomerID,
+		InvoiceDate:       invoice.InvoiceDate,
+		DueDate:           invoice.DueDate,
+		Memo:              nullString(invoice.Memo),
+		Subtotal:          invoice.Subtotal,
+		TaxAmount:         invoice.TaxAmount,
+		Total:             invoice.Total,
+		JournalID:         invoice.JournalID.String(),
+		CreatedBy:         invoice.CreatedBy.String(),
+		CreatedAt:         invoice.CreatedAt,
+		PaidAmount:        invoice.PaidAmount,
+		CreditedAmount:    invoice.CreditedAmount,
+		OutstandingAmount: invoice.Total - invoice.PaidAmount - invoice.CreditedAmount,
+		Voided:            invoice.Voided,
+	}
+
+	if invoice.Voided {
+		result.OutstandingAmount = 0
+	}
+
+	return result
+}
+
+type WriteSalesInvoiceInput struct {
+	CustomerID  int64                         `json:"customerID"`
+	InvoiceDate *time.Time                    `json:"invoiceDate"`
+	DueDate     *time.Time                    `json:"dueDate"`
+	Memo        *string                       `json:"memo"`
+	Lines       []*WriteSalesInvoiceLineInput `json:"lines"`
+}
+
+func (w *WriteSalesInvoiceInput) Domain() domain.SalesInvoice {
+	invoice := domain.SalesInvoice{
+		CustomerID: w.CustomerID,
+		Memo:       toNullString(w.Memo),
+		Lines:      make([]domain.SalesInvoiceLine, len(w.Lines)),
+	}
+
+	if w.InvoiceDate != nil {
+		invoice.InvoiceDate = *w.InvoiceDate
+	}
+
+	if w.DueDate != nil {
+		invoice.DueDate = *w.DueDate
+	}
+
+	for i, line := range w.Lines {
+		invoice.Lines[i] = line.Domain()
+	}
+
+	return invoice
+}
+
+type WriteSalesInvoiceLineInput struct {
+	AccountID   int64    `json:"accountID"`
+	Description *string  `json:"description"`
+	Quantity    *float64 `json:"quantity"`
+	UnitPrice   float64  `json:"unitPrice"`
+	TaxRate     *float64 `json:"taxRate"`
+}
+
+func (w *WriteSalesInvoiceLineInput) Domain() domain.SalesInvoiceLine {
+	line := domain.SalesInvoiceLine{AccountID: w.AccountID, Quantity: 1, UnitPrice: w.UnitPrice}
+
+	if w.Description != nil {
+		line.Description = *w.Description
+	}
+
+	if w.Quantity != nil {
+		line.Quantity = *w.Quantity
+	}
+
+	if w.TaxRate != nil {
+		line.TaxRate = *w.TaxRate
+	}
+
+	return line
+}
+
+type SalesInvoiceLine struct {
+	ID          int64   `json:"id"`
+	InvoiceID   int64   `json:"invoiceID"`
+	AccountID   int64   `json:"accountID"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unitPrice"`
+	TaxRate     float64 `json:"taxRate"`
+	Amount      float64 `json:"amount"`
+	TaxAmount   float64 `json:"taxAmount"`
+}
+
+func NewSalesInvoiceLine(line domain.SalesInvoiceLine) *SalesInvoiceLine {
+	return &SalesInvoiceLine{
+		ID:          line.ID,
+		InvoiceID:   line.InvoiceID,
+		AccountID:   line.AccountID,
+		Description: line.Description,
+		Quantity:    line.Quantity,
+		UnitPrice:   line.UnitPrice,
+		TaxRate:     line.TaxRate,
+		Amount:      line.Amount,
+		TaxAmount:   line.TaxAmount,
+	}
+}
+
+type CustomerReceipt struct {
+	ID              int64     `json:"id"`
+	CustomerID      int64     `json:"customerID"`
+	BankAccountID   int64     `json:"bankAccountID"`
+	ReceiptDate     time.Time `json:"receiptDate"`
+	Amount          float64   `json:"amount"`
+	Memo            *string   `json:"memo"`
+	JournalID       string    `json:"journalID"`
+	CreatedBy       string    `json:"createdBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+	AllocatedAmount float64   `json:"allocatedAmount"`
+	UnappliedAmount float64   `json:"unappliedAmount"`
+}
+
+func NewCustomerReceipt(receipt domain.CustomerReceipt) *CustomerReceipt {
+	return &CustomerReceipt{
+		ID:              receipt.ID,
+		CustomerID:      receipt.CustomerID,
+		BankAccountID:   receipt.BankAccountID,
+		ReceiptDate:     receipt.ReceiptDate,
+		Amount:          receipt.Amount,
+		Memo:            nullString(receipt.Memo),
+		JournalID:       receipt.JournalID.String(),
+		CreatedBy:       receipt.CreatedBy.String(),
+		CreatedAt:       receipt.CreatedAt,
+		AllocatedAmount: receipt.AllocatedAmount,
+		UnappliedAmount: receipt.Amount - receipt.AllocatedAmount,
+	}
+}
+
+type WriteCustomerReceiptInput struct {
+	CustomerID    int64                          `json:"customerID"`
+	BankAccountID int64                          `json:"bankAccountID"`
+	ReceiptDate   *time.Time                     `json:"receiptDate"`
+	Amount        float64                        `json:"amount"`
+	Memo          *string                        `json:"memo"`
+	Allocations   []*WriteReceiptAllocationInput `json:"allocations"`
+}
+
+func (w *WriteCustomerReceiptInput) Domain() domain.CustomerReceipt {
+	receipt := domain.CustomerReceipt{
+		CustomerID:    w.CustomerID,
+		BankAccountID: w.BankAccountID,
+		Amount:        w.Amount,
+		Memo:          toNullString(w.Memo),
+		Allocations:   NewReceiptAllocationsDomain(w.Allocations),
+	}
+
+	if w.ReceiptDate != nil {
+		receipt.ReceiptDate = *w.ReceiptDate
+	}
+
+	return receipt
+}
+
+type WriteReceiptAllocationInput struct {
+	InvoiceID int64   `json:"invoiceID"`
+	Amount    float64 `json:"amount"`
+}
+
+func NewReceiptAllocationsDomain(input []*WriteReceiptAllocationInput) []domain.ReceiptAllocation {
+	allocations := make([]domain.ReceiptAllocation, len(input))
+	for i, allocation := range input {
+		allocations[i] = domain.ReceiptAllocation{InvoiceID: allocation.InvoiceID, Amount: allocation.Amount}
+	}
+
+	return allocations
+}
+
+type ReceiptAllocation struct {
+	ID        int64   `json:"id"`
+	ReceiptID int64   `json:"receiptID"`
+	InvoiceID int64   `json:"invoiceID"`
+	Amount    float64 `json:"amount"`
+}
+
+func NewReceiptAllocation(allocation domain.ReceiptAllocation) *ReceiptAllocation {
+	return &ReceiptAllocation{
+		ID:        allocation.ID,
+		ReceiptID: allocation.ReceiptID,
+		InvoiceID: allocation.InvoiceID,
+		Amount:    allocation.Amount,
+	}
+}
+
+type AgingReport struct {
+	AsOf  time.Time   `json:"asOf"`
+	Rows  []*AgingRow `json:"rows"`
+	Total *AgingRow   `json:"total"`
+}
+
+func NewAgingReport(report domain.AgingReport) *AgingReport {
+	result := &AgingReport{
+		AsOf:  report.AsOf,
+		Rows:  make([]*AgingRow, len(report.Rows)),
+		Total: NewAgingRow(report.Total),
+	}
+
+	for i, row := range report.Rows {
+		result.Rows[i] = NewAgingRow(row)
+	}
+
+	return result
+}
+
+type AgingRow struct {
+	PartyID    int64   `json:"partyID"`
+	PartyName  string  `json:"partyName"`
+	Current    float64 `json:"current"`
+	Days1To30  float64 `json:"days1To30"`
+	Days31To60 float64 `json:"days31To60"`
+	Days61To90 float64 `json:"days61To90"`
+	Over90     float64 `json:"over90"`
+	Total      float64 `json:"total"`
+	Unapplied  float64 `json:"unapplied"`
+}
+
+func NewAgingRow(row domain.AgingRow) *AgingRow {
+	return &AgingRow{
+		PartyID:    row.PartyID,
+		PartyName:  row.PartyName,
+		Current:    row.Current,
+		Days1To30:  row.Days1To30,
+		Days31To60: row.Days31To60,
+		Days61To90: row.Days61To90,
+		Over90:     row.Over90,
+		Total:      row.Total,
+		Unapplied:  row.Unapplied,
+	}
+}
+
+type PartyStatement struct {
+	PartyID        int64                 `json:"partyID"`
+	StartDate      time.Time             `json:"startDate"`
+	EndDate        time.Time             `json:"endDate"`
+	OpeningBalance float64               `json:"openingBalance"`
+	Lines          []*PartyStatementLine `json:"lines"`
+	ClosingBalance float64               `json:"closingBalance"`
+}
+
+func NewPartyStatement(statement domain.PartyStatement) *PartyStatement {
+	result := &PartyStatement{
+		PartyID:        statement.PartyID,
+		StartDate:      statement.StartDate,
+		EndDate:        statement.EndDate,
+		OpeningBalance: statement.OpeningBalance,
+		Lines:          make([]*PartyStatementLine, len(statement.Lines)),
+		ClosingBalance: statement.ClosingBalance,
+	}
+
+	for i, line := range statement.Lines {
+		result.Lines[i] = &PartyStatementLine{
+			Date:      line.Date,
+			Kind:      line.Kind,
+			ID:        line.ID,
+			Reference: line.Reference,
+			Memo:      line.Memo,
+			Amount:    line.Amount,
+			Balance:   line.Balance,
+		}
+	}
+
+	return result
+}
+
+type PartyStatementLine struct {
+	Date      time.Time `json:"date"`
+	Kind      string    `json:"kind"`
+	ID        int64     `json:"id"`
+	Reference string    `json:"reference"`
+	Memo      string    `json:"memo"`
+	Amount    float64   `json:"amount"`
+	Balance   float64   `json:"balance"`
+}
+
+type Vendor struct {
+	ID               int64     `json:"id"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Email            *string   `json:"email"`
+	Phone            *string   `json:"phone"`
+	Address          *string   `json:"address"`
+	TaxNumber        *string   `json:"taxNumber"`
+	PaymentTermsDays int64     `json:"paymentTermsDays"`
+	Inactive         bool      `json:"inactive"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func NewVendor(vendor domain.Vendor) *Vendor {
+	return &Vendor{
+		ID:               vendor.ID,
+		Code:             vendor.Code,
+		Name:             vendor.Name,
+		Email:            nullString(vendor.Email),
+		Phone:            nullString(vendor.Phone),
+		Address:          nullString(vendor.Address),
+		TaxNumber:        nullString(vendor.TaxNumber),
+		PaymentTermsDays: vendor.PaymentTermsDays,
+		Inactive:         vendor.Inactive,
+		CreatedAt:        vendor.CreatedAt,
+	}
+}
+
+type WriteVendorInput struct {
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Email            *string `json:"email"`
+	Phone            *string `json:"phone"`
+	Address          *string `json:"address"`
+	TaxNumber        *string `json:"taxNumber"`
+	PaymentTermsDays *int64  `json:"paymentTermsDays"`
+	Inactive         *bool   `json:"inactive"`
+}
+
+func (w *WriteVendorInput) Domain() domain.Vendor {
+	vendor := domain.Vendor{
+		Code:             w.Code,
+		Name:             w.Name,
+		Email:            toNullString(w.Email),
+		Phone:            toNullString(w.Phone),
+		Address:          toNullString(w.Address),
+		TaxNumber:        toNullString(w.TaxNumber),
+		PaymentTermsDays: 30,
+	}
+
+	if w.PaymentTermsDays != nil {
+		vendor.PaymentTermsDays = *w.PaymentTermsDays
+	}
+
+	if w.Inactive != nil {
+		vendor.Inactive = *w.Inactive
+	}
+
+	return vendor
+}
+
+type PurchaseBill struct {
+	ID                int64     `json:"id"`
+	Number            string    `json:"number"`
+	VendorID          int64     `json:"vendorID"`
+	VendorReference   string    `json:"vendorReference"`
+	BillDate          time.Time `json:"billDate"`
+	DueDate           time.Time `json:"dueDate"`
+	Memo              *string   `json:"memo"`
+	Subtotal          float64   `json:"subtotal"`
+	TaxAmount         float64   `json:"taxAmount"`
+	Total             float64   `json:"total"`
+	JournalID         string    `json:"journalID"`
+	CreatedBy         string    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+	PaidAmount        float64   `json:"paidAmount"`
+	DebitedAmount     float64   `json:"debitedAmount"`
+	OutstandingAmount float64   `json:"outstandingAmount"`
+	Voided            bool      `json:"voided"`
+}
+
+func NewPurchaseBill(bill domain.PurchaseBill) *PurchaseBill {
+	result := &PurchaseBill{
+		ID:                bill.ID,
+		Number:            bill.Number,
+		VendorID:          bill.VendorID,
+		VendorReference:   bill.VendorReference,
+		BillDate:          bill.BillDate,
+		DueDate:           bill.DueDate,
+		Memo:              nullString(bill.Memo),
+		Subtotal:          bill.Subtotal,
+		TaxAmount:         bill.TaxAmount,
+		Total:             bill.Total,
+		JournalID:         bill.JournalID.String(),
+		CreatedBy:         bill.CreatedBy.String(),
+		CreatedAt:         bill.CreatedAt,
+		PaidAmount:        bill.PaidAmount,
+		DebitedAmount:     bill.DebitedAmount,
+		OutstandingAmount: bill.Total - bill.PaidAmount - bill.DebitedAmount,
+		Voided:            bill.Voided,
+	}
+
+	if bill.Voided {
+		result.OutstandingAmount = 0
+	}
+
+	return result
+}
+
+type WritePurchaseBillInput struct {
+	VendorID        int64                         `json:"vendorID"`
+	VendorReference *string                       `json:"vendorReference"`
+	BillDate        *time.Time                    `json:"billDate"`
+	DueDate         *time.Time                    `json:"dueDate"`
+	Memo            *string                       `json:"memo"`
+	Lines           []*WritePurchaseBillLineInput `json:"lines"`
+}
+
+func (w *WritePurchaseBillInput) Domain() domain.PurchaseBill {
+	bill := domain.PurchaseBill{
+		VendorID: w.VendorID,
+		Memo:     toNullString(w.Memo),
+		Lines:    make([]domain.PurchaseBillLine, len(w.Lines)),
+	}
+
+	if w.VendorReference != nil {
+		bill.VendorReference = *w.VendorReference
+	}
+
+	if w.BillDate != nil {
+		bill.BillDate = *w.BillDate
+	}
+
+	if w.DueDate != nil {
+		bill.DueDate = *w.DueDate
+	}
+
+	for i, line := range w.Lines {
+		bill.Lines[i] = line.Domain()
+	}
+
+	return bill
+}
+
+type WritePurchaseBillLineInput struct {
+	AccountID   int64    `json:"accountID"`
+	Description *string  `json:"description"`
+	Quantity    *float64 `json:"quantity"`
+	UnitPrice   float64  `json:"unitPrice"`
+	TaxRate     *float64 `json:"taxRate"`
+}
+
+func (w *WritePurchaseBillLineInput) Domain() domain.PurchaseBillLine {
+	line := domain.PurchaseBillLine{AccountID: w.AccountID, Quantity: 1, UnitPrice: w.UnitPrice}
+
+	if w.Description != nil {
+		line.Description = *w.Description
+	}
+
+	if w.Quantity != nil {
+		line.Quantity = *w.Quantity
+	}
+
+	if w.TaxRate != nil {
+		line.TaxRate = *w.TaxRate
+	}
+
+	return line
+}
+
+type PurchaseBillLine struct {
+	ID          int64   `json:"id"`
+	BillID      int64   `json:"billID"`
+	AccountID   int64   `json:"accountID"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unitPrice"`
+	TaxRate     float64 `json:"taxRate"`
+	Amount      float64 `json:"amount"`
+	TaxAmount   float64 `json:"taxAmount"`
+}
+
+func NewPurchaseBillLine(line domain.PurchaseBillLine) *PurchaseBillLine {
+	return &PurchaseBillLine{
+		ID:          line.ID,
+		BillID:      line.BillID,
+		AccountID:   line.AccountID,
+		Description: line.Description,
+		Quantity:    line.Quantity,
+		UnitPrice:   line.UnitPrice,
+		TaxRate:     line.TaxRate,
+		Amount:      line.Amount,
+		TaxAmount:   line.TaxAmount,
+	}
+}
+
+type VendorPayment struct {
+	ID              int64     `json:"id"`
+	VendorID        int64     `json:"vendorID"`
+	BankAccountID   int64     `json:"bankAccountID"`
+	PaymentDate     time.Time `json:"paymentDate"`
+	Amount          float64   `json:"amount"`
+	Memo            *string   `json:"memo"`
+	JournalID       string    `json:"journalID"`
+	CreatedBy       string    `json:"createdBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+	AllocatedAmount float64   `json:"allocatedAmount"`
+	UnappliedAmount float64   `json:"unappliedAmount"`
+}
+
+func NewVendorPayment(payment domain.VendorPayment) *VendorPayment {
+	return &VendorPayment{
+		ID:              payment.ID,
+		VendorID:        payment.VendorID,
+		BankAccountID:   payment.BankAccountID,
+		PaymentDate:     payment.PaymentDate,
+		Amount:          payment.Amount,
+		Memo:            nullString(payment.Memo),
+		JournalID:       payment.JournalID.String(),
+		CreatedBy:       payment.CreatedBy.String(),
+		CreatedAt:       payment.CreatedAt,
+		AllocatedAmount: payment.AllocatedAmount,
+		UnappliedAmount: payment.Amount - payment.AllocatedAmount,
+	}
+}
+
+type WriteVendorPaymentInput struct {
+	VendorID      int64                          `json:"vendorID"`
+	BankAccountID int64                          `json:"bankAccountID"`
+	PaymentDate   *time.Time                     `json:"paymentDate"`
+	Amount        float64                        `json:"amount"`
+	Memo          *string                        `json:"memo"`
+	Allocations   []*WritePaymentAllocationInput `json:"allocations"`
+}
+
+func (w *WriteVendorPaymentInput) Domain() domain.VendorPayment {
+	payment := domain.VendorPayment{
+		VendorID:      w.VendorID,
+		BankAccountID: w.BankAccountID,
+		Amount:        w.Amount,
+		Memo:          toNullString(w.Memo),
+		Allocations:   NewPaymentAllocationsDomain(w.Allocations),
+	}
+
+	if w.PaymentDate != nil {
+		payment.PaymentDate = *w.PaymentDate
+	}
+
+	return payment
+}
+
+type WritePaymentAllocationInput struct {
+	BillID int64   `json:"billID"`
+	Amount float64 `json:"amount"`
+}
+
+func NewPaymentAllocationsDomain(input []*WritePaymentAllocationInput) []domain.PaymentAllocation {
+	allocations := make([]domain.PaymentAllocation, len(input))
+	for i, allocation := range input {
+		allocations[i] = domain.PaymentAllocation{BillID: allocation.BillID, Amount: allocation.Amount}
+	}
+
+	return allocations
+}
+
+type PaymentAllocation struct {
+	ID        int64   `json:"id"`
+	PaymentID int64   `json:"paymentID"`
+	BillID    int64   `json:"billID"`
+	Amount    float64 `json:"amount"`
+}
+
+func NewPaymentAllocation(allocation domain.PaymentAllocation) *PaymentAllocation {
+	return &PaymentAllocation{
+		ID:        allocation.ID,
+		PaymentID: allocation.PaymentID,
+		BillID:    allocation.BillID,
+		Amount:    allocation.Amount,
+	}
+}
+
+type CreditNote struct {
+	ID        int64     `json:"id"`
+	Number    string    `json:"number"`
+	InvoiceID int64     `json:"invoiceID"`
+	NoteDate  time.Time `json:"noteDate"`
+	Memo      *string   `json:"memo"`
+	Subtotal  float64   `json:"subtotal"`
+	TaxAmount float64   `json:"taxAmount"`
+	Total     float64   `json:"total"`
+	JournalID string    `json:"journalID"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	Voided    bool      `json:"voided"`
+}
+
+func NewCreditNote(note domain.CreditNote) *CreditNote {
+	return &CreditNote{
+		ID:        note.ID,
+		Number:    note.Number,
+		InvoiceID: note.InvoiceID,
+		NoteDate:  note.NoteDate,
+		Memo:      nullString(note.Memo),
+		Subtotal:  note.Subtotal,
+		TaxAmount: note.TaxAmount,
+		Total:     note.Total,
+		JournalID: note.JournalID.String(),
+		CreatedBy: note.CreatedBy.String(),
+		CreatedAt: note.CreatedAt,
+		Voided:    note.Voided,
+	}
+}
+
+type WriteCreditNoteInput struct {
+	InvoiceID int64      `json:"invoiceID"`
+	NoteDate  *time.Time `json:"noteDate"`
+	Total     float64    `json:"total"`
+	Memo      *string    `json:"memo"`
+}
+
+func (w *WriteCreditNoteInput) Domain() domain.CreditNote {
+	note := domain.CreditNote{InvoiceID: w.InvoiceID, Total: w.Total, Memo: toNullString(w.Memo)}
+
+	if w.NoteDate != nil {
+		note.NoteDate = *w.NoteDate
+	}
+
+	return note
+}
+
+type DebitNote struct {
+	ID        int64     `json:"id"`
+	Number    string    `json:"number"`
+	BillID    int64     `json:"billID"`
+	NoteDate  time.Time `json:"noteDate"`
+	Memo      *string   `json:"memo"`
+	Subtotal  float64   `json:"subtotal"`
+	TaxAmount float64   `json:"taxAmount"`
+	Total     float64   `json:"total"`
+	JournalID string    `json:"journalID"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	Voided    bool      `json:"voided"`
+}
+
+func NewDebitNote(note domain.DebitNote) *DebitNote {
+	return &DebitNote{
+		ID:        note.ID,
+		Number:    note.Number,
+		BillID:    note.BillID,
+		NoteDate:  note.NoteDate,
+		Memo:      nullString(note.Memo),
+		Subtotal:  note.Subtotal,
+		TaxAmount: note.TaxAmount,
+		Total:     note.Total,
+		JournalID: note.JournalID.String(),
+		CreatedBy: note.CreatedBy.String(),
+		CreatedAt: note.CreatedAt,
+		Voided:    note.Voided,
+	}
+}
+
+type WriteDebitNoteInput struct {
+	BillID   int64      `json:"billID"`
+	NoteDate *time.Time `json:"noteDate"`
+	Total    float64    `json:"total"`
+	Memo     *string    `json:"memo"`
+}
+
+func (w *WriteDebitNoteInput) Domain() domain.DebitNote {
+	note := domain.DebitNote{BillID: w.BillID, Total: w.Total, Memo: toNullString(w.Memo)}
+
+	if w.NoteDate != nil {
+		note.NoteDate = *w.NoteDate
+	}
+
+	return note
+}
+
+type ApprovalRule struct {
+	ID                int64   `json:"id"`
+	AccountClassID    *int64  `json:"accountClassID"`
+	MinAmount         float64 `json:"minAmount"`
+	RequiredApprovals int64   `json:"requiredApprovals"`
+}
+
+func NewApprovalRule(rule domain.ApprovalRule) *ApprovalRule {
+	result := &ApprovalRule{
+		ID:                rule.ID,
+		MinAmount:         rule.MinAmount,
+		RequiredApprovals: rule.RequiredApprovals,
+	}
+
+	if rule.AccountClassID.Valid {
+		result.AccountClassID = &rule.AccountClassID.Int64
+	}
+
+	return result
+}
+
+type WriteApprovalRuleInput struct {
+	AccountClassID    *int64  `json:"accountClassID"`
+	MinAmount         float64 `json:"minAmount"`
+	RequiredApprovals int64   `json:"requiredApprovals"`
+}
+
+func (w *WriteApprovalRuleInput) Domain() (rule domain.ApprovalRule, err error) {
+	rule.MinAmount = w.MinAmount
+	rule.RequiredApprovals = w.RequiredApprovals
+
+	if w.AccountClassID != nil && *w.AccountClassID > 0 {
+		if err = rule.AccountClassID.Scan(*w.AccountClassID); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+type Company struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewCompany(company domain.Company) *Company {
+	return &Company{
+		ID:        company.ID,
+		Code:      company.Code,
+		Name:      company.Name,
+		CreatedAt: company.CreatedAt,
+	}
+}
+
+type WriteCompanyInput struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+func (w *WriteCompanyInput) Domain() domain.Company {
+	return domain.Company{Code: w.Code, Name: w.Name}
+}
+
+type GroupAccount struct {
+	ID      int64  `json:"id"`
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	ClassID int64  `json:"classID"`
+}
+
+func NewGroupAccount(groupAccount domain.GroupAccount) *GroupAccount {
+	return &GroupAccount{
+		ID:      groupAccount.ID,
+		Code:    groupAccount.Code,
+		Name:    groupAccount.Name,
+		ClassID: groupAccount.ClassID,
+	}
+}
+
+type WriteGroupAccountInput struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	ClassID int64  `json:"classID"`
+}
+
+func (w *WriteGroupAccountInput) Domain() domain.GroupAccount {
+	return domain.GroupAccount{Code: w.Code, Name: w.Name, ClassID: w.ClassID}
+}
+
+type CompanyBalance struct {
+	CompanyID int64   `json:"companyID"`
+	Amount    float64 `json:"amount"`
+}
+
+type ConsolidationRow struct {
+	GroupAccountID *int64            `json:"groupAccountID"`
+	AccountID      *int64            `json:"accountID"`
+	Code           *string           `json:"code"`
+	Name           string            `json:"name"`
+	ClassTypeID    int64             `json:"classTypeID"`
+	Balances       []*CompanyBalance `json:"balances"`
+	Elimination    float64           `json:"elimination"`
+	Amount         float64           `json:"amount"`
+	NormalBalance  string            `json:"normalBalance"`
+}
+
+func NewConsolidationRow(row domain.ConsolidationRow) *ConsolidationRow {
+	result := &ConsolidationRow{
+		Code:        nullString(row.Code),
+		Name:        row.Name,
+		ClassTypeID: row.ClassTypeID,
+		Balances:    make([]*CompanyBalance, len(row.Balances)),
+		Elimination: row.Elimination,
+		Amount:      row.Amount,
+
+		NormalBalance: row.NormalBalance,
+	}
+
+	if row.GroupAccountID.Valid {
+		result.GroupAccountID = &row.GroupAccountID.Int64
+	}
+
+	if row.AccountID.Valid {
+		result.AccountID = &row.AccountID.Int64
+	}
+
+	for i, balance := range row.Balances {
+		result.Balances[i] = &CompanyBalance{CompanyID: balance.CompanyID, Amount: balance.Amount}
+	}
+
+	return result
+}
+
+func newConsolidationRows(rows []domain.ConsolidationRow) []*ConsolidationRow {
+	result := make([]*ConsolidationRow, len(rows))
+	for i, row := range rows {
+		result[i] = NewConsolidationRow(row)
+	}
+
+	return result
+}
+
+type ConsolidatedTrialBalance struct {
+	AsOf        time.Time           `json:"asOf"`
+	CompanyIDs  []int64             `json:"companyIDs"`
+	Rows        []*ConsolidationRow `json:"rows"`
+	TotalDebit  float64             `json:"totalDebit"`
+	TotalCredit float64             `json:"totalCredit"`
+}
+
+func NewConsolidatedTrialBalance(report domain.ConsolidatedTrialBalance) *ConsolidatedTrialBalance {
+	return &ConsolidatedTrialBalance{
+		AsOf:        report.AsOf,
+		CompanyIDs:  report.CompanyIDs,
+		Rows:        newConsolidationRows(report.Rows),
+		TotalDebit:  report.TotalDebit,
+		TotalCredit: report.TotalCredit,
+	}
+}
+
+type ConsolidatedBalanceSheetSection struct {
+	ClassTypeID int64               `json:"classTypeID"`
+	Name        string              `json:"name"`
+	Rows        []*ConsolidationRow `json:"rows"`
+	Total       float64             `json:"total"`
+}
+
+type ConsolidatedBalanceSheet struct {
+	AsOf                      time.Time                          `json:"asOf"`
+	CompanyIDs                []int64                            `json:"companyIDs"`
+	Sections                  []*ConsolidatedBalanceSheetSection `json:"sections"`
+	NetIncome                 float64                            `json:"netIncome"`
+	TotalAssets               float64                            `json:"totalAssets"`
+	TotalLiabilitiesAndEquity float64                            `json:"totalLiabilitiesAndEquity"`
+}
+
+func NewConsolidatedBalanceSheet(report domain.ConsolidatedBalanceSheet) *ConsolidatedBalanceSheet {
+	result := &ConsolidatedBalanceSheet{
+		AsOf:                      report.AsOf,
+		CompanyIDs:                report.CompanyIDs,
+		Sections:                  make([]*ConsolidatedBalanceSheetSection, len(report.Sections)),
+		NetIncome:                 report.NetIncome,
+		TotalAssets:               report.TotalAssets,
+		TotalLiabilitiesAndEquity: report.TotalLiabilitiesAndEquity,
+	}
+
+	for i, section := range report.Sections {
+		result.Sections[i] = &ConsolidatedBalanceSheetSection{
+			ClassTypeID: section.ClassTypeID,
+			Name:        section.Name,
+			Rows:        newConsolidationRows(section.Rows),
+			Total:       section.Total,
+		}
+	}
+
+	return result
+}
+
+type IntercompanyTransaction struct {
+	ID            int64     `json:"id"`
+	Reference     string    `json:"reference"`
+	FromCompanyID int64     `json:"fromCompanyID"`
+	ToCompanyID   int64     `json:"toCompanyID"`
+	TransDate     time.Time `json:"transDate"`
+	Memo          *string   `json:"memo"`
+	Amount        float64   `json:"amount"`
+	FromJournalID string    `json:"fromJournalID"`
+	ToJournalID   string    `json:"toJournalID"`
+	CreatedBy     string    `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Voided        bool      `json:"voided"`
+}
+
+func NewIntercompanyTransaction(transaction domain.IntercompanyTransaction) *IntercompanyTransaction {
+	return &IntercompanyTransaction{
+		ID:            transaction.ID,
+		Reference:     transaction.Reference,
+		FromCompanyID: transaction.FromCompanyID,
+		ToCompanyID:   transaction.ToCompanyID,
+		TransDate:     transaction.TransDate,
+		Memo:          nullString(transaction.Memo),
+		Amount:        transaction.Amount,
+		FromJournalID: transaction.FromJournalID.String(),
+		ToJournalID:   transaction.ToJournalID.String(),
+		CreatedBy:     transaction.CreatedBy.String(),
+		CreatedAt:     transaction.CreatedAt,
+		Voided:        transaction.Voided,
+	}
+}
+
+type WriteIntercompanyTransactionLine struct {
+	AccountID int64   `json:"accountID"`
+	Amount    float64 `json:"amount"`
+	Memo      *string `json:"memo"`
+}
+
+type WriteIntercompanyTransactionInput struct {
+	ToCompanyID int64                               `json:"toCompanyID"`
+	TransDate   *time.Time                          `json:"transDate"`
+	Amount      float64                             `json:"amount"`
+	Memo        *string                             `json:"memo"`
+	FromLines   []*WriteIntercompanyTransactionLine `json:"fromLines"`
+	ToLines     []*WriteIntercompanyTransactionLine `json:"toLines"`
+}
+
+func newIntercompanyTransactionLines(lines []*WriteIntercompanyTransactionLine) []domain.IntercompanyTransactionLine {
+	result := make([]domain.IntercompanyTransactionLine, len(lines))
+	for i, line := range lines {
+		result[i] = domain.IntercompanyTransactionLine{AccountID: line.AccountID, Amount: line.Amount}
+		if line.Memo != nil {
+			result[i].Memo = *line.Memo
+		}
+	}
+
+	return result
+}
+
+func (w *WriteIntercompanyTransactionInput) Domain() domain.IntercompanyTransaction {
+	transaction := domain.IntercompanyTransaction{
+		ToCompanyID: w.ToCompanyID,
+		Amount:      w.Amount,
+		Memo:        toNullString(w.Memo),
+		FromLines:   newIntercompanyTransactionLines(w.FromLines),
+		ToLines:     newIntercompanyTransactionLines(w.ToLines),
+	}
+
+	if w.TransDate != nil {
+		transaction.TransDate = *w.TransDate
+	}
+
+	return transaction
+}
+
+type IntercompanyBalance struct {
+	CompanyID             int64   `json:"companyID"`
+	CounterpartyCompanyID int64   `json:"counterpartyCompanyID"`
+	Amount                float64 `json:"amount"`
+	CounterpartyAmount    float64 `json:"counterpartyAmount"`
+	Difference            float64 `json:"difference"`
+}
+
+func NewIntercompanyBalance(balance domain.IntercompanyBalance) *IntercompanyBalance {
+	return &IntercompanyBalance{
+		CompanyID:             balance.CompanyID,
+		CounterpartyCompanyID: balance.CounterpartyCompanyID,
+		Amount:                balance.Amount,
+		CounterpartyAmount:    balance.CounterpartyAmount,
+		Difference:            balance.Difference,
+	}
+}
+
+type AuditLog struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"companyID"`
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entityID"`
+	Operation string    `json:"operation"`
+	UserID    *string   `json:"userID"`
+	RequestID *string   `json:"requestID"`
+	Before    *string   `json:"before"`
+	After     *string   `json:"after"`
+	CreatedAt time.Time `json:"createdAt"`
+	PrevHash  string    `json:"prevHash"`
+	Hash      string    `json:"hash"`
+}
+
+func NewAuditLog(log domain.AuditLog) *AuditLog {
+	result := &AuditLog{
+		ID:        log.ID,
+		CompanyID: log.CompanyID,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Operation: log.Operation,
+		RequestID: nullString(log.RequestID),
+		Before:    nullString(log.Before),
+		After:     nullString(log.After),
+		CreatedAt: log.CreatedAt,
+		PrevHash:  log.PrevHash,
+		Hash:      log.Hash,
+	}
+
+	if log.UserID.Valid {
+		userID := log.UserID.UUID.String()
+		result.UserID = &userID
+	}
+
+	return result
+}
+
+type AuditLogVerification struct {
+	Valid      bool   `json:"valid"`
+	Checked    int64  `json:"checked"`
+	BrokenAtID *int64 `json:"brokenAtID"`
+}
+
+func NewAuditLogVerification(verification domain.AuditLogVerification) *AuditLogVerification {
+	result := &AuditLogVerification{Valid: verification.Valid, Checked: verification.Checked}
+	if verification.BrokenAtID.Valid {
+		result.BrokenAtID = &verification.BrokenAtID.Int64
+	}
+
+	return result
+}
+
+type LedgerVerification struct {
+	CompanyID       int64          `json:"companyID"`
+	Valid           bool           `json:"valid"`
+	CheckedJournals int64          `json:"checkedJournals"`
+	Issues          []*LedgerIssue `json:"issues"`
+}
+
+func NewLedgerVerification(verification domain.LedgerVerification) *LedgerVerification {
+	result := &LedgerVerification{
+		CompanyID:       verification.CompanyID,
+		Valid:           verification.Valid,
+		CheckedJournals: verification.CheckedJournals,
+		Issues:          make([]*LedgerIssue, len(verification.Issues)),
+	}
+
+	for i, issue := range verification.Issues {
+		result.Issues[i] = NewLedgerIssue(issue)
+	}
+
+	return result
+}
+
+type LedgerIssue struct {
+	Check             string  `json:"check"`
+	JournalID         *string `json:"journalID"`
+	BankTransactionID *int64  `json:"bankTransactionID"`
+	BankAccountID     *int64  `json:"bankAccountID"`
+	FiscalYearID      *int64  `json:"fiscalYearID"`
+	Expected          float64 `json:"expected"`
+	Actual            float64 `json:"actual"`
+	Message           string  `json:"message"`
+}
+
+func NewLedgerIssue(issue domain.LedgerIssue) *LedgerIssue {
+	result := &LedgerIssue{Check: issue.Check, Expected: issue.Expected, Actual: issue.Actual, Message: issue.Message}
+	if issue.JournalID.Valid {
+		journalID := issue.JournalID.UUID.String()
+		result.JournalID = &journalID
+	}
+
+	if issue.BankTransactionID.Valid {
+		result.BankTransactionID = &issue.BankTransactionID.Int64
+	}
+
+	if issue.BankAccountID.Valid {
+		result.BankAccountID = &issue.BankAccountID.Int64
+	}
+
+	if issue.FiscalYearID.Valid {
+		result.FiscalYearID = &issue.FiscalYearID.Int64
+	}
+
+	return result
+}
+
+type ImportJournalsInput struct {
+	BatchID string          `json:"batchID"`
+	Format  string          `json:"format"`
+	Mode    string          `json:"mode"`
+	Data    *string         `json:"data"`
+	File    *graphql.Upload `json:"file"`
+}
+
+type JournalImport struct {
+	BatchID     string                  `json:"batchID"`
+	Mode        string                  `json:"mode"`
+	Replayed    bool                    `json:"replayed"`
+	PostedCount int64                   `json:"postedCount"`
+	ErrorCount  int64                   `json:"errorCount"`
+	CreatedAt   *time.Time              `json:"createdAt"`
+	Journals    []*JournalImportJournal `json:"journals"`
+	Errors      []*JournalImportError   `json:"errors"`
+}
+
+func NewJournalImport(journalImport domain.JournalImport) *JournalImport {
+	result := &JournalImport{
+		BatchID:     journalImport.BatchID,
+		Mode:        journalImport.Mode,
+		Replayed:    journalImport.Replayed,
+		PostedCount: journalImport.PostedCount,
+		ErrorCount:  journalImport.ErrorCount,
+		Journals:    make([]*JournalImportJournal, len(journalImport.Journals)),
+		Errors:      make([]*JournalImportError, len(journalImport.Errors)),
+	}
+
+	if !journalImport.CreatedAt.IsZero() {
+		result.CreatedAt = &journalImport.CreatedAt
+	}
+
+	for i, journal := range journalImport.Journals {
+		result.Journals[i] = &JournalImportJournal{Row: journal.Row, Reference: journal.Reference, Memo: journal.Memo}
+
+		if !journal.TransDate.IsZero() {
+			result.Journals[i].TransDate = &journalImport.Journals[i].TransDate
+		}
+
+		if journal.JournalID.Valid {
+			journalID := journal.JournalID.UUID.String()
+			result.Journals[i].JournalID = &journalID
+		}
+	}
+
+	for i, importError := range journalImport.Errors {
+		result.Errors[i] = &JournalImportError{Row: importError.Row, Reference: importError.Reference, Message: importError.Message}
+	}
+
+	return result
+}
+
+type JournalImportJournal struct {
+	Row       int64      `json:"row"`
+	Reference string     `json:"reference"`
+	TransDate *time.Time `json:"transDate"`
+	Memo      string     `json:"memo"`
+	JournalID *string    `json:"journalID"`
+}
+
+type JournalImportError struct {
+	Row       int64  `json:"row"`
+	Reference string `json:"reference"`
+	Message   string `json:"message"`
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	sdkAuth "github.com/QuickAmethyst/monosvc/stdlibgo/auth"
+	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/google/uuid"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// StandardAuditFileDownloadHandler streams the SAF-T file of the company picked by the X-Company-ID header, the
+// default company when there is none, for the date range given by the from and to query parameters (YYYY-MM-DD).
+// It authenticates the bearer token the way the GraphQL API does.
+func (r *Resolver) StandardAuditFileDownloadHandler(auth sdkAuth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		claim, err := auth.Authenticate(appcontext.GetBearerToken(ctx))
+		if err != nil {
+			http.Error(w, "Authenticate failed", http.StatusUnauthorized)
+			return
+		}
+
+		userID, err := uuid.Parse(claim.Subject)
+		if err != nil {
+			http.Error(w, "Authenticate failed", http.StatusUnauthorized)
+			return
+		}
+
+		ctx = appcontext.SetUserID(ctx, userID)
+
+		companyID := appcontext.GetCompanyID(ctx)
+		if companyID == 0 {
+			companyID = sql.DefaultCompanyID
+		}
+
+		hasAccess, err := r.AccountingUsecase.HasCompanyAccess(ctx, userID, companyID)
+		if err != nil {
+			r.Logger.Error(err.Error())
+			http.Error(w, "Failed on check company access", http.StatusInternalServerError)
+			return
+		}
+
+		if !hasAccess {
+			http.Error(w, "Company access denied", http.StatusForbidden)
+			return
+		}
+
+		startDate, err := time.Parse("2006-01-02", req.URL.Query().Get("from"))
+		if err != nil {
+			http.Error(w, "from must be a date formatted YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+
+		endDate, err := time.Parse("2006-01-02", req.URL.Query().Get("to"))
+		if err != nil {
+			http.Error(w, "to must be a date formatted YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+
+		var body bytes.Buffer
+		if err = r.AccountingUsecase.ExportStandardAuditFile(ctx, startDate, endDate, &body); err != nil {
+			switch libErr.GetCode(err) {
+			case sql.EcodeStandardAuditFileInvalid:
+				http.Error(w, libErr.RootCause(err).Error(), http.StatusUnprocessableEntity)
+			case sql.EcodeStandardAuditFileSchemaNotConfigured:
+				r.Logger.Error(err.Error())
+				http.Error(w, "Standard audit file schema validation is not configured", http.StatusServiceUnavailable)
+			case sql.EcodeNotFound:
+				http.Error(w, "Company not found", http.StatusNotFound)
+			default:
+				r.Logger.Error(err.Error())
+				http.Error(w, "Failed on export standard audit file", http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		fileName := fmt.Sprintf("SAF-T_%d_%s_%s.xml", companyID, startDate.Format("20060102"), endDate.Format("20060102"))
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Header().Set("Content-Length", fmt.Sprint(body.Len()))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		if _, err = body.WriteTo(w); err != nil {
+			r.Logger.Error(err.Error())
+		}
+	}
+}
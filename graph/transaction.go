@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/graph/model"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+)
+
+// newTransactionRows turns the input rows into transaction rows. A row is either given as a signed amount,
+// debit positive and credit negative, or as a debit or a credit which are both entered positive.
+func newTransactionRows(rows []model.WriteTransactionRow) ([]sql.TransactionRow, error) {
+	var fieldErrors libErr.ValidationErrors
+
+	transactions := make([]sql.TransactionRow, len(rows))
+	for i, row := range rows {
+		amount, fieldError := transactionRowAmount(&rows[i])
+		if fieldError != nil {
+			fieldError.Field = fmt.Sprintf("data[%d].%s", i, fieldError.Field)
+			fieldErrors = append(fieldErrors, *fieldError)
+			continue
+		}
+
+		transactions[i] = sql.TransactionRow{
+			AccountID:             row.AccountID,
+			Amount:                amount,
+			Memo:                  row.Memo,
+			ExternalReference:     row.ExternalReference,
+			CounterpartyCompanyID: row.CounterpartyCompanyID,
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, libErr.PropagateWithCode(fieldErrors, sql.EcodeTransactionRowInvalid, "Invalid transaction row")
+	}
+
+	return transactions, nil
+}
+
+func transactionRowAmount(row *model.WriteTransactionRow) (float64, *libErr.FieldError) {
+	if row.Amount != nil {
+		if row.Debit != nil || row.Credit != nil {
+			return 0, &libErr.FieldError{Field: "amount", Message: "Amount can not be given along with debit or credit"}
+		}
+
+		return *row.Amount, nil
+	}
+
+	var debit, credit float64
+	if row.Debit != nil {
+		debit = *row.Debit
+	}
+
+	if row.Credit != nil {
+		credit = *row.Credit
+	}
+
+	switch {
+	case row.Debit == nil && row.Credit == nil:
+		return 0, &libErr.FieldError{Field: "amount", Message: "Amount, debit or credit is required"}
+	case debit < 0:
+		return 0, &libErr.FieldError{Field: "debit", Message: "Debit must not be negative"}
+	case credit < 0:
+		return 0, &libErr.FieldError{Field: "credit", Message: "Credit must not be negative"}
+	case debit != 0 && credit != 0:
+		return 0, &libErr.FieldError{Field: "credit", Message: "Row can not be debited and credited at once"}
+	}
+
+	return debit - credit, nil
+}
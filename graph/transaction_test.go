@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"github.com/QuickAmethyst/monosvc/graph/model"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	libErr "github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func TestTransactionRowAmount(t *testing.T) {
+	type returnVal struct {
+		amount float64
+		field  string
+	}
+
+	tests := []struct {
+		name  string
+		input model.WriteTransactionRow
+		exp   returnVal
+	}{
+		{"signed debit amount", model.WriteTransactionRow{Amount: float(10)}, returnVal{10, ""}},
+		{"signed credit amount", model.WriteTransactionRow{Amount: float(-10)}, returnVal{-10, ""}},
+		{"debit", model.WriteTransactionRow{Debit: float(10)}, returnVal{10, ""}},
+		{"credit", model.WriteTransactionRow{Credit: float(10)}, returnVal{-10, ""}},
+		{"credit with zero debit", model.WriteTransactionRow{Debit: float(0), Credit: float(10)}, returnVal{-10, ""}},
+		{"amount with debit", model.WriteTransactionRow{Amount: float(10), Debit: float(10)}, returnVal{0, "amount"}},
+		{"amount with credit", model.WriteTransactionRow{Amount: float(-10), Credit: float(10)}, returnVal{0, "amount"}},
+		{"negative debit", model.WriteTransactionRow{Debit: float(-10)}, returnVal{0, "debit"}},
+		{"negative credit", model.WriteTransactionRow{Credit: float(-10)}, returnVal{0, "credit"}},
+		{"debit and credit", model.WriteTransactionRow{Debit: float(10), Credit: float(5)}, returnVal{0, "credit"}},
+		{"neither", model.WriteTransactionRow{}, returnVal{0, "amount"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, fieldError := transactionRowAmount(&tt.input)
+
+			var field string
+			if fieldError != nil {
+				field = fieldError.Field
+			}
+
+			assert.Equal(t, tt.exp, returnVal{amount, field})
+		})
+	}
+}
+
+func TestNewTransactionRows(t *testing.T) {
+	t.Run("rows are converted in order", func(t *testing.T) {
+		rows, err := newTransactionRows([]model.WriteTransactionRow{
+			{AccountID: 1, Debit: float(10), Memo: "rent"},
+			{AccountID: 2, Credit: float(10)},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []sql.TransactionRow{
+			{AccountID: 1, Amount: 10, Memo: "rent"},
+			{AccountID: 2, Amount: -10},
+		}, rows)
+	})
+
+	t.Run("every invalid row is reported", func(t *testing.T) {
+		_, err := newTransactionRows([]model.WriteTransactionRow{
+			{AccountID: 1, Debit: float(10)},
+			{AccountID: 2},
+			{AccountID: 3, Debit: float(10), Credit: float(10)},
+		})
+
+		assert.EqualValues(t, sql.EcodeTransactionRowInvalid, libErr.GetCode(err))
+
+		fieldErrors, ok := libErr.RootCause(err).(libErr.ValidationErrors)
+		if assert.True(t, ok) {
+			assert.Equal(t, []string{"data[1].amount", "data[2].credit"}, []string{fieldErrors[0].Field, fieldErrors[1].Field})
+		}
+	})
+}
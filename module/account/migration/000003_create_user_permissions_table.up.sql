@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_permissions
+(
+    user_id     uuid     NOT NULL,
+    resource_id int      NOT NULL,
+    permission  smallint NOT NULL,
+
+    PRIMARY KEY (user_id, resource_id, permission),
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id)
+)
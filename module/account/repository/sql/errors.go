@@ -4,4 +4,5 @@ const (
 	EcodeGetUserNotFound = iota + 1
 	EcodeGetUserFailed
 	EcodeGetUserProfileFailed
+	EcodeGetUserPermissionFailed
 )
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/QuickAmethyst/monosvc/module/account/domain"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/auth"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
 	qb "github.com/QuickAmethyst/monosvc/stdlibgo/querybuilder/sql"
 	sdkSql "github.com/QuickAmethyst/monosvc/stdlibgo/sql"
@@ -15,6 +16,7 @@ type Reader interface {
 	GetUser(ctx context.Context, stmt UserStatement) (user domain.User, err error)
 	GetUserByEmail(ctx context.Context, email string) (user domain.User, err error)
 	GetUserProfileByUserID(ctx context.Context, userID uuid.UUID) (user domain.UserProfile, err error)
+	HasPermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (hasPermission bool, err error)
 }
 
 type reader struct {
@@ -62,6 +64,23 @@ func (r *reader) GetUser(ctx context.Context, stmt UserStatement) (user domain.U
 	return
 }
 
+func (r *reader) HasPermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (hasPermission bool, err error) {
+	whereClause, whereArgs, err := qb.NewWhereClause(UserPermissionStatement{UserID: userID, ResourceID: resource})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetUserPermissionFailed, "Failed to get user permission")
+		return
+	}
+
+	// permission is appended manually since READ is the zero value and would be skipped by the where clause builder
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM user_permissions %s AND permission = ?)", whereClause)
+	if err = r.db.GetContext(ctx, &hasPermission, r.db.Rebind(query), append(whereArgs, permission)...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetUserPermissionFailed, "Failed to get user permission")
+		return
+	}
+
+	return
+}
+
 func NewReader(opt *Options) Reader {
 	return &reader{db: opt.SlaveDB}
 }
@@ -10,3 +10,9 @@ type UserStatement struct {
 type UserProfileStatement struct {
 	UserID uuid.UUID
 }
+
+type UserPermissionStatement struct {
+	UserID     uuid.UUID
+	ResourceID int64
+	Permission int8
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/QuickAmethyst/monosvc/module/account/repository/sql"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/auth"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/google/uuid"
 )
 
 type Reader interface {
 	GetUser(ctx context.Context, stmt sql.UserStatement) (user domain.User, err error)
 	SignInWithEmail(ctx context.Context, params SignInWithEmailParams) (accessTokenDetail auth.TokenDetail, refreshTokenDetail auth.TokenDetail, err error)
 	RefreshAccessToken(rToken string) (accessTokenDetail auth.TokenDetail, refreshTokenDetail auth.TokenDetail, err error)
+	HasPermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (hasPermission bool, err error)
 }
 
 type reader struct {
@@ -49,6 +51,14 @@ func (r *reader) RefreshAccessToken(rToken string) (accessTokenDetail auth.Token
 	return r.Auth.RefreshAccessToken(rToken)
 }
 
+func (r *reader) HasPermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (hasPermission bool, err error) {
+	if userID == uuid.Nil {
+		return false, nil
+	}
+
+	return r.AccountSQL.HasPermission(ctx, userID, resource, permission)
+}
+
 func (r *reader) GetUser(ctx context.Context, stmt sql.UserStatement) (user domain.User, err error) {
 	return r.AccountSQL.GetUser(ctx, stmt)
 }
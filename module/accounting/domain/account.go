@@ -1,8 +1,16 @@
 package domain
 
+import "database/sql"
+
 type Account struct {
-	ID       int64
-	Name     string
-	GroupID  int64 `db:"group_id"`
-	Inactive bool
+	ID             int64
+	Code           sql.NullString
+	Name           string
+	GroupID        int64 `db:"group_id"`
+	Inactive       bool
+	GroupAccountID sql.NullInt64 `db:"group_account_id"`
+
+	// NormalBalance overrides the normal balance of the class type for a contra account, e.g. a credit
+	// accumulated depreciation under the assets.
+	NormalBalance sql.NullString `db:"normal_balance"`
 }
@@ -1,7 +1,10 @@
 package domain
 
+import "database/sql"
+
 type AccountClass struct {
 	ID       int64
+	Code     sql.NullString
 	Name     string
 	TypeID   int64 `db:"type_id"`
 	Inactive bool
@@ -4,6 +4,7 @@ import "database/sql"
 
 type AccountGroup struct {
 	ID       int64
+	Code     sql.NullString
 	ParentID sql.NullInt64 `db:"parent_id"`
 	ClassID  int64         `db:"class_id"`
 	Name     string
@@ -1,6 +1,13 @@
 package domain
 
+const (
+	DebitNormalBalance  = "debit"
+	CreditNormalBalance = "credit"
+)
+
+// AccountClassType carries the side, debit or credit, its accounts normally hold their balance on.
 type AccountClassType struct {
-	ID   int64
-	Name string
+	ID            int64
+	Name          string
+	NormalBalance string
 }
@@ -0,0 +1,8 @@
+package domain
+
+// AccountCodeFormat is the regular expression account codes must match for accounts of a class type.
+// An empty pattern disables the check.
+type AccountCodeFormat struct {
+	ClassTypeID int64 `db:"class_type_id"`
+	Pattern     string
+}
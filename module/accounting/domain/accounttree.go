@@ -0,0 +1,38 @@
+package domain
+
+import "database/sql"
+
+// AccountTreeClass is the root of the chart of accounts tree. Balance is only
+// set when the tree is requested with balances and rolls up every account below it.
+// NormalBalance is that of the class type, which its groups inherit.
+type AccountTreeClass struct {
+	ID            int64
+	Code          sql.NullString
+	Name          string
+	TypeID        int64
+	Inactive      bool
+	Balance       sql.NullFloat64
+	NormalBalance string
+	Groups        []AccountTreeGroup
+}
+
+type AccountTreeGroup struct {
+	ID            int64
+	Code          sql.NullString
+	Name          string
+	ParentID      sql.NullInt64
+	Inactive      bool
+	Balance       sql.NullFloat64
+	NormalBalance string
+	Groups        []AccountTreeGroup
+	Accounts      []AccountTreeAccount
+}
+
+type AccountTreeAccount struct {
+	ID            int64
+	Code          sql.NullString
+	Name          string
+	Inactive      bool
+	Balance       sql.NullFloat64
+	NormalBalance string
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	MonthlyAmortizationMethod int64 = iota + 1
+	DailyAmortizationMethod
+)
+
+const (
+	ActiveAmortizationStatus int64 = iota + 1
+	CompletedAmortizationStatus
+	TerminatedAmortizationStatus
+)
+
+// AmortizationSchedule spreads an amount parked on a balance sheet account, such as a prepayment, an accrual
+// or deferred revenue, over the months between StartDate and EndDate into a profit and loss account.
+type AmortizationSchedule struct {
+	ID              int64
+	Name            string
+	TotalAmount     float64      `db:"total_amount"`
+	StartDate       time.Time    `db:"start_date"`
+	EndDate         time.Time    `db:"end_date"`
+	SourceAccountID int64        `db:"source_account_id"`
+	TargetAccountID int64        `db:"target_account_id"`
+	MethodID        int64        `db:"method_id"`
+	StatusID        int64        `db:"status_id"`
+	TerminatedAt    sql.NullTime `db:"terminated_at"`
+	CreatedBy       uuid.UUID    `db:"created_by"`
+	CreatedAt       time.Time    `db:"created_at"`
+	CompanyID       int64        `db:"company_id"`
+	// PostedAmount sums the entries whose journal has not been voided.
+	PostedAmount float64 `db:"posted_amount"`
+}
+
+type AmortizationEntry struct {
+	ID          int64
+	ScheduleID  int64     `db:"schedule_id"`
+	PeriodStart time.Time `db:"period_start"`
+	PeriodEnd   time.Time `db:"period_end"`
+	Amount      float64
+	JournalID   uuid.UUID `db:"journal_id"`
+	CatchUp     bool      `db:"catch_up"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// AmortizationPlanLine is the amount a schedule amortizes over one calendar month, or the part of it the schedule covers.
+type AmortizationPlanLine struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Amount      float64
+}
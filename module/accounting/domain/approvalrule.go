@@ -0,0 +1,10 @@
+package domain
+
+import "database/sql"
+
+type ApprovalRule struct {
+	ID                int64
+	AccountClassID    sql.NullInt64 `db:"account_class_id"`
+	MinAmount         float64       `db:"min_amount"`
+	RequiredApprovals int64         `db:"required_approvals"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AttachmentFile is a stored document addressed by its SHA-256 digest, shared by every attachment
+// that uploads the same content.
+type AttachmentFile struct {
+	ID          uuid.UUID
+	SHA256      string `db:"sha256"`
+	ContentType string `db:"content_type"`
+	Size        int64
+	StorageKey  string    `db:"storage_key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type Attachment struct {
+	ID                uuid.UUID
+	FileID            uuid.UUID     `db:"file_id"`
+	JournalID         uuid.NullUUID `db:"journal_id"`
+	BankTransactionID sql.NullInt64 `db:"bank_transaction_id"`
+	FileName          string        `db:"file_name"`
+	ContentType       string        `db:"content_type"`
+	Size              int64
+	SHA256            string    `db:"sha256"`
+	StorageKey        string    `db:"storage_key"`
+	CreatedBy         uuid.UUID `db:"created_by"`
+	CreatedAt         time.Time `db:"created_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AuditLog is one change to the ledger, with the entity as stored before and after it in JSON. Entries are chained
+// by hash, each one hashing the hash of the entry before it, so an entry changed or removed afterwards breaks the
+// chain from there on.
+type AuditLog struct {
+	ID        int64
+	CompanyID int64 `db:"company_id"`
+	Entity    string
+	EntityID  string `db:"entity_id"`
+	Operation string
+	UserID    uuid.NullUUID  `db:"user_id"`
+	RequestID sql.NullString `db:"request_id"`
+	Before    sql.NullString
+	After     sql.NullString
+	CreatedAt time.Time `db:"created_at"`
+	PrevHash  string    `db:"prev_hash"`
+	Hash      string
+}
+
+// AuditLogVerification is the result of walking the audit log chain. BrokenAtID is the first entry whose hash does
+// not match its content or the entry before it.
+type AuditLogVerification struct {
+	Valid      bool
+	Checked    int64
+	BrokenAtID sql.NullInt64
+}
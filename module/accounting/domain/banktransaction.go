@@ -7,12 +7,12 @@ import (
 
 type BankTransaction struct {
 	ID            int64
-	JournalID     uuid.UUID
-	BankAccountID int64
-	UserID        uuid.UUID
+	JournalID     uuid.UUID `db:"journal_id"`
+	BankAccountID int64     `db:"bank_account_id"`
+	UserID        uuid.UUID `db:"created_by"`
 	Amount        float64
 	Balance       float64
 	Memo          string
-	TransDate     time.Time
-	CreatedAt     time.Time
+	TransDate     time.Time `db:"trans_date"`
+	CreatedAt     time.Time `db:"created_at"`
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Budget is one named version, such as original or revised, of the budget of a fiscal year.
+type Budget struct {
+	ID           int64
+	FiscalYearID int64 `db:"fiscal_year_id"`
+	Name         string
+	Description  sql.NullString
+	CreatedBy    uuid.UUID `db:"created_by"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// BudgetLine is the budgeted amount of an account in a fiscal period, signed like the general ledger:
+// debit positive, credit negative. Dimension is an optional free form tag such as a department.
+type BudgetLine struct {
+	ID             int64
+	BudgetID       int64 `db:"budget_id"`
+	AccountID      int64 `db:"account_id"`
+	FiscalPeriodID int64 `db:"fiscal_period_id"`
+	Dimension      string
+	Amount         float64
+}
+
+// BudgetVsActual compares a budget with the general ledger over a range of fiscal periods.
+// Rows are the class, group and account tree flattened depth first, nodes without budget and actual left out.
+type BudgetVsActual struct {
+	BudgetID  int64
+	StartDate time.Time
+	EndDate   time.Time
+	Rows      []BudgetVsActualRow
+}
+
+type BudgetVsActualRow struct {
+	Kind     string
+	ID       int64
+	Code     sql.NullString
+	Name     string
+	Depth    int
+	Budget   float64
+	Actual   float64
+	Variance float64
+	// NormalBalance is the side the amounts of the row are presented on.
+	NormalBalance string
+	// VariancePercent is the variance against the absolute budget, invalid when nothing was budgeted.
+	VariancePercent sql.NullFloat64
+}
@@ -0,0 +1,52 @@
+package domain
+
+const (
+	ChartOfAccountsClassKind   = "class"
+	ChartOfAccountsGroupKind   = "group"
+	ChartOfAccountsAccountKind = "account"
+
+	ChartOfAccountsCreateAction = "create"
+	ChartOfAccountsUpdateAction = "update"
+)
+
+// ChartOfAccounts is the class, group and account tree used for import, export and templates.
+// Nodes are identified by code when one is given, otherwise by name.
+type ChartOfAccounts struct {
+	Classes []ChartOfAccountsClass `json:"classes"`
+}
+
+type ChartOfAccountsClass struct {
+	Code     string                 `json:"code,omitempty"`
+	Name     string                 `json:"name"`
+	TypeID   int64                  `json:"typeID"`
+	Inactive bool                   `json:"inactive,omitempty"`
+	Groups   []ChartOfAccountsGroup `json:"groups,omitempty"`
+}
+
+type ChartOfAccountsGroup struct {
+	Code     string                   `json:"code,omitempty"`
+	Name     string                   `json:"name"`
+	Inactive bool                     `json:"inactive,omitempty"`
+	Groups   []ChartOfAccountsGroup   `json:"groups,omitempty"`
+	Accounts []ChartOfAccountsAccount `json:"accounts,omitempty"`
+}
+
+type ChartOfAccountsAccount struct {
+	Code     string `json:"code,omitempty"`
+	Name     string `json:"name"`
+	Inactive bool   `json:"inactive,omitempty"`
+}
+
+// ChartOfAccountsChange describes one create or update applied, or to be applied on a dry run, by an import.
+type ChartOfAccountsChange struct {
+	Kind   string
+	Action string
+	Name   string
+	Fields []string
+}
+
+type ChartOfAccountsTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ChartOfAccounts
+}
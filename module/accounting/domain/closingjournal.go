@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ClosingJournal is the year-end journal that zeroes every income, COGS and expense account
+// of a fiscal year against the retained earnings account.
+type ClosingJournal struct {
+	FiscalYearID int64
+	TransDate    time.Time
+	NetIncome    float64
+	Lines        []ClosingJournalLine
+}
+
+type ClosingJournalLine struct {
+	AccountID int64 `db:"account_id"`
+	Amount    float64
+}
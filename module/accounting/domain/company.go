@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Company owns its own accounts, fiscal years, journals, bank accounts and general ledger preferences.
+// Account classes and groups are shared by every company.
+type Company struct {
+	ID        int64
+	Code      string
+	Name      string
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// GroupAccount is an account of the group chart the charts of the companies are mapped onto for consolidation.
+type GroupAccount struct {
+	ID      int64
+	Code    string
+	Name    string
+	ClassID int64 `db:"class_id"`
+}
+
+type CompanyBalance struct {
+	CompanyID int64 `db:"company_id"`
+	Amount    float64
+}
+
+// ConsolidationRow is one group account of a consolidation, or one company account not mapped to the group chart yet.
+// Amount is the sum of the company balances less the elimination, debit positive and credit negative.
+type ConsolidationRow struct {
+	GroupAccountID sql.NullInt64
+	AccountID      sql.NullInt64
+	Code           sql.NullString
+	Name           string
+	ClassTypeID    int64
+	NormalBalance  string
+	Balances       []CompanyBalance
+	Elimination    float64
+	Amount         float64
+}
+
+type ConsolidatedTrialBalance struct {
+	AsOf        time.Time
+	CompanyIDs  []int64
+	Rows        []ConsolidationRow
+	TotalDebit  float64
+	TotalCredit float64
+}
+
+// ConsolidatedBalanceSheetSection holds the rows of one balance sheet class type. Total is shown with the natural
+// sign of the class type, positive for a debit asset and for a credit liability or equity.
+type ConsolidatedBalanceSheetSection struct {
+	ClassTypeID int64
+	Name        string
+	Rows        []ConsolidationRow
+	Total       float64
+}
+
+// ConsolidatedBalanceSheet carries the consolidated profit and loss not closed yet as NetIncome, which is counted
+// with the equity in TotalLiabilitiesAndEquity.
+type ConsolidatedBalanceSheet struct {
+	AsOf                      time.Time
+	CompanyIDs                []int64
+	Sections                  []ConsolidatedBalanceSheetSection
+	NetIncome                 float64
+	TotalAssets               float64
+	TotalLiabilitiesAndEquity float64
+}
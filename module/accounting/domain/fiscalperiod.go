@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	OpenPeriodStatus int64 = iota + 1
+	SoftLockedPeriodStatus
+	HardLockedPeriodStatus
+)
+
+type FiscalPeriod struct {
+	ID           int64
+	FiscalYearID int64     `db:"fiscal_year_id"`
+	StartDate    time.Time `db:"start_date"`
+	EndDate      time.Time `db:"end_date"`
+	StatusID     int64     `db:"status_id"`
+}
+
+type FiscalPeriodHistory struct {
+	ID             int64
+	FiscalPeriodID int64 `db:"fiscal_period_id"`
+	FromStatusID   int64 `db:"from_status_id"`
+	ToStatusID     int64 `db:"to_status_id"`
+	Reason         sql.NullString
+	CreatedBy      uuid.UUID `db:"created_by"`
+	CreatedAt      time.Time `db:"created_at"`
+}
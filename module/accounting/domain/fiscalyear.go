@@ -1,6 +1,15 @@
 package domain
 
-import "time"
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	CloseFiscalYearAction int64 = iota + 1
+	ReopenFiscalYearAction
+)
 
 type FiscalYear struct {
 	ID        int64
@@ -8,3 +17,13 @@ type FiscalYear struct {
 	EndDate   time.Time `db:"end_date"`
 	Closed    bool
 }
+
+type FiscalYearHistory struct {
+	ID           int64
+	FiscalYearID int64         `db:"fiscal_year_id"`
+	ActionID     int64         `db:"action_id"`
+	JournalID    uuid.NullUUID `db:"journal_id"`
+	Reason       sql.NullString
+	CreatedBy    uuid.UUID `db:"created_by"`
+	CreatedAt    time.Time `db:"created_at"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	StraightLineDepreciationMethod int64 = iota + 1
+	DecliningBalanceDepreciationMethod
+)
+
+const (
+	ActiveFixedAssetStatus int64 = iota + 1
+	DisposedFixedAssetStatus
+)
+
+// AssetCategory holds the accounts and the depreciation defaults a fixed asset takes when it is registered.
+type AssetCategory struct {
+	ID                               int64
+	Name                             string
+	AssetAccountID                   int64   `db:"asset_account_id"`
+	AccumulatedDepreciationAccountID int64   `db:"accumulated_depreciation_account_id"`
+	DepreciationExpenseAccountID     int64   `db:"depreciation_expense_account_id"`
+	UsefulLifeMonths                 int64   `db:"useful_life_months"`
+	MethodID                         int64   `db:"method_id"`
+	DecliningFactor                  float64 `db:"declining_factor"`
+}
+
+// FixedAsset is depreciated monthly from the month of InServiceDate down to its SalvageValue
+// over UsefulLifeMonths, the method and life copied from its category unless given.
+type FixedAsset struct {
+	ID                int64
+	Name              string
+	CategoryID        int64           `db:"category_id"`
+	AcquisitionCost   float64         `db:"acquisition_cost"`
+	InServiceDate     time.Time       `db:"in_service_date"`
+	SalvageValue      float64         `db:"salvage_value"`
+	UsefulLifeMonths  int64           `db:"useful_life_months"`
+	MethodID          int64           `db:"method_id"`
+	DecliningFactor   float64         `db:"declining_factor"`
+	StatusID          int64           `db:"status_id"`
+	DisposedAt        sql.NullTime    `db:"disposed_at"`
+	DisposalProceeds  sql.NullFloat64 `db:"disposal_proceeds"`
+	DisposalJournalID uuid.NullUUID   `db:"disposal_journal_id"`
+	CreatedBy         uuid.UUID       `db:"created_by"`
+	CreatedAt         time.Time       `db:"created_at"`
+	// AccumulatedDepreciation sums the depreciation posted by runs whose journal has not been voided.
+	AccumulatedDepreciation float64 `db:"accumulated_depreciation"`
+}
+
+// DepreciationRun posts the depreciation of every active asset for the month ending on PeriodEnd in one journal.
+type DepreciationRun struct {
+	ID        int64
+	PeriodEnd time.Time `db:"period_end"`
+	JournalID uuid.UUID `db:"journal_id"`
+	CreatedBy uuid.UUID `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	Entries   []DepreciationEntry
+}
+
+type DepreciationEntry struct {
+	ID      int64
+	RunID   int64 `db:"run_id"`
+	AssetID int64 `db:"asset_id"`
+	Amount  float64
+}
+
+// FixedAssetRegisterRow is an asset with the depreciation posted through the date of the register.
+type FixedAssetRegisterRow struct {
+	Asset                   FixedAsset
+	AccumulatedDepreciation float64
+	NetBookValue            float64
+}
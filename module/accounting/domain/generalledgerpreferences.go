@@ -6,3 +6,14 @@ type GeneralLedgerPreference struct {
 	ID        int64
 	AccountID sql.NullInt64 `db:"account_id"`
 }
+
+// GeneralLedgerPreferenceDefinition describes a general ledger preference: the class types its account must be one
+// of, and whether an account must be set at all.
+type GeneralLedgerPreferenceDefinition struct {
+	ID           int64
+	Key          string
+	Name         string
+	Description  string
+	ClassTypeIDs []int64
+	Required     bool
+}
@@ -1,13 +1,20 @@
 package domain
 
 import (
+	"database/sql"
 	"github.com/google/uuid"
 )
 
 type GeneralLedger struct {
-	ID        uuid.UUID
-	JournalID uuid.UUID
-	AccountID int64
-	Amount    float64
-	CreatedBy uuid.UUID
+	ID                uuid.UUID
+	JournalID         uuid.UUID `db:"journal_id"`
+	AccountID         int64     `db:"account_id"`
+	Amount            float64
+	CreatedBy         uuid.UUID `db:"created_by"`
+	Memo              sql.NullString
+	ExternalReference sql.NullString `db:"external_reference"`
+
+	// CounterpartyCompanyID tags an intercompany line with the company on the other side,
+	// consolidation eliminates it when both companies are consolidated together.
+	CounterpartyCompanyID sql.NullInt64 `db:"counterparty_company_id"`
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// IntercompanyTransaction is paid by one company on behalf of another. The paying company posts its lines against
+// the amount due from the other company, and the other company posts the mirror lines against the amount due to
+// the paying company, both journals carrying the reference of the transaction.
+type IntercompanyTransaction struct {
+	ID            int64
+	Reference     string
+	FromCompanyID int64     `db:"from_company_id"`
+	ToCompanyID   int64     `db:"to_company_id"`
+	TransDate     time.Time `db:"trans_date"`
+	Memo          sql.NullString
+	Amount        float64
+	FromJournalID uuid.UUID `db:"from_journal_id"`
+	ToJournalID   uuid.UUID `db:"to_journal_id"`
+	CreatedBy     uuid.UUID `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+	Voided        bool
+
+	// FromLines are posted by the paying company and credit the amount in total, ToLines are posted by the other
+	// company and debit it. They are only read when the transaction is stored, the journals keep them after.
+	FromLines []IntercompanyTransactionLine `db:"-"`
+	ToLines   []IntercompanyTransactionLine `db:"-"`
+}
+
+// IntercompanyTransactionLine is debit positive and credit negative.
+type IntercompanyTransactionLine struct {
+	AccountID int64
+	Amount    float64
+	Memo      string
+}
+
+// IntercompanyBalance pairs what a company has booked against a counterparty company with what the counterparty
+// has booked back against it, debit positive and credit negative. The two match when they net to zero, anything
+// else is left as the difference.
+type IntercompanyBalance struct {
+	CompanyID             int64 `db:"company_id"`
+	CounterpartyCompanyID int64 `db:"counterparty_company_id"`
+	Amount                float64
+	CounterpartyAmount    float64
+	Difference            float64
+}
@@ -8,9 +8,13 @@ import (
 
 type Journal struct {
 	ID        uuid.UUID
+	TypeID    int64 `db:"type_id"`
+	Number    sql.NullString
 	Amount    float64
-	CreatedAt time.Time
-	TransDate time.Time
+	CreatedAt time.Time `db:"created_at"`
+	TransDate time.Time `db:"trans_date"`
 	Memo      sql.NullString
-	DeletedAt time.Time
+	DeletedAt time.Time `db:"deleted_at"`
+	Closing   bool
+	Opening   bool
 }
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	DraftJournalStatus int64 = iota + 1
+	SubmittedJournalStatus
+	ApprovedJournalStatus
+	RejectedJournalStatus
+)
+
+type JournalDraft struct {
+	ID          uuid.UUID
+	Amount      float64
+	TransDate   time.Time `db:"trans_date"`
+	Memo        sql.NullString
+	StatusID    int64         `db:"status_id"`
+	JournalID   uuid.NullUUID `db:"journal_id"`
+	CreatedBy   uuid.UUID     `db:"created_by"`
+	CreatedAt   time.Time     `db:"created_at"`
+	SubmittedAt sql.NullTime  `db:"submitted_at"`
+}
+
+type JournalDraftLine struct {
+	ID                uuid.UUID
+	DraftID           uuid.UUID `db:"draft_id"`
+	AccountID         int64     `db:"account_id"`
+	Amount            float64
+	Memo              sql.NullString
+	ExternalReference sql.NullString `db:"external_reference"`
+}
+
+type JournalDraftReview struct {
+	ID        int64
+	DraftID   uuid.UUID `db:"draft_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Approved  bool
+	Comment   sql.NullString
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// JournalImportAllOrNothingMode posts the journals of a batch only when every one of them is valid.
+	JournalImportAllOrNothingMode = "all_or_nothing"
+	// JournalImportValidOnlyMode posts the valid journals of a batch and reports the others.
+	JournalImportValidOnlyMode = "valid_only"
+)
+
+// JournalImport is a batch of journals handed over by another system, identified by the batch ID of that system so
+// the batch is posted once however often it is uploaded. A batch is recorded when any of its journals is posted.
+type JournalImport struct {
+	ID          int64
+	BatchID     string `db:"batch_id"`
+	Mode        string
+	PostedCount int64     `db:"posted_count"`
+	ErrorCount  int64     `db:"error_count"`
+	CreatedBy   uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+
+	// Replayed tells that the batch had been imported before and nothing was posted again.
+	Replayed bool `db:"-"`
+
+	Journals []JournalImportJournal `db:"-"`
+	Errors   []JournalImportError   `db:"-"`
+}
+
+// JournalImportJournal is a journal of a batch, identified within the batch by its reference. JournalID is set once
+// it is posted.
+type JournalImportJournal struct {
+	Row       int64 `db:"line"`
+	Reference string
+	TransDate time.Time `db:"trans_date"`
+	Memo      string
+	JournalID uuid.NullUUID       `db:"journal_id"`
+	Lines     []JournalImportLine `db:"-"`
+}
+
+// JournalImportLine is debit positive and credit negative.
+type JournalImportLine struct {
+	Row               int64
+	AccountID         int64
+	Amount            float64
+	Memo              string
+	ExternalReference string
+}
+
+// JournalImportError reports what is wrong with a row of the file, Row being the line of a CSV file or the position
+// of the journal in a JSON file.
+type JournalImportError struct {
+	Row       int64 `db:"line"`
+	Reference string
+	Message   string
+}
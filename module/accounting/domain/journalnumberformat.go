@@ -0,0 +1,6 @@
+package domain
+
+type JournalNumberFormat struct {
+	TypeID int64 `db:"type_id"`
+	Format string
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// JournalVersion is a journal as it stood between two amendments, the last version being the journal as it is now.
+// CreatedBy and CreatedAt tell who made the version, the creator of the journal for the first one and the amending
+// user for the others.
+type JournalVersion struct {
+	Version   int64
+	TransDate time.Time
+	Memo      sql.NullString
+	Amount    float64
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+	Reason    sql.NullString
+	Lines     []JournalVersionLine
+
+	// Changes are the account amounts changed from the version before, none for the first version.
+	Changes []JournalLineChange
+}
+
+type JournalVersionLine struct {
+	AccountID             int64 `db:"account_id"`
+	Amount                float64
+	Memo                  sql.NullString
+	ExternalReference     sql.NullString `db:"external_reference"`
+	CounterpartyCompanyID sql.NullInt64  `db:"counterparty_company_id"`
+}
+
+// JournalLineChange is the net amount of an account before and after an amendment, debit positive and credit
+// negative. An account added by the amendment has no amount before, a removed one has none after.
+type JournalLineChange struct {
+	AccountID int64
+	Before    float64
+	After     float64
+}
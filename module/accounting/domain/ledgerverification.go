@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	UnbalancedJournalCheck         = "unbalanced_journal"
+	JournalAmountMismatchCheck     = "journal_amount_mismatch"
+	UnmatchedBankTransactionCheck  = "unmatched_bank_transaction"
+	BankBalanceDriftCheck          = "bank_balance_drift"
+	PostingOutsideFiscalYearCheck  = "posting_outside_fiscal_year"
+	PostingInClosedFiscalYearCheck = "posting_in_closed_fiscal_year"
+)
+
+// LedgerIssue is one inconsistency found by a ledger check, with the ids of what it was found on. Expected is
+// what the ledger says and Actual what was stored, for the checks that compare amounts.
+type LedgerIssue struct {
+	Check             string
+	JournalID         uuid.NullUUID `db:"journal_id"`
+	BankTransactionID sql.NullInt64 `db:"bank_transaction_id"`
+	BankAccountID     sql.NullInt64 `db:"bank_account_id"`
+	FiscalYearID      sql.NullInt64 `db:"fiscal_year_id"`
+	Expected          float64
+	Actual            float64
+	Message           string
+}
+
+// LedgerVerification is the result of running every ledger check over the journals of a company,
+// voided journals left out.
+type LedgerVerification struct {
+	CompanyID       int64
+	Valid           bool
+	CheckedJournals int64
+	Issues          []LedgerIssue
+}
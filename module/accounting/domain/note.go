@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreditNote takes part or all of a sales invoice back. It reverses the income lines and the sales tax of the
+// invoice in proportion to its total against the accounts receivable control account, and lowers what is left
+// open on the invoice by its total.
+type CreditNote struct {
+	ID        int64
+	Number    string
+	InvoiceID int64     `db:"invoice_id"`
+	NoteDate  time.Time `db:"note_date"`
+	Memo      sql.NullString
+	Subtotal  float64
+	TaxAmount float64 `db:"tax_amount"`
+	Total     float64
+	JournalID uuid.UUID `db:"journal_id"`
+	CreatedBy uuid.UUID `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	Voided    bool
+}
+
+// DebitNote takes part or all of a purchase bill back. It reverses the lines and the purchase tax of the bill
+// in proportion to its total against the accounts payable control account, and lowers what is left open on
+// the bill by its total.
+type DebitNote struct {
+	ID        int64
+	Number    string
+	BillID    int64     `db:"bill_id"`
+	NoteDate  time.Time `db:"note_date"`
+	Memo      sql.NullString
+	Subtotal  float64
+	TaxAmount float64 `db:"tax_amount"`
+	Total     float64
+	JournalID uuid.UUID `db:"journal_id"`
+	CreatedBy uuid.UUID `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	Voided    bool
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Vendor struct {
+	ID               int64
+	Code             string
+	Name             string
+	Email            sql.NullString
+	Phone            sql.NullString
+	Address          sql.NullString
+	TaxNumber        sql.NullString `db:"tax_number"`
+	PaymentTermsDays int64          `db:"payment_terms_days"`
+	Inactive         bool
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// PurchaseBill credits its total to the accounts payable control account and debits each line to its asset,
+// cost of goods sold or expense account and the tax to the purchase tax recoverable account, in one journal
+// whose number becomes the bill number. VendorReference keeps the invoice number the vendor gave the bill.
+type PurchaseBill struct {
+	ID              int64
+	Number          string
+	VendorID        int64     `db:"vendor_id"`
+	VendorReference string    `db:"vendor_reference"`
+	BillDate        time.Time `db:"bill_date"`
+	DueDate         time.Time `db:"due_date"`
+	Memo            sql.NullString
+	Subtotal        float64
+	TaxAmount       float64 `db:"tax_amount"`
+	Total           float64
+	JournalID       uuid.UUID `db:"journal_id"`
+	CreatedBy       uuid.UUID `db:"created_by"`
+	CreatedAt       time.Time `db:"created_at"`
+	// PaidAmount sums the payments allocated to the bill.
+	PaidAmount float64 `db:"paid_amount"`
+	// DebitedAmount sums the debit notes raised against the bill.
+	DebitedAmount float64 `db:"debited_amount"`
+	Voided        bool
+	Lines         []PurchaseBillLine
+}
+
+type PurchaseBillLine struct {
+	ID          int64
+	BillID      int64 `db:"bill_id"`
+	AccountID   int64 `db:"account_id"`
+	Description string
+	Quantity    float64
+	UnitPrice   float64 `db:"unit_price"`
+	// TaxRate is a percentage of the line amount.
+	TaxRate   float64 `db:"tax_rate"`
+	Amount    float64
+	TaxAmount float64 `db:"tax_amount"`
+}
+
+// VendorPayment is money paid to a vendor out of a bank account. It debits the accounts payable control
+// account and is allocated to bills of the vendor, the part not allocated yet stays unapplied.
+type VendorPayment struct {
+	ID            int64
+	VendorID      int64     `db:"vendor_id"`
+	BankAccountID int64     `db:"bank_account_id"`
+	PaymentDate   time.Time `db:"payment_date"`
+	Amount        float64
+	Memo          sql.NullString
+	JournalID     uuid.UUID `db:"journal_id"`
+	CreatedBy     uuid.UUID `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+	// AllocatedAmount sums the allocations of the payment.
+	AllocatedAmount float64 `db:"allocated_amount"`
+	Allocations     []PaymentAllocation
+}
+
+type PaymentAllocation struct {
+	ID        int64
+	PaymentID int64 `db:"payment_id"`
+	BillID    int64 `db:"bill_id"`
+	Amount    float64
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Customer struct {
+	ID               int64
+	Code             string
+	Name             string
+	Email            sql.NullString
+	Phone            sql.NullString
+	Address          sql.NullString
+	TaxNumber        sql.NullString `db:"tax_number"`
+	PaymentTermsDays int64          `db:"payment_terms_days"`
+	Inactive         bool
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// SalesInvoice debits its total to the accounts receivable control account and credits each line to its income
+// account and the tax to the sales tax payable account, in one journal whose number becomes the invoice number.
+type SalesInvoice struct {
+	ID          int64
+	Number      string
+	CustomerID  int64     `db:"customer_id"`
+	InvoiceDate time.Time `db:"invoice_date"`
+	DueDate     time.Time `db:"due_date"`
+	Memo        sql.NullString
+	Subtotal    float64
+	TaxAmount   float64 `db:"tax_amount"`
+	Total       float64
+	JournalID   uuid.UUID `db:"journal_id"`
+	CreatedBy   uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	// PaidAmount sums the receipts allocated to the invoice.
+	PaidAmount float64 `db:"paid_amount"`
+	// CreditedAmount sums the credit notes raised against the invoice.
+	CreditedAmount float64 `db:"credited_amount"`
+	Voided         bool
+	Lines          []SalesInvoiceLine
+}
+
+type SalesInvoiceLine struct {
+	ID          int64
+	InvoiceID   int64 `db:"invoice_id"`
+	AccountID   int64 `db:"account_id"`
+	Description string
+	Quantity    float64
+	UnitPrice   float64 `db:"unit_price"`
+	// TaxRate is a percentage of the line amount.
+	TaxRate   float64 `db:"tax_rate"`
+	Amount    float64
+	TaxAmount float64 `db:"tax_amount"`
+}
+
+// CustomerReceipt is money received from a customer into a bank account. It credits the accounts receivable
+// control account and is allocated to invoices of the customer, the part not allocated yet stays unapplied.
+type CustomerReceipt struct {
+	ID            int64
+	CustomerID    int64     `db:"customer_id"`
+	BankAccountID int64     `db:"bank_account_id"`
+	ReceiptDate   time.Time `db:"receipt_date"`
+	Amount        float64
+	Memo          sql.NullString
+	JournalID     uuid.UUID `db:"journal_id"`
+	CreatedBy     uuid.UUID `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+	// AllocatedAmount sums the allocations of the receipt.
+	AllocatedAmount float64 `db:"allocated_amount"`
+	Allocations     []ReceiptAllocation
+}
+
+type ReceiptAllocation struct {
+	ID        int64
+	ReceiptID int64 `db:"receipt_id"`
+	InvoiceID int64 `db:"invoice_id"`
+	Amount    float64
+}
+
+// AgingReport buckets what each party owes, or is owed, as of a date by the days past the due date of each document.
+// Unapplied holds payments not allocated to a document yet and is not part of Total.
+type AgingReport struct {
+	AsOf  time.Time
+	Rows  []AgingRow
+	Total AgingRow
+}
+
+type AgingRow struct {
+	PartyID    int64
+	PartyName  string
+	Current    float64
+	Days1To30  float64
+	Days31To60 float64
+	Days61To90 float64
+	Over90     float64
+	Total      float64
+	Unapplied  float64
+}
+
+// PartyStatement lists the documents and payments of a customer or vendor between two dates with a running balance.
+type PartyStatement struct {
+	PartyID        int64
+	StartDate      time.Time
+	EndDate        time.Time
+	OpeningBalance float64
+	Lines          []PartyStatementLine
+	ClosingBalance float64
+}
+
+type PartyStatementLine struct {
+	Date      time.Time
+	Kind      string
+	ID        int64
+	Reference string
+	Memo      string
+	// Amount raises the balance for a document and lowers it for a payment.
+	Amount  float64
+	Balance float64
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// StandardAuditFile is the content of a SAF-T export of a company for a date range. Balances are debit positive
+// and credit negative, the opening balance taken before the start date and the closing balance at the end date.
+type StandardAuditFile struct {
+	Company   Company
+	StartDate time.Time
+	EndDate   time.Time
+	Accounts  []StandardAuditFileAccount
+	Journals  []StandardAuditFileJournal
+	Customers []Customer
+	Vendors   []Vendor
+	TaxRates  []StandardAuditFileTaxRate
+}
+
+type StandardAuditFileAccount struct {
+	ID             int64
+	Code           sql.NullString
+	Name           string
+	ClassTypeID    int64   `db:"class_type_id"`
+	OpeningBalance float64 `db:"opening_balance"`
+	ClosingBalance float64 `db:"closing_balance"`
+}
+
+type StandardAuditFileJournal struct {
+	Journal
+	Lines []GeneralLedger
+}
+
+// StandardAuditFileTaxRate is a tax rate used on the sales invoice or purchase bill lines of the date range,
+// there being no tax code master data.
+type StandardAuditFileTaxRate struct {
+	Kind string
+	Rate float64
+}
+
+const (
+	SalesTaxRateKind    = "sales"
+	PurchaseTaxRateKind = "purchase"
+)
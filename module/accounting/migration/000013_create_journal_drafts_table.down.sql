@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS journal_draft_reviews;
+DROP TABLE IF EXISTS journal_draft_lines;
+DROP TABLE IF EXISTS journal_drafts;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS journal_drafts
+(
+    id           uuid PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    amount       numeric(18, 8)           NOT NULL DEFAULT 0,
+    trans_date   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    memo         text,
+    status_id    int                      NOT NULL DEFAULT 1,
+    journal_id   uuid,
+    created_by   uuid                     NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id)
+);
+
+CREATE TABLE IF NOT EXISTS journal_draft_lines
+(
+    id         uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    draft_id   uuid           NOT NULL,
+    account_id int            NOT NULL,
+    amount     numeric(18, 8) NOT NULL,
+
+    CONSTRAINT fk_draft_id FOREIGN KEY (draft_id) REFERENCES journal_drafts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX idx_journal_draft_lines_draft_id ON journal_draft_lines (draft_id);
+
+CREATE TABLE IF NOT EXISTS journal_draft_reviews
+(
+    id         SERIAL PRIMARY KEY,
+    draft_id   uuid                     NOT NULL,
+    user_id    uuid                     NOT NULL,
+    approved   BOOLEAN                  NOT NULL,
+    comment    text,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_draft_id FOREIGN KEY (draft_id) REFERENCES journal_drafts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_journal_draft_reviews_draft_id ON journal_draft_reviews (draft_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS approval_rules
+(
+    id                 SERIAL PRIMARY KEY,
+    account_class_id   int,
+    min_amount         numeric(18, 8) NOT NULL DEFAULT 0,
+    required_approvals int            NOT NULL DEFAULT 1,
+
+    CONSTRAINT fk_account_class_id FOREIGN KEY (account_class_id) REFERENCES account_classes (id),
+    CHECK (required_approvals > 0)
+);
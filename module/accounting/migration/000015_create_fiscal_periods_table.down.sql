@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fiscal_period_histories;
+DROP TABLE IF EXISTS fiscal_periods;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS fiscal_periods
+(
+    id             SERIAL PRIMARY KEY,
+    fiscal_year_id int                      NOT NULL,
+    start_date     TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date       TIMESTAMP WITH TIME ZONE NOT NULL,
+    status_id      int                      NOT NULL DEFAULT 1,
+
+    UNIQUE (start_date, end_date),
+    CONSTRAINT fk_fiscal_year_id FOREIGN KEY (fiscal_year_id) REFERENCES fiscal_years (id)
+);
+
+CREATE INDEX idx_fiscal_periods_fiscal_year_id ON fiscal_periods (fiscal_year_id);
+
+CREATE TABLE IF NOT EXISTS fiscal_period_histories
+(
+    id               SERIAL PRIMARY KEY,
+    fiscal_period_id int                      NOT NULL,
+    from_status_id   int                      NOT NULL,
+    to_status_id     int                      NOT NULL,
+    reason           text,
+    created_by       uuid                     NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_fiscal_period_id FOREIGN KEY (fiscal_period_id) REFERENCES fiscal_periods (id)
+);
+
+CREATE INDEX idx_fiscal_period_histories_fiscal_period_id ON fiscal_period_histories (fiscal_period_id);
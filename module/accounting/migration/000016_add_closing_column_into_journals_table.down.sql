@@ -0,0 +1,2 @@
+ALTER TABLE journals
+DROP COLUMN closing;
@@ -0,0 +1,2 @@
+ALTER TABLE journals
+ADD closing BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS fiscal_year_histories
+(
+    id             SERIAL PRIMARY KEY,
+    fiscal_year_id int                      NOT NULL,
+    action_id      int                      NOT NULL,
+    journal_id     uuid,
+    reason         text,
+    created_by     uuid                     NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_fiscal_year_id FOREIGN KEY (fiscal_year_id) REFERENCES fiscal_years (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id)
+);
+
+CREATE INDEX idx_fiscal_year_histories_fiscal_year_id ON fiscal_year_histories (fiscal_year_id);
@@ -0,0 +1,4 @@
+DELETE FROM general_ledger_preferences WHERE id = 2;
+
+ALTER TABLE journals
+DROP COLUMN opening;
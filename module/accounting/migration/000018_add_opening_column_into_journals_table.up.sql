@@ -0,0 +1,5 @@
+ALTER TABLE journals
+ADD opening BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO general_ledger_preferences (id)
+VALUES (2);
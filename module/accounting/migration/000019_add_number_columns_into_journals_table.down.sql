@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS journal_sequences;
+DROP TABLE IF EXISTS journal_number_formats;
+
+ALTER TABLE journals
+DROP COLUMN number;
+
+ALTER TABLE journals
+DROP COLUMN type_id;
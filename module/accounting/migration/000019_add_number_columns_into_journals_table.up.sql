@@ -0,0 +1,29 @@
+ALTER TABLE journals
+ADD type_id int NOT NULL DEFAULT 1;
+
+ALTER TABLE journals
+ADD number varchar(64);
+
+CREATE INDEX idx_journals_number ON journals (number);
+
+CREATE TABLE IF NOT EXISTS journal_number_formats
+(
+    type_id int PRIMARY KEY,
+    format  varchar(64) NOT NULL
+);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (1, 'JV/{YYYY}/{MM}/{NNNNN}'),
+       (2, 'BD/{YYYY}/{MM}/{NNNNN}'),
+       (3, 'CL/{YYYY}/{NNNNN}'),
+       (4, 'OB/{YYYY}/{NNNNN}');
+
+CREATE TABLE IF NOT EXISTS journal_sequences
+(
+    fiscal_year_id int NOT NULL,
+    type_id        int NOT NULL,
+    last_number    int NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (fiscal_year_id, type_id),
+    CONSTRAINT fk_fiscal_year_id FOREIGN KEY (fiscal_year_id) REFERENCES fiscal_years (id)
+);
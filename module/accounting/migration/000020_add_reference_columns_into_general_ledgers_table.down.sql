@@ -0,0 +1,9 @@
+ALTER TABLE journal_draft_lines
+DROP COLUMN external_reference,
+DROP COLUMN memo;
+
+DROP INDEX IF EXISTS idx_general_ledgers_external_reference;
+
+ALTER TABLE general_ledgers
+DROP COLUMN external_reference,
+DROP COLUMN memo;
@@ -0,0 +1,9 @@
+ALTER TABLE general_ledgers
+ADD memo text,
+ADD external_reference varchar(255);
+
+CREATE INDEX idx_general_ledgers_external_reference ON general_ledgers (external_reference);
+
+ALTER TABLE journal_draft_lines
+ADD memo text,
+ADD external_reference varchar(255);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS attachment_files;
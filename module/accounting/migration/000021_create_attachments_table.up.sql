@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS attachment_files
+(
+    id           uuid PRIMARY KEY,
+    sha256       char(64)                 NOT NULL,
+    content_type varchar(255)             NOT NULL,
+    size         bigint                   NOT NULL,
+    storage_key  text                     NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT uq_attachment_files_sha256 UNIQUE (sha256)
+);
+
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id                  uuid PRIMARY KEY,
+    file_id             uuid                     NOT NULL,
+    journal_id          uuid,
+    bank_transaction_id int,
+    file_name           varchar(255)             NOT NULL,
+    created_by          uuid                     NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at          TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_file_id FOREIGN KEY (file_id) REFERENCES attachment_files (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CONSTRAINT fk_bank_transaction_id FOREIGN KEY (bank_transaction_id) REFERENCES bank_transactions (id),
+    CONSTRAINT ck_attachments_target CHECK ((journal_id IS NULL) <> (bank_transaction_id IS NULL))
+);
+
+CREATE INDEX idx_attachments_journal_id ON attachments (journal_id);
+CREATE INDEX idx_attachments_bank_transaction_id ON attachments (bank_transaction_id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS account_code_formats;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS uq_accounts_group_id_name;
+ALTER TABLE accounts ADD CONSTRAINT accounts_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_accounts_code_pattern;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS code;
+ALTER TABLE account_groups DROP COLUMN IF EXISTS code;
+ALTER TABLE account_classes DROP COLUMN IF EXISTS code;
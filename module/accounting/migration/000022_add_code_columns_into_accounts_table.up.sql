@@ -0,0 +1,26 @@
+ALTER TABLE account_classes ADD COLUMN IF NOT EXISTS code varchar(32);
+ALTER TABLE account_groups ADD COLUMN IF NOT EXISTS code varchar(32);
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS code varchar(32);
+
+ALTER TABLE account_classes ADD CONSTRAINT uq_account_classes_code UNIQUE (code);
+ALTER TABLE account_groups ADD CONSTRAINT uq_account_groups_code UNIQUE (code);
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_code UNIQUE (code);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_code_pattern ON accounts (code varchar_pattern_ops);
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_name_key;
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_group_id_name UNIQUE (group_id, name);
+
+CREATE TABLE IF NOT EXISTS account_code_formats
+(
+    class_type_id int PRIMARY KEY,
+    pattern       text NOT NULL
+);
+
+INSERT INTO account_code_formats (class_type_id, pattern)
+VALUES (1, '^1-[0-9]{4}$'),
+       (2, '^2-[0-9]{4}$'),
+       (3, '^3-[0-9]{4}$'),
+       (4, '^4-[0-9]{4}$'),
+       (5, '^5-[0-9]{4}$'),
+       (6, '^6-[0-9]{4}$');
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS budgets
+(
+    id             SERIAL PRIMARY KEY,
+    fiscal_year_id int                      NOT NULL,
+    name           varchar(255)             NOT NULL,
+    description    text,
+    created_by     uuid                     NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_fiscal_year_id FOREIGN KEY (fiscal_year_id) REFERENCES fiscal_years (id),
+    CONSTRAINT uq_budgets_fiscal_year_id_name UNIQUE (fiscal_year_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS budget_lines
+(
+    id               SERIAL PRIMARY KEY,
+    budget_id        int            NOT NULL,
+    account_id       int            NOT NULL,
+    fiscal_period_id int            NOT NULL,
+    dimension        varchar(255)   NOT NULL DEFAULT '',
+    amount           numeric(18, 8) NOT NULL,
+
+    CONSTRAINT fk_budget_id FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_fiscal_period_id FOREIGN KEY (fiscal_period_id) REFERENCES fiscal_periods (id),
+    CONSTRAINT uq_budget_lines UNIQUE (budget_id, account_id, fiscal_period_id, dimension)
+);
+
+CREATE INDEX idx_budget_lines_account_id ON budget_lines (account_id);
@@ -0,0 +1,4 @@
+DELETE FROM journal_number_formats WHERE type_id = 5;
+
+DROP TABLE IF EXISTS amortization_entries;
+DROP TABLE IF EXISTS amortization_schedules;
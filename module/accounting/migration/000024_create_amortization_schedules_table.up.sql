@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS amortization_schedules
+(
+    id                SERIAL PRIMARY KEY,
+    name              varchar(255)             NOT NULL,
+    total_amount      numeric(18, 8)           NOT NULL,
+    start_date        date                     NOT NULL,
+    end_date          date                     NOT NULL,
+    source_account_id int                      NOT NULL,
+    target_account_id int                      NOT NULL,
+    method_id         int                      NOT NULL DEFAULT 1,
+    status_id         int                      NOT NULL DEFAULT 1,
+    terminated_at     date,
+    created_by        uuid                     NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_source_account_id FOREIGN KEY (source_account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_target_account_id FOREIGN KEY (target_account_id) REFERENCES accounts (id),
+    CHECK (total_amount > 0),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_amortization_schedules_status_id ON amortization_schedules (status_id);
+
+CREATE TABLE IF NOT EXISTS amortization_entries
+(
+    id           SERIAL PRIMARY KEY,
+    schedule_id  int                      NOT NULL,
+    period_start date                     NOT NULL,
+    period_end   date                     NOT NULL,
+    amount       numeric(18, 8)           NOT NULL,
+    journal_id   uuid                     NOT NULL,
+    catch_up     BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_schedule_id FOREIGN KEY (schedule_id) REFERENCES amortization_schedules (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id)
+);
+
+CREATE INDEX idx_amortization_entries_schedule_id ON amortization_entries (schedule_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (5, 'AM/{YYYY}/{MM}/{NNNNN}');
@@ -0,0 +1,7 @@
+DELETE FROM general_ledger_preferences WHERE id = 3;
+DELETE FROM journal_number_formats WHERE type_id = 6;
+
+DROP TABLE IF EXISTS depreciation_entries;
+DROP TABLE IF EXISTS depreciation_runs;
+DROP TABLE IF EXISTS fixed_assets;
+DROP TABLE IF EXISTS asset_categories;
@@ -0,0 +1,78 @@
+CREATE TABLE IF NOT EXISTS asset_categories
+(
+    id                                  SERIAL PRIMARY KEY,
+    name                                varchar(255)   NOT NULL,
+    asset_account_id                    int            NOT NULL,
+    accumulated_depreciation_account_id int            NOT NULL,
+    depreciation_expense_account_id     int            NOT NULL,
+    useful_life_months                  int            NOT NULL,
+    method_id                           int            NOT NULL DEFAULT 1,
+    declining_factor                    numeric(18, 8) NOT NULL DEFAULT 2,
+
+    CONSTRAINT asset_categories_name_key UNIQUE (name),
+    CONSTRAINT fk_asset_account_id FOREIGN KEY (asset_account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_accumulated_depreciation_account_id FOREIGN KEY (accumulated_depreciation_account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_depreciation_expense_account_id FOREIGN KEY (depreciation_expense_account_id) REFERENCES accounts (id),
+    CHECK (useful_life_months > 0),
+    CHECK (declining_factor > 0)
+);
+
+CREATE TABLE IF NOT EXISTS fixed_assets
+(
+    id                  SERIAL PRIMARY KEY,
+    name                varchar(255)             NOT NULL,
+    category_id         int                      NOT NULL,
+    acquisition_cost    numeric(18, 8)           NOT NULL,
+    in_service_date     date                     NOT NULL,
+    salvage_value       numeric(18, 8)           NOT NULL DEFAULT 0,
+    useful_life_months  int                      NOT NULL,
+    method_id           int                      NOT NULL,
+    declining_factor    numeric(18, 8)           NOT NULL,
+    status_id           int                      NOT NULL DEFAULT 1,
+    disposed_at         date,
+    disposal_proceeds   numeric(18, 8),
+    disposal_journal_id uuid,
+    created_by          uuid                     NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_category_id FOREIGN KEY (category_id) REFERENCES asset_categories (id),
+    CONSTRAINT fk_disposal_journal_id FOREIGN KEY (disposal_journal_id) REFERENCES journals (id),
+    CHECK (acquisition_cost > 0),
+    CHECK (salvage_value >= 0 AND salvage_value <= acquisition_cost),
+    CHECK (useful_life_months > 0)
+);
+
+CREATE INDEX idx_fixed_assets_category_id ON fixed_assets (category_id);
+CREATE INDEX idx_fixed_assets_status_id ON fixed_assets (status_id);
+
+CREATE TABLE IF NOT EXISTS depreciation_runs
+(
+    id         SERIAL PRIMARY KEY,
+    period_end date                     NOT NULL,
+    journal_id uuid                     NOT NULL,
+    created_by uuid                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id)
+);
+
+CREATE INDEX idx_depreciation_runs_period_end ON depreciation_runs (period_end);
+
+CREATE TABLE IF NOT EXISTS depreciation_entries
+(
+    id       SERIAL PRIMARY KEY,
+    run_id   int            NOT NULL,
+    asset_id int            NOT NULL,
+    amount   numeric(18, 8) NOT NULL,
+
+    CONSTRAINT fk_run_id FOREIGN KEY (run_id) REFERENCES depreciation_runs (id) ON DELETE CASCADE,
+    CONSTRAINT fk_asset_id FOREIGN KEY (asset_id) REFERENCES fixed_assets (id)
+);
+
+CREATE INDEX idx_depreciation_entries_asset_id ON depreciation_entries (asset_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (6, 'FA/{YYYY}/{MM}/{NNNNN}');
+
+INSERT INTO general_ledger_preferences (id)
+VALUES (3);
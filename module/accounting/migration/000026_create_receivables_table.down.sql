@@ -0,0 +1,8 @@
+DELETE FROM general_ledger_preferences WHERE id IN (4, 5);
+DELETE FROM journal_number_formats WHERE type_id = 7;
+
+DROP TABLE IF EXISTS receipt_allocations;
+DROP TABLE IF EXISTS customer_receipts;
+DROP TABLE IF EXISTS sales_invoice_lines;
+DROP TABLE IF EXISTS sales_invoices;
+DROP TABLE IF EXISTS customers;
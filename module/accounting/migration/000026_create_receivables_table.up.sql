@@ -0,0 +1,98 @@
+CREATE TABLE IF NOT EXISTS customers
+(
+    id                 SERIAL PRIMARY KEY,
+    code               varchar(50)              NOT NULL,
+    name               varchar(255)             NOT NULL,
+    email              varchar(255),
+    phone              varchar(50),
+    address            text,
+    tax_number         varchar(50),
+    payment_terms_days int                      NOT NULL DEFAULT 30,
+    inactive           BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT customers_code_key UNIQUE (code),
+    CHECK (payment_terms_days >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS sales_invoices
+(
+    id           SERIAL PRIMARY KEY,
+    number       varchar(255)             NOT NULL,
+    customer_id  int                      NOT NULL,
+    invoice_date date                     NOT NULL,
+    due_date     date                     NOT NULL,
+    memo         text,
+    subtotal     numeric(18, 8)           NOT NULL,
+    tax_amount   numeric(18, 8)           NOT NULL DEFAULT 0,
+    total        numeric(18, 8)           NOT NULL,
+    journal_id   uuid                     NOT NULL,
+    created_by   uuid                     NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_customer_id FOREIGN KEY (customer_id) REFERENCES customers (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (due_date >= invoice_date)
+);
+
+CREATE INDEX idx_sales_invoices_customer_id ON sales_invoices (customer_id);
+
+CREATE TABLE IF NOT EXISTS sales_invoice_lines
+(
+    id          SERIAL PRIMARY KEY,
+    invoice_id  int            NOT NULL,
+    account_id  int            NOT NULL,
+    description varchar(255)   NOT NULL DEFAULT '',
+    quantity    numeric(18, 8) NOT NULL,
+    unit_price  numeric(18, 8) NOT NULL,
+    tax_rate    numeric(18, 8) NOT NULL DEFAULT 0,
+    amount      numeric(18, 8) NOT NULL,
+    tax_amount  numeric(18, 8) NOT NULL DEFAULT 0,
+
+    CONSTRAINT fk_invoice_id FOREIGN KEY (invoice_id) REFERENCES sales_invoices (id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX idx_sales_invoice_lines_invoice_id ON sales_invoice_lines (invoice_id);
+
+CREATE TABLE IF NOT EXISTS customer_receipts
+(
+    id              SERIAL PRIMARY KEY,
+    customer_id     int                      NOT NULL,
+    bank_account_id int                      NOT NULL,
+    receipt_date    date                     NOT NULL,
+    amount          numeric(18, 8)           NOT NULL,
+    memo            text,
+    journal_id      uuid                     NOT NULL,
+    created_by      uuid                     NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_customer_id FOREIGN KEY (customer_id) REFERENCES customers (id),
+    CONSTRAINT fk_bank_account_id FOREIGN KEY (bank_account_id) REFERENCES bank_accounts (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX idx_customer_receipts_customer_id ON customer_receipts (customer_id);
+
+CREATE TABLE IF NOT EXISTS receipt_allocations
+(
+    id         SERIAL PRIMARY KEY,
+    receipt_id int            NOT NULL,
+    invoice_id int            NOT NULL,
+    amount     numeric(18, 8) NOT NULL,
+
+    CONSTRAINT receipt_allocations_receipt_id_invoice_id_key UNIQUE (receipt_id, invoice_id),
+    CONSTRAINT fk_receipt_id FOREIGN KEY (receipt_id) REFERENCES customer_receipts (id),
+    CONSTRAINT fk_invoice_id FOREIGN KEY (invoice_id) REFERENCES sales_invoices (id),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX idx_receipt_allocations_invoice_id ON receipt_allocations (invoice_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (7, 'INV/{YYYY}/{MM}/{NNNNN}');
+
+INSERT INTO general_ledger_preferences (id)
+VALUES (4),
+       (5);
@@ -0,0 +1,8 @@
+DELETE FROM general_ledger_preferences WHERE id IN (6, 7);
+DELETE FROM journal_number_formats WHERE type_id IN (8, 9);
+
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS vendor_payments;
+DROP TABLE IF EXISTS purchase_bill_lines;
+DROP TABLE IF EXISTS purchase_bills;
+DROP TABLE IF EXISTS vendors;
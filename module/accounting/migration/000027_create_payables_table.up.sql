@@ -0,0 +1,101 @@
+CREATE TABLE IF NOT EXISTS vendors
+(
+    id                 SERIAL PRIMARY KEY,
+    code               varchar(50)              NOT NULL,
+    name               varchar(255)             NOT NULL,
+    email              varchar(255),
+    phone              varchar(50),
+    address            text,
+    tax_number         varchar(50),
+    payment_terms_days int                      NOT NULL DEFAULT 30,
+    inactive           BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT vendors_code_key UNIQUE (code),
+    CHECK (payment_terms_days >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS purchase_bills
+(
+    id               SERIAL PRIMARY KEY,
+    number           varchar(255)             NOT NULL,
+    vendor_id        int                      NOT NULL,
+    vendor_reference varchar(255)             NOT NULL DEFAULT '',
+    bill_date        date                     NOT NULL,
+    due_date         date                     NOT NULL,
+    memo             text,
+    subtotal         numeric(18, 8)           NOT NULL,
+    tax_amount       numeric(18, 8)           NOT NULL DEFAULT 0,
+    total            numeric(18, 8)           NOT NULL,
+    journal_id       uuid                     NOT NULL,
+    created_by       uuid                     NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_vendor_id FOREIGN KEY (vendor_id) REFERENCES vendors (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (due_date >= bill_date)
+);
+
+CREATE INDEX idx_purchase_bills_vendor_id ON purchase_bills (vendor_id);
+CREATE INDEX idx_purchase_bills_due_date ON purchase_bills (due_date);
+
+CREATE TABLE IF NOT EXISTS purchase_bill_lines
+(
+    id          SERIAL PRIMARY KEY,
+    bill_id     int            NOT NULL,
+    account_id  int            NOT NULL,
+    description varchar(255)   NOT NULL DEFAULT '',
+    quantity    numeric(18, 8) NOT NULL,
+    unit_price  numeric(18, 8) NOT NULL,
+    tax_rate    numeric(18, 8) NOT NULL DEFAULT 0,
+    amount      numeric(18, 8) NOT NULL,
+    tax_amount  numeric(18, 8) NOT NULL DEFAULT 0,
+
+    CONSTRAINT fk_bill_id FOREIGN KEY (bill_id) REFERENCES purchase_bills (id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX idx_purchase_bill_lines_bill_id ON purchase_bill_lines (bill_id);
+
+CREATE TABLE IF NOT EXISTS vendor_payments
+(
+    id              SERIAL PRIMARY KEY,
+    vendor_id       int                      NOT NULL,
+    bank_account_id int                      NOT NULL,
+    payment_date    date                     NOT NULL,
+    amount          numeric(18, 8)           NOT NULL,
+    memo            text,
+    journal_id      uuid                     NOT NULL,
+    created_by      uuid                     NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_vendor_id FOREIGN KEY (vendor_id) REFERENCES vendors (id),
+    CONSTRAINT fk_bank_account_id FOREIGN KEY (bank_account_id) REFERENCES bank_accounts (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX idx_vendor_payments_vendor_id ON vendor_payments (vendor_id);
+
+CREATE TABLE IF NOT EXISTS payment_allocations
+(
+    id         SERIAL PRIMARY KEY,
+    payment_id int            NOT NULL,
+    bill_id    int            NOT NULL,
+    amount     numeric(18, 8) NOT NULL,
+
+    CONSTRAINT payment_allocations_payment_id_bill_id_key UNIQUE (payment_id, bill_id),
+    CONSTRAINT fk_payment_id FOREIGN KEY (payment_id) REFERENCES vendor_payments (id),
+    CONSTRAINT fk_bill_id FOREIGN KEY (bill_id) REFERENCES purchase_bills (id),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX idx_payment_allocations_bill_id ON payment_allocations (bill_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (8, 'BILL/{YYYY}/{MM}/{NNNNN}'),
+       (9, 'BP/{YYYY}/{MM}/{NNNNN}');
+
+INSERT INTO general_ledger_preferences (id)
+VALUES (6),
+       (7);
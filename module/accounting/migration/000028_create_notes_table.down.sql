@@ -0,0 +1,4 @@
+DELETE FROM journal_number_formats WHERE type_id IN (10, 11);
+
+DROP TABLE IF EXISTS debit_notes;
+DROP TABLE IF EXISTS credit_notes;
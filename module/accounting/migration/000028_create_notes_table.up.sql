@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS credit_notes
+(
+    id         SERIAL PRIMARY KEY,
+    number     varchar(255)             NOT NULL,
+    invoice_id int                      NOT NULL,
+    note_date  date                     NOT NULL,
+    memo       text,
+    subtotal   numeric(18, 8)           NOT NULL,
+    tax_amount numeric(18, 8)           NOT NULL DEFAULT 0,
+    total      numeric(18, 8)           NOT NULL,
+    journal_id uuid                     NOT NULL,
+    created_by uuid                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_invoice_id FOREIGN KEY (invoice_id) REFERENCES sales_invoices (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (total > 0)
+);
+
+CREATE INDEX idx_credit_notes_invoice_id ON credit_notes (invoice_id);
+
+CREATE TABLE IF NOT EXISTS debit_notes
+(
+    id         SERIAL PRIMARY KEY,
+    number     varchar(255)             NOT NULL,
+    bill_id    int                      NOT NULL,
+    note_date  date                     NOT NULL,
+    memo       text,
+    subtotal   numeric(18, 8)           NOT NULL,
+    tax_amount numeric(18, 8)           NOT NULL DEFAULT 0,
+    total      numeric(18, 8)           NOT NULL,
+    journal_id uuid                     NOT NULL,
+    created_by uuid                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_bill_id FOREIGN KEY (bill_id) REFERENCES purchase_bills (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CHECK (total > 0)
+);
+
+CREATE INDEX idx_debit_notes_bill_id ON debit_notes (bill_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (10, 'CN/{YYYY}/{MM}/{NNNNN}'),
+       (11, 'DN/{YYYY}/{MM}/{NNNNN}');
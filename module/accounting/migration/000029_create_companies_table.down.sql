@@ -0,0 +1,29 @@
+ALTER TABLE general_ledgers DROP COLUMN IF EXISTS counterparty_company_id;
+ALTER TABLE accounts DROP COLUMN IF EXISTS group_account_id;
+
+DROP TABLE IF EXISTS group_accounts;
+
+DELETE FROM general_ledger_preferences WHERE company_id <> 1;
+ALTER TABLE general_ledger_preferences DROP CONSTRAINT IF EXISTS general_ledger_preferences_pkey;
+ALTER TABLE general_ledger_preferences ADD PRIMARY KEY (id);
+
+ALTER TABLE fiscal_periods DROP CONSTRAINT IF EXISTS uq_fiscal_periods_fiscal_year_id_start_date_end_date;
+ALTER TABLE fiscal_periods ADD CONSTRAINT fiscal_periods_start_date_end_date_key UNIQUE (start_date, end_date);
+ALTER TABLE fiscal_years DROP CONSTRAINT IF EXISTS uq_fiscal_years_company_id_start_date_end_date;
+ALTER TABLE fiscal_years ADD CONSTRAINT fiscal_years_start_date_end_date_key UNIQUE (start_date, end_date);
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS uq_accounts_company_id_group_id_name;
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_group_id_name UNIQUE (group_id, name);
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS uq_accounts_company_id_code;
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_code UNIQUE (code);
+
+DROP INDEX IF EXISTS idx_journals_company_id;
+
+ALTER TABLE general_ledger_preferences DROP COLUMN IF EXISTS company_id;
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS company_id;
+ALTER TABLE fiscal_years DROP COLUMN IF EXISTS company_id;
+ALTER TABLE journals DROP COLUMN IF EXISTS company_id;
+ALTER TABLE accounts DROP COLUMN IF EXISTS company_id;
+
+DROP TABLE IF EXISTS company_users;
+DROP TABLE IF EXISTS companies;
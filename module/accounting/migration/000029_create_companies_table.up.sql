@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS companies
+(
+    id         SERIAL PRIMARY KEY,
+    code       varchar(32)              NOT NULL,
+    name       varchar(255)             NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT companies_code_key UNIQUE (code)
+);
+
+INSERT INTO companies (id, code, name)
+VALUES (1, 'DEFAULT', 'Default Company');
+
+SELECT setval('companies_id_seq', (SELECT MAX(id) FROM companies));
+
+CREATE TABLE IF NOT EXISTS company_users
+(
+    company_id int                      NOT NULL,
+    user_id    uuid                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (company_id, user_id),
+    CONSTRAINT fk_company_id FOREIGN KEY (company_id) REFERENCES companies (id)
+);
+
+CREATE INDEX idx_company_users_user_id ON company_users (user_id);
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE journals ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE fiscal_years ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE general_ledger_preferences ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+
+CREATE INDEX idx_journals_company_id ON journals (company_id);
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS uq_accounts_code;
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_company_id_code UNIQUE (company_id, code);
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS uq_accounts_group_id_name;
+ALTER TABLE accounts ADD CONSTRAINT uq_accounts_company_id_group_id_name UNIQUE (company_id, group_id, name);
+
+ALTER TABLE fiscal_years DROP CONSTRAINT IF EXISTS fiscal_years_start_date_end_date_key;
+ALTER TABLE fiscal_years ADD CONSTRAINT uq_fiscal_years_company_id_start_date_end_date UNIQUE (company_id, start_date, end_date);
+ALTER TABLE fiscal_periods DROP CONSTRAINT IF EXISTS fiscal_periods_start_date_end_date_key;
+ALTER TABLE fiscal_periods ADD CONSTRAINT uq_fiscal_periods_fiscal_year_id_start_date_end_date UNIQUE (fiscal_year_id, start_date, end_date);
+
+ALTER TABLE general_ledger_preferences DROP CONSTRAINT IF EXISTS general_ledger_preferences_pkey;
+ALTER TABLE general_ledger_preferences ADD PRIMARY KEY (company_id, id);
+
+CREATE TABLE IF NOT EXISTS group_accounts
+(
+    id       SERIAL PRIMARY KEY,
+    code     varchar(32)  NOT NULL,
+    name     varchar(255) NOT NULL,
+    class_id int          NOT NULL,
+
+    CONSTRAINT group_accounts_code_key UNIQUE (code),
+    CONSTRAINT fk_class_id FOREIGN KEY (class_id) REFERENCES account_classes (id)
+);
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS group_account_id int REFERENCES group_accounts (id);
+ALTER TABLE general_ledgers ADD COLUMN IF NOT EXISTS counterparty_company_id int REFERENCES companies (id);
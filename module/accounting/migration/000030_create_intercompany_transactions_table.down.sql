@@ -0,0 +1,4 @@
+DELETE FROM general_ledger_preferences WHERE id IN (8, 9);
+DELETE FROM journal_number_formats WHERE type_id = 12;
+
+DROP TABLE IF EXISTS intercompany_transactions;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS intercompany_transactions
+(
+    id              SERIAL PRIMARY KEY,
+    reference       varchar(32)              NOT NULL,
+    from_company_id int                      NOT NULL,
+    to_company_id   int                      NOT NULL,
+    trans_date      date                     NOT NULL,
+    memo            text,
+    amount          numeric(18, 8)           NOT NULL,
+    from_journal_id uuid                     NOT NULL,
+    to_journal_id   uuid                     NOT NULL,
+    created_by      uuid                     NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT uq_intercompany_transactions_reference UNIQUE (reference),
+    CONSTRAINT fk_from_company_id FOREIGN KEY (from_company_id) REFERENCES companies (id),
+    CONSTRAINT fk_to_company_id FOREIGN KEY (to_company_id) REFERENCES companies (id),
+    CONSTRAINT fk_from_journal_id FOREIGN KEY (from_journal_id) REFERENCES journals (id),
+    CONSTRAINT fk_to_journal_id FOREIGN KEY (to_journal_id) REFERENCES journals (id),
+    CHECK (from_company_id <> to_company_id),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX idx_intercompany_transactions_from_company_id ON intercompany_transactions (from_company_id);
+CREATE INDEX idx_intercompany_transactions_to_company_id ON intercompany_transactions (to_company_id);
+
+INSERT INTO journal_number_formats (type_id, format)
+VALUES (12, 'IC/{YYYY}/{MM}/{NNNNN}');
+
+INSERT INTO general_ledger_preferences (company_id, id)
+SELECT companies.id, preferences.id
+FROM companies, (VALUES (8), (9)) AS preferences (id);
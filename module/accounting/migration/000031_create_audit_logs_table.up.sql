@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id         BIGSERIAL PRIMARY KEY,
+    company_id int                      NOT NULL,
+    entity     varchar(64)              NOT NULL,
+    entity_id  varchar(64)              NOT NULL,
+    operation  varchar(16)              NOT NULL,
+    user_id    uuid,
+    request_id varchar(255),
+    before     json,
+    after      json,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    prev_hash  char(64)                 NOT NULL,
+    hash       char(64)                 NOT NULL,
+
+    CONSTRAINT uq_audit_logs_hash UNIQUE (hash),
+    CONSTRAINT fk_company_id FOREIGN KEY (company_id) REFERENCES companies (id)
+);
+
+CREATE INDEX idx_audit_logs_entity_entity_id ON audit_logs (entity, entity_id);
+CREATE INDEX idx_audit_logs_company_id_created_at ON audit_logs (company_id, created_at);
+
+-- the log is append-only, entries can not be changed or removed once written
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE PROCEDURE audit_logs_append_only();
+
+CREATE TRIGGER trg_audit_logs_no_truncate
+    BEFORE TRUNCATE ON audit_logs
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_logs_append_only();
@@ -0,0 +1 @@
+DELETE FROM general_ledger_preferences WHERE id IN (10, 11, 12, 13);
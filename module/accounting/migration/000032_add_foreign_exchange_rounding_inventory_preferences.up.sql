@@ -0,0 +1,4 @@
+INSERT INTO general_ledger_preferences (company_id, id)
+SELECT companies.id, preferences.id
+FROM companies, (VALUES (10), (11), (12), (13)) AS preferences (id)
+ON CONFLICT DO NOTHING;
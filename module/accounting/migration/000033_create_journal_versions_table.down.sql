@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS journal_version_lines;
+DROP TABLE IF EXISTS journal_versions;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS journal_versions
+(
+    id         bigserial PRIMARY KEY,
+    journal_id uuid                     NOT NULL,
+    version    int                      NOT NULL,
+    trans_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    memo       text,
+    amount     numeric(18, 8)           NOT NULL,
+    amended_by uuid                     NOT NULL,
+    amended_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    reason     text,
+
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id),
+    CONSTRAINT uq_journal_versions_journal_id_version UNIQUE (journal_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS journal_version_lines
+(
+    id                      bigserial PRIMARY KEY,
+    version_id              bigint         NOT NULL,
+    account_id              int            NOT NULL,
+    amount                  numeric(18, 8) NOT NULL,
+    memo                    text,
+    external_reference      varchar(255),
+    counterparty_company_id int,
+
+    CONSTRAINT fk_version_id FOREIGN KEY (version_id) REFERENCES journal_versions (id),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX idx_journal_version_lines_version_id ON journal_version_lines (version_id);
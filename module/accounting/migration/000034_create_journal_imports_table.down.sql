@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS journal_import_errors;
+DROP TABLE IF EXISTS journal_import_journals;
+DROP TABLE IF EXISTS journal_imports;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS journal_imports
+(
+    id           bigserial PRIMARY KEY,
+    company_id   int                      NOT NULL,
+    batch_id     varchar(255)             NOT NULL,
+    mode         varchar(32)              NOT NULL,
+    posted_count int                      NOT NULL,
+    error_count  int                      NOT NULL,
+    created_by   uuid                     NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_company_id FOREIGN KEY (company_id) REFERENCES companies (id),
+    CONSTRAINT uq_journal_imports_company_id_batch_id UNIQUE (company_id, batch_id)
+);
+
+CREATE TABLE IF NOT EXISTS journal_import_journals
+(
+    id         bigserial PRIMARY KEY,
+    import_id  bigint                   NOT NULL,
+    line       int                      NOT NULL,
+    reference  varchar(255)             NOT NULL,
+    trans_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    memo       text                     NOT NULL,
+    journal_id uuid,
+
+    CONSTRAINT fk_import_id FOREIGN KEY (import_id) REFERENCES journal_imports (id),
+    CONSTRAINT fk_journal_id FOREIGN KEY (journal_id) REFERENCES journals (id)
+);
+
+CREATE INDEX idx_journal_import_journals_import_id ON journal_import_journals (import_id);
+
+CREATE TABLE IF NOT EXISTS journal_import_errors
+(
+    id        bigserial PRIMARY KEY,
+    import_id bigint       NOT NULL,
+    line      int          NOT NULL,
+    reference varchar(255) NOT NULL,
+    message   text         NOT NULL,
+
+    CONSTRAINT fk_import_id FOREIGN KEY (import_id) REFERENCES journal_imports (id)
+);
+
+CREATE INDEX idx_journal_import_errors_import_id ON journal_import_errors (import_id);
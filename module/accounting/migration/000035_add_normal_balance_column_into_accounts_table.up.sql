@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS normal_balance varchar(6) CHECK (normal_balance IN ('debit', 'credit'));
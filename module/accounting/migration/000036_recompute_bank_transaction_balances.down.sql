@@ -0,0 +1,7 @@
+UPDATE bank_transactions bt
+SET balance = running.balance
+FROM (
+    SELECT id, SUM(amount) OVER (ORDER BY id) AS balance
+    FROM bank_transactions
+) AS running
+WHERE running.id = bt.id;
@@ -0,0 +1,8 @@
+-- the running balance used to be one total over every bank account, it is now kept per bank account
+UPDATE bank_transactions bt
+SET balance = running.balance
+FROM (
+    SELECT id, SUM(amount) OVER (PARTITION BY bank_account_id ORDER BY id) AS balance
+    FROM bank_transactions
+) AS running
+WHERE running.id = bt.id;
@@ -0,0 +1,52 @@
+DROP INDEX IF EXISTS idx_attachments_company_id;
+DROP INDEX IF EXISTS idx_journal_drafts_company_id;
+DROP INDEX IF EXISTS idx_vendor_payments_company_id;
+DROP INDEX IF EXISTS idx_purchase_bills_company_id;
+DROP INDEX IF EXISTS idx_customer_receipts_company_id;
+DROP INDEX IF EXISTS idx_sales_invoices_company_id;
+
+-- customers and vendors copied per company fold back into the oldest one of the same code
+UPDATE purchase_bills t
+SET vendor_id = original.id
+FROM vendors v, (SELECT code, MIN(id) AS id FROM vendors GROUP BY code) AS original
+WHERE v.id = t.vendor_id AND original.code = v.code AND original.id <> v.id;
+
+UPDATE vendor_payments t
+SET vendor_id = original.id
+FROM vendors v, (SELECT code, MIN(id) AS id FROM vendors GROUP BY code) AS original
+WHERE v.id = t.vendor_id AND original.code = v.code AND original.id <> v.id;
+
+DELETE FROM vendors v USING vendors original WHERE original.code = v.code AND original.id < v.id;
+
+ALTER TABLE vendors DROP CONSTRAINT IF EXISTS uq_vendors_company_id_code;
+ALTER TABLE vendors ADD CONSTRAINT vendors_code_key UNIQUE (code);
+
+UPDATE sales_invoices t
+SET customer_id = original.id
+FROM customers c, (SELECT code, MIN(id) AS id FROM customers GROUP BY code) AS original
+WHERE c.id = t.customer_id AND original.code = c.code AND original.id <> c.id;
+
+UPDATE customer_receipts t
+SET customer_id = original.id
+FROM customers c, (SELECT code, MIN(id) AS id FROM customers GROUP BY code) AS original
+WHERE c.id = t.customer_id AND original.code = c.code AND original.id <> c.id;
+
+DELETE FROM customers c USING customers original WHERE original.code = c.code AND original.id < c.id;
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS uq_customers_company_id_code;
+ALTER TABLE customers ADD CONSTRAINT customers_code_key UNIQUE (code);
+
+ALTER TABLE attachments DROP COLUMN IF EXISTS company_id;
+ALTER TABLE approval_rules DROP COLUMN IF EXISTS company_id;
+ALTER TABLE journal_drafts DROP COLUMN IF EXISTS company_id;
+ALTER TABLE fixed_assets DROP COLUMN IF EXISTS company_id;
+ALTER TABLE amortization_schedules DROP COLUMN IF EXISTS company_id;
+ALTER TABLE budgets DROP COLUMN IF EXISTS company_id;
+ALTER TABLE debit_notes DROP COLUMN IF EXISTS company_id;
+ALTER TABLE vendor_payments DROP COLUMN IF EXISTS company_id;
+ALTER TABLE purchase_bills DROP COLUMN IF EXISTS company_id;
+ALTER TABLE vendors DROP COLUMN IF EXISTS company_id;
+ALTER TABLE credit_notes DROP COLUMN IF EXISTS company_id;
+ALTER TABLE customer_receipts DROP COLUMN IF EXISTS company_id;
+ALTER TABLE sales_invoices DROP COLUMN IF EXISTS company_id;
+ALTER TABLE customers DROP COLUMN IF EXISTS company_id;
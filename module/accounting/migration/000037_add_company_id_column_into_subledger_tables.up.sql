@@ -0,0 +1,132 @@
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE sales_invoices ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE customer_receipts ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE credit_notes ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE purchase_bills ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE vendor_payments ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE debit_notes ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE amortization_schedules ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE fixed_assets ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE journal_drafts ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE approval_rules ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+
+-- documents belong to the company of the journal they posted
+UPDATE sales_invoices t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+UPDATE customer_receipts t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+UPDATE credit_notes t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+UPDATE purchase_bills t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+UPDATE vendor_payments t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+UPDATE debit_notes t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+
+UPDATE budgets t SET company_id = fy.company_id FROM fiscal_years fy WHERE fy.id = t.fiscal_year_id;
+UPDATE amortization_schedules t SET company_id = acc.company_id FROM accounts acc WHERE acc.id = t.source_account_id;
+
+UPDATE fixed_assets t
+SET company_id = acc.company_id
+FROM asset_categories c
+INNER JOIN accounts acc ON acc.id = c.asset_account_id
+WHERE c.id = t.category_id;
+
+UPDATE journal_drafts t
+SET company_id = lines.company_id
+FROM (
+    SELECT jdl.draft_id, MIN(acc.company_id) AS company_id
+    FROM journal_draft_lines jdl
+    INNER JOIN accounts acc ON acc.id = jdl.account_id
+    GROUP BY jdl.draft_id
+) AS lines
+WHERE lines.draft_id = t.id;
+
+UPDATE journal_drafts t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+
+UPDATE attachments t SET company_id = j.company_id FROM journals j WHERE j.id = t.journal_id;
+
+UPDATE attachments t
+SET company_id = ba.company_id
+FROM bank_transactions bt
+INNER JOIN bank_accounts ba ON ba.id = bt.bank_account_id
+WHERE bt.id = t.bank_transaction_id;
+
+-- customers and vendors were shared by every company: each keeps the company of its oldest document
+-- and is copied into every other company it has documents in, those documents moved onto the copy
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_code_key;
+ALTER TABLE customers ADD CONSTRAINT uq_customers_company_id_code UNIQUE (company_id, code);
+
+UPDATE customers c
+SET company_id = documents.company_id
+FROM (
+    SELECT customer_id, MIN(company_id) AS company_id
+    FROM (
+        SELECT customer_id, company_id FROM sales_invoices
+        UNION ALL
+        SELECT customer_id, company_id FROM customer_receipts
+    ) AS documents
+    GROUP BY customer_id
+) AS documents
+WHERE documents.customer_id = c.id;
+
+INSERT INTO customers (code, name, email, phone, address, tax_number, payment_terms_days, inactive, created_at, company_id)
+SELECT c.code, c.name, c.email, c.phone, c.address, c.tax_number, c.payment_terms_days, c.inactive, c.created_at, documents.company_id
+FROM customers c
+INNER JOIN (
+    SELECT customer_id, company_id FROM sales_invoices
+    UNION
+    SELECT customer_id, company_id FROM customer_receipts
+) AS documents ON documents.customer_id = c.id
+WHERE documents.company_id <> c.company_id;
+
+UPDATE sales_invoices t
+SET customer_id = copy.id
+FROM customers c, customers copy
+WHERE c.id = t.customer_id AND c.company_id <> t.company_id AND copy.code = c.code AND copy.company_id = t.company_id;
+
+UPDATE customer_receipts t
+SET customer_id = copy.id
+FROM customers c, customers copy
+WHERE c.id = t.customer_id AND c.company_id <> t.company_id AND copy.code = c.code AND copy.company_id = t.company_id;
+
+ALTER TABLE vendors DROP CONSTRAINT IF EXISTS vendors_code_key;
+ALTER TABLE vendors ADD CONSTRAINT uq_vendors_company_id_code UNIQUE (company_id, code);
+
+UPDATE vendors v
+SET company_id = documents.company_id
+FROM (
+    SELECT vendor_id, MIN(company_id) AS company_id
+    FROM (
+        SELECT vendor_id, company_id FROM purchase_bills
+        UNION ALL
+        SELECT vendor_id, company_id FROM vendor_payments
+    ) AS documents
+    GROUP BY vendor_id
+) AS documents
+WHERE documents.vendor_id = v.id;
+
+INSERT INTO vendors (code, name, email, phone, address, tax_number, payment_terms_days, inactive, created_at, company_id)
+SELECT v.code, v.name, v.email, v.phone, v.address, v.tax_number, v.payment_terms_days, v.inactive, v.created_at, documents.company_id
+FROM vendors v
+INNER JOIN (
+    SELECT vendor_id, company_id FROM purchase_bills
+    UNION
+    SELECT vendor_id, company_id FROM vendor_payments
+) AS documents ON documents.vendor_id = v.id
+WHERE documents.company_id <> v.company_id;
+
+UPDATE purchase_bills t
+SET vendor_id = copy.id
+FROM vendors v, vendors copy
+WHERE v.id = t.vendor_id AND v.company_id <> t.company_id AND copy.code = v.code AND copy.company_id = t.company_id;
+
+UPDATE vendor_payments t
+SET vendor_id = copy.id
+FROM vendors v, vendors copy
+WHERE v.id = t.vendor_id AND v.company_id <> t.company_id AND copy.code = v.code AND copy.company_id = t.company_id;
+
+CREATE INDEX idx_sales_invoices_company_id ON sales_invoices (company_id);
+CREATE INDEX idx_customer_receipts_company_id ON customer_receipts (company_id);
+CREATE INDEX idx_purchase_bills_company_id ON purchase_bills (company_id);
+CREATE INDEX idx_vendor_payments_company_id ON vendor_payments (company_id);
+CREATE INDEX idx_journal_drafts_company_id ON journal_drafts (company_id);
+CREATE INDEX idx_attachments_company_id ON attachments (company_id);
@@ -0,0 +1,44 @@
+DROP INDEX IF EXISTS idx_account_groups_company_id;
+DROP INDEX IF EXISTS idx_account_classes_company_id;
+
+-- classes and groups copied per company fold back into the oldest one of the same name
+UPDATE accounts t
+SET group_id = original.id
+FROM account_groups g, (SELECT name, MIN(id) AS id FROM account_groups GROUP BY name) AS original
+WHERE g.id = t.group_id AND original.name = g.name AND original.id <> g.id;
+
+UPDATE account_groups t
+SET parent_id = original.id
+FROM account_groups g, (SELECT name, MIN(id) AS id FROM account_groups GROUP BY name) AS original
+WHERE g.id = t.parent_id AND original.name = g.name AND original.id <> g.id;
+
+UPDATE account_groups t
+SET class_id = original.id
+FROM account_classes c, (SELECT name, MIN(id) AS id FROM account_classes GROUP BY name) AS original
+WHERE c.id = t.class_id AND original.name = c.name AND original.id <> c.id;
+
+UPDATE approval_rules t
+SET account_class_id = original.id
+FROM account_classes c, (SELECT name, MIN(id) AS id FROM account_classes GROUP BY name) AS original
+WHERE c.id = t.account_class_id AND original.name = c.name AND original.id <> c.id;
+
+UPDATE group_accounts t
+SET class_id = original.id
+FROM account_classes c, (SELECT name, MIN(id) AS id FROM account_classes GROUP BY name) AS original
+WHERE c.id = t.class_id AND original.name = c.name AND original.id <> c.id;
+
+DELETE FROM account_groups g USING account_groups original WHERE original.name = g.name AND original.id < g.id;
+DELETE FROM account_classes c USING account_classes original WHERE original.name = c.name AND original.id < c.id;
+
+ALTER TABLE account_groups DROP CONSTRAINT IF EXISTS uq_account_groups_company_id_code;
+ALTER TABLE account_groups ADD CONSTRAINT uq_account_groups_code UNIQUE (code);
+ALTER TABLE account_groups DROP CONSTRAINT IF EXISTS uq_account_groups_company_id_name;
+ALTER TABLE account_groups ADD CONSTRAINT account_groups_name_key UNIQUE (name);
+
+ALTER TABLE account_classes DROP CONSTRAINT IF EXISTS uq_account_classes_company_id_code;
+ALTER TABLE account_classes ADD CONSTRAINT uq_account_classes_code UNIQUE (code);
+ALTER TABLE account_classes DROP CONSTRAINT IF EXISTS uq_account_classes_company_id_name;
+ALTER TABLE account_classes ADD CONSTRAINT account_classes_name_key UNIQUE (name);
+
+ALTER TABLE account_groups DROP COLUMN IF EXISTS company_id;
+ALTER TABLE account_classes DROP COLUMN IF EXISTS company_id;
@@ -0,0 +1,47 @@
+ALTER TABLE account_classes ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+ALTER TABLE account_groups ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+
+ALTER TABLE account_classes DROP CONSTRAINT IF EXISTS account_classes_name_key;
+ALTER TABLE account_classes ADD CONSTRAINT uq_account_classes_company_id_name UNIQUE (company_id, name);
+ALTER TABLE account_classes DROP CONSTRAINT IF EXISTS uq_account_classes_code;
+ALTER TABLE account_classes ADD CONSTRAINT uq_account_classes_company_id_code UNIQUE (company_id, code);
+
+ALTER TABLE account_groups DROP CONSTRAINT IF EXISTS account_groups_name_key;
+ALTER TABLE account_groups ADD CONSTRAINT uq_account_groups_company_id_name UNIQUE (company_id, name);
+ALTER TABLE account_groups DROP CONSTRAINT IF EXISTS uq_account_groups_code;
+ALTER TABLE account_groups ADD CONSTRAINT uq_account_groups_company_id_code UNIQUE (company_id, code);
+
+-- classes and groups were shared by every company: the default company keeps them and every other company
+-- gets a copy, its accounts and approval rules moved onto the copy of the same name
+INSERT INTO account_classes (code, name, type_id, inactive, company_id)
+SELECT c.code, c.name, c.type_id, c.inactive, companies.id
+FROM account_classes c
+CROSS JOIN companies
+WHERE c.company_id = 1 AND companies.id <> 1;
+
+INSERT INTO account_groups (code, class_id, name, inactive, company_id)
+SELECT g.code, copy.id, g.name, g.inactive, copy.company_id
+FROM account_groups g
+INNER JOIN account_classes c ON c.id = g.class_id
+INNER JOIN account_classes copy ON copy.name = c.name AND copy.company_id <> 1
+WHERE g.company_id = 1;
+
+UPDATE account_groups t
+SET parent_id = parent_copy.id
+FROM account_groups g, account_groups parent, account_groups parent_copy
+WHERE
+    t.company_id <> 1 AND g.company_id = 1 AND g.name = t.name AND
+    parent.id = g.parent_id AND parent_copy.name = parent.name AND parent_copy.company_id = t.company_id;
+
+UPDATE accounts t
+SET group_id = copy.id
+FROM account_groups g, account_groups copy
+WHERE g.id = t.group_id AND g.company_id <> t.company_id AND copy.name = g.name AND copy.company_id = t.company_id;
+
+UPDATE approval_rules t
+SET account_class_id = copy.id
+FROM account_classes c, account_classes copy
+WHERE c.id = t.account_class_id AND c.company_id <> t.company_id AND copy.name = c.name AND copy.company_id = t.company_id;
+
+CREATE INDEX idx_account_classes_company_id ON account_classes (company_id);
+CREATE INDEX idx_account_groups_company_id ON account_groups (company_id);
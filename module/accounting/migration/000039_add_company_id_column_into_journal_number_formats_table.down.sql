@@ -0,0 +1,5 @@
+DELETE FROM journal_number_formats WHERE company_id <> 1;
+ALTER TABLE journal_number_formats DROP CONSTRAINT IF EXISTS journal_number_formats_pkey;
+ALTER TABLE journal_number_formats ADD PRIMARY KEY (type_id);
+
+ALTER TABLE journal_number_formats DROP COLUMN IF EXISTS company_id;
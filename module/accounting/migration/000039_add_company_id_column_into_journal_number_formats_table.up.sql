@@ -0,0 +1,11 @@
+ALTER TABLE journal_number_formats ADD COLUMN IF NOT EXISTS company_id int NOT NULL DEFAULT 1 REFERENCES companies (id);
+
+ALTER TABLE journal_number_formats DROP CONSTRAINT IF EXISTS journal_number_formats_pkey;
+ALTER TABLE journal_number_formats ADD PRIMARY KEY (company_id, type_id);
+
+-- every other company starts from the formats the default company uses
+INSERT INTO journal_number_formats (company_id, type_id, format)
+SELECT c.id, f.type_id, f.format
+FROM companies c
+CROSS JOIN journal_number_formats f
+WHERE f.company_id = 1 AND c.id <> 1;
@@ -0,0 +1,40 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const MaxAccountCodeLength = 32
+
+// ValidAccountCodeFormat reports whether pattern compiles as an account code format. An empty pattern is valid
+// and accepts any code.
+func ValidAccountCodeFormat(pattern string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+func matchAccountCode(pattern string, code string) error {
+	if len(code) > MaxAccountCodeLength {
+		return fmt.Errorf("code must not exceed %d characters", MaxAccountCodeLength)
+	}
+
+	if pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("code format %s is not valid", pattern)
+	}
+
+	if !re.MatchString(code) {
+		return fmt.Errorf("code %s does not match format %s", code, pattern)
+	}
+
+	return nil
+}
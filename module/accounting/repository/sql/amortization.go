@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"math"
+	"time"
+)
+
+// AmortizationPlan splits the total amount of a schedule into one line per calendar month between its start and end date.
+// The monthly method gives every whole month the same share and a month the schedule only partly covers the part of that
+// share its days make up, so a year starting mid-month charges twelve equal months split over thirteen lines. The daily
+// method shares by the number of days covered. Amounts are rounded to cents and the last line takes the rounding difference.
+func AmortizationPlan(schedule domain.AmortizationSchedule) (plan []domain.AmortizationPlanLine) {
+	start, end := truncateDate(schedule.StartDate), truncateDate(schedule.EndDate)
+	if end.Before(start) {
+		return
+	}
+
+	var weights []float64
+	var totalWeight float64
+
+	for periodStart := start; !periodStart.After(end); {
+		monthEnd := time.Date(periodStart.Year(), periodStart.Month()+1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+		periodEnd := monthEnd
+		if periodEnd.After(end) {
+			periodEnd = end
+		}
+
+		weight := daysBetween(periodStart, periodEnd)
+		if schedule.MethodID != domain.DailyAmortizationMethod {
+			monthStart := time.Date(periodStart.Year(), periodStart.Month(), 1, 0, 0, 0, 0, time.UTC)
+			weight /= daysBetween(monthStart, monthEnd)
+		}
+
+		plan = append(plan, domain.AmortizationPlanLine{PeriodStart: periodStart, PeriodEnd: periodEnd})
+		weights = append(weights, weight)
+		totalWeight += weight
+		periodStart = periodEnd.AddDate(0, 0, 1)
+	}
+
+	var allocated float64
+
+	for i := range plan {
+		if i == len(plan)-1 {
+			plan[i].Amount = roundCents(schedule.TotalAmount - allocated)
+			break
+		}
+
+		plan[i].Amount = roundCents(schedule.TotalAmount * weights[i] / totalWeight)
+		allocated += plan[i].Amount
+	}
+
+	return
+}
+
+// amortizedThrough is the amount the plan has amortized by the end of date, the month holding date prorated by day.
+func amortizedThrough(plan []domain.AmortizationPlanLine, date time.Time) (amount float64) {
+	date = truncateDate(date)
+
+	for _, line := range plan {
+		switch {
+		case !line.PeriodEnd.After(date):
+			amount += line.Amount
+		case !line.PeriodStart.After(date):
+			amount += line.Amount * daysBetween(line.PeriodStart, date) / daysBetween(line.PeriodStart, line.PeriodEnd)
+		}
+	}
+
+	return roundCents(amount)
+}
+
+// daysBetween counts the days from start to end, both inclusive.
+func daysBetween(start time.Time, end time.Time) float64 {
+	return math.Round(end.Sub(start).Hours()/24) + 1
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
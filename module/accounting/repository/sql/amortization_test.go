@@ -0,0 +1,115 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func planAmounts(plan []domain.AmortizationPlanLine) (amounts []float64) {
+	for _, line := range plan {
+		amounts = append(amounts, line.Amount)
+	}
+
+	return
+}
+
+func TestAmortizationPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    domain.AmortizationSchedule
+		expected []float64
+	}{
+		{
+			name: "last month takes the rounding remainder",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 100, StartDate: date(2024, 1, 1), EndDate: date(2024, 3, 31), MethodID: domain.MonthlyAmortizationMethod,
+			},
+			expected: []float64{33.33, 33.33, 33.34},
+		},
+		{
+			name: "daily shares by days covered",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 100, StartDate: date(2024, 1, 1), EndDate: date(2024, 3, 31), MethodID: domain.DailyAmortizationMethod,
+			},
+			expected: []float64{34.07, 31.87, 34.06},
+		},
+		{
+			name: "monthly mid-month start prorates the stub months",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 1200, StartDate: date(2024, 1, 15), EndDate: date(2025, 1, 14), MethodID: domain.MonthlyAmortizationMethod,
+			},
+			expected: []float64{54.84, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 45.16},
+		},
+		{
+			name: "daily mid-month start",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 600, StartDate: date(2024, 1, 15), EndDate: date(2024, 3, 14), MethodID: domain.DailyAmortizationMethod,
+			},
+			expected: []float64{170, 290, 140},
+		},
+		{
+			name: "single partial month takes everything",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 50, StartDate: date(2024, 2, 10), EndDate: date(2024, 2, 20), MethodID: domain.MonthlyAmortizationMethod,
+			},
+			expected: []float64{50},
+		},
+		{
+			name: "end before start has no plan",
+			input: domain.AmortizationSchedule{
+				TotalAmount: 100, StartDate: date(2024, 3, 1), EndDate: date(2024, 2, 1), MethodID: domain.MonthlyAmortizationMethod,
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, planAmounts(AmortizationPlan(tt.input)))
+		})
+	}
+
+	t.Run("periods follow calendar months", func(t *testing.T) {
+		plan := AmortizationPlan(domain.AmortizationSchedule{
+			TotalAmount: 1200, StartDate: date(2024, 1, 15), EndDate: date(2025, 1, 14), MethodID: domain.MonthlyAmortizationMethod,
+		})
+
+		assert.Equal(t, date(2024, 1, 15), plan[0].PeriodStart)
+		assert.Equal(t, date(2024, 1, 31), plan[0].PeriodEnd)
+		assert.Equal(t, date(2024, 2, 1), plan[1].PeriodStart)
+		assert.Equal(t, date(2024, 2, 29), plan[1].PeriodEnd)
+		assert.Equal(t, date(2025, 1, 1), plan[len(plan)-1].PeriodStart)
+		assert.Equal(t, date(2025, 1, 14), plan[len(plan)-1].PeriodEnd)
+	})
+}
+
+func TestAmortizedThrough(t *testing.T) {
+	plan := AmortizationPlan(domain.AmortizationSchedule{
+		TotalAmount: 100, StartDate: date(2024, 1, 1), EndDate: date(2024, 3, 31), MethodID: domain.MonthlyAmortizationMethod,
+	})
+
+	tests := []struct {
+		name     string
+		input    time.Time
+		expected float64
+	}{
+		{"before start", date(2023, 12, 31), 0},
+		{"first day", date(2024, 1, 1), 1.08},
+		{"month end", date(2024, 1, 31), 33.33},
+		{"mid-month prorated by day", date(2024, 2, 15), 50.57},
+		{"end date", date(2024, 3, 31), 100},
+		{"after end", date(2024, 6, 30), 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, amortizedThrough(plan, tt.input))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	goSql "database/sql"
+	"encoding/hex"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AuditEntityAccountClass            = "account_class"
+	AuditEntityAccountGroup            = "account_group"
+	AuditEntityAccount                 = "account"
+	AuditEntityBankAccount             = "bank_account"
+	AuditEntityFiscalYear              = "fiscal_year"
+	AuditEntityFiscalPeriod            = "fiscal_period"
+	AuditEntityGeneralLedgerPreference = "general_ledger_preference"
+	AuditEntityJournal                 = "journal"
+)
+
+const (
+	AuditOperationCreate = "create"
+	AuditOperationUpdate = "update"
+	AuditOperationDelete = "delete"
+	AuditOperationVoid   = "void"
+)
+
+// AuditLogGenesisHash is the previous hash of the first entry of the audit log.
+var AuditLogGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// auditLogLockKey serializes the writers of the audit log, so every entry chains onto the one committed before it.
+const auditLogLockKey int64 = 7_001_044
+
+// auditSnapshotQueries read an entity as stored, a journal together with its general ledger lines.
+var auditSnapshotQueries = map[string]string{
+	AuditEntityAccountClass:            "SELECT row_to_json(t)::text FROM account_classes t WHERE t.id = ?",
+	AuditEntityAccountGroup:            "SELECT row_to_json(t)::text FROM account_groups t WHERE t.id = ?",
+	AuditEntityAccount:                 "SELECT row_to_json(t)::text FROM accounts t WHERE t.id = ?",
+	AuditEntityBankAccount:             "SELECT row_to_json(t)::text FROM bank_accounts t WHERE t.id = ?",
+	AuditEntityFiscalYear:              "SELECT row_to_json(t)::text FROM fiscal_years t WHERE t.id = ?",
+	AuditEntityFiscalPeriod:            "SELECT row_to_json(t)::text FROM fiscal_periods t WHERE t.id = ?",
+	AuditEntityGeneralLedgerPreference: "SELECT row_to_json(t)::text FROM general_ledger_preferences t WHERE t.id = ? AND t.company_id = ?",
+	AuditEntityJournal: `
+		SELECT json_build_object(
+			'journal', row_to_json(j),
+			'lines', COALESCE((SELECT json_agg(row_to_json(gl) ORDER BY gl.id) FROM general_ledgers gl WHERE gl.journal_id = j.id), '[]')
+		)::text
+		FROM journals j
+		WHERE j.id = ?
+	`,
+}
+
+// snapshotTx reads an entity within tx as JSON, so the audit log holds exactly what is stored. It is null when
+// there is no such entity.
+func snapshotTx(tx sql.Tx, ctx context.Context, entity string, entityID interface{}) (snapshot goSql.NullString, err error) {
+	args := []interface{}{entityID}
+	if entity == AuditEntityGeneralLedgerPreference {
+		args = append(args, companyID(ctx))
+	}
+
+	err = tx.GetContext(ctx, &snapshot, tx.Rebind(auditSnapshotQueries[entity]), args...)
+	if err == goSql.ErrNoRows {
+		return goSql.NullString{}, nil
+	}
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAuditLogFailed, fmt.Sprintf("Failed on snapshot %s", entity))
+		return
+	}
+
+	return
+}
+
+// AuditLogHash hashes an entry together with the hash of the entry before it.
+func AuditLogHash(log domain.AuditLog) string {
+	var userID string
+	if log.UserID.Valid {
+		userID = log.UserID.UUID.String()
+	}
+
+	hash := sha256.New()
+	for _, field := range []string{
+		log.PrevHash,
+		strconv.FormatInt(log.CompanyID, 10),
+		log.Entity,
+		log.EntityID,
+		log.Operation,
+		userID,
+		log.RequestID.String,
+		log.Before.String,
+		log.After.String,
+		log.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// auditLogChains tells whether an entry hashes to its stored hash and chains onto prevHash, the hash of the entry
+// before it.
+func auditLogChains(prevHash string, log domain.AuditLog) bool {
+	return log.PrevHash == prevHash && AuditLogHash(log) == log.Hash
+}
+
+// auditTx appends the change of an entity to the audit log within the transaction making it. before is the snapshot
+// taken ahead of the change, the entity is read again for after. Nothing is written when neither exists.
+func auditTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, entity string, entityID interface{}, operation string, before goSql.NullString) (err error) {
+	after, err := snapshotTx(tx, ctx, entity, entityID)
+	if err != nil {
+		return
+	}
+
+	if !before.Valid && !after.Valid {
+		return
+	}
+
+	log := domain.AuditLog{
+		CompanyID: companyID(ctx),
+		Entity:    entity,
+		EntityID:  fmt.Sprint(entityID),
+		Operation: operation,
+		UserID:    uuid.NullUUID{UUID: userID, Valid: userID != uuid.Nil},
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	if requestID := appcontext.GetRequestID(ctx); requestID != "" {
+		log.RequestID = goSql.NullString{String: requestID, Valid: true}
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT pg_advisory_xact_lock(?)"), auditLogLockKey); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAuditLogFailed, "Failed on lock audit log")
+		return
+	}
+
+	err = tx.GetContext(ctx, &log.PrevHash, "SELECT hash FROM audit_logs ORDER BY id DESC LIMIT 1")
+	if err == goSql.ErrNoRows {
+		log.PrevHash = AuditLogGenesisHash
+	} else if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAuditLogFailed, "Failed on get last audit log hash")
+		return
+	}
+
+	log.Hash = AuditLogHash(log)
+
+	query := `
+		INSERT INTO audit_logs (
+			company_id, entity, entity_id, operation, user_id, request_id, before, after, created_at, prev_hash, hash
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		tx.Rebind(query),
+		log.CompanyID, log.Entity, log.EntityID, log.Operation, log.UserID, log.RequestID, log.Before, log.After,
+		log.CreatedAt, log.PrevHash, log.Hash,
+	)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAuditLogFailed, "Failed on store audit log")
+		return
+	}
+
+	return nil
+}
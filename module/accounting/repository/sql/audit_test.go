@@ -0,0 +1,176 @@
+package sql
+
+import (
+	goSql "database/sql"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func auditLogEntry(prevHash string) domain.AuditLog {
+	log := domain.AuditLog{
+		ID:        1,
+		CompanyID: 1,
+		Entity:    AuditEntityAccount,
+		EntityID:  "42",
+		Operation: AuditOperationUpdate,
+		UserID:    uuid.NullUUID{UUID: uuid.MustParse("7f9c24e5-0f1c-4d0a-9a57-3c2f1b6e8d10"), Valid: true},
+		RequestID: goSql.NullString{String: "req-1", Valid: true},
+		Before:    goSql.NullString{String: `{"name":"Cash"}`, Valid: true},
+		After:     goSql.NullString{String: `{"name":"Cash on hand"}`, Valid: true},
+		CreatedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+		PrevHash:  prevHash,
+	}
+	log.Hash = AuditLogHash(log)
+
+	return log
+}
+
+func TestAuditLogHash(t *testing.T) {
+	base := auditLogEntry(AuditLogGenesisHash)
+
+	tests := []struct {
+		name     string
+		change   func(log *domain.AuditLog)
+		expected bool
+	}{
+		{
+			name:     "the same entry hashes the same",
+			change:   func(log *domain.AuditLog) {},
+			expected: true,
+		},
+		{
+			name:     "the stored id and hash are not hashed",
+			change:   func(log *domain.AuditLog) { log.ID, log.Hash = 2, "" },
+			expected: true,
+		},
+		{
+			name:     "the time is hashed in UTC",
+			change:   func(log *domain.AuditLog) { log.CreatedAt = log.CreatedAt.In(time.FixedZone("WIB", 7*60*60)) },
+			expected: true,
+		},
+		{
+			name:     "previous hash",
+			change:   func(log *domain.AuditLog) { log.PrevHash = base.Hash },
+			expected: false,
+		},
+		{
+			name:     "company",
+			change:   func(log *domain.AuditLog) { log.CompanyID = 2 },
+			expected: false,
+		},
+		{
+			name:     "entity",
+			change:   func(log *domain.AuditLog) { log.Entity = AuditEntityAccountGroup },
+			expected: false,
+		},
+		{
+			name:     "entity id",
+			change:   func(log *domain.AuditLog) { log.EntityID = "43" },
+			expected: false,
+		},
+		{
+			name:     "operation",
+			change:   func(log *domain.AuditLog) { log.Operation = AuditOperationDelete },
+			expected: false,
+		},
+		{
+			name:     "user",
+			change:   func(log *domain.AuditLog) { log.UserID = uuid.NullUUID{} },
+			expected: false,
+		},
+		{
+			name:     "request",
+			change:   func(log *domain.AuditLog) { log.RequestID.String = "req-2" },
+			expected: false,
+		},
+		{
+			name:     "before",
+			change:   func(log *domain.AuditLog) { log.Before = goSql.NullString{} },
+			expected: false,
+		},
+		{
+			name:     "after",
+			change:   func(log *domain.AuditLog) { log.After.String = `{"name":"Petty cash"}` },
+			expected: false,
+		},
+		{
+			name:     "created at",
+			change:   func(log *domain.AuditLog) { log.CreatedAt = log.CreatedAt.Add(time.Nanosecond) },
+			expected: false,
+		},
+		{
+			name: "fields do not run into each other",
+			change: func(log *domain.AuditLog) {
+				log.Entity, log.EntityID = AuditEntityAccount+"4", "2"
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := base
+			tt.change(&log)
+			assert.Equal(t, tt.expected, AuditLogHash(log) == base.Hash)
+		})
+	}
+}
+
+func TestAuditLogChains(t *testing.T) {
+	first := auditLogEntry(AuditLogGenesisHash)
+
+	tests := []struct {
+		name     string
+		prevHash string
+		log      func() domain.AuditLog
+		expected bool
+	}{
+		{
+			name:     "the first entry chains onto the genesis hash",
+			prevHash: AuditLogGenesisHash,
+			log:      func() domain.AuditLog { return first },
+			expected: true,
+		},
+		{
+			name:     "an entry chains onto the one before it",
+			prevHash: first.Hash,
+			log:      func() domain.AuditLog { return auditLogEntry(first.Hash) },
+			expected: true,
+		},
+		{
+			name:     "an entry pointing elsewhere breaks the chain",
+			prevHash: first.Hash,
+			log:      func() domain.AuditLog { return auditLogEntry(AuditLogGenesisHash) },
+			expected: false,
+		},
+		{
+			name:     "an entry edited after it was written breaks the chain",
+			prevHash: AuditLogGenesisHash,
+			log: func() domain.AuditLog {
+				log := first
+				log.After.String = `{"name":"Petty cash"}`
+				return log
+			},
+			expected: false,
+		},
+		{
+			name:     "an entry rehashed with another previous hash breaks the chain",
+			prevHash: AuditLogGenesisHash,
+			log: func() domain.AuditLog {
+				log := first
+				log.Hash = auditLogEntry(first.Hash).Hash
+				return log
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, auditLogChains(tt.prevHash, tt.log()))
+		})
+	}
+}
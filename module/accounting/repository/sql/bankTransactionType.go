@@ -4,4 +4,5 @@ type BankTransactionType int
 
 const (
 	Deposit BankTransactionType = iota
+	Payment
 )
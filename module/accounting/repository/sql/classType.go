@@ -1,6 +1,9 @@
 package sql
 
-import "github.com/QuickAmethyst/monosvc/module/accounting/domain"
+import (
+	goSql "database/sql"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+)
 
 const (
 	AssetClassType int64 = iota + 1
@@ -12,14 +15,46 @@ const (
 )
 
 var classTypes = map[int64]domain.AccountClassType{
-	AssetClassType:       {AssetClassType, "Asset"},
-	LiabilitiesClassType: {LiabilitiesClassType, "Liability"},
-	EquityClassType:      {EquityClassType, "Equity"},
-	IncomeClassType:      {IncomeClassType, "Income"},
-	COGSClassType:        {COGSClassType, "Cost of Good Solds"},
-	ExpenseClassType:     {ExpenseClassType, "Expense"},
+	AssetClassType:       {AssetClassType, "Asset", domain.DebitNormalBalance},
+	LiabilitiesClassType: {LiabilitiesClassType, "Liability", domain.CreditNormalBalance},
+	EquityClassType:      {EquityClassType, "Equity", domain.CreditNormalBalance},
+	IncomeClassType:      {IncomeClassType, "Income", domain.CreditNormalBalance},
+	COGSClassType:        {COGSClassType, "Cost of Good Solds", domain.DebitNormalBalance},
+	ExpenseClassType:     {ExpenseClassType, "Expense", domain.DebitNormalBalance},
 }
 
 func IsBalanceSheetAccount(classType int64) bool {
 	return classType > 0 && classType <= EquityClassType
 }
+
+// ClassTypeNormalBalance is the normal balance of a class type, debit for an unknown one.
+func ClassTypeNormalBalance(classType int64) string {
+	if classType, ok := classTypes[classType]; ok {
+		return classType.NormalBalance
+	}
+
+	return domain.DebitNormalBalance
+}
+
+// AccountNormalBalance is the normal balance of an account, its override or else the one of its class type.
+func AccountNormalBalance(classType int64, override goSql.NullString) string {
+	if override.Valid && ValidNormalBalance(override.String) {
+		return override.String
+	}
+
+	return ClassTypeNormalBalance(classType)
+}
+
+func ValidNormalBalance(normalBalance string) bool {
+	return normalBalance == domain.DebitNormalBalance || normalBalance == domain.CreditNormalBalance
+}
+
+// PresentationAmount turns a debit positive and credit negative amount into one that is positive on the side of
+// normalBalance, so a liability, equity or income balance reads positive.
+func PresentationAmount(amount float64, normalBalance string) float64 {
+	if normalBalance == domain.CreditNormalBalance && amount != 0 {
+		return -amount
+	}
+
+	return amount
+}
@@ -0,0 +1,19 @@
+package sql
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+)
+
+// DefaultCompanyID is the company every ledger existing before companies were introduced belongs to,
+// and the one used when the request does not pick a company.
+const DefaultCompanyID int64 = 1
+
+// companyID returns the company the request works in.
+func companyID(ctx context.Context) int64 {
+	if id := appcontext.GetCompanyID(ctx); id != 0 {
+		return id
+	}
+
+	return DefaultCompanyID
+}
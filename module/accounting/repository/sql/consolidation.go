@@ -0,0 +1,216 @@
+package sql
+
+import (
+	goSql "database/sql"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"sort"
+)
+
+// consolidationBalance is the balance of one company account as of the consolidation date.
+type consolidationBalance struct {
+	CompanyID        int64            `db:"company_id"`
+	AccountID        int64            `db:"account_id"`
+	AccountCode      goSql.NullString `db:"account_code"`
+	AccountName      string           `db:"account_name"`
+	GroupAccountID   goSql.NullInt64  `db:"group_account_id"`
+	GroupAccountCode goSql.NullString `db:"group_account_code"`
+	GroupAccountName goSql.NullString `db:"group_account_name"`
+	ClassTypeID      int64            `db:"class_type_id"`
+	NormalBalance    goSql.NullString `db:"normal_balance"`
+	Amount           float64
+	EliminatedAmount float64 `db:"eliminated_amount"`
+}
+
+// newConsolidationRows adds the company balances up per group account. An account not mapped to the group chart
+// keeps a row of its own so it shows up instead of being left out. Every row carries one balance per company in
+// the order of companyIDs, rows are ordered by class type with the unmapped accounts after the group accounts.
+// A group account row takes the normal balance of its class type, an unmapped account row its own override too.
+func newConsolidationRows(companyIDs []int64, balances []consolidationBalance) (rows []domain.ConsolidationRow) {
+	var (
+		rowIndex     = make(map[string]int)
+		companyIndex = make(map[int64]int, len(companyIDs))
+	)
+
+	for i, id := range companyIDs {
+		companyIndex[id] = i
+	}
+
+	rows = make([]domain.ConsolidationRow, 0)
+	for _, balance := range balances {
+		key := fmt.Sprintf("account:%d", balance.AccountID)
+		if balance.GroupAccountID.Valid {
+			key = fmt.Sprintf("group:%d", balance.GroupAccountID.Int64)
+		}
+
+		i, ok := rowIndex[key]
+		if !ok {
+			row := domain.ConsolidationRow{
+				ClassTypeID: balance.ClassTypeID,
+				Balances:    make([]domain.CompanyBalance, len(companyIDs)),
+			}
+
+			if balance.GroupAccountID.Valid {
+				row.GroupAccountID = balance.GroupAccountID
+				row.Code = balance.GroupAccountCode
+				row.Name = balance.GroupAccountName.String
+				row.NormalBalance = ClassTypeNormalBalance(balance.ClassTypeID)
+			} else {
+				row.AccountID = goSql.NullInt64{Int64: balance.AccountID, Valid: true}
+				row.Code = balance.AccountCode
+				row.Name = balance.AccountName
+				row.NormalBalance = AccountNormalBalance(balance.ClassTypeID, balance.NormalBalance)
+			}
+
+			for j, id := range companyIDs {
+				row.Balances[j].CompanyID = id
+			}
+
+			i = len(rows)
+			rowIndex[key] = i
+			rows = append(rows, row)
+		}
+
+		row := &rows[i]
+		row.Balances[companyIndex[balance.CompanyID]].Amount += balance.Amount
+		row.Elimination += balance.EliminatedAmount
+	}
+
+	result := make([]domain.ConsolidationRow, 0, len(rows))
+	for _, row := range rows {
+		var total float64
+		for j := range row.Balances {
+			row.Balances[j].Amount = roundCents(row.Balances[j].Amount)
+			total += row.Balances[j].Amount
+		}
+
+		row.Elimination = roundCents(row.Elimination)
+		row.Amount = roundCents(total - row.Elimination)
+
+		if row.Amount == 0 && row.Elimination == 0 && roundCents(total) == 0 {
+			continue
+		}
+
+		result = append(result, row)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ClassTypeID != b.ClassTypeID {
+			return a.ClassTypeID < b.ClassTypeID
+		}
+
+		if a.GroupAccountID.Valid != b.GroupAccountID.Valid {
+			return a.GroupAccountID.Valid
+		}
+
+		if a.Code.Valid != b.Code.Valid {
+			return a.Code.Valid
+		}
+
+		if a.Code.String != b.Code.String {
+			return a.Code.String < b.Code.String
+		}
+
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// newConsolidatedBalanceSheet splits the consolidation rows into the asset, liability and equity sections.
+// The income, COGS and expense rows are not closed into equity yet, their sum is carried as the net income.
+func newConsolidatedBalanceSheet(rows []domain.ConsolidationRow) (report domain.ConsolidatedBalanceSheet) {
+	report.Sections = make([]domain.ConsolidatedBalanceSheetSection, 0, EquityClassType)
+	for classTypeID := AssetClassType; classTypeID <= EquityClassType; classTypeID++ {
+		report.Sections = append(report.Sections, domain.ConsolidatedBalanceSheetSection{
+			ClassTypeID: classTypeID,
+			Name:        classTypes[classTypeID].Name,
+			Rows:        make([]domain.ConsolidationRow, 0),
+		})
+	}
+
+	for _, row := range rows {
+		if !IsBalanceSheetAccount(row.ClassTypeID) {
+			report.NetIncome -= row.Amount
+			continue
+		}
+
+		section := &report.Sections[row.ClassTypeID-1]
+		section.Rows = append(section.Rows, row)
+
+		if row.ClassTypeID == AssetClassType {
+			section.Total += row.Amount
+		} else {
+			section.Total -= row.Amount
+		}
+	}
+
+	for i := range report.Sections {
+		report.Sections[i].Total = roundCents(report.Sections[i].Total)
+	}
+
+	report.NetIncome = roundCents(report.NetIncome)
+	report.TotalAssets = report.Sections[AssetClassType-1].Total
+	report.TotalLiabilitiesAndEquity = roundCents(
+		report.Sections[LiabilitiesClassType-1].Total + report.Sections[EquityClassType-1].Total + report.NetIncome,
+	)
+
+	return
+}
+
+// newIntercompanyReconciliation pairs the balance of each company against a counterparty with the balance of the
+// counterparty against it, and keeps the pairs that leave a difference.
+func newIntercompanyReconciliation(rows []domain.IntercompanyBalance) (balances []domain.IntercompanyBalance) {
+	type pair struct{ companyID, counterpartyCompanyID int64 }
+
+	var (
+		pairs     = make([]pair, 0)
+		pairIndex = make(map[pair]*domain.IntercompanyBalance)
+	)
+
+	for _, row := range rows {
+		key := pair{row.CompanyID, row.CounterpartyCompanyID}
+		if key.companyID == key.counterpartyCompanyID {
+			continue
+		}
+
+		if key.companyID > key.counterpartyCompanyID {
+			key = pair{key.counterpartyCompanyID, key.companyID}
+		}
+
+		balance, ok := pairIndex[key]
+		if !ok {
+			balance = &domain.IntercompanyBalance{CompanyID: key.companyID, CounterpartyCompanyID: key.counterpartyCompanyID}
+			pairIndex[key] = balance
+			pairs = append(pairs, key)
+		}
+
+		if row.CompanyID == key.companyID {
+			balance.Amount += row.Amount
+		} else {
+			balance.CounterpartyAmount += row.Amount
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].companyID != pairs[j].companyID {
+			return pairs[i].companyID < pairs[j].companyID
+		}
+
+		return pairs[i].counterpartyCompanyID < pairs[j].counterpartyCompanyID
+	})
+
+	balances = make([]domain.IntercompanyBalance, 0)
+	for _, key := range pairs {
+		balance := *pairIndex[key]
+		balance.Amount, balance.CounterpartyAmount = roundCents(balance.Amount), roundCents(balance.CounterpartyAmount)
+		balance.Difference = roundCents(balance.Amount + balance.CounterpartyAmount)
+
+		if balance.Difference != 0 {
+			balances = append(balances, balance)
+		}
+	}
+
+	return
+}
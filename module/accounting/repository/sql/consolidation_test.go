@@ -0,0 +1,276 @@
+package sql
+
+import (
+	goSql "database/sql"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func mappedBalance(companyID int64, accountID int64, groupAccountID int64, code string, name string, classTypeID int64, amount float64) consolidationBalance {
+	return consolidationBalance{
+		CompanyID:        companyID,
+		AccountID:        accountID,
+		GroupAccountID:   goSql.NullInt64{Int64: groupAccountID, Valid: true},
+		GroupAccountCode: goSql.NullString{String: code, Valid: true},
+		GroupAccountName: goSql.NullString{String: name, Valid: true},
+		ClassTypeID:      classTypeID,
+		Amount:           amount,
+	}
+}
+
+func unmappedBalance(companyID int64, accountID int64, code string, name string, classTypeID int64, amount float64) consolidationBalance {
+	return consolidationBalance{
+		CompanyID:   companyID,
+		AccountID:   accountID,
+		AccountCode: goSql.NullString{String: code, Valid: code != ""},
+		AccountName: name,
+		ClassTypeID: classTypeID,
+		Amount:      amount,
+	}
+}
+
+func TestNewConsolidationRows(t *testing.T) {
+	eliminated := func(balance consolidationBalance, amount float64) consolidationBalance {
+		balance.EliminatedAmount = amount
+		return balance
+	}
+
+	creditNormal := func(balance consolidationBalance) consolidationBalance {
+		balance.NormalBalance = goSql.NullString{String: domain.CreditNormalBalance, Valid: true}
+		return balance
+	}
+
+	tests := []struct {
+		name     string
+		balances []consolidationBalance
+		expected []domain.ConsolidationRow
+	}{
+		{
+			name: "accounts of every company add up on their group account",
+			balances: []consolidationBalance{
+				mappedBalance(1, 10, 100, "1000", "Cash", AssetClassType, 50),
+				mappedBalance(2, 20, 100, "1000", "Cash", AssetClassType, 30),
+				mappedBalance(1, 11, 100, "1000", "Cash", AssetClassType, 20),
+			},
+			expected: []domain.ConsolidationRow{
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 100, Valid: true},
+					Code:           goSql.NullString{String: "1000", Valid: true},
+					Name:           "Cash",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 70}, {CompanyID: 2, Amount: 30}},
+					Amount:         100,
+				},
+			},
+		},
+		{
+			name: "eliminated amounts are taken off the total",
+			balances: []consolidationBalance{
+				eliminated(mappedBalance(1, 10, 100, "1200", "Intercompany receivable", AssetClassType, 80), 60),
+				eliminated(mappedBalance(2, 20, 200, "2200", "Intercompany payable", LiabilitiesClassType, -60), -60),
+			},
+			expected: []domain.ConsolidationRow{
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 100, Valid: true},
+					Code:           goSql.NullString{String: "1200", Valid: true},
+					Name:           "Intercompany receivable",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 80}, {CompanyID: 2}},
+					Elimination:    60,
+					Amount:         20,
+				},
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 200, Valid: true},
+					Code:           goSql.NullString{String: "2200", Valid: true},
+					Name:           "Intercompany payable",
+					ClassTypeID:    LiabilitiesClassType,
+					NormalBalance:  domain.CreditNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1}, {CompanyID: 2, Amount: -60}},
+					Elimination:    -60,
+				},
+			},
+		},
+		{
+			name: "unmapped accounts keep a row of their own with their own normal balance",
+			balances: []consolidationBalance{
+				creditNormal(unmappedBalance(2, 21, "1900", "Allowance", AssetClassType, -5)),
+				mappedBalance(1, 10, 100, "1000", "Cash", AssetClassType, 10),
+			},
+			expected: []domain.ConsolidationRow{
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 100, Valid: true},
+					Code:           goSql.NullString{String: "1000", Valid: true},
+					Name:           "Cash",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 10}, {CompanyID: 2}},
+					Amount:         10,
+				},
+				{
+					AccountID:     goSql.NullInt64{Int64: 21, Valid: true},
+					Code:          goSql.NullString{String: "1900", Valid: true},
+					Name:          "Allowance",
+					ClassTypeID:   AssetClassType,
+					NormalBalance: domain.CreditNormalBalance,
+					Balances:      []domain.CompanyBalance{{CompanyID: 1}, {CompanyID: 2, Amount: -5}},
+					Amount:        -5,
+				},
+			},
+		},
+		{
+			name: "rows sort by class type, then mapped before unmapped, then coded before uncoded, then by code",
+			balances: []consolidationBalance{
+				unmappedBalance(1, 30, "", "Misc expense", ExpenseClassType, 1),
+				unmappedBalance(1, 31, "6100", "Rent", ExpenseClassType, 2),
+				mappedBalance(1, 12, 300, "6000", "Salaries", ExpenseClassType, 3),
+				mappedBalance(1, 13, 400, "1100", "Bank", AssetClassType, 4),
+				mappedBalance(1, 10, 100, "1000", "Cash", AssetClassType, 5),
+			},
+			expected: []domain.ConsolidationRow{
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 100, Valid: true},
+					Code:           goSql.NullString{String: "1000", Valid: true},
+					Name:           "Cash",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 5}, {CompanyID: 2}},
+					Amount:         5,
+				},
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 400, Valid: true},
+					Code:           goSql.NullString{String: "1100", Valid: true},
+					Name:           "Bank",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 4}, {CompanyID: 2}},
+					Amount:         4,
+				},
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 300, Valid: true},
+					Code:           goSql.NullString{String: "6000", Valid: true},
+					Name:           "Salaries",
+					ClassTypeID:    ExpenseClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 3}, {CompanyID: 2}},
+					Amount:         3,
+				},
+				{
+					AccountID:     goSql.NullInt64{Int64: 31, Valid: true},
+					Code:          goSql.NullString{String: "6100", Valid: true},
+					Name:          "Rent",
+					ClassTypeID:   ExpenseClassType,
+					NormalBalance: domain.DebitNormalBalance,
+					Balances:      []domain.CompanyBalance{{CompanyID: 1, Amount: 2}, {CompanyID: 2}},
+					Amount:        2,
+				},
+				{
+					AccountID:     goSql.NullInt64{Int64: 30, Valid: true},
+					Name:          "Misc expense",
+					ClassTypeID:   ExpenseClassType,
+					NormalBalance: domain.DebitNormalBalance,
+					Balances:      []domain.CompanyBalance{{CompanyID: 1, Amount: 1}, {CompanyID: 2}},
+					Amount:        1,
+				},
+			},
+		},
+		{
+			name: "amounts are rounded to cents",
+			balances: []consolidationBalance{
+				mappedBalance(1, 10, 100, "1000", "Cash", AssetClassType, 0.1),
+				mappedBalance(1, 11, 100, "1000", "Cash", AssetClassType, 0.2),
+			},
+			expected: []domain.ConsolidationRow{
+				{
+					GroupAccountID: goSql.NullInt64{Int64: 100, Valid: true},
+					Code:           goSql.NullString{String: "1000", Valid: true},
+					Name:           "Cash",
+					ClassTypeID:    AssetClassType,
+					NormalBalance:  domain.DebitNormalBalance,
+					Balances:       []domain.CompanyBalance{{CompanyID: 1, Amount: 0.3}, {CompanyID: 2}},
+					Amount:         0.3,
+				},
+			},
+		},
+		{
+			name: "rows that net to zero without an elimination are left out",
+			balances: []consolidationBalance{
+				mappedBalance(1, 10, 100, "1000", "Cash", AssetClassType, 25),
+				mappedBalance(2, 20, 100, "1000", "Cash", AssetClassType, -25),
+				unmappedBalance(2, 21, "1900", "Suspense", AssetClassType, 0.001),
+			},
+			expected: []domain.ConsolidationRow{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newConsolidationRows([]int64{1, 2}, tt.balances))
+		})
+	}
+}
+
+func TestNewIntercompanyReconciliation(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []domain.IntercompanyBalance
+		expected []domain.IntercompanyBalance
+	}{
+		{
+			name: "both directions of a pair land under the lower company",
+			rows: []domain.IntercompanyBalance{
+				{CompanyID: 2, CounterpartyCompanyID: 1, Amount: -90},
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 100},
+			},
+			expected: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 100, CounterpartyAmount: -90, Difference: 10},
+			},
+		},
+		{
+			name: "pairs that reconcile are left out",
+			rows: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 100},
+				{CompanyID: 2, CounterpartyCompanyID: 1, Amount: -100},
+			},
+			expected: []domain.IntercompanyBalance{},
+		},
+		{
+			name: "a company against itself is skipped",
+			rows: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 1, Amount: 100},
+			},
+			expected: []domain.IntercompanyBalance{},
+		},
+		{
+			name: "rows of the same direction add up and are rounded to cents",
+			rows: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 0.1},
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 0.2},
+			},
+			expected: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 2, Amount: 0.3, Difference: 0.3},
+			},
+		},
+		{
+			name: "pairs sort by company then counterparty",
+			rows: []domain.IntercompanyBalance{
+				{CompanyID: 3, CounterpartyCompanyID: 2, Amount: 5},
+				{CompanyID: 1, CounterpartyCompanyID: 3, Amount: 7},
+				{CompanyID: 2, CounterpartyCompanyID: 1, Amount: -4},
+			},
+			expected: []domain.IntercompanyBalance{
+				{CompanyID: 1, CounterpartyCompanyID: 2, CounterpartyAmount: -4, Difference: -4},
+				{CompanyID: 1, CounterpartyCompanyID: 3, Amount: 7, Difference: 7},
+				{CompanyID: 2, CounterpartyCompanyID: 3, CounterpartyAmount: 5, Difference: 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newIntercompanyReconciliation(tt.rows))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"math"
+	"time"
+)
+
+// DepreciationThrough is the depreciation an asset accumulates by the end of the month holding date. The month the asset
+// goes into service counts as a full month. Straight-line spreads cost less salvage evenly over the useful life, declining
+// balance charges the net book value times factor / life each month and switches to straight-line over the remaining life
+// once that charges more, so both methods reach the salvage value at the end of the life.
+func DepreciationThrough(asset domain.FixedAsset, date time.Time) (accumulated float64) {
+	depreciable := roundCents(asset.AcquisitionCost - asset.SalvageValue)
+	if depreciable <= 0 || asset.UsefulLifeMonths <= 0 {
+		return
+	}
+
+	months := depreciationMonths(asset.InServiceDate, date)
+	if months > asset.UsefulLifeMonths {
+		months = asset.UsefulLifeMonths
+	}
+
+	for month := int64(0); month < months; month++ {
+		remainingMonths := asset.UsefulLifeMonths - month
+		remaining := roundCents(depreciable - accumulated)
+		if remainingMonths == 1 {
+			accumulated += remaining
+			break
+		}
+
+		amount := depreciable / float64(asset.UsefulLifeMonths)
+		if asset.MethodID == domain.DecliningBalanceDepreciationMethod {
+			amount = math.Max(
+				(asset.AcquisitionCost-accumulated)*asset.DecliningFactor/float64(asset.UsefulLifeMonths),
+				remaining/float64(remainingMonths),
+			)
+		}
+
+		accumulated += math.Min(roundCents(amount), remaining)
+	}
+
+	return roundCents(accumulated)
+}
+
+// depreciationMonths counts the calendar months from the month of inService through the month of date, both inclusive.
+func depreciationMonths(inService time.Time, date time.Time) int64 {
+	months := int64(date.Year()-inService.Year())*12 + int64(date.Month()-inService.Month()) + 1
+	if months < 0 {
+		return 0
+	}
+
+	return months
+}
+
+// endOfMonth is the last day of the month holding date.
+func endOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+}
@@ -0,0 +1,123 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func straightLineAsset(cost float64, salvage float64, lifeMonths int64) domain.FixedAsset {
+	return domain.FixedAsset{
+		AcquisitionCost:  cost,
+		SalvageValue:     salvage,
+		UsefulLifeMonths: lifeMonths,
+		InServiceDate:    date(2024, 1, 15),
+		MethodID:         domain.StraightLineDepreciationMethod,
+	}
+}
+
+func decliningBalanceAsset(cost float64, salvage float64, lifeMonths int64) domain.FixedAsset {
+	asset := straightLineAsset(cost, salvage, lifeMonths)
+	asset.MethodID = domain.DecliningBalanceDepreciationMethod
+	asset.DecliningFactor = 2
+
+	return asset
+}
+
+func TestDepreciationThrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		asset    domain.FixedAsset
+		date     time.Time
+		expected float64
+	}{
+		{
+			name:     "straight-line charges an equal share each month, the first month in full",
+			asset:    straightLineAsset(1200, 0, 12),
+			date:     date(2024, 3, 10),
+			expected: 300,
+		},
+		{
+			name:     "straight-line spreads the cost less the salvage value",
+			asset:    straightLineAsset(1300, 100, 12),
+			date:     date(2024, 6, 30),
+			expected: 600,
+		},
+		{
+			name:     "straight-line rounds each month to cents",
+			asset:    straightLineAsset(100, 0, 3),
+			date:     date(2024, 2, 29),
+			expected: 66.66,
+		},
+		{
+			name:     "final month takes the rounding remainder",
+			asset:    straightLineAsset(100, 0, 3),
+			date:     date(2024, 3, 31),
+			expected: 100,
+		},
+		{
+			name:     "nothing before the asset goes into service",
+			asset:    straightLineAsset(1200, 0, 12),
+			date:     date(2023, 12, 31),
+			expected: 0,
+		},
+		{
+			name:     "nothing past the useful life",
+			asset:    straightLineAsset(1200, 0, 12),
+			date:     date(2030, 1, 31),
+			expected: 1200,
+		},
+		{
+			name:     "nothing to depreciate below the salvage value",
+			asset:    straightLineAsset(100, 100, 12),
+			date:     date(2024, 6, 30),
+			expected: 0,
+		},
+		{
+			name:     "declining balance charges the book value times factor over life",
+			asset:    decliningBalanceAsset(1200, 0, 12),
+			date:     date(2024, 3, 31),
+			expected: 505.56,
+		},
+		{
+			name:     "declining balance keeps its rate while it charges more than straight-line",
+			asset:    decliningBalanceAsset(1200, 0, 12),
+			date:     date(2024, 7, 31),
+			expected: 865.11,
+		},
+		{
+			name:     "declining balance switches to straight-line over the remaining life",
+			asset:    decliningBalanceAsset(1200, 0, 12),
+			date:     date(2024, 9, 30),
+			expected: 999.07,
+		},
+		{
+			name:     "declining balance reaches the cost at the end of the life",
+			asset:    decliningBalanceAsset(1200, 0, 12),
+			date:     date(2024, 12, 31),
+			expected: 1200,
+		},
+		{
+			name:     "declining balance stops at the salvage value",
+			asset:    decliningBalanceAsset(1200, 200, 12),
+			date:     date(2024, 10, 31),
+			expected: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DepreciationThrough(tt.asset, tt.date))
+		})
+	}
+
+	t.Run("straight-line after the switch charges the same every month, give or take a cent", func(t *testing.T) {
+		asset := decliningBalanceAsset(1200, 0, 12)
+
+		for month := time.August; month <= time.December; month++ {
+			charge := DepreciationThrough(asset, endOfMonth(date(2024, month, 1))) - DepreciationThrough(asset, endOfMonth(date(2024, month-1, 1)))
+			assert.InDelta(t, 66.98, charge, 0.01, month.String())
+		}
+	})
+}
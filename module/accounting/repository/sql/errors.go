@@ -60,4 +60,200 @@ const (
 	EcodeGetAccountClassTransactionFailed
 	EcodeGetAccountBalanceFailed
 	EcodeGetAccountClassBalanceFailed
+	EcodeStoreJournalDraftFailed
+	EcodeUpdateJournalDraftFailed
+	EcodeGetJournalDraftFailed
+	EcodeGetJournalDraftListFailed
+	EcodeGetAllJournalDraftLinesFailed
+	EcodeGetAllJournalDraftReviewsFailed
+	EcodeJournalDraftStatusInvalid
+	EcodeJournalDraftReviewerInvalid
+	EcodeJournalDraftEditorInvalid
+	EcodeSubmitJournalDraftFailed
+	EcodeApproveJournalDraftFailed
+	EcodeRejectJournalDraftFailed
+	EcodeReviewCommentRequired
+	EcodeStoreApprovalRuleFailed
+	EcodeUpdateApprovalRuleFailed
+	EcodeDeleteApprovalRuleFailed
+	EcodeGetAllApprovalRulesFailed
+	EcodeGetRequiredApprovalsFailed
+	EcodeGetFiscalPeriodFailed
+	EcodeGetAllFiscalPeriodsFailed
+	EcodeGetAllFiscalPeriodHistoriesFailed
+	EcodeGenerateFiscalPeriodsFailed
+	EcodeFiscalPeriodsAlreadyGenerated
+	EcodeUpdateFiscalPeriodStatusFailed
+	EcodeFiscalPeriodStatusInvalid
+	EcodeFiscalPeriodLocked
+	EcodePermissionDenied
+	EcodeGetClosingJournalFailed
+	EcodeRetainedEarningsNotSet
+	EcodeFiscalYearAlreadyClosed
+	EcodeGetFiscalYearHistoryFailed
+	EcodeGetAllFiscalYearHistoriesFailed
+	EcodeStoreFiscalYearHistoryFailed
+	EcodeReopenFiscalYearFailed
+	EcodeFiscalYearNotClosed
+	EcodeLaterFiscalYearClosed
+	EcodeReopenFiscalYearReasonRequired
+	EcodeImportOpeningBalancesFailed
+	EcodeOpeningBalancesLocked
+	EcodeOpeningBalanceEquityNotSet
+	EcodeParseCSVFailed
+	EcodeAllocateJournalNumberFailed
+	EcodeGetAllJournalNumberFormatsFailed
+	EcodeUpdateJournalNumberFormatFailed
+	EcodeJournalNumberFormatInvalid
+	EcodeSearchGeneralLedgersFailed
+	EcodeGetAttachmentFailed
+	EcodeGetAllAttachmentsFailed
+	EcodeStoreAttachmentFailed
+	EcodeDeleteAttachmentFailed
+	EcodeAttachmentTooLarge
+	EcodeAttachmentContentTypeInvalid
+	EcodeAttachmentTargetInvalid
+	EcodeAttachmentURLInvalid
+	EcodeImportChartOfAccountsFailed
+	EcodeChartOfAccountsInvalid
+	EcodeChartOfAccountsNotEmpty
+	EcodeChartOfAccountsTemplateNotFound
+	EcodeExportChartOfAccountsFailed
+	EcodeSearchAccountsFailed
+	EcodeGetAllAccountCodeFormatsFailed
+	EcodeUpdateAccountCodeFormatFailed
+	EcodeAccountCodeFormatInvalid
+	EcodeAccountCodeInvalid
+	EcodeAccountCodeAlreadyExists
+	EcodeGetAccountTreeFailed
+	EcodeAccountGroupCycle
+	EcodeAccountGroupClassMismatch
+	EcodeGetAllBudgetsFailed
+	EcodeGetBudgetFailed
+	EcodeStoreBudgetFailed
+	EcodeUpdateBudgetFailed
+	EcodeDeleteBudgetFailed
+	EcodeGetAllBudgetLinesFailed
+	EcodeStoreBudgetLinesFailed
+	EcodeBudgetLineInvalid
+	EcodeGenerateBudgetFailed
+	EcodeGetBudgetVsActualFailed
+	EcodeGetAllAmortizationSchedulesFailed
+	EcodeGetAmortizationScheduleFailed
+	EcodeGetAllAmortizationEntriesFailed
+	EcodeStoreAmortizationScheduleFailed
+	EcodeAmortizationScheduleInvalid
+	EcodePostAmortizationEntriesFailed
+	EcodeTerminateAmortizationScheduleFailed
+	EcodeAmortizationScheduleNotActive
+	EcodeGetAllAssetCategoriesFailed
+	EcodeGetAssetCategoryFailed
+	EcodeStoreAssetCategoryFailed
+	EcodeUpdateAssetCategoryFailed
+	EcodeAssetCategoryInvalid
+	EcodeGetAllFixedAssetsFailed
+	EcodeGetFixedAssetFailed
+	EcodeStoreFixedAssetFailed
+	EcodeUpdateFixedAssetFailed
+	EcodeFixedAssetInvalid
+	EcodeFixedAssetNotActive
+	EcodeGetAllDepreciationRunsFailed
+	EcodeRunDepreciationFailed
+	EcodeDepreciationAlreadyRun
+	EcodeNothingToDepreciate
+	EcodeDisposeFixedAssetFailed
+	EcodeFixedAssetDisposalGainLossNotSet
+	EcodeGetFixedAssetRegisterFailed
+	EcodeGetAllDepreciationEntriesFailed
+	EcodeGetAllCustomersFailed
+	EcodeGetCustomerFailed
+	EcodeStoreCustomerFailed
+	EcodeUpdateCustomerFailed
+	EcodeCustomerInvalid
+	EcodeGetAllSalesInvoicesFailed
+	EcodeGetSalesInvoiceFailed
+	EcodeGetAllSalesInvoiceLinesFailed
+	EcodeStoreSalesInvoiceFailed
+	EcodeSalesInvoiceInvalid
+	EcodeVoidSalesInvoiceFailed
+	EcodeSalesInvoiceHasAllocations
+	EcodeAccountsReceivableNotSet
+	EcodeSalesTaxPayableNotSet
+	EcodeGetAllCustomerReceiptsFailed
+	EcodeGetCustomerReceiptFailed
+	EcodeGetAllReceiptAllocationsFailed
+	EcodeStoreCustomerReceiptFailed
+	EcodeAllocateCustomerReceiptFailed
+	EcodeReceiptAllocationInvalid
+	EcodeGetAgingReportFailed
+	EcodeGetPartyStatementFailed
+	EcodeGetAllVendorsFailed
+	EcodeGetVendorFailed
+	EcodeStoreVendorFailed
+	EcodeUpdateVendorFailed
+	EcodeVendorInvalid
+	EcodeGetAllPurchaseBillsFailed
+	EcodeGetPurchaseBillFailed
+	EcodeGetAllPurchaseBillLinesFailed
+	EcodeGetPurchaseBillsDueFailed
+	EcodeStorePurchaseBillFailed
+	EcodePurchaseBillInvalid
+	EcodePurchaseBillHasAllocations
+	EcodeAccountsPayableNotSet
+	EcodePurchaseTaxRecoverableNotSet
+	EcodeGetAllVendorPaymentsFailed
+	EcodeGetVendorPaymentFailed
+	EcodeGetAllPaymentAllocationsFailed
+	EcodeStoreVendorPaymentFailed
+	EcodeAllocateVendorPaymentFailed
+	EcodePaymentAllocationInvalid
+	EcodeGetAllCreditNotesFailed
+	EcodeGetCreditNoteFailed
+	EcodeStoreCreditNoteFailed
+	EcodeCreditNoteInvalid
+	EcodeGetAllDebitNotesFailed
+	EcodeGetDebitNoteFailed
+	EcodeStoreDebitNoteFailed
+	EcodeDebitNoteInvalid
+	EcodeGetAllCompaniesFailed
+	EcodeGetCompanyFailed
+	EcodeStoreCompanyFailed
+	EcodeCompanyInvalid
+	EcodeCompanyAccessDenied
+	EcodeStoreCompanyUserFailed
+	EcodeAccountNotInCompany
+	EcodeGetAllGroupAccountsFailed
+	EcodeGetGroupAccountFailed
+	EcodeStoreGroupAccountFailed
+	EcodeUpdateGroupAccountFailed
+	EcodeGroupAccountInvalid
+	EcodeMapAccountToGroupAccountFailed
+	EcodeGetConsolidationFailed
+	EcodeIntercompanyReceivableNotSet
+	EcodeIntercompanyPayableNotSet
+	EcodeGetAllIntercompanyTransactionsFailed
+	EcodeGetIntercompanyTransactionFailed
+	EcodeStoreIntercompanyTransactionFailed
+	EcodeIntercompanyTransactionInvalid
+	EcodeVoidIntercompanyJournalProhibited
+	EcodeGetIntercompanyReconciliationFailed
+	EcodeStoreAuditLogFailed
+	EcodeGetAllAuditLogsFailed
+	EcodeVerifyAuditLogFailed
+	EcodeAmendJournalFailed
+	EcodeAmendJournalProhibited
+	EcodeAmendBankJournalProhibited
+	EcodeGetAllJournalVersionsFailed
+	EcodeImportJournalsFailed
+	EcodeJournalImportInvalid
+	EcodeGetJournalImportFailed
+	EcodeGetStandardAuditFileFailed
+	EcodeStandardAuditFileInvalid
+	EcodeExportStandardAuditFileFailed
+	EcodeVerifyLedgerFailed
+	EcodeAccountNormalBalanceInvalid
+	EcodeGetAccountNormalBalanceFailed
+	EcodeTransactionRowInvalid
+	EcodeStandardAuditFileSchemaNotConfigured
+	EcodeGetBankTransactionFailed
 )
@@ -0,0 +1,3 @@
+package sql
+
+const DefaultFiscalPeriodMonths = 1
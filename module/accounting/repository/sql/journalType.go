@@ -0,0 +1,45 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	GeneralJournalType int64 = iota + 1
+	BankDepositJournalType
+	ClosingJournalType
+	OpeningJournalType
+	AmortizationJournalType
+	FixedAssetJournalType
+	SalesInvoiceJournalType
+	PurchaseBillJournalType
+	BankPaymentJournalType
+	CreditNoteJournalType
+	DebitNoteJournalType
+	IntercompanyJournalType
+)
+
+var journalNumberSequencePattern = regexp.MustCompile(`\{N+\}`)
+
+// ValidJournalNumberFormat reports whether format has exactly one sequence placeholder, e.g. {NNNNN}.
+func ValidJournalNumberFormat(format string) bool {
+	return len(journalNumberSequencePattern.FindAllString(format, -1)) == 1
+}
+
+// FormatJournalNumber renders format with the {YYYY}, {YY}, {MM} and {DD} placeholders taken from date,
+// and the sequence placeholder zero padded to its number of N.
+func FormatJournalNumber(format string, date time.Time, sequence int64) string {
+	number := strings.NewReplacer(
+		"{YYYY}", date.Format("2006"),
+		"{YY}", date.Format("06"),
+		"{MM}", date.Format("01"),
+		"{DD}", date.Format("02"),
+	).Replace(format)
+
+	return journalNumberSequencePattern.ReplaceAllStringFunc(number, func(placeholder string) string {
+		return fmt.Sprintf("%0*d", len(placeholder)-2, sequence)
+	})
+}
@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"sort"
+)
+
+// newJournalLineChanges nets the lines of two versions of a journal by account, and keeps the accounts whose net
+// amount differs between them.
+func newJournalLineChanges(before []domain.JournalVersionLine, after []domain.JournalVersionLine) (changes []domain.JournalLineChange) {
+	var (
+		accountIDs = make([]int64, 0)
+		amounts    = make(map[int64]*domain.JournalLineChange)
+	)
+
+	change := func(accountID int64) *domain.JournalLineChange {
+		amount, ok := amounts[accountID]
+		if !ok {
+			amount = &domain.JournalLineChange{AccountID: accountID}
+			amounts[accountID] = amount
+			accountIDs = append(accountIDs, accountID)
+		}
+
+		return amount
+	}
+
+	for _, line := range before {
+		change(line.AccountID).Before += line.Amount
+	}
+
+	for _, line := range after {
+		change(line.AccountID).After += line.Amount
+	}
+
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+	changes = make([]domain.JournalLineChange, 0)
+	for _, accountID := range accountIDs {
+		amount := *amounts[accountID]
+		amount.Before, amount.After = roundCents(amount.Before), roundCents(amount.After)
+
+		if amount.Before != amount.After {
+			changes = append(changes, amount)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,128 @@
+package sql
+
+import (
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+)
+
+type ledgerCheck struct {
+	check string
+	// query takes the company id once and selects the columns of domain.LedgerIssue it has ids or amounts for.
+	query   string
+	message func(issue domain.LedgerIssue) string
+}
+
+// ledgerChecks run in this order, each on the journals of one company that have not been voided. Amounts less than
+// half a cent apart are taken as equal.
+var ledgerChecks = []ledgerCheck{
+	{
+		check: domain.UnbalancedJournalCheck,
+		query: `
+			SELECT j.id AS journal_id, 0 AS expected, SUM(gl.amount) AS actual
+			FROM journals j
+			INNER JOIN general_ledgers gl ON gl.journal_id = j.id
+			WHERE j.deleted_at IS NULL AND j.company_id = ?
+			GROUP BY j.id, j.trans_date
+			HAVING ABS(SUM(gl.amount)) >= 0.005
+			ORDER BY j.trans_date ASC, j.id ASC
+		`,
+		message: func(issue domain.LedgerIssue) string {
+			return fmt.Sprintf("Journal lines sum to %.2f instead of zero", issue.Actual)
+		},
+	},
+	{
+		check: domain.JournalAmountMismatchCheck,
+		query: `
+			SELECT j.id AS journal_id, COALESCE(SUM(GREATEST(gl.amount, 0)), 0) AS expected, j.amount AS actual
+			FROM journals j
+			LEFT JOIN general_ledgers gl ON gl.journal_id = j.id
+			WHERE j.deleted_at IS NULL AND j.company_id = ?
+			GROUP BY j.id, j.amount, j.trans_date
+			HAVING ABS(j.amount - COALESCE(SUM(GREATEST(gl.amount, 0)), 0)) >= 0.005
+			ORDER BY j.trans_date ASC, j.id ASC
+		`,
+		message: func(issue domain.LedgerIssue) string {
+			return fmt.Sprintf("Journal amount is %.2f but its lines debit %.2f", issue.Actual, issue.Expected)
+		},
+	},
+	{
+		check: domain.UnmatchedBankTransactionCheck,
+		query: `
+			SELECT bt.id AS bank_transaction_id, bt.bank_account_id, bt.journal_id, bt.amount AS expected, 0 AS actual
+			FROM bank_transactions bt
+			INNER JOIN journals j ON j.id = bt.journal_id
+			LEFT JOIN bank_accounts ba ON ba.id = bt.bank_account_id
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND NOT EXISTS (
+				SELECT 1
+				FROM general_ledgers gl
+				WHERE gl.journal_id = bt.journal_id AND gl.account_id = ba.account_id AND ABS(gl.amount - bt.amount) < 0.005
+			)
+			ORDER BY bt.id ASC
+		`,
+		message: func(issue domain.LedgerIssue) string {
+			return fmt.Sprintf("Bank transaction has no general ledger line of %.2f on the account of its bank account", issue.Expected)
+		},
+	},
+	{
+		check: domain.BankBalanceDriftCheck,
+		query: `
+			SELECT bank_account_id, expected, actual
+			FROM (
+				SELECT
+					ba.id AS bank_account_id,
+					COALESCE((
+						SELECT SUM(gl.amount)
+						FROM general_ledgers gl
+						INNER JOIN journals j ON j.id = gl.journal_id
+						WHERE j.deleted_at IS NULL AND gl.account_id = ba.account_id
+					), 0) AS expected,
+					COALESCE((
+						SELECT bt.balance FROM bank_transactions bt WHERE bt.bank_account_id = ba.id ORDER BY bt.id DESC LIMIT 1
+					), 0) AS actual
+				FROM bank_accounts ba
+				WHERE ba.company_id = ?
+			) AS bank_balances
+			WHERE ABS(expected - actual) >= 0.005
+			ORDER BY bank_account_id ASC
+		`,
+		message: func(issue domain.LedgerIssue) string {
+			return fmt.Sprintf("Bank balance is %.2f but the ledger balance of its account is %.2f", issue.Actual, issue.Expected)
+		},
+	},
+	{
+		check: domain.PostingOutsideFiscalYearCheck,
+		query: `
+			SELECT j.id AS journal_id
+			FROM journals j
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND NOT EXISTS (
+				SELECT 1
+				FROM fiscal_years fy
+				WHERE fy.company_id = j.company_id AND DATE(j.trans_date) BETWEEN DATE(fy.start_date) AND DATE(fy.end_date)
+			)
+			ORDER BY j.trans_date ASC, j.id ASC
+		`,
+		message: func(issue domain.LedgerIssue) string {
+			return "Journal is dated outside every fiscal year"
+		},
+	},
+	{
+		// a closed year takes no journal created after it was last closed but its closing entries,
+		// a year closed without a close history entry cannot be told apart and is left alone.
+		check: domain.PostingInClosedFiscalYearCheck,
+		query: fmt.Sprintf(`
+			SELECT j.id AS journal_id, fy.id AS fiscal_year_id
+			FROM journals j
+			INNER JOIN fiscal_years fy
+				ON fy.company_id = j.company_id AND DATE(j.trans_date) BETWEEN DATE(fy.start_date) AND DATE(fy.end_date)
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND fy.closed AND NOT j.closing AND j.created_at > (
+				SELECT MAX(h.created_at)
+				FROM fiscal_year_histories h
+				WHERE h.fiscal_year_id = fy.id AND h.action_id = %d
+			)
+			ORDER BY j.trans_date ASC, j.id ASC
+		`, domain.CloseFiscalYearAction),
+		message: func(issue domain.LedgerIssue) string {
+			return "Journal was posted after its fiscal year was closed"
+		},
+	},
+}
@@ -6,8 +6,13 @@ import (
 )
 
 type TransactionRow struct {
-	AccountID int64
-	Amount    float64
+	AccountID         int64
+	Amount            float64
+	Memo              string
+	ExternalReference string
+
+	// CounterpartyCompanyID tags the row as intercompany with the company on the other side, zero when it is not.
+	CounterpartyCompanyID int64
 }
 
 type Transaction struct {
@@ -15,6 +20,22 @@ type Transaction struct {
 	Memo      string
 	Data      []TransactionRow
 	journalID uuid.UUID
+	closing   bool
+	opening   bool
+	typeID    int64
+}
+
+func (t Transaction) journalTypeID() int64 {
+	switch {
+	case t.closing:
+		return ClosingJournalType
+	case t.opening:
+		return OpeningJournalType
+	case t.typeID != 0:
+		return t.typeID
+	default:
+		return GeneralJournalType
+	}
 }
 
 type BankTransaction struct {
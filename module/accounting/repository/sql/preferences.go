@@ -1,7 +1,127 @@
 package sql
 
+import "github.com/QuickAmethyst/monosvc/module/accounting/domain"
+
 type GeneralLedgerPreferenceID int64
 
 const (
 	RetainedEarnings GeneralLedgerPreferenceID = iota + 1
+	OpeningBalanceEquity
+	FixedAssetDisposalGainLoss
+	AccountsReceivable
+	SalesTaxPayable
+	AccountsPayable
+	PurchaseTaxRecoverable
+	IntercompanyReceivable
+	IntercompanyPayable
+	ForeignExchangeGainLoss
+	RoundingDifference
+	Inventory
+	CostOfGoodsSold
 )
+
+// generalLedgerPreferences is the registry of general ledger preferences, in the order of their id. Validation of
+// the preferences is driven from it, a new preference only needs its entry here and a row for every company.
+var generalLedgerPreferences = []domain.GeneralLedgerPreferenceDefinition{
+	{
+		ID:           int64(RetainedEarnings),
+		Key:          "retained_earnings",
+		Name:         "Retained Earnings",
+		Description:  "Receives the profit or loss of a fiscal year when it is closed.",
+		ClassTypeIDs: []int64{AssetClassType, LiabilitiesClassType, EquityClassType},
+		Required:     true,
+	},
+	{
+		ID:           int64(OpeningBalanceEquity),
+		Key:          "opening_balance_equity",
+		Name:         "Opening Balance Equity",
+		Description:  "Offsets the opening balances of accounts.",
+		ClassTypeIDs: []int64{EquityClassType},
+	},
+	{
+		ID:           int64(FixedAssetDisposalGainLoss),
+		Key:          "fixed_asset_disposal_gain_loss",
+		Name:         "Fixed Asset Disposal Gain/Loss",
+		Description:  "Receives the difference between the proceeds and the book value of a disposed fixed asset.",
+		ClassTypeIDs: []int64{IncomeClassType, COGSClassType, ExpenseClassType},
+	},
+	{
+		ID:           int64(AccountsReceivable),
+		Key:          "accounts_receivable",
+		Name:         "Accounts Receivable",
+		Description:  "Control account of the sales invoices and customer receipts.",
+		ClassTypeIDs: []int64{AssetClassType},
+	},
+	{
+		ID:           int64(SalesTaxPayable),
+		Key:          "sales_tax_payable",
+		Name:         "Sales Tax Payable",
+		Description:  "Default account of the tax charged on sales invoices.",
+		ClassTypeIDs: []int64{LiabilitiesClassType},
+	},
+	{
+		ID:           int64(AccountsPayable),
+		Key:          "accounts_payable",
+		Name:         "Accounts Payable",
+		Description:  "Control account of the purchase bills and vendor payments.",
+		ClassTypeIDs: []int64{LiabilitiesClassType},
+	},
+	{
+		ID:           int64(PurchaseTaxRecoverable),
+		Key:          "purchase_tax_recoverable",
+		Name:         "Purchase Tax Recoverable",
+		Description:  "Default account of the tax paid on purchase bills.",
+		ClassTypeIDs: []int64{AssetClassType},
+	},
+	{
+		ID:           int64(IntercompanyReceivable),
+		Key:          "intercompany_receivable",
+		Name:         "Intercompany Receivable",
+		Description:  "Amount due from other companies for intercompany transactions paid on their behalf.",
+		ClassTypeIDs: []int64{AssetClassType},
+	},
+	{
+		ID:           int64(IntercompanyPayable),
+		Key:          "intercompany_payable",
+		Name:         "Intercompany Payable",
+		Description:  "Amount due to other companies for intercompany transactions paid on behalf of this one.",
+		ClassTypeIDs: []int64{LiabilitiesClassType},
+	},
+	{
+		ID:           int64(ForeignExchangeGainLoss),
+		Key:          "foreign_exchange_gain_loss",
+		Name:         "Foreign Exchange Gain/Loss",
+		Description:  "Receives the differences arising from exchange rate changes.",
+		ClassTypeIDs: []int64{IncomeClassType, ExpenseClassType},
+	},
+	{
+		ID:           int64(RoundingDifference),
+		Key:          "rounding",
+		Name:         "Rounding",
+		Description:  "Receives the differences left by rounding amounts.",
+		ClassTypeIDs: []int64{IncomeClassType, ExpenseClassType},
+	},
+	{
+		ID:           int64(Inventory),
+		Key:          "inventory",
+		Name:         "Inventory",
+		Description:  "Default account of the goods held for sale.",
+		ClassTypeIDs: []int64{AssetClassType},
+	},
+	{
+		ID:           int64(CostOfGoodsSold),
+		Key:          "cost_of_goods_sold",
+		Name:         "Cost of Goods Sold",
+		Description:  "Default account of the cost of the goods sold.",
+		ClassTypeIDs: []int64{COGSClassType},
+	},
+}
+
+// getGeneralLedgerPreferenceDefinition looks up a preference in the registry, ok is false when there is no such one.
+func getGeneralLedgerPreferenceDefinition(id int64) (definition domain.GeneralLedgerPreferenceDefinition, ok bool) {
+	if id < 1 || id > int64(len(generalLedgerPreferences)) {
+		return
+	}
+
+	return generalLedgerPreferences[id-1], true
+}
@@ -2,12 +2,14 @@ package sql
 
 import (
 	"context"
+	goSql "database/sql"
 	"fmt"
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
 	qb "github.com/QuickAmethyst/monosvc/stdlibgo/querybuilder/sql"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
 	"github.com/google/uuid"
+	"math"
 	"strings"
 	"time"
 )
@@ -17,9 +19,15 @@ type Reader interface {
 	GetAccountClass(ctx context.Context, stmt AccountClassStatement) (accountClass domain.AccountClass, err error)
 	GetAccountClassByID(ctx context.Context, id int64) (accountClass domain.AccountClass, err error)
 	GetAccountClassBalanceByID(ctx context.Context, id int64) (balance float64, err error)
+	GetAccountClassByAccountID(ctx context.Context, accountID int64) (accountClass domain.AccountClass, err error)
+
+	GetChartOfAccounts(ctx context.Context) (chart domain.ChartOfAccounts, err error)
+	GetAccountTree(ctx context.Context, withBalances bool) (classes []domain.AccountTreeClass, err error)
 
 	GetAllAccountTypes(ctx context.Context) (result []domain.AccountClassType)
 	GetAccountClassTypeByID(ctx context.Context, id int64) (accountClassType domain.AccountClassType)
+	GetAllGeneralLedgerPreferenceDefinitions(ctx context.Context) (result []domain.GeneralLedgerPreferenceDefinition)
+	GetGeneralLedgerPreferenceDefinitionByID(ctx context.Context, id int64) (definition domain.GeneralLedgerPreferenceDefinition)
 
 	GetAllAccountGroups(ctx context.Context, stmt AccountGroupStatement) (result []domain.AccountGroup, err error)
 	GetAccountGroup(ctx context.Context, stmt AccountGroupStatement) (accountGroup domain.AccountGroup, err error)
@@ -28,8 +36,13 @@ type Reader interface {
 	GetAllAccounts(ctx context.Context, stmt AccountStatement) (result []domain.Account, err error)
 	GetAccount(ctx context.Context, stmt AccountStatement) (account domain.Account, err error)
 	GetAccountByID(ctx context.Context, id int64) (account domain.Account, err error)
+	SearchAccounts(ctx context.Context, query string, includeInactive bool, limit int) (accounts []domain.Account, err error)
+	ValidateAccountCode(ctx context.Context, groupID int64, code string) (err error)
+
+	GetAllAccountCodeFormats(ctx context.Context) (formats []domain.AccountCodeFormat, err error)
 	AccountHasTransaction(ctx context.Context, id int64) (hasTransaction bool, err error)
 	GetAccountBalanceByID(ctx context.Context, id int64) (balance float64, err error)
+	GetAccountNormalBalanceByID(ctx context.Context, id int64) (normalBalance string, err error)
 
 	ValidatePreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error)
 	GetAllGeneralLedgerPreferences(ctx context.Context, stmt GeneralLedgerPreferenceStatement) (preferences []domain.GeneralLedgerPreference, err error)
@@ -39,7 +52,17 @@ type Reader interface {
 	GetFiscalYear(ctx context.Context, stmt FiscalYearStatement) (fiscalYear domain.FiscalYear, err error)
 	GetActiveFiscalYear(ctx context.Context) (fiscalYear domain.FiscalYear, err error)
 
+	GetAllFiscalPeriods(ctx context.Context, stmt FiscalPeriodStatement) (periods []domain.FiscalPeriod, err error)
+	GetFiscalPeriod(ctx context.Context, stmt FiscalPeriodStatement) (period domain.FiscalPeriod, err error)
+	GetFiscalPeriodByID(ctx context.Context, id int64) (period domain.FiscalPeriod, err error)
+	GetFiscalPeriodByDate(ctx context.Context, date time.Time) (period domain.FiscalPeriod, err error)
+	GetAllFiscalPeriodHistoriesByPeriodID(ctx context.Context, periodID int64) (histories []domain.FiscalPeriodHistory, err error)
+
+	GetFiscalYearHistory(ctx context.Context, stmt FiscalYearHistoryStatement) (history domain.FiscalYearHistory, err error)
+	GetAllFiscalYearHistoriesByFiscalYearID(ctx context.Context, fiscalYearID int64) (histories []domain.FiscalYearHistory, err error)
+
 	GetBalanceSheetAmount(ctx context.Context, startDate time.Time, endDate time.Time) (amount float64, err error)
+	GetClosingJournal(ctx context.Context, fiscalYearID int64) (closingJournal domain.ClosingJournal, err error)
 
 	GetAllBankAccountTypes(ctx context.Context) (bankAccountTypes []domain.BankAccountType)
 	GetBankAccountList(ctx context.Context, stmt BankAccountStatement, p qb.Paging) (result []domain.BankAccount, paging qb.Paging, err error)
@@ -48,17 +71,140 @@ type Reader interface {
 	IsBankAccount(ctx context.Context, accountID int64) (isBankAccount bool, err error)
 	GetBankAccountBalanceByID(ctx context.Context, id int64) (balance float64, err error)
 	GetAllBankTransactionsByJournalID(ctx context.Context, journalID uuid.UUID) (bankTransactions []domain.BankTransaction, err error)
+	GetBankTransactionByID(ctx context.Context, id int64) (bankTransaction domain.BankTransaction, err error)
 
 	GetGeneralLedgerByAccountID(ctx context.Context, accountID int64, p qb.Paging) (gls []domain.GeneralLedger, paging qb.Paging, err error)
 	GetAllGeneralLedgersByJournalID(ctx context.Context, journalID uuid.UUID) (gls []domain.GeneralLedger, err error)
+	SearchGeneralLedgers(ctx context.Context, stmt GeneralLedgerStatement, search string, p qb.Paging) (gls []domain.GeneralLedger, paging qb.Paging, err error)
 
+	GetAllJournalNumberFormats(ctx context.Context) (formats []domain.JournalNumberFormat, err error)
+	GetJournalNumberFormat(ctx context.Context, stmt JournalNumberFormatStatement) (format domain.JournalNumberFormat, err error)
+
+	GetAllAttachments(ctx context.Context, stmt AttachmentStatement) (attachments []domain.Attachment, err error)
+	GetAttachment(ctx context.Context, stmt AttachmentStatement) (attachment domain.Attachment, err error)
+	GetAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error)
+	GetDownloadableAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error)
+	GetAttachmentFileBySHA256(ctx context.Context, sha256 string) (file domain.AttachmentFile, err error)
+
+	GetJournal(ctx context.Context, stmt JournalStatement) (journal domain.Journal, err error)
 	GetJournalByID(ctx context.Context, id uuid.UUID) (journal domain.Journal, err error)
+	GetAllJournalVersions(ctx context.Context, journalID uuid.UUID) (versions []domain.JournalVersion, err error)
+	GetJournalImportByBatchID(ctx context.Context, batchID string) (journalImport domain.JournalImport, err error)
+
+	GetJournalDraftList(ctx context.Context, stmt JournalDraftStatement, p qb.Paging) (result []domain.JournalDraft, paging qb.Paging, err error)
+	GetJournalDraft(ctx context.Context, stmt JournalDraftStatement) (draft domain.JournalDraft, err error)
+	GetJournalDraftByID(ctx context.Context, id uuid.UUID) (draft domain.JournalDraft, err error)
+	GetAllJournalDraftLinesByDraftID(ctx context.Context, draftID uuid.UUID) (lines []domain.JournalDraftLine, err error)
+	GetAllJournalDraftReviewsByDraftID(ctx context.Context, draftID uuid.UUID) (reviews []domain.JournalDraftReview, err error)
+
+	GetAllApprovalRules(ctx context.Context, stmt ApprovalRuleStatement) (rules []domain.ApprovalRule, err error)
+	GetRequiredApprovalsByDraftID(ctx context.Context, draftID uuid.UUID) (requiredApprovals int64, err error)
+
+	GetAllBudgets(ctx context.Context, stmt BudgetStatement) (budgets []domain.Budget, err error)
+	GetBudget(ctx context.Context, stmt BudgetStatement) (budget domain.Budget, err error)
+	GetBudgetByID(ctx context.Context, id int64) (budget domain.Budget, err error)
+	GetAllBudgetLines(ctx context.Context, stmt BudgetLineStatement) (lines []domain.BudgetLine, err error)
+	GetAllActualsByFiscalPeriod(ctx context.Context, fiscalYearID int64) (actuals []domain.BudgetLine, err error)
+	GetBudgetVsActual(ctx context.Context, budgetID int64, fromPeriodID int64, toPeriodID int64) (report domain.BudgetVsActual, err error)
+
+	GetAllAmortizationSchedules(ctx context.Context, stmt AmortizationScheduleStatement) (schedules []domain.AmortizationSchedule, err error)
+	GetAmortizationSchedule(ctx context.Context, stmt AmortizationScheduleStatement) (schedule domain.AmortizationSchedule, err error)
+	GetAmortizationScheduleByID(ctx context.Context, id int64) (schedule domain.AmortizationSchedule, err error)
+	GetAllAmortizationEntries(ctx context.Context, stmt AmortizationEntryStatement) (entries []domain.AmortizationEntry, err error)
+
+	GetAllAssetCategories(ctx context.Context, stmt AssetCategoryStatement) (categories []domain.AssetCategory, err error)
+	GetAssetCategory(ctx context.Context, stmt AssetCategoryStatement) (category domain.AssetCategory, err error)
+	GetAssetCategoryByID(ctx context.Context, id int64) (category domain.AssetCategory, err error)
+	GetAllFixedAssets(ctx context.Context, stmt FixedAssetStatement) (assets []domain.FixedAsset, err error)
+	GetFixedAsset(ctx context.Context, stmt FixedAssetStatement) (asset domain.FixedAsset, err error)
+	GetFixedAssetByID(ctx context.Context, id int64) (asset domain.FixedAsset, err error)
+	GetAllDepreciationRuns(ctx context.Context, stmt DepreciationRunStatement) (runs []domain.DepreciationRun, err error)
+	GetAllDepreciationEntries(ctx context.Context, stmt DepreciationEntryStatement) (entries []domain.DepreciationEntry, err error)
+	GetFixedAssetRegister(ctx context.Context, asOf time.Time) (rows []domain.FixedAssetRegisterRow, err error)
+
+	GetAllCustomers(ctx context.Context, stmt CustomerStatement) (customers []domain.Customer, err error)
+	GetCustomer(ctx context.Context, stmt CustomerStatement) (customer domain.Customer, err error)
+	GetCustomerByID(ctx context.Context, id int64) (customer domain.Customer, err error)
+	GetAllSalesInvoices(ctx context.Context, stmt SalesInvoiceStatement) (invoices []domain.SalesInvoice, err error)
+	GetSalesInvoice(ctx context.Context, stmt SalesInvoiceStatement) (invoice domain.SalesInvoice, err error)
+	GetSalesInvoiceByID(ctx context.Context, id int64) (invoice domain.SalesInvoice, err error)
+	GetAllSalesInvoiceLines(ctx context.Context, stmt SalesInvoiceLineStatement) (lines []domain.SalesInvoiceLine, err error)
+	GetAllCustomerReceipts(ctx context.Context, stmt CustomerReceiptStatement) (receipts []domain.CustomerReceipt, err error)
+	GetCustomerReceipt(ctx context.Context, stmt CustomerReceiptStatement) (receipt domain.CustomerReceipt, err error)
+	GetCustomerReceiptByID(ctx context.Context, id int64) (receipt domain.CustomerReceipt, err error)
+	GetAllReceiptAllocations(ctx context.Context, stmt ReceiptAllocationStatement) (allocations []domain.ReceiptAllocation, err error)
+	GetReceivableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error)
+	GetCustomerAccountStatement(ctx context.Context, customerID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error)
+	GetAllVendors(ctx context.Context, stmt VendorStatement) (vendors []domain.Vendor, err error)
+	GetVendor(ctx context.Context, stmt VendorStatement) (vendor domain.Vendor, err error)
+	GetVendorByID(ctx context.Context, id int64) (vendor domain.Vendor, err error)
+	GetAllPurchaseBills(ctx context.Context, stmt PurchaseBillStatement) (bills []domain.PurchaseBill, err error)
+	GetPurchaseBill(ctx context.Context, stmt PurchaseBillStatement) (bill domain.PurchaseBill, err error)
+	GetPurchaseBillByID(ctx context.Context, id int64) (bill domain.PurchaseBill, err error)
+	GetAllPurchaseBillLines(ctx context.Context, stmt PurchaseBillLineStatement) (lines []domain.PurchaseBillLine, err error)
+	GetAllVendorPayments(ctx context.Context, stmt VendorPaymentStatement) (payments []domain.VendorPayment, err error)
+	GetVendorPayment(ctx context.Context, stmt VendorPaymentStatement) (payment domain.VendorPayment, err error)
+	GetVendorPaymentByID(ctx context.Context, id int64) (payment domain.VendorPayment, err error)
+	GetAllPaymentAllocations(ctx context.Context, stmt PaymentAllocationStatement) (allocations []domain.PaymentAllocation, err error)
+	GetAllPurchaseBillsDue(ctx context.Context, through time.Time) (bills []domain.PurchaseBill, err error)
+	GetPayableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error)
+	GetVendorAccountStatement(ctx context.Context, vendorID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error)
+	GetAllCreditNotes(ctx context.Context, stmt CreditNoteStatement) (notes []domain.CreditNote, err error)
+	GetCreditNote(ctx context.Context, stmt CreditNoteStatement) (note domain.CreditNote, err error)
+	GetCreditNoteByID(ctx context.Context, id int64) (note domain.CreditNote, err error)
+	GetAllDebitNotes(ctx context.Context, stmt DebitNoteStatement) (notes []domain.DebitNote, err error)
+	GetDebitNote(ctx context.Context, stmt DebitNoteStatement) (note domain.DebitNote, err error)
+	GetDebitNoteByID(ctx context.Context, id int64) (note domain.DebitNote, err error)
+
+	GetAllCompanies(ctx context.Context, stmt CompanyStatement) (companies []domain.Company, err error)
+	GetCompany(ctx context.Context, stmt CompanyStatement) (company domain.Company, err error)
+	GetCompanyByID(ctx context.Context, id int64) (company domain.Company, err error)
+	GetAllCompaniesByUserID(ctx context.Context, userID uuid.UUID) (companies []domain.Company, err error)
+	HasCompanyAccess(ctx context.Context, userID uuid.UUID, companyID int64) (hasAccess bool, err error)
+	GetAllGroupAccounts(ctx context.Context, stmt GroupAccountStatement) (groupAccounts []domain.GroupAccount, err error)
+	GetGroupAccount(ctx context.Context, stmt GroupAccountStatement) (groupAccount domain.GroupAccount, err error)
+	GetGroupAccountByID(ctx context.Context, id int64) (groupAccount domain.GroupAccount, err error)
+	GetConsolidatedTrialBalance(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedTrialBalance, err error)
+	GetConsolidatedBalanceSheet(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedBalanceSheet, err error)
+
+	GetAllIntercompanyTransactions(ctx context.Context, stmt IntercompanyTransactionStatement) (transactions []domain.IntercompanyTransaction, err error)
+	GetIntercompanyTransaction(ctx context.Context, stmt IntercompanyTransactionStatement) (transaction domain.IntercompanyTransaction, err error)
+	GetIntercompanyTransactionByID(ctx context.Context, id int64) (transaction domain.IntercompanyTransaction, err error)
+	GetIntercompanyReconciliation(ctx context.Context, companyIDs []int64, asOf time.Time) (balances []domain.IntercompanyBalance, err error)
+
+	GetAllAuditLogs(ctx context.Context, stmt AuditLogStatement) (logs []domain.AuditLog, err error)
+	VerifyAuditLog(ctx context.Context) (verification domain.AuditLogVerification, err error)
+	VerifyLedger(ctx context.Context) (verification domain.LedgerVerification, err error)
+
+	GetStandardAuditFile(ctx context.Context, startDate time.Time, endDate time.Time) (file domain.StandardAuditFile, err error)
 }
 
 type reader struct {
 	db sql.DB
 }
 
+func (r *reader) GetAccountClassByAccountID(ctx context.Context, accountID int64) (accountClass domain.AccountClass, err error) {
+	query := `
+		SELECT account_classes.id, account_classes.code, account_classes.name, account_classes.type_id, account_classes.inactive
+		FROM account_classes, account_groups, accounts
+		WHERE
+			accounts.group_id = account_groups.id AND account_groups.class_id = account_classes.id AND
+			accounts.id = ?
+	`
+
+	if err = r.db.GetContext(ctx, &accountClass, r.db.Rebind(query), accountID); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Account not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAccountClassFailed, "Failed on get account class")
+		return
+	}
+
+	return
+}
+
 func (r *reader) GetAccountClassBalanceByID(ctx context.Context, id int64) (balance float64, err error) {
 	query := fmt.Sprintf(`
 		SELECT COALESCE(SUM(gl.amount), 0)
@@ -74,10 +220,11 @@ func (r *reader) GetAccountClassBalanceByID(ctx context.Context, id int64) (bala
 			accgroup.class_id = accclass.id AND
 			j.id = gl.journal_id AND
 			j.deleted_at IS NULL AND
+			acc.company_id = ? AND
 			accclass.id = ?
 	`)
 
-	if err = r.db.QueryRowContext(ctx, r.db.Rebind(query), id).Scan(&balance); err != nil {
+	if err = r.db.QueryRowContext(ctx, r.db.Rebind(query), companyID(ctx), id).Scan(&balance); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountClassBalanceFailed, "Failed on get account class balance")
 		return
 	}
@@ -96,10 +243,11 @@ func (r *reader) GetAccountBalanceByID(ctx context.Context, id int64) (balance f
 			gl.account_id = acc.id AND
 			j.id = gl.journal_id AND
 			j.deleted_at IS NULL AND
+			acc.company_id = ? AND
 			acc.id = ?
 	`
 
-	if err = r.db.QueryRowContext(ctx, r.db.Rebind(query), id).Scan(&balance); err != nil {
+	if err = r.db.QueryRowContext(ctx, r.db.Rebind(query), companyID(ctx), id).Scan(&balance); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountBalanceFailed, "Failed on get account balance")
 		return
 	}
@@ -107,6 +255,37 @@ func (r *reader) GetAccountBalanceByID(ctx context.Context, id int64) (balance f
 	return
 }
 
+// GetAccountNormalBalanceByID returns the side an account normally holds its balance on, its own override
+// when it has one and that of its class type otherwise.
+func (r *reader) GetAccountNormalBalanceByID(ctx context.Context, id int64) (normalBalance string, err error) {
+	query := `
+		SELECT acc.normal_balance, cls.type_id
+		FROM
+			accounts acc,
+			account_groups grp,
+			account_classes cls
+		WHERE
+			acc.group_id = grp.id AND
+			grp.class_id = cls.id AND
+			acc.company_id = ? AND
+			acc.id = ?
+	`
+
+	var override goSql.NullString
+	var classType int64
+	if err = r.db.QueryRowContext(ctx, r.db.Rebind(query), companyID(ctx), id).Scan(&override, &classType); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Account not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAccountNormalBalanceFailed, "Failed on get account normal balance")
+		return
+	}
+
+	return AccountNormalBalance(classType, override), nil
+}
+
 func (r *reader) GetAllBankTransactionsByJournalID(ctx context.Context, journalID uuid.UUID) (bankTransactions []domain.BankTransaction, err error) {
 	bankTransactions = make([]domain.BankTransaction, 0)
 
@@ -117,12 +296,12 @@ func (r *reader) GetAllBankTransactionsByJournalID(ctx context.Context, journalI
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, journal_id, bank_account_id, user_id, amount, balance, created_at
+		SELECT id, journal_id, bank_account_id, created_by, amount, balance, trans_date, created_at
 		FROM bank_transactions
-		WHERE %s
+		%s
 	`, whereClause)
 
-	if err = r.db.SelectContext(ctx, &bankTransactions, query, whereClauseArgs...); err != nil {
+	if err = r.db.SelectContext(ctx, &bankTransactions, r.db.Rebind(query), whereClauseArgs...); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAllBankTransactionsFailed, "Failed on get bank transactions")
 		return
 	}
@@ -130,6 +309,28 @@ func (r *reader) GetAllBankTransactionsByJournalID(ctx context.Context, journalI
 	return
 }
 
+// GetBankTransactionByID finds a transaction on a bank account of the company.
+func (r *reader) GetBankTransactionByID(ctx context.Context, id int64) (bankTransaction domain.BankTransaction, err error) {
+	query := `
+		SELECT bt.id, bt.journal_id, bt.bank_account_id, bt.created_by, bt.amount, bt.balance, bt.trans_date, bt.created_at
+		FROM bank_transactions bt
+		INNER JOIN bank_accounts ba ON ba.id = bt.bank_account_id
+		WHERE bt.id = ? AND ba.company_id = ?
+	`
+
+	if err = r.db.GetContext(ctx, &bankTransaction, r.db.Rebind(query), id, companyID(ctx)); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Bank transaction not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetBankTransactionFailed, "Failed on get bank transaction")
+		return
+	}
+
+	return
+}
+
 func (r *reader) GetAllGeneralLedgersByJournalID(ctx context.Context, journalID uuid.UUID) (gls []domain.GeneralLedger, err error) {
 	gls = make([]domain.GeneralLedger, 0)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(GeneralLedgerStatement{JournalID: journalID})
@@ -138,7 +339,7 @@ func (r *reader) GetAllGeneralLedgersByJournalID(ctx context.Context, journalID
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, journal_id, account_id, amount, created_by FROM general_ledgers WHERE %s", whereClause)
+	query := fmt.Sprintf("SELECT id, journal_id, account_id, amount, created_by, memo, external_reference, counterparty_company_id FROM general_ledgers %s", whereClause)
 	if err = r.db.SelectContext(ctx, &gls, r.db.Rebind(query), whereClauseArgs...); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAllGeneralLedgersFailed, "Failed on get general ledgers")
 		return
@@ -147,6 +348,186 @@ func (r *reader) GetAllGeneralLedgersByJournalID(ctx context.Context, journalID
 	return
 }
 
+// SearchGeneralLedgers lists general ledgers matching stmt whose memo, external reference or journal number
+// contains search.
+func (r *reader) SearchGeneralLedgers(ctx context.Context, stmt GeneralLedgerStatement, search string, p qb.Paging) (gls []domain.GeneralLedger, paging qb.Paging, err error) {
+	gls = make([]domain.GeneralLedger, 0)
+
+	paging = p
+	paging.Normalize()
+
+	limitClause, limitClauseArgs := paging.BuildQuery()
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeSearchGeneralLedgersFailed, "Failed on build where clause")
+		return
+	}
+
+	if whereClause == "" {
+		whereClause = "WHERE journals.company_id = ?"
+	} else {
+		whereClause += " AND journals.company_id = ?"
+	}
+
+	whereClauseArgs = append(whereClauseArgs, companyID(ctx))
+
+	if search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		whereClause += " AND (general_ledgers.memo ILIKE ? OR general_ledgers.external_reference ILIKE ? OR journals.number ILIKE ?)"
+		whereClauseArgs = append(whereClauseArgs, pattern, pattern, pattern)
+	}
+
+	fromClause := "FROM general_ledgers JOIN journals ON journals.id = general_ledgers.journal_id"
+	query := fmt.Sprintf(`
+		SELECT
+			general_ledgers.id, general_ledgers.journal_id, general_ledgers.account_id, general_ledgers.amount,
+			general_ledgers.created_by, general_ledgers.memo, general_ledgers.external_reference,
+			general_ledgers.counterparty_company_id
+		%s %s
+		ORDER BY journals.trans_date DESC, journals.number DESC
+		%s
+	`, fromClause, whereClause, limitClause)
+
+	args := append(whereClauseArgs, limitClauseArgs...)
+	if err = r.db.SelectContext(ctx, &gls, r.db.Rebind(query), args...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeSearchGeneralLedgersFailed, "Failed on search general ledgers")
+		return
+	}
+
+	query = fmt.Sprintf("SELECT COUNT(*) %s %s", fromClause, whereClause)
+	if err = r.db.GetContext(ctx, &paging.Total, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeSearchGeneralLedgersFailed, "Failed on count general ledgers")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllJournalNumberFormats(ctx context.Context) (formats []domain.JournalNumberFormat, err error) {
+	formats = make([]domain.JournalNumberFormat, 0)
+
+	query := "SELECT type_id, format FROM journal_number_formats WHERE company_id = ? ORDER BY type_id ASC"
+	if err = r.db.SelectContext(ctx, &formats, r.db.Rebind(query), companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalNumberFormatsFailed, "Failed on get journal number formats")
+		return
+	}
+
+	return
+}
+
+// attachmentsQuery joins attachments with their stored file so the where clause can filter on unqualified
+// attachment columns. Deleted attachments are never returned.
+const attachmentsQuery = `
+	SELECT id, file_id, journal_id, bank_transaction_id, file_name, content_type, size, sha256, storage_key, created_by, created_at
+	FROM (
+		SELECT
+			attachments.id, attachments.file_id, attachments.journal_id, attachments.bank_transaction_id,
+			attachments.file_name, attachment_files.content_type, attachment_files.size, attachment_files.sha256,
+			attachment_files.storage_key, attachments.created_by, attachments.created_at, attachments.company_id
+		FROM attachments
+		INNER JOIN attachment_files ON attachment_files.id = attachments.file_id
+		WHERE attachments.deleted_at IS NULL
+	) AS attachments
+	%s
+`
+
+func (r *reader) GetAllAttachments(ctx context.Context, stmt AttachmentStatement) (attachments []domain.Attachment, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAttachmentsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(attachmentsQuery, whereClause+" ORDER BY created_at")
+	if err = r.db.SelectContext(ctx, &attachments, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAttachmentsFailed, "Failed on get all attachments")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAttachment(ctx context.Context, stmt AttachmentStatement) (attachment domain.Attachment, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAttachmentFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(attachmentsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &attachment, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Attachment not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAttachmentFailed, "Failed on get attachment")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error) {
+	return r.GetAttachment(ctx, AttachmentStatement{ID: id})
+}
+
+// GetDownloadableAttachmentByID finds an attachment of any company. It serves the signed download links only,
+// which carry no company and are verified before the lookup.
+func (r *reader) GetDownloadableAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error) {
+	query := fmt.Sprintf(attachmentsQuery, "WHERE id = ?")
+	if err = r.db.GetContext(ctx, &attachment, r.db.Rebind(query), id); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Attachment not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAttachmentFailed, "Failed on get attachment")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAttachmentFileBySHA256(ctx context.Context, sha256 string) (file domain.AttachmentFile, err error) {
+	query := "SELECT id, sha256, content_type, size, storage_key, created_at FROM attachment_files WHERE sha256 = ?"
+	if err = r.db.GetContext(ctx, &file, r.db.Rebind(query), sha256); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Attachment file not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAttachmentFailed, "Failed on get attachment file")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetJournalNumberFormat(ctx context.Context, stmt JournalNumberFormatStatement) (format domain.JournalNumberFormat, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalNumberFormatsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT type_id, format FROM journal_number_formats %s", whereClause)
+	if err = r.db.GetContext(ctx, &format, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Journal number format not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalNumberFormatsFailed, "Failed on get journal number format")
+		return
+	}
+
+	return
+}
+
 func (r *reader) GetBankAccountBalanceByID(ctx context.Context, id int64) (balance float64, err error) {
 	var bankTransaction domain.BankTransaction
 
@@ -180,14 +561,20 @@ func (r *reader) GetJournalByID(ctx context.Context, id uuid.UUID) (journal doma
 }
 
 func (r *reader) GetJournal(ctx context.Context, stmt JournalStatement) (journal domain.Journal, err error) {
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetJournalFailed, "Failed on get journal")
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, amount, created_at, trans_date, memo FROM journal %s", whereClause)
+	query := fmt.Sprintf("SELECT id, type_id, number, amount, created_at, trans_date, memo, closing, opening FROM journals %s", whereClause)
 	if err = r.db.GetContext(ctx, &journal, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Journal not found")
+			return
+		}
+
 		err = errors.PropagateWithCode(err, EcodeGetJournalFailed, "Failed on get journal")
 		return
 	}
@@ -222,7 +609,7 @@ func (r *reader) GetGeneralLedgerByAccountID(ctx context.Context, id int64, p qb
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, journal_id, account_id, amount, created_by FROM general_ledgers %s %s", whereClause, limitClause)
+	query := fmt.Sprintf("SELECT id, journal_id, account_id, amount, created_by, memo, external_reference, counterparty_company_id FROM general_ledgers %s %s", whereClause, limitClause)
 	args := append(whereClauseArgs, limitClauseArgs...)
 	if err = r.db.SelectContext(ctx, &gls, r.db.Rebind(query), args...); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerFailed, "Failed on get general ledger")
@@ -263,6 +650,7 @@ func (r *reader) GetBankAccountList(ctx context.Context, stmt BankAccountStateme
 	paging = p
 	paging.Normalize()
 
+	stmt.CompanyID = companyID(ctx)
 	fromClause := "FROM bank_accounts"
 	limitClause, limitClauseArgs := paging.BuildQuery()
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
@@ -287,6 +675,7 @@ func (r *reader) GetBankAccountList(ctx context.Context, stmt BankAccountStateme
 }
 
 func (r *reader) GetBankAccount(ctx context.Context, stmt BankAccountStatement) (bankAccount domain.BankAccount, err error) {
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetBankAccountFailed, "Failed on get bank account")
@@ -308,6 +697,7 @@ func (r *reader) GetBankAccount(ctx context.Context, stmt BankAccountStatement)
 }
 
 func (r *reader) GetGeneralLedgerPreferenceByID(ctx context.Context, stmt GeneralLedgerPreferenceStatement) (preference domain.GeneralLedgerPreference, err error) {
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
@@ -324,20 +714,21 @@ func (r *reader) GetGeneralLedgerPreferenceByID(ctx context.Context, stmt Genera
 }
 
 func (r *reader) GetFiscalYear(ctx context.Context, statement FiscalYearStatement) (fiscalYear domain.FiscalYear, err error) {
+	statement.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(statement)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			err = errors.PropagateWithCode(err, EcodeNotFound, "Failed on get fiscal year")
-			return
-		}
-
 		err = errors.PropagateWithCode(err, EcodeGetFiscalYearFailed, "Failed on get fiscal year")
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, start_date, end_date, closed FROM fiscal_years %s ORDER BY id ASC", whereClause)
+	query := fmt.Sprintf("SELECT id, start_date, end_date, closed FROM fiscal_years %s ORDER BY id ASC LIMIT 1", whereClause)
 
 	if err = r.db.GetContext(ctx, &fiscalYear, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Failed on get fiscal year")
+			return
+		}
+
 		err = errors.PropagateWithCode(err, EcodeGetFiscalYearFailed, "Failed on get fiscal year")
 		return
 	}
@@ -349,12 +740,12 @@ func (r *reader) GetActiveFiscalYear(ctx context.Context) (fiscalYear domain.Fis
 	query := `
 		SELECT id, start_date, end_date, closed
 		FROM fiscal_years
-		WHERE closed = FALSE
+		WHERE closed = FALSE AND company_id = ?
 		ORDER BY id ASC, start_date ASC, end_date ASC
 		LIMIT 1
 	`
 
-	if err = r.db.GetContext(ctx, &fiscalYear, r.db.Rebind(query)); err != nil {
+	if err = r.db.GetContext(ctx, &fiscalYear, r.db.Rebind(query), companyID(ctx)); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetActiveFiscalYearFailed, "Failed on get active fiscal year")
 		return
 	}
@@ -362,135 +753,407 @@ func (r *reader) GetActiveFiscalYear(ctx context.Context) (fiscalYear domain.Fis
 	return
 }
 
-func (r *reader) GetFiscalYearList(ctx context.Context, stmt FiscalYearStatement, p qb.Paging) (result []domain.FiscalYear, paging qb.Paging, err error) {
-	result = make([]domain.FiscalYear, 0)
-	paging = p
-	paging.Normalize()
+// companyFiscalPeriods stands in for the fiscal_periods table, narrowed to the periods of the fiscal years
+// of one company, so that the where clauses built from a statement still apply to its columns.
+const companyFiscalPeriods = `(
+	SELECT fp.*
+	FROM fiscal_periods fp
+	INNER JOIN fiscal_years fy ON fy.id = fp.fiscal_year_id
+	WHERE fy.company_id = ?
+) AS fiscal_periods`
+
+func (r *reader) GetAllFiscalPeriods(ctx context.Context, stmt FiscalPeriodStatement) (periods []domain.FiscalPeriod, err error) {
+	periods = make([]domain.FiscalPeriod, 0)
 
-	fromClause := "FROM fiscal_years"
-	limitClause, limitClauseArgs := paging.BuildQuery()
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select fiscal year")
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalPeriodsFailed, "Failed on build where clause")
 		return
 	}
 
-	selectQuery := fmt.Sprintf("SELECT id, start_date, end_date, closed %s %s %s", fromClause, whereClause, limitClause)
-	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", fromClause, whereClause)
-
-	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(selectQuery), append(whereClauseArgs, limitClauseArgs...)...); err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select fiscal year")
-		return
-	}
+	query := fmt.Sprintf(`
+		SELECT id, fiscal_year_id, start_date, end_date, status_id
+		FROM %s
+		%s
+		ORDER BY start_date ASC
+	`, companyFiscalPeriods, whereClause)
 
-	if err = r.db.GetContext(ctx, &paging.Total, r.db.Rebind(countQuery), whereClauseArgs...); err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select count fiscal year")
+	args := append([]interface{}{companyID(ctx)}, whereClauseArgs...)
+	if err = r.db.SelectContext(ctx, &periods, r.db.Rebind(query), args...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalPeriodsFailed, "Failed on get fiscal periods")
 		return
 	}
 
 	return
 }
 
-func (r *reader) GetAllGeneralLedgerPreferences(ctx context.Context, stmt GeneralLedgerPreferenceStatement) (preferences []domain.GeneralLedgerPreference, err error) {
-	preferences = make([]domain.GeneralLedgerPreference, 0)
-	fromClause := "FROM general_ledger_preferences"
+func (r *reader) GetFiscalPeriod(ctx context.Context, stmt FiscalPeriodStatement) (period domain.FiscalPeriod, err error) {
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAllGeneralLedgerPreferencesFailed, "Failed on get all general ledger preferences")
+		err = errors.PropagateWithCode(err, EcodeGetFiscalPeriodFailed, "Failed on build where clause")
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, account_id %s %s", fromClause, whereClause)
-	if err = r.db.SelectContext(ctx, &preferences, r.db.Rebind(query), whereClauseArgs...); err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAllGeneralLedgerPreferencesFailed, "Failed on get all general ledger preferences")
+	query := fmt.Sprintf("SELECT id, fiscal_year_id, start_date, end_date, status_id FROM %s %s", companyFiscalPeriods, whereClause)
+	args := append([]interface{}{companyID(ctx)}, whereClauseArgs...)
+	if err = r.db.GetContext(ctx, &period, r.db.Rebind(query), args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Fiscal period not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetFiscalPeriodFailed, "Failed on get fiscal period")
 		return
 	}
 
 	return
 }
 
-func (r *reader) GetAllAccounts(ctx context.Context, stmt AccountStatement) (result []domain.Account, err error) {
-	result = make([]domain.Account, 0)
-	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAllAccountsFailed, "Failed on get all accounts")
+func (r *reader) GetFiscalPeriodByID(ctx context.Context, id int64) (period domain.FiscalPeriod, err error) {
+	return r.GetFiscalPeriod(ctx, FiscalPeriodStatement{ID: id})
+}
+
+func (r *reader) GetFiscalPeriodByDate(ctx context.Context, date time.Time) (period domain.FiscalPeriod, err error) {
+	query := `
+		SELECT fp.id, fp.fiscal_year_id, fp.start_date, fp.end_date, fp.status_id
+		FROM fiscal_periods fp
+		INNER JOIN fiscal_years fy ON fy.id = fp.fiscal_year_id
+		WHERE fy.company_id = ? AND DATE(fp.start_date) <= DATE(?) AND DATE(fp.end_date) >= DATE(?)
+		ORDER BY fp.start_date ASC
+		LIMIT 1
+	`
+
+	if err = r.db.GetContext(ctx, &period, r.db.Rebind(query), companyID(ctx), date, date); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Fiscal period not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetFiscalPeriodFailed, "Failed on get fiscal period")
 		return
 	}
 
-	query := "SELECT accounts.id, accounts.name, accounts.group_id, accounts.inactive FROM accounts"
-	if stmt.ClassType > 0 || stmt.AccountClassID > 0 {
-		query += ", account_groups, account_classes"
-		query += " WHERE accounts.group_id = account_groups.id AND account_groups.class_id = account_classes.id"
-	}
+	return
+}
 
-	if stmt.ClassType > 0 {
-		query += " AND account_classes.type_id = ? AND"
-		whereClauseArgs = append(whereClauseArgs, stmt.ClassType)
-	}
+func (r *reader) GetAllFiscalPeriodHistoriesByPeriodID(ctx context.Context, periodID int64) (histories []domain.FiscalPeriodHistory, err error) {
+	histories = make([]domain.FiscalPeriodHistory, 0)
 
-	if stmt.AccountClassID > 0 {
-		query += " AND account_classes.id = ? AND"
-		whereClauseArgs = append(whereClauseArgs, stmt.AccountClassID)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(FiscalPeriodHistoryStatement{FiscalPeriodID: periodID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalPeriodHistoriesFailed, "Failed on build where clause")
+		return
 	}
 
-	if strings.HasSuffix(query, "AND") && whereClause == "" {
-		query = query[:len(query)-4]
-	} else {
-		query += " " + whereClause
-	}
+	query := fmt.Sprintf(`
+		SELECT id, fiscal_period_id, from_status_id, to_status_id, reason, created_by, created_at
+		FROM fiscal_period_histories
+		%s
+		ORDER BY id ASC
+	`, whereClause)
 
-	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(query), whereClauseArgs...); err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAllAccountGroupsFailed, "Failed on get all accounts")
+	if err = r.db.SelectContext(ctx, &histories, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalPeriodHistoriesFailed, "Failed on get fiscal period histories")
 		return
 	}
 
 	return
 }
 
-func (r *reader) GetAccount(ctx context.Context, stmt AccountStatement) (account domain.Account, err error) {
+// GetFiscalYearHistory returns the latest fiscal year history matching stmt.
+func (r *reader) GetFiscalYearHistory(ctx context.Context, stmt FiscalYearHistoryStatement) (history domain.FiscalYearHistory, err error) {
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAccountFailed, "Failed on get account failed")
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearHistoryFailed, "Failed on build where clause")
 		return
 	}
 
-	query := fmt.Sprintf("SELECT id, name, group_id, inactive FROM accounts %s", whereClause)
-	if err = r.db.GetContext(ctx, &account, r.db.Rebind(query), whereClauseArgs...); err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetAccountFailed, "Failed on get account failed")
+	query := fmt.Sprintf(`
+		SELECT id, fiscal_year_id, action_id, journal_id, reason, created_by, created_at
+		FROM fiscal_year_histories
+		%s
+		ORDER BY id DESC
+		LIMIT 1
+	`, whereClause)
+
+	if err = r.db.GetContext(ctx, &history, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Fiscal year history not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearHistoryFailed, "Failed on get fiscal year history")
 		return
 	}
 
 	return
 }
 
-func (r *reader) GetAccountByID(ctx context.Context, id int64) (account domain.Account, err error) {
-	return r.GetAccount(ctx, AccountStatement{ID: id})
-}
+func (r *reader) GetAllFiscalYearHistoriesByFiscalYearID(ctx context.Context, fiscalYearID int64) (histories []domain.FiscalYearHistory, err error) {
+	histories = make([]domain.FiscalYearHistory, 0)
 
-func (r *reader) GetAllAccountTypes(ctx context.Context) (result []domain.AccountClassType) {
-	result = make([]domain.AccountClassType, len(classTypes))
-	for id, classType := range classTypes {
-		result[id-1] = classType
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(FiscalYearHistoryStatement{FiscalYearID: fiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalYearHistoriesFailed, "Failed on build where clause")
+		return
 	}
 
-	return
-}
+	query := fmt.Sprintf(`
+		SELECT id, fiscal_year_id, action_id, journal_id, reason, created_by, created_at
+		FROM fiscal_year_histories
+		%s
+		ORDER BY id ASC
+	`, whereClause)
 
-func (r *reader) GetAccountClassTypeByID(ctx context.Context, id int64) (accountClassType domain.AccountClassType) {
+	if err = r.db.SelectContext(ctx, &histories, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFiscalYearHistoriesFailed, "Failed on get fiscal year histories")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetFiscalYearList(ctx context.Context, stmt FiscalYearStatement, p qb.Paging) (result []domain.FiscalYear, paging qb.Paging, err error) {
+	result = make([]domain.FiscalYear, 0)
+	paging = p
+	paging.Normalize()
+
+	stmt.CompanyID = companyID(ctx)
+	fromClause := "FROM fiscal_years"
+	limitClause, limitClauseArgs := paging.BuildQuery()
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select fiscal year")
+		return
+	}
+
+	selectQuery := fmt.Sprintf("SELECT id, start_date, end_date, closed %s %s %s", fromClause, whereClause, limitClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", fromClause, whereClause)
+
+	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(selectQuery), append(whereClauseArgs, limitClauseArgs...)...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select fiscal year")
+		return
+	}
+
+	if err = r.db.GetContext(ctx, &paging.Total, r.db.Rebind(countQuery), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearListFailed, "Failed on select count fiscal year")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllGeneralLedgerPreferences(ctx context.Context, stmt GeneralLedgerPreferenceStatement) (preferences []domain.GeneralLedgerPreference, err error) {
+	preferences = make([]domain.GeneralLedgerPreference, 0)
+	stmt.CompanyID = companyID(ctx)
+	fromClause := "FROM general_ledger_preferences"
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllGeneralLedgerPreferencesFailed, "Failed on get all general ledger preferences")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, account_id %s %s", fromClause, whereClause)
+	if err = r.db.SelectContext(ctx, &preferences, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllGeneralLedgerPreferencesFailed, "Failed on get all general ledger preferences")
+		return
+	}
+
+	return
+}
+
+// accountsQuery exposes the class of each account so AccountStatement can filter on it with unqualified columns.
+const accountsQuery = `
+	SELECT id, code, name, group_id, inactive, group_account_id, normal_balance
+	FROM (
+		SELECT
+			accounts.id, accounts.code, accounts.name, accounts.group_id, accounts.inactive,
+			accounts.group_account_id, accounts.normal_balance, accounts.company_id,
+			account_classes.id AS account_class_id, account_classes.type_id AS class_type
+		FROM accounts
+		INNER JOIN account_groups ON account_groups.id = accounts.group_id
+		INNER JOIN account_classes ON account_classes.id = account_groups.class_id
+	) AS accounts
+	%s
+`
+
+func (r *reader) GetAllAccounts(ctx context.Context, stmt AccountStatement) (result []domain.Account, err error) {
+	result = make([]domain.Account, 0)
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAccountsFailed, "Failed on get all accounts")
+		return
+	}
+
+	query := fmt.Sprintf(accountsQuery, whereClause+" ORDER BY code NULLS LAST, id")
+	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAccountGroupsFailed, "Failed on get all accounts")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAccount(ctx context.Context, stmt AccountStatement) (account domain.Account, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAccountFailed, "Failed on get account failed")
+		return
+	}
+
+	query := fmt.Sprintf(accountsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &account, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Account not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAccountFailed, "Failed on get account failed")
+		return
+	}
+
+	return
+}
+
+// SearchAccounts matches accounts by code prefix or name substring. Results are ranked exact code first, then code
+// prefix, name prefix and name substring, each ordered by code.
+func (r *reader) SearchAccounts(ctx context.Context, query string, includeInactive bool, limit int) (accounts []domain.Account, err error) {
+	accounts = make([]domain.Account, 0)
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+
+	pattern := escapeLike(query)
+	selectQuery := `
+		SELECT id, code, name, group_id, inactive, group_account_id, normal_balance
+		FROM (
+			SELECT
+				id, code, name, group_id, inactive, group_account_id, normal_balance,
+				CASE
+					WHEN lower(code) = lower(?) THEN 0
+					WHEN code ILIKE ? THEN 1
+					WHEN name ILIKE ? THEN 2
+					WHEN name ILIKE ? THEN 3
+				END AS rank
+			FROM accounts
+			WHERE company_id = ? AND (inactive = FALSE OR ?)
+		) AS accounts
+		WHERE rank IS NOT NULL
+		ORDER BY rank, code NULLS LAST, name
+		LIMIT ?
+	`
+
+	err = r.db.SelectContext(
+		ctx,
+		&accounts,
+		r.db.Rebind(selectQuery),
+		query, pattern+"%", pattern+"%", "%"+pattern+"%", companyID(ctx), includeInactive, limit,
+	)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeSearchAccountsFailed, "Failed on search accounts")
+		return
+	}
+
+	return
+}
+
+// ValidateAccountCode checks a code against the format configured for the class type of the group.
+func (r *reader) ValidateAccountCode(ctx context.Context, groupID int64, code string) (err error) {
+	var pattern goSql.NullString
+
+	query := `
+		SELECT account_code_formats.pattern
+		FROM account_groups
+		INNER JOIN account_classes ON account_classes.id = account_groups.class_id
+		LEFT JOIN account_code_formats ON account_code_formats.class_type_id = account_classes.type_id
+		WHERE account_groups.id = ? AND account_groups.company_id = ?
+	`
+
+	if err = r.db.GetContext(ctx, &pattern, r.db.Rebind(query), groupID, companyID(ctx)); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Account group not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAllAccountCodeFormatsFailed, "Failed on get account code format")
+		return
+	}
+
+	if matchErr := matchAccountCode(pattern.String, code); matchErr != nil {
+		err = errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "code", Message: matchErr.Error()}},
+			EcodeAccountCodeInvalid,
+			matchErr.Error(),
+		)
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllAccountCodeFormats(ctx context.Context) (formats []domain.AccountCodeFormat, err error) {
+	formats = make([]domain.AccountCodeFormat, 0)
+
+	query := "SELECT class_type_id, pattern FROM account_code_formats ORDER BY class_type_id"
+	if err = r.db.SelectContext(ctx, &formats, query); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAccountCodeFormatsFailed, "Failed on get all account code formats")
+		return
+	}
+
+	return
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search term.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *reader) GetAccountByID(ctx context.Context, id int64) (account domain.Account, err error) {
+	return r.GetAccount(ctx, AccountStatement{ID: id})
+}
+
+func (r *reader) GetAllAccountTypes(ctx context.Context) (result []domain.AccountClassType) {
+	result = make([]domain.AccountClassType, len(classTypes))
+	for id, classType := range classTypes {
+		result[id-1] = classType
+	}
+
+	return
+}
+
+func (r *reader) GetAccountClassTypeByID(ctx context.Context, id int64) (accountClassType domain.AccountClassType) {
 	return classTypes[id]
 }
 
+func (r *reader) GetAllGeneralLedgerPreferenceDefinitions(ctx context.Context) (result []domain.GeneralLedgerPreferenceDefinition) {
+	result = make([]domain.GeneralLedgerPreferenceDefinition, len(generalLedgerPreferences))
+	copy(result, generalLedgerPreferences)
+
+	return
+}
+
+func (r *reader) GetGeneralLedgerPreferenceDefinitionByID(ctx context.Context, id int64) (definition domain.GeneralLedgerPreferenceDefinition) {
+	definition, _ = getGeneralLedgerPreferenceDefinition(id)
+	return
+}
+
 func (r *reader) GetAllAccountGroups(ctx context.Context, stmt AccountGroupStatement) (result []domain.AccountGroup, err error) {
 	result = make([]domain.AccountGroup, 0)
 	fromClause := "FROM account_groups"
 
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAllAccountGroupsFailed, "Failed on build where clause")
 		return
 	}
 
-	selectQuery := fmt.Sprintf("SELECT id, parent_id, class_id, name, inactive %s %s", fromClause, whereClause)
+	selectQuery := fmt.Sprintf("SELECT id, code, parent_id, class_id, name, inactive %s %s ORDER BY code NULLS LAST, id", fromClause, whereClause)
 	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(selectQuery), whereClauseArgs...); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAllTopLevelAccountGroupFailed, "Failed on select all account group")
 		return
@@ -500,6 +1163,7 @@ func (r *reader) GetAllAccountGroups(ctx context.Context, stmt AccountGroupState
 }
 
 func (r *reader) GetAccountGroup(ctx context.Context, stmt AccountGroupStatement) (accountGroup domain.AccountGroup, err error) {
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountGroupFailed, "Failed on build where clause")
@@ -507,7 +1171,7 @@ func (r *reader) GetAccountGroup(ctx context.Context, stmt AccountGroupStatement
 	}
 
 	selectQuery := fmt.Sprintf(`
-		SELECT id, parent_id, class_id, name, inactive
+		SELECT id, code, parent_id, class_id, name, inactive
 		FROM account_groups
 		%s
 	`, whereClause)
@@ -529,6 +1193,7 @@ func (r *reader) GetAccountClassByID(ctx context.Context, id int64) (accountClas
 }
 
 func (r *reader) GetAccountClass(ctx context.Context, stmt AccountClassStatement) (accountClass domain.AccountClass, err error) {
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountClassFailed, "Failed on build where clause")
@@ -536,7 +1201,7 @@ func (r *reader) GetAccountClass(ctx context.Context, stmt AccountClassStatement
 	}
 
 	selectQuery := fmt.Sprintf(`
-		SELECT id, name, type_id, inactive
+		SELECT id, code, name, type_id, inactive
 		FROM account_classes
 		%s
 	`, whereClause)
@@ -552,13 +1217,14 @@ func (r *reader) GetAccountClass(ctx context.Context, stmt AccountClassStatement
 func (r *reader) GetAllAccountClasses(ctx context.Context, stmt AccountClassStatement) (result []domain.AccountClass, err error) {
 	result = make([]domain.AccountClass, 0)
 	fromClause := "FROM account_classes"
+	stmt.CompanyID = companyID(ctx)
 	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountClassListFailed, "Failed on build where clause")
 		return
 	}
 
-	selectQuery := fmt.Sprintf("SELECT id, name, type_id, inactive %s %s", fromClause, whereClause)
+	selectQuery := fmt.Sprintf("SELECT id, code, name, type_id, inactive %s %s ORDER BY code NULLS LAST, id", fromClause, whereClause)
 
 	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(selectQuery), whereClauseArgs...); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetAccountClassListFailed, "Failed on select account class")
@@ -568,34 +1234,262 @@ func (r *reader) GetAllAccountClasses(ctx context.Context, stmt AccountClassStat
 	return
 }
 
+// GetChartOfAccounts returns the whole class, group and account tree ordered by code, then by creation.
+func (r *reader) GetChartOfAccounts(ctx context.Context) (chart domain.ChartOfAccounts, err error) {
+	tree, err := r.GetAccountTree(ctx, false)
+	if err != nil {
+		return
+	}
+
+	var buildGroups func(groups []domain.AccountTreeGroup) []domain.ChartOfAccountsGroup
+	buildGroups = func(groups []domain.AccountTreeGroup) (result []domain.ChartOfAccountsGroup) {
+		for _, group := range groups {
+			var accounts []domain.ChartOfAccountsAccount
+			for _, account := range group.Accounts {
+				accounts = append(accounts, domain.ChartOfAccountsAccount{
+					Code:     account.Code.String,
+					Name:     account.Name,
+					Inactive: account.Inactive,
+				})
+			}
+
+			result = append(result, domain.ChartOfAccountsGroup{
+				Code:     group.Code.String,
+				Name:     group.Name,
+				Inactive: group.Inactive,
+				Groups:   buildGroups(group.Groups),
+				Accounts: accounts,
+			})
+		}
+
+		return
+	}
+
+	chart.Classes = make([]domain.ChartOfAccountsClass, len(tree))
+	for i, class := range tree {
+		chart.Classes[i] = domain.ChartOfAccountsClass{
+			Code:     class.Code.String,
+			Name:     class.Name,
+			TypeID:   class.TypeID,
+			Inactive: class.Inactive,
+			Groups:   buildGroups(class.Groups),
+		}
+	}
+
+	return
+}
+
+type accountTreeRow struct {
+	Kind     string
+	ID       int64
+	ParentID goSql.NullInt64 `db:"parent_id"`
+	ClassID  int64           `db:"class_id"`
+	TypeID   int64           `db:"type_id"`
+	Code     goSql.NullString
+	Name     string
+	Inactive bool
+	Balance  goSql.NullFloat64
+	Depth    int
+	// NormalBalance is the override of an account row, null for the other rows.
+	NormalBalance goSql.NullString `db:"normal_balance"`
+}
+
+// accountTreeQuery walks the account groups from the top level down in a single recursive CTE.
+// The path array stops the walk on a group that was already visited, so a corrupted parent
+// chain can not loop forever, and doubles as the ancestor list used to roll balances up.
+// Balances are only summed when $1 is true, classes, groups, accounts and journals are those of the company $2.
+const accountTreeQuery = `
+	WITH RECURSIVE group_tree AS (
+		SELECT id, class_id, ARRAY[id] AS path
+		FROM account_groups
+		WHERE parent_id IS NULL AND company_id = $2
+		UNION ALL
+		SELECT g.id, g.class_id, t.path || g.id
+		FROM account_groups g
+		JOIN group_tree t ON g.parent_id = t.id
+		WHERE NOT g.id = ANY(t.path)
+	),
+	account_balances AS (
+		SELECT gl.account_id, SUM(gl.amount) AS balance
+		FROM general_ledgers gl
+		JOIN journals j ON j.id = gl.journal_id
+		WHERE $1::boolean AND j.deleted_at IS NULL AND j.company_id = $2
+		GROUP BY gl.account_id
+	),
+	group_balances AS (
+		SELECT ancestor.id, SUM(ab.balance) AS balance
+		FROM group_tree t
+		CROSS JOIN LATERAL unnest(t.path) AS ancestor(id)
+		JOIN accounts a ON a.group_id = t.id
+		JOIN account_balances ab ON ab.account_id = a.id
+		GROUP BY ancestor.id
+	),
+	class_balances AS (
+		SELECT t.class_id, SUM(ab.balance) AS balance
+		FROM group_tree t
+		JOIN accounts a ON a.group_id = t.id
+		JOIN account_balances ab ON ab.account_id = a.id
+		GROUP BY t.class_id
+	)
+	SELECT
+		'class' AS kind, c.id, NULL::bigint AS parent_id, c.id AS class_id, c.type_id, c.code, c.name, c.inactive,
+		CASE WHEN $1::boolean THEN COALESCE(cb.balance, 0) END AS balance, 0 AS depth,
+		NULL::varchar AS normal_balance
+	FROM account_classes c
+	LEFT JOIN class_balances cb ON cb.class_id = c.id
+	WHERE c.company_id = $2
+	UNION ALL
+	SELECT
+		'group', g.id, g.parent_id, t.class_id, 0, g.code, g.name, g.inactive,
+		CASE WHEN $1::boolean THEN COALESCE(gb.balance, 0) END, array_length(t.path, 1), NULL
+	FROM group_tree t
+	JOIN account_groups g ON g.id = t.id
+	LEFT JOIN group_balances gb ON gb.id = t.id
+	UNION ALL
+	SELECT
+		'account', a.id, a.group_id, t.class_id, 0, a.code, a.name, a.inactive,
+		CASE WHEN $1::boolean THEN COALESCE(ab.balance, 0) END, array_length(t.path, 1) + 1, a.normal_balance
+	FROM accounts a
+	JOIN group_tree t ON t.id = a.group_id
+	LEFT JOIN account_balances ab ON ab.account_id = a.id
+	WHERE a.company_id = $2
+	ORDER BY depth, code NULLS LAST, id
+`
+
+// GetAccountTree returns every class with its nested groups and accounts. When withBalances is set,
+// each node carries the sum of the general ledger amounts of all accounts below it. Groups take the
+// normal balance of their class and accounts their own override, if any.
+func (r *reader) GetAccountTree(ctx context.Context, withBalances bool) (classes []domain.AccountTreeClass, err error) {
+	classes = make([]domain.AccountTreeClass, 0)
+
+	var rows []accountTreeRow
+	if err = r.db.SelectContext(ctx, &rows, accountTreeQuery, withBalances, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAccountTreeFailed, "Failed on get account tree")
+		return
+	}
+
+	classTypeIDs := make(map[int64]int64)
+	groupsByClass := make(map[int64][]accountTreeRow)
+	groupsByParent := make(map[int64][]accountTreeRow)
+	accountsByGroup := make(map[int64][]domain.AccountTreeAccount)
+	for _, row := range rows {
+		switch {
+		case row.Kind == "class":
+			classTypeIDs[row.ID] = row.TypeID
+		case row.Kind == "group" && row.ParentID.Valid:
+			groupsByParent[row.ParentID.Int64] = append(groupsByParent[row.ParentID.Int64], row)
+		case row.Kind == "group":
+			groupsByClass[row.ClassID] = append(groupsByClass[row.ClassID], row)
+		case row.Kind == "account":
+			accountsByGroup[row.ParentID.Int64] = append(accountsByGroup[row.ParentID.Int64], domain.AccountTreeAccount{
+				ID:       row.ID,
+				Code:     row.Code,
+				Name:     row.Name,
+				Inactive: row.Inactive,
+				Balance:  row.Balance,
+				// Classes come first in the rows, so the type of the class is known here.
+				NormalBalance: AccountNormalBalance(classTypeIDs[row.ClassID], row.NormalBalance),
+			})
+		}
+	}
+
+	var buildGroups func(rows []accountTreeRow) []domain.AccountTreeGroup
+	buildGroups = func(rows []accountTreeRow) (result []domain.AccountTreeGroup) {
+		for _, row := range rows {
+			result = append(result, domain.AccountTreeGroup{
+				ID:       row.ID,
+				Code:     row.Code,
+				Name:     row.Name,
+				ParentID: row.ParentID,
+				Inactive: row.Inactive,
+				Balance:  row.Balance,
+				Groups:   buildGroups(groupsByParent[row.ID]),
+				Accounts: accountsByGroup[row.ID],
+
+				NormalBalance: ClassTypeNormalBalance(classTypeIDs[row.ClassID]),
+			})
+		}
+
+		return
+	}
+
+	for _, row := range rows {
+		if row.Kind != "class" {
+			continue
+		}
+
+		classes = append(classes, domain.AccountTreeClass{
+			ID:       row.ID,
+			Code:     row.Code,
+			Name:     row.Name,
+			TypeID:   row.TypeID,
+			Inactive: row.Inactive,
+			Balance:  row.Balance,
+			Groups:   buildGroups(groupsByClass[row.ID]),
+
+			NormalBalance: ClassTypeNormalBalance(row.TypeID),
+		})
+	}
+
+	return
+}
+
 func (r *reader) ValidatePreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error) {
 	var fieldErrors []errors.FieldError
 
 	query := `
-		SELECT account_classes.id, account_classes.name, account_classes.type_id, account_classes.inactive
+		SELECT account_classes.id, account_classes.code, account_classes.name, account_classes.type_id, account_classes.inactive
 		FROM account_classes, account_groups, accounts
 		WHERE 
 			accounts.group_id = account_groups.id AND account_groups.class_id = account_classes.id AND
-			accounts.id = ?
+			accounts.company_id = ? AND accounts.id = ?
 	`
 
 	for _, preference := range preferences {
 		var accountClass domain.AccountClass
 		field := fmt.Sprintf("%d", preference.ID)
 
-		if preference.AccountID.Valid && preference.AccountID.Int64 != 0 {
-			err = r.db.GetContext(ctx, &accountClass, query, preference.AccountID)
-			if err == sql.ErrNoRows {
-				fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Account not found"})
-			} else if err != nil {
-				fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: err.Error()})
-				continue
+		definition, ok := getGeneralLedgerPreferenceDefinition(preference.ID)
+		if !ok {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Preference not found"})
+			continue
+		}
+
+		if !preference.AccountID.Valid || preference.AccountID.Int64 == 0 {
+			if definition.Required {
+				fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: fmt.Sprintf("%s account is required", definition.Name)})
 			}
+
+			continue
 		}
 
-		if preference.ID == int64(RetainedEarnings) && !IsBalanceSheetAccount(accountClass.TypeID) {
-			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Account must be one of the balance sheet account"})
+		err = r.db.GetContext(ctx, &accountClass, r.db.Rebind(query), companyID(ctx), preference.AccountID)
+		if err == sql.ErrNoRows {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Account not found"})
 			continue
+		} else if err != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: err.Error()})
+			continue
+		}
+
+		allowed := false
+		for _, classTypeID := range definition.ClassTypeIDs {
+			if classTypeID == accountClass.TypeID {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			names := make([]string, len(definition.ClassTypeIDs))
+			for i, classTypeID := range definition.ClassTypeIDs {
+				names[i] = strings.ToLower(classTypes[classTypeID].Name)
+			}
+
+			fieldErrors = append(fieldErrors, errors.FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("Account must be one of the %s account", strings.Join(names, ", ")),
+			})
 		}
 	}
 
@@ -616,11 +1510,12 @@ func (r *reader) GetBalanceSheetAmount(ctx context.Context, startDate time.Time,
 			acc.group_id = accGrp.id AND
 			accGrp.class_id = accCls.id AND
 			accCls.type_id > 0 AND accCls.type_id <= ? AND
+			j.company_id = ? AND
 			j.trans_date >= ? AND
 			j.trans_date <= ?
 	`
 
-	if err = r.db.GetContext(ctx, &amount, r.db.Rebind(query), EquityClassType, startDate, endDate); err != nil {
+	if err = r.db.GetContext(ctx, &amount, r.db.Rebind(query), EquityClassType, companyID(ctx), startDate, endDate); err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetBalanceSheetAmountFailed, "Failed on get balance sheet amount")
 		return
 	}
@@ -628,6 +1523,2312 @@ func (r *reader) GetBalanceSheetAmount(ctx context.Context, startDate time.Time,
 	return
 }
 
-func NewReader(opt *Options) Reader {
-	return &reader{db: opt.SlaveDB}
+func (r *reader) GetClosingJournal(ctx context.Context, fiscalYearID int64) (closingJournal domain.ClosingJournal, err error) {
+	var lines []domain.ClosingJournalLine
+
+	fiscalYear, err := r.GetFiscalYear(ctx, FiscalYearStatement{ID: fiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
+		return
+	}
+
+	retainedEarningsGLP, err := r.GetGeneralLedgerPreferenceByID(ctx, GeneralLedgerPreferenceStatement{ID: int64(RetainedEarnings)})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
+		return
+	}
+
+	if !retainedEarningsGLP.AccountID.Valid || retainedEarningsGLP.AccountID.Int64 == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("retained earnings account not set"), EcodeRetainedEarningsNotSet, "Retained earnings account is not set")
+		return
+	}
+
+	if err = r.ValidatePreferences(ctx, []domain.GeneralLedgerPreference{retainedEarningsGLP}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetClosingJournalFailed, "Validate general ledger preferences failed")
+		return
+	}
+
+	// balance of every income, COGS and expense account within the fiscal year,
+	// previous closing journals excluded so a reopened year can be closed again.
+	query := `
+		SELECT gl.account_id, SUM(gl.amount) AS amount
+		FROM general_ledgers gl, journals j, accounts acc, account_groups accGrp, account_classes accCls
+		WHERE
+			gl.journal_id = j.id AND
+			gl.account_id = acc.id AND
+			acc.group_id = accGrp.id AND
+			accGrp.class_id = accCls.id AND
+			accCls.type_id > ? AND
+			j.company_id = ? AND
+			j.deleted_at IS NULL AND
+			j.closing = FALSE AND
+			DATE(j.trans_date) >= DATE(?) AND
+			DATE(j.trans_date) <= DATE(?)
+		GROUP BY gl.account_id
+		HAVING SUM(gl.amount) <> 0
+		ORDER BY gl.account_id ASC
+	`
+
+	err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), EquityClassType, companyID(ctx), fiscalYear.StartDate, fiscalYear.EndDate)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetClosingJournalFailed, "Failed on get profit and loss balances")
+		return
+	}
+
+	closingJournal = domain.ClosingJournal{
+		FiscalYearID: fiscalYear.ID,
+		TransDate:    fiscalYear.EndDate,
+		Lines:        make([]domain.ClosingJournalLine, 0, len(lines)+1),
+	}
+
+	for _, line := range lines {
+		closingJournal.NetIncome -= line.Amount
+		closingJournal.Lines = append(closingJournal.Lines, domain.ClosingJournalLine{
+			AccountID: line.AccountID,
+			Amount:    -line.Amount,
+		})
+	}
+
+	if len(closingJournal.Lines) > 0 {
+		closingJournal.Lines = append(closingJournal.Lines, domain.ClosingJournalLine{
+			AccountID: retainedEarningsGLP.AccountID.Int64,
+			Amount:    -closingJournal.NetIncome,
+		})
+	}
+
+	return
+}
+
+func (r *reader) GetJournalDraftList(ctx context.Context, stmt JournalDraftStatement, p qb.Paging) (result []domain.JournalDraft, paging qb.Paging, err error) {
+	result = make([]domain.JournalDraft, 0)
+	paging = p
+	paging.Normalize()
+
+	stmt.CompanyID = companyID(ctx)
+	fromClause := "FROM journal_drafts"
+	limitClause, limitClauseArgs := paging.BuildQuery()
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftListFailed, "Failed on get journal draft list")
+		return
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, amount, trans_date, memo, status_id, journal_id, created_by, created_at, submitted_at
+		%s %s
+		ORDER BY created_at DESC
+		%s
+	`, fromClause, whereClause, limitClause)
+
+	if err = r.db.SelectContext(ctx, &result, r.db.Rebind(selectQuery), append(whereClauseArgs, limitClauseArgs...)...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftListFailed, "Failed on get journal draft list")
+		return
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", fromClause, whereClause)
+	if err = r.db.GetContext(ctx, &paging.Total, r.db.Rebind(countQuery), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftListFailed, "Failed on count journal draft list")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetJournalDraft(ctx context.Context, stmt JournalDraftStatement) (draft domain.JournalDraft, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftFailed, "Failed on get journal draft")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, amount, trans_date, memo, status_id, journal_id, created_by, created_at, submitted_at
+		FROM journal_drafts
+		%s
+	`, whereClause)
+
+	if err = r.db.GetContext(ctx, &draft, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Journal draft not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftFailed, "Failed on get journal draft")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetJournalDraftByID(ctx context.Context, id uuid.UUID) (draft domain.JournalDraft, err error) {
+	return r.GetJournalDraft(ctx, JournalDraftStatement{ID: id})
+}
+
+func (r *reader) GetAllJournalDraftLinesByDraftID(ctx context.Context, draftID uuid.UUID) (lines []domain.JournalDraftLine, err error) {
+	lines = make([]domain.JournalDraftLine, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(JournalDraftLineStatement{DraftID: draftID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeBuildQueryFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, draft_id, account_id, amount, memo, external_reference FROM journal_draft_lines %s", whereClause)
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalDraftLinesFailed, "Failed on get journal draft lines")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllJournalDraftReviewsByDraftID(ctx context.Context, draftID uuid.UUID) (reviews []domain.JournalDraftReview, err error) {
+	reviews = make([]domain.JournalDraftReview, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(JournalDraftReviewStatement{DraftID: draftID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeBuildQueryFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, draft_id, user_id, approved, comment, created_at
+		FROM journal_draft_reviews
+		%s
+		ORDER BY id ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &reviews, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalDraftReviewsFailed, "Failed on get journal draft reviews")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllApprovalRules(ctx context.Context, stmt ApprovalRuleStatement) (rules []domain.ApprovalRule, err error) {
+	rules = make([]domain.ApprovalRule, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllApprovalRulesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, account_class_id, min_amount, required_approvals
+		FROM approval_rules
+		%s
+		ORDER BY min_amount ASC, id ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &rules, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllApprovalRulesFailed, "Failed on get approval rules")
+		return
+	}
+
+	return
+}
+
+// requiredApprovalsQuery picks the highest approval count among the rules matching a draft.
+const requiredApprovalsQuery = `
+	SELECT COALESCE(MAX(ar.required_approvals), 1)
+	FROM approval_rules ar, journal_drafts jd
+	WHERE
+		jd.id = ? AND
+		ar.company_id = jd.company_id AND
+		ar.min_amount <= jd.amount AND
+		(
+			ar.account_class_id IS NULL OR
+			ar.account_class_id IN (
+				SELECT accgroup.class_id
+				FROM journal_draft_lines jdl, accounts acc, account_groups accgroup
+				WHERE jdl.account_id = acc.id AND acc.group_id = accgroup.id AND jdl.draft_id = jd.id
+			)
+		)
+`
+
+// GetRequiredApprovalsByDraftID returns the highest approval count among the rules matching
+// the draft amount and the account classes touched by its lines. Drafts matching no rule need one approval.
+func (r *reader) GetRequiredApprovalsByDraftID(ctx context.Context, draftID uuid.UUID) (requiredApprovals int64, err error) {
+	if err = r.db.GetContext(ctx, &requiredApprovals, r.db.Rebind(requiredApprovalsQuery), draftID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetRequiredApprovalsFailed, "Failed on get required approvals")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllBudgets(ctx context.Context, stmt BudgetStatement) (budgets []domain.Budget, err error) {
+	budgets = make([]domain.Budget, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllBudgetsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, fiscal_year_id, name, description, created_by, created_at
+		FROM budgets
+		%s
+		ORDER BY fiscal_year_id DESC, id ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &budgets, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllBudgetsFailed, "Failed on get budgets")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetBudget(ctx context.Context, stmt BudgetStatement) (budget domain.Budget, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetBudgetFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, fiscal_year_id, name, description, created_by, created_at FROM budgets %s", whereClause)
+	if err = r.db.GetContext(ctx, &budget, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Budget not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetBudgetFailed, "Failed on get budget")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetBudgetByID(ctx context.Context, id int64) (budget domain.Budget, err error) {
+	return r.GetBudget(ctx, BudgetStatement{ID: id})
+}
+
+func (r *reader) GetAllBudgetLines(ctx context.Context, stmt BudgetLineStatement) (lines []domain.BudgetLine, err error) {
+	lines = make([]domain.BudgetLine, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllBudgetLinesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, budget_id, account_id, fiscal_period_id, dimension, amount
+		FROM budget_lines
+		%s
+		ORDER BY account_id ASC, fiscal_period_id ASC, dimension ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllBudgetLinesFailed, "Failed on get budget lines")
+		return
+	}
+
+	return
+}
+
+// GetAllActualsByFiscalPeriod returns the general ledger movement of every account per fiscal period of a fiscal year.
+// Opening and closing journals are left out, they are not activity of the period.
+func (r *reader) GetAllActualsByFiscalPeriod(ctx context.Context, fiscalYearID int64) (actuals []domain.BudgetLine, err error) {
+	actuals = make([]domain.BudgetLine, 0)
+
+	query := `
+		SELECT gl.account_id, fp.id AS fiscal_period_id, SUM(gl.amount) AS amount
+		FROM general_ledgers gl, journals j, fiscal_periods fp
+		WHERE
+			gl.journal_id = j.id AND
+			j.company_id = ? AND
+			j.deleted_at IS NULL AND
+			j.opening = FALSE AND
+			j.closing = FALSE AND
+			fp.fiscal_year_id = ? AND
+			DATE(j.trans_date) >= DATE(fp.start_date) AND
+			DATE(j.trans_date) <= DATE(fp.end_date)
+		GROUP BY gl.account_id, fp.id
+		HAVING SUM(gl.amount) <> 0
+		ORDER BY gl.account_id ASC, fp.id ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &actuals, r.db.Rebind(query), companyID(ctx), fiscalYearID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetBudgetVsActualFailed, "Failed on get actuals by fiscal period")
+		return
+	}
+
+	return
+}
+
+type accountAmount struct {
+	AccountID int64 `db:"account_id"`
+	Amount    float64
+}
+
+// GetBudgetVsActual sums a budget and the general ledger per account between two fiscal periods of the budget fiscal year,
+// both inclusive and defaulting to the first and the last period, and rolls them up to groups and classes.
+func (r *reader) GetBudgetVsActual(ctx context.Context, budgetID int64, fromPeriodID int64, toPeriodID int64) (report domain.BudgetVsActual, err error) {
+	budget, err := r.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get budget")
+		return
+	}
+
+	periods, err := r.GetAllFiscalPeriods(ctx, FiscalPeriodStatement{FiscalYearID: budget.FiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal periods")
+		return
+	}
+
+	if len(periods) == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal periods not generated"), EcodeGetBudgetVsActualFailed, "Fiscal periods of the budget fiscal year are not generated")
+		return
+	}
+
+	report = domain.BudgetVsActual{BudgetID: budget.ID, Rows: make([]domain.BudgetVsActualRow, 0)}
+	for _, period := range periods {
+		if period.ID == fromPeriodID || (fromPeriodID == 0 && report.StartDate.IsZero()) {
+			report.StartDate = period.StartDate
+		}
+
+		if period.ID == toPeriodID || toPeriodID == 0 {
+			report.EndDate = period.EndDate
+		}
+	}
+
+	if report.StartDate.IsZero() || report.EndDate.IsZero() || report.EndDate.Before(report.StartDate) {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid period range"), EcodeBudgetLineInvalid, "Periods must belong to the budget fiscal year and be in order")
+		return
+	}
+
+	var budgetAmounts, actualAmounts []accountAmount
+
+	budgetQuery := `
+		SELECT bl.account_id, SUM(bl.amount) AS amount
+		FROM budget_lines bl, fiscal_periods fp
+		WHERE
+			bl.fiscal_period_id = fp.id AND
+			bl.budget_id = ? AND
+			fp.start_date >= ? AND
+			fp.end_date <= ?
+		GROUP BY bl.account_id
+	`
+
+	if err = r.db.SelectContext(ctx, &budgetAmounts, r.db.Rebind(budgetQuery), budget.ID, report.StartDate, report.EndDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetBudgetVsActualFailed, "Failed on get budget amounts")
+		return
+	}
+
+	actualQuery := `
+		SELECT gl.account_id, SUM(gl.amount) AS amount
+		FROM general_ledgers gl, journals j
+		WHERE
+			gl.journal_id = j.id AND
+			j.company_id = ? AND
+			j.deleted_at IS NULL AND
+			j.opening = FALSE AND
+			j.closing = FALSE AND
+			DATE(j.trans_date) >= DATE(?) AND
+			DATE(j.trans_date) <= DATE(?)
+		GROUP BY gl.account_id
+	`
+
+	if err = r.db.SelectContext(ctx, &actualAmounts, r.db.Rebind(actualQuery), companyID(ctx), report.StartDate, report.EndDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetBudgetVsActualFailed, "Failed on get actual amounts")
+		return
+	}
+
+	budgets := make(map[int64]float64, len(budgetAmounts))
+	for _, amount := range budgetAmounts {
+		budgets[amount.AccountID] = amount.Amount
+	}
+
+	actuals := make(map[int64]float64, len(actualAmounts))
+	for _, amount := range actualAmounts {
+		actuals[amount.AccountID] = amount.Amount
+	}
+
+	tree, err := r.GetAccountTree(ctx, false)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get account tree")
+		return
+	}
+
+	// Each node is appended before its children and filled in once they are summed,
+	// then dropped again with its subtree when neither side has an amount.
+	var addGroups func(groups []domain.AccountTreeGroup, depth int) (budget float64, actual float64)
+	addGroups = func(groups []domain.AccountTreeGroup, depth int) (budget float64, actual float64) {
+		for _, group := range groups {
+			i := len(report.Rows)
+			report.Rows = append(report.Rows, domain.BudgetVsActualRow{
+				Kind:          domain.ChartOfAccountsGroupKind,
+				ID:            group.ID,
+				Code:          group.Code,
+				Name:          group.Name,
+				Depth:         depth,
+				NormalBalance: group.NormalBalance,
+			})
+
+			groupBudget, groupActual := addGroups(group.Groups, depth+1)
+			for _, account := range group.Accounts {
+				if budgets[account.ID] == 0 && actuals[account.ID] == 0 {
+					continue
+				}
+
+				report.Rows = append(report.Rows, newBudgetVsActualRow(domain.BudgetVsActualRow{
+					Kind:   domain.ChartOfAccountsAccountKind,
+					ID:     account.ID,
+					Code:   account.Code,
+					Name:   account.Name,
+					Depth:  depth + 1,
+					Budget: budgets[account.ID],
+					Actual: actuals[account.ID],
+
+					NormalBalance: account.NormalBalance,
+				}))
+
+				groupBudget += budgets[account.ID]
+				groupActual += actuals[account.ID]
+			}
+
+			if len(report.Rows) == i+1 {
+				report.Rows = report.Rows[:i]
+				continue
+			}
+
+			report.Rows[i].Budget, report.Rows[i].Actual = groupBudget, groupActual
+			report.Rows[i] = newBudgetVsActualRow(report.Rows[i])
+			budget += groupBudget
+			actual += groupActual
+		}
+
+		return
+	}
+
+	for _, class := range tree {
+		i := len(report.Rows)
+		report.Rows = append(report.Rows, domain.BudgetVsActualRow{
+			Kind:          domain.ChartOfAccountsClassKind,
+			ID:            class.ID,
+			Code:          class.Code,
+			Name:          class.Name,
+			NormalBalance: class.NormalBalance,
+		})
+
+		classBudget, classActual := addGroups(class.Groups, 1)
+		if len(report.Rows) == i+1 {
+			report.Rows = report.Rows[:i]
+			continue
+		}
+
+		report.Rows[i].Budget, report.Rows[i].Actual = classBudget, classActual
+		report.Rows[i] = newBudgetVsActualRow(report.Rows[i])
+	}
+
+	return
+}
+
+func newBudgetVsActualRow(row domain.BudgetVsActualRow) domain.BudgetVsActualRow {
+	row.Variance = row.Actual - row.Budget
+	if row.Budget != 0 {
+		row.VariancePercent = goSql.NullFloat64{Float64: row.Variance / math.Abs(row.Budget) * 100, Valid: true}
+	}
+
+	return row
+}
+
+// amortizationSchedulesQuery adds the amount posted so far to each schedule, entries of voided journals left out.
+const amortizationSchedulesQuery = `
+	SELECT
+		id, name, total_amount, start_date, end_date, source_account_id, target_account_id,
+		method_id, status_id, terminated_at, created_by, created_at, company_id, posted_amount
+	FROM (
+		SELECT
+			s.*,
+			COALESCE((
+				SELECT SUM(e.amount)
+				FROM amortization_entries e
+				INNER JOIN journals j ON j.id = e.journal_id
+				WHERE e.schedule_id = s.id AND j.deleted_at IS NULL
+			), 0) AS posted_amount
+		FROM amortization_schedules s
+	) AS amortization_schedules
+	%s
+`
+
+// amortizationEntriesQuery leaves out the entries whose journal has been voided.
+const amortizationEntriesQuery = `
+	SELECT id, schedule_id, period_start, period_end, amount, journal_id, catch_up, created_at
+	FROM (
+		SELECT e.*
+		FROM amortization_entries e
+		INNER JOIN journals j ON j.id = e.journal_id
+		WHERE j.deleted_at IS NULL
+	) AS amortization_entries
+	%s
+	ORDER BY period_end ASC, id ASC
+`
+
+func (r *reader) GetAllAmortizationSchedules(ctx context.Context, stmt AmortizationScheduleStatement) (schedules []domain.AmortizationSchedule, err error) {
+	schedules = make([]domain.AmortizationSchedule, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationSchedulesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(amortizationSchedulesQuery, whereClause+" ORDER BY start_date ASC, id ASC")
+	if err = r.db.SelectContext(ctx, &schedules, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationSchedulesFailed, "Failed on get amortization schedules")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAmortizationSchedule(ctx context.Context, stmt AmortizationScheduleStatement) (schedule domain.AmortizationSchedule, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAmortizationScheduleFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(amortizationSchedulesQuery, whereClause)
+	if err = r.db.GetContext(ctx, &schedule, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Amortization schedule not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAmortizationScheduleFailed, "Failed on get amortization schedule")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAmortizationScheduleByID(ctx context.Context, id int64) (schedule domain.AmortizationSchedule, err error) {
+	return r.GetAmortizationSchedule(ctx, AmortizationScheduleStatement{ID: id})
+}
+
+func (r *reader) GetAllAmortizationEntries(ctx context.Context, stmt AmortizationEntryStatement) (entries []domain.AmortizationEntry, err error) {
+	entries = make([]domain.AmortizationEntry, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationEntriesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(amortizationEntriesQuery, whereClause)
+	if err = r.db.SelectContext(ctx, &entries, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationEntriesFailed, "Failed on get amortization entries")
+		return
+	}
+
+	return
+}
+
+const assetCategoriesQuery = `
+	SELECT
+		id, name, asset_account_id, accumulated_depreciation_account_id, depreciation_expense_account_id,
+		useful_life_months, method_id, declining_factor
+	FROM asset_categories
+	%s
+`
+
+// fixedAssetsQuery adds the depreciation posted so far to each asset, runs of voided journals left out.
+const fixedAssetsQuery = `
+	SELECT
+		id, name, category_id, acquisition_cost, in_service_date, salvage_value, useful_life_months, method_id,
+		declining_factor, status_id, disposed_at, disposal_proceeds, disposal_journal_id, created_by, created_at,
+		accumulated_depreciation
+	FROM (
+		SELECT
+			a.*,
+			COALESCE((
+				SELECT SUM(e.amount)
+				FROM depreciation_entries e
+				INNER JOIN depreciation_runs dr ON dr.id = e.run_id
+				INNER JOIN journals j ON j.id = dr.journal_id
+				WHERE e.asset_id = a.id AND j.deleted_at IS NULL
+			), 0) AS accumulated_depreciation
+		FROM fixed_assets a
+	) AS fixed_assets
+	%s
+`
+
+// depreciationRunsQuery leaves out the runs whose journal has been voided.
+const depreciationRunsQuery = `
+	SELECT id, period_end, journal_id, created_by, created_at
+	FROM (
+		SELECT dr.*, j.company_id
+		FROM depreciation_runs dr
+		INNER JOIN journals j ON j.id = dr.journal_id
+		WHERE j.deleted_at IS NULL
+	) AS depreciation_runs
+	%s
+	ORDER BY period_end DESC, id DESC
+`
+
+func (r *reader) GetAllAssetCategories(ctx context.Context, stmt AssetCategoryStatement) (categories []domain.AssetCategory, err error) {
+	categories = make([]domain.AssetCategory, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAssetCategoriesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(assetCategoriesQuery, whereClause+" ORDER BY name ASC")
+	if err = r.db.SelectContext(ctx, &categories, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAssetCategoriesFailed, "Failed on get asset categories")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAssetCategory(ctx context.Context, stmt AssetCategoryStatement) (category domain.AssetCategory, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAssetCategoryFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(assetCategoriesQuery, whereClause)
+	if err = r.db.GetContext(ctx, &category, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Asset category not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAssetCategoryFailed, "Failed on get asset category")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAssetCategoryByID(ctx context.Context, id int64) (category domain.AssetCategory, err error) {
+	return r.GetAssetCategory(ctx, AssetCategoryStatement{ID: id})
+}
+
+func (r *reader) GetAllFixedAssets(ctx context.Context, stmt FixedAssetStatement) (assets []domain.FixedAsset, err error) {
+	assets = make([]domain.FixedAsset, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFixedAssetsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(fixedAssetsQuery, whereClause+" ORDER BY in_service_date ASC, id ASC")
+	if err = r.db.SelectContext(ctx, &assets, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllFixedAssetsFailed, "Failed on get fixed assets")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetFixedAsset(ctx context.Context, stmt FixedAssetStatement) (asset domain.FixedAsset, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFixedAssetFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(fixedAssetsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &asset, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Fixed asset not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetFixedAssetFailed, "Failed on get fixed asset")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetFixedAssetByID(ctx context.Context, id int64) (asset domain.FixedAsset, err error) {
+	return r.GetFixedAsset(ctx, FixedAssetStatement{ID: id})
+}
+
+func (r *reader) GetAllDepreciationRuns(ctx context.Context, stmt DepreciationRunStatement) (runs []domain.DepreciationRun, err error) {
+	runs = make([]domain.DepreciationRun, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDepreciationRunsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(depreciationRunsQuery, whereClause)
+	if err = r.db.SelectContext(ctx, &runs, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDepreciationRunsFailed, "Failed on get depreciation runs")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllDepreciationEntries(ctx context.Context, stmt DepreciationEntryStatement) (entries []domain.DepreciationEntry, err error) {
+	entries = make([]domain.DepreciationEntry, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDepreciationEntriesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, run_id, asset_id, amount FROM depreciation_entries %s ORDER BY id ASC", whereClause)
+	if err = r.db.SelectContext(ctx, &entries, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDepreciationEntriesFailed, "Failed on get depreciation entries")
+		return
+	}
+
+	return
+}
+
+// GetFixedAssetRegister lists the assets in service on asOf and not disposed by then, each with the depreciation
+// its runs posted through asOf and the net book value left.
+func (r *reader) GetFixedAssetRegister(ctx context.Context, asOf time.Time) (rows []domain.FixedAssetRegisterRow, err error) {
+	rows = make([]domain.FixedAssetRegisterRow, 0)
+	assets := make([]domain.FixedAsset, 0)
+
+	query := `
+		SELECT
+			a.id, a.name, a.category_id, a.acquisition_cost, a.in_service_date, a.salvage_value, a.useful_life_months,
+			a.method_id, a.declining_factor, a.status_id, a.disposed_at, a.disposal_proceeds, a.disposal_journal_id,
+			a.created_by, a.created_at,
+			COALESCE((
+				SELECT SUM(e.amount)
+				FROM depreciation_entries e
+				INNER JOIN depreciation_runs dr ON dr.id = e.run_id
+				INNER JOIN journals j ON j.id = dr.journal_id
+				WHERE e.asset_id = a.id AND j.deleted_at IS NULL AND dr.period_end <= ?
+			), 0) AS accumulated_depreciation
+		FROM fixed_assets a
+		WHERE a.company_id = ? AND a.in_service_date <= ? AND (a.disposed_at IS NULL OR a.disposed_at > ?)
+		ORDER BY a.category_id ASC, a.in_service_date ASC, a.id ASC
+	`
+
+	asOf = truncateDate(asOf)
+	if err = r.db.SelectContext(ctx, &assets, r.db.Rebind(query), asOf, companyID(ctx), asOf, asOf); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFixedAssetRegisterFailed, "Failed on get fixed asset register")
+		return
+	}
+
+	for _, asset := range assets {
+		rows = append(rows, domain.FixedAssetRegisterRow{
+			Asset:                   asset,
+			AccumulatedDepreciation: asset.AccumulatedDepreciation,
+			NetBookValue:            roundCents(asset.AcquisitionCost - asset.AccumulatedDepreciation),
+		})
+	}
+
+	return
+}
+
+const customersQuery = `
+	SELECT id, code, name, email, phone, address, tax_number, payment_terms_days, inactive, created_at
+	FROM customers
+	%s
+`
+
+// salesInvoicesQuery adds the receipts allocated to each invoice, the credit notes raised against it
+// and whether its journal has been voided.
+const salesInvoicesQuery = `
+	SELECT
+		id, number, customer_id, invoice_date, due_date, memo, subtotal, tax_amount, total, journal_id,
+		created_by, created_at, paid_amount, credited_amount, voided
+	FROM (
+		SELECT
+			si.*,
+			COALESCE((SELECT SUM(ra.amount) FROM receipt_allocations ra WHERE ra.invoice_id = si.id), 0) AS paid_amount,
+			COALESCE((
+				SELECT SUM(cn.total)
+				FROM credit_notes cn
+				INNER JOIN journals cj ON cj.id = cn.journal_id
+				WHERE cn.invoice_id = si.id AND cj.deleted_at IS NULL
+			), 0) AS credited_amount,
+			j.deleted_at IS NOT NULL AS voided
+		FROM sales_invoices si
+		INNER JOIN journals j ON j.id = si.journal_id
+	) AS sales_invoices
+	%s
+`
+
+// customerReceiptsQuery adds the amount allocated to invoices to each receipt.
+const customerReceiptsQuery = `
+	SELECT
+		id, customer_id, bank_account_id, receipt_date, amount, memo, journal_id, created_by, created_at,
+		allocated_amount
+	FROM (
+		SELECT
+			cr.*,
+			COALESCE((SELECT SUM(ra.amount) FROM receipt_allocations ra WHERE ra.receipt_id = cr.id), 0) AS allocated_amount
+		FROM customer_receipts cr
+	) AS customer_receipts
+	%s
+`
+
+func (r *reader) GetAllCustomers(ctx context.Context, stmt CustomerStatement) (customers []domain.Customer, err error) {
+	customers = make([]domain.Customer, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCustomersFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(customersQuery, whereClause+" ORDER BY name ASC, id ASC")
+	if err = r.db.SelectContext(ctx, &customers, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCustomersFailed, "Failed on get customers")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCustomer(ctx context.Context, stmt CustomerStatement) (customer domain.Customer, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetCustomerFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(customersQuery, whereClause)
+	if err = r.db.GetContext(ctx, &customer, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Customer not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetCustomerFailed, "Failed on get customer")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCustomerByID(ctx context.Context, id int64) (customer domain.Customer, err error) {
+	return r.GetCustomer(ctx, CustomerStatement{ID: id})
+}
+
+func (r *reader) GetAllSalesInvoices(ctx context.Context, stmt SalesInvoiceStatement) (invoices []domain.SalesInvoice, err error) {
+	invoices = make([]domain.SalesInvoice, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllSalesInvoicesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(salesInvoicesQuery, whereClause+" ORDER BY invoice_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &invoices, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllSalesInvoicesFailed, "Failed on get sales invoices")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetSalesInvoice(ctx context.Context, stmt SalesInvoiceStatement) (invoice domain.SalesInvoice, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetSalesInvoiceFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(salesInvoicesQuery, whereClause)
+	if err = r.db.GetContext(ctx, &invoice, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Sales invoice not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetSalesInvoiceFailed, "Failed on get sales invoice")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetSalesInvoiceByID(ctx context.Context, id int64) (invoice domain.SalesInvoice, err error) {
+	return r.GetSalesInvoice(ctx, SalesInvoiceStatement{ID: id})
+}
+
+func (r *reader) GetAllSalesInvoiceLines(ctx context.Context, stmt SalesInvoiceLineStatement) (lines []domain.SalesInvoiceLine, err error) {
+	lines = make([]domain.SalesInvoiceLine, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllSalesInvoiceLinesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, invoice_id, account_id, description, quantity, unit_price, tax_rate, amount, tax_amount
+		FROM sales_invoice_lines
+		%s
+		ORDER BY id ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllSalesInvoiceLinesFailed, "Failed on get sales invoice lines")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllCustomerReceipts(ctx context.Context, stmt CustomerReceiptStatement) (receipts []domain.CustomerReceipt, err error) {
+	receipts = make([]domain.CustomerReceipt, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCustomerReceiptsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(customerReceiptsQuery, whereClause+" ORDER BY receipt_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &receipts, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCustomerReceiptsFailed, "Failed on get customer receipts")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCustomerReceipt(ctx context.Context, stmt CustomerReceiptStatement) (receipt domain.CustomerReceipt, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetCustomerReceiptFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(customerReceiptsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &receipt, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Customer receipt not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetCustomerReceiptFailed, "Failed on get customer receipt")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCustomerReceiptByID(ctx context.Context, id int64) (receipt domain.CustomerReceipt, err error) {
+	return r.GetCustomerReceipt(ctx, CustomerReceiptStatement{ID: id})
+}
+
+func (r *reader) GetAllReceiptAllocations(ctx context.Context, stmt ReceiptAllocationStatement) (allocations []domain.ReceiptAllocation, err error) {
+	allocations = make([]domain.ReceiptAllocation, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllReceiptAllocationsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, receipt_id, invoice_id, amount FROM receipt_allocations %s ORDER BY id ASC", whereClause)
+	if err = r.db.SelectContext(ctx, &allocations, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllReceiptAllocationsFailed, "Failed on get receipt allocations")
+		return
+	}
+
+	return
+}
+
+// GetReceivableAging ages the invoices not voided and dated on or before asOf by what is left of them after
+// the receipts and credit notes dated on or before asOf, with the part of those receipts not allocated yet as unapplied.
+func (r *reader) GetReceivableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error) {
+	var invoices, receipts []agingItem
+
+	asOf = truncateDate(asOf)
+
+	query := `
+		SELECT
+			si.customer_id AS party_id, c.name AS party_name, si.due_date,
+			si.total - COALESCE((
+				SELECT SUM(ra.amount)
+				FROM receipt_allocations ra
+				INNER JOIN customer_receipts cr ON cr.id = ra.receipt_id
+				WHERE ra.invoice_id = si.id AND cr.receipt_date <= ?
+			), 0) - COALESCE((
+				SELECT SUM(cn.total)
+				FROM credit_notes cn
+				INNER JOIN journals cj ON cj.id = cn.journal_id
+				WHERE cn.invoice_id = si.id AND cj.deleted_at IS NULL AND cn.note_date <= ?
+			), 0) AS amount
+		FROM sales_invoices si
+		INNER JOIN journals j ON j.id = si.journal_id
+		INNER JOIN customers c ON c.id = si.customer_id
+		WHERE j.deleted_at IS NULL AND si.company_id = ? AND si.invoice_date <= ?
+		ORDER BY c.name ASC, c.id ASC, si.due_date ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &invoices, r.db.Rebind(query), asOf, asOf, companyID(ctx), asOf); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAgingReportFailed, "Failed on get open sales invoices")
+		return
+	}
+
+	query = `
+		SELECT
+			cr.customer_id AS party_id, c.name AS party_name, cr.receipt_date AS due_date,
+			cr.amount - COALESCE((
+				SELECT SUM(ra.amount)
+				FROM receipt_allocations ra
+				INNER JOIN sales_invoices si ON si.id = ra.invoice_id
+				WHERE ra.receipt_id = cr.id AND si.invoice_date <= ?
+			), 0) AS amount
+		FROM customer_receipts cr
+		INNER JOIN customers c ON c.id = cr.customer_id
+		WHERE cr.company_id = ? AND cr.receipt_date <= ?
+		ORDER BY c.name ASC, c.id ASC, cr.receipt_date ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &receipts, r.db.Rebind(query), asOf, companyID(ctx), asOf); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAgingReportFailed, "Failed on get unapplied customer receipts")
+		return
+	}
+
+	return newAgingReport(asOf, invoices, receipts), nil
+}
+
+// GetCustomerAccountStatement lists the invoices, raising the balance, and the receipts and credit notes, lowering it,
+// of a customer up to endDate, those before startDate folded into the opening balance.
+func (r *reader) GetCustomerAccountStatement(ctx context.Context, customerID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error) {
+	var lines []domain.PartyStatementLine
+
+	startDate, endDate = truncateDate(startDate), truncateDate(endDate)
+
+	query := `
+		SELECT date, kind, id, reference, memo, amount
+		FROM (
+			SELECT si.invoice_date AS date, 'invoice' AS kind, si.id, si.number AS reference, COALESCE(si.memo, '') AS memo, si.total AS amount
+			FROM sales_invoices si
+			INNER JOIN journals j ON j.id = si.journal_id
+			WHERE j.deleted_at IS NULL AND si.company_id = ? AND si.customer_id = ?
+			UNION ALL
+			SELECT cr.receipt_date, 'receipt', cr.id, COALESCE(j.number, ''), COALESCE(cr.memo, ''), -cr.amount
+			FROM customer_receipts cr
+			INNER JOIN journals j ON j.id = cr.journal_id
+			WHERE j.deleted_at IS NULL AND cr.company_id = ? AND cr.customer_id = ?
+			UNION ALL
+			SELECT cn.note_date, 'credit_note', cn.id, cn.number, COALESCE(cn.memo, ''), -cn.total
+			FROM credit_notes cn
+			INNER JOIN journals j ON j.id = cn.journal_id
+			INNER JOIN sales_invoices si ON si.id = cn.invoice_id
+			WHERE j.deleted_at IS NULL AND si.company_id = ? AND si.customer_id = ?
+		) AS statement_lines
+		WHERE date <= ?
+		ORDER BY date ASC, kind ASC, id ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), companyID(ctx), customerID, companyID(ctx), customerID, companyID(ctx), customerID, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetPartyStatementFailed, "Failed on get customer statement lines")
+		return
+	}
+
+	return newPartyStatement(customerID, startDate, endDate, lines), nil
+}
+
+const vendorsQuery = `
+	SELECT id, code, name, email, phone, address, tax_number, payment_terms_days, inactive, created_at
+	FROM vendors
+	%s
+`
+
+// purchaseBillsQuery adds the payments allocated to each bill, the debit notes raised against it
+// and whether its journal has been voided.
+const purchaseBillsQuery = `
+	SELECT
+		id, number, vendor_id, vendor_reference, bill_date, due_date, memo, subtotal, tax_amount, total, journal_id,
+		created_by, created_at, paid_amount, debited_amount, voided
+	FROM (
+		SELECT
+			pb.*,
+			COALESCE((SELECT SUM(pa.amount) FROM payment_allocations pa WHERE pa.bill_id = pb.id), 0) AS paid_amount,
+			COALESCE((
+				SELECT SUM(dn.total)
+				FROM debit_notes dn
+				INNER JOIN journals dj ON dj.id = dn.journal_id
+				WHERE dn.bill_id = pb.id AND dj.deleted_at IS NULL
+			), 0) AS debited_amount,
+			j.deleted_at IS NOT NULL AS voided
+		FROM purchase_bills pb
+		INNER JOIN journals j ON j.id = pb.journal_id
+	) AS purchase_bills
+	%s
+`
+
+// vendorPaymentsQuery adds the amount allocated to bills to each payment.
+const vendorPaymentsQuery = `
+	SELECT
+		id, vendor_id, bank_account_id, payment_date, amount, memo, journal_id, created_by, created_at,
+		allocated_amount
+	FROM (
+		SELECT
+			vp.*,
+			COALESCE((SELECT SUM(pa.amount) FROM payment_allocations pa WHERE pa.payment_id = vp.id), 0) AS allocated_amount
+		FROM vendor_payments vp
+	) AS vendor_payments
+	%s
+`
+
+func (r *reader) GetAllVendors(ctx context.Context, stmt VendorStatement) (vendors []domain.Vendor, err error) {
+	vendors = make([]domain.Vendor, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllVendorsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(vendorsQuery, whereClause+" ORDER BY name ASC, id ASC")
+	if err = r.db.SelectContext(ctx, &vendors, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllVendorsFailed, "Failed on get vendors")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetVendor(ctx context.Context, stmt VendorStatement) (vendor domain.Vendor, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetVendorFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(vendorsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &vendor, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Vendor not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetVendorFailed, "Failed on get vendor")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetVendorByID(ctx context.Context, id int64) (vendor domain.Vendor, err error) {
+	return r.GetVendor(ctx, VendorStatement{ID: id})
+}
+
+func (r *reader) GetAllPurchaseBills(ctx context.Context, stmt PurchaseBillStatement) (bills []domain.PurchaseBill, err error) {
+	bills = make([]domain.PurchaseBill, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPurchaseBillsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(purchaseBillsQuery, whereClause+" ORDER BY bill_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &bills, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPurchaseBillsFailed, "Failed on get purchase bills")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetPurchaseBill(ctx context.Context, stmt PurchaseBillStatement) (bill domain.PurchaseBill, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetPurchaseBillFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(purchaseBillsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &bill, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Purchase bill not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetPurchaseBillFailed, "Failed on get purchase bill")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetPurchaseBillByID(ctx context.Context, id int64) (bill domain.PurchaseBill, err error) {
+	return r.GetPurchaseBill(ctx, PurchaseBillStatement{ID: id})
+}
+
+func (r *reader) GetAllPurchaseBillLines(ctx context.Context, stmt PurchaseBillLineStatement) (lines []domain.PurchaseBillLine, err error) {
+	lines = make([]domain.PurchaseBillLine, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPurchaseBillLinesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, bill_id, account_id, description, quantity, unit_price, tax_rate, amount, tax_amount
+		FROM purchase_bill_lines
+		%s
+		ORDER BY id ASC
+	`, whereClause)
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPurchaseBillLinesFailed, "Failed on get purchase bill lines")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllVendorPayments(ctx context.Context, stmt VendorPaymentStatement) (payments []domain.VendorPayment, err error) {
+	payments = make([]domain.VendorPayment, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllVendorPaymentsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(vendorPaymentsQuery, whereClause+" ORDER BY payment_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &payments, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllVendorPaymentsFailed, "Failed on get vendor payments")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetVendorPayment(ctx context.Context, stmt VendorPaymentStatement) (payment domain.VendorPayment, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetVendorPaymentFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(vendorPaymentsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &payment, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Vendor payment not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetVendorPaymentFailed, "Failed on get vendor payment")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetVendorPaymentByID(ctx context.Context, id int64) (payment domain.VendorPayment, err error) {
+	return r.GetVendorPayment(ctx, VendorPaymentStatement{ID: id})
+}
+
+func (r *reader) GetAllPaymentAllocations(ctx context.Context, stmt PaymentAllocationStatement) (allocations []domain.PaymentAllocation, err error) {
+	allocations = make([]domain.PaymentAllocation, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPaymentAllocationsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, payment_id, bill_id, amount FROM payment_allocations %s ORDER BY id ASC", whereClause)
+	if err = r.db.SelectContext(ctx, &allocations, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllPaymentAllocationsFailed, "Failed on get payment allocations")
+		return
+	}
+
+	return
+}
+
+// GetAllPurchaseBillsDue lists the bills not voided with an outstanding amount that fall due on or before
+// through, overdue ones included, earliest due first, for a payment run.
+func (r *reader) GetAllPurchaseBillsDue(ctx context.Context, through time.Time) (bills []domain.PurchaseBill, err error) {
+	bills = make([]domain.PurchaseBill, 0)
+
+	query := fmt.Sprintf(purchaseBillsQuery, "WHERE company_id = ? AND NOT voided AND total > paid_amount + debited_amount AND due_date <= ? ORDER BY due_date ASC, id ASC")
+	if err = r.db.SelectContext(ctx, &bills, r.db.Rebind(query), companyID(ctx), truncateDate(through)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetPurchaseBillsDueFailed, "Failed on get purchase bills due")
+		return
+	}
+
+	return
+}
+
+// GetPayableAging ages the bills not voided and dated on or before asOf by what is left of them after
+// the payments and debit notes dated on or before asOf, with the part of those payments not allocated yet as unapplied.
+func (r *reader) GetPayableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error) {
+	var bills, payments []agingItem
+
+	asOf = truncateDate(asOf)
+
+	query := `
+		SELECT
+			pb.vendor_id AS party_id, v.name AS party_name, pb.due_date,
+			pb.total - COALESCE((
+				SELECT SUM(pa.amount)
+				FROM payment_allocations pa
+				INNER JOIN vendor_payments vp ON vp.id = pa.payment_id
+				WHERE pa.bill_id = pb.id AND vp.payment_date <= ?
+			), 0) - COALESCE((
+				SELECT SUM(dn.total)
+				FROM debit_notes dn
+				INNER JOIN journals dj ON dj.id = dn.journal_id
+				WHERE dn.bill_id = pb.id AND dj.deleted_at IS NULL AND dn.note_date <= ?
+			), 0) AS amount
+		FROM purchase_bills pb
+		INNER JOIN journals j ON j.id = pb.journal_id
+		INNER JOIN vendors v ON v.id = pb.vendor_id
+		WHERE j.deleted_at IS NULL AND pb.company_id = ? AND pb.bill_date <= ?
+		ORDER BY v.name ASC, v.id ASC, pb.due_date ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &bills, r.db.Rebind(query), asOf, asOf, companyID(ctx), asOf); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAgingReportFailed, "Failed on get open purchase bills")
+		return
+	}
+
+	query = `
+		SELECT
+			vp.vendor_id AS party_id, v.name AS party_name, vp.payment_date AS due_date,
+			vp.amount - COALESCE((
+				SELECT SUM(pa.amount)
+				FROM payment_allocations pa
+				INNER JOIN purchase_bills pb ON pb.id = pa.bill_id
+				WHERE pa.payment_id = vp.id AND pb.bill_date <= ?
+			), 0) AS amount
+		FROM vendor_payments vp
+		INNER JOIN vendors v ON v.id = vp.vendor_id
+		WHERE vp.company_id = ? AND vp.payment_date <= ?
+		ORDER BY v.name ASC, v.id ASC, vp.payment_date ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &payments, r.db.Rebind(query), asOf, companyID(ctx), asOf); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAgingReportFailed, "Failed on get unapplied vendor payments")
+		return
+	}
+
+	return newAgingReport(asOf, bills, payments), nil
+}
+
+// GetVendorAccountStatement lists the bills, raising the balance, and the payments and debit notes, lowering it,
+// of a vendor up to endDate, those before startDate folded into the opening balance.
+func (r *reader) GetVendorAccountStatement(ctx context.Context, vendorID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error) {
+	var lines []domain.PartyStatementLine
+
+	startDate, endDate = truncateDate(startDate), truncateDate(endDate)
+
+	query := `
+		SELECT date, kind, id, reference, memo, amount
+		FROM (
+			SELECT pb.bill_date AS date, 'bill' AS kind, pb.id, pb.number AS reference, COALESCE(pb.memo, '') AS memo, pb.total AS amount
+			FROM purchase_bills pb
+			INNER JOIN journals j ON j.id = pb.journal_id
+			WHERE j.deleted_at IS NULL AND pb.company_id = ? AND pb.vendor_id = ?
+			UNION ALL
+			SELECT vp.payment_date, 'payment', vp.id, COALESCE(j.number, ''), COALESCE(vp.memo, ''), -vp.amount
+			FROM vendor_payments vp
+			INNER JOIN journals j ON j.id = vp.journal_id
+			WHERE j.deleted_at IS NULL AND vp.company_id = ? AND vp.vendor_id = ?
+			UNION ALL
+			SELECT dn.note_date, 'debit_note', dn.id, dn.number, COALESCE(dn.memo, ''), -dn.total
+			FROM debit_notes dn
+			INNER JOIN journals j ON j.id = dn.journal_id
+			INNER JOIN purchase_bills pb ON pb.id = dn.bill_id
+			WHERE j.deleted_at IS NULL AND pb.company_id = ? AND pb.vendor_id = ?
+		) AS statement_lines
+		WHERE date <= ?
+		ORDER BY date ASC, kind ASC, id ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(query), companyID(ctx), vendorID, companyID(ctx), vendorID, companyID(ctx), vendorID, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetPartyStatementFailed, "Failed on get vendor statement lines")
+		return
+	}
+
+	return newPartyStatement(vendorID, startDate, endDate, lines), nil
+}
+
+// creditNotesQuery adds whether the journal of each note has been voided.
+const creditNotesQuery = `
+	SELECT
+		id, number, invoice_id, note_date, memo, subtotal, tax_amount, total, journal_id, created_by, created_at,
+		voided
+	FROM (
+		SELECT cn.*, j.deleted_at IS NOT NULL AS voided
+		FROM credit_notes cn
+		INNER JOIN journals j ON j.id = cn.journal_id
+	) AS credit_notes
+	%s
+`
+
+// debitNotesQuery adds whether the journal of each note has been voided.
+const debitNotesQuery = `
+	SELECT
+		id, number, bill_id, note_date, memo, subtotal, tax_amount, total, journal_id, created_by, created_at,
+		voided
+	FROM (
+		SELECT dn.*, j.deleted_at IS NOT NULL AS voided
+		FROM debit_notes dn
+		INNER JOIN journals j ON j.id = dn.journal_id
+	) AS debit_notes
+	%s
+`
+
+func (r *reader) GetAllCreditNotes(ctx context.Context, stmt CreditNoteStatement) (notes []domain.CreditNote, err error) {
+	notes = make([]domain.CreditNote, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCreditNotesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(creditNotesQuery, whereClause+" ORDER BY note_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &notes, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCreditNotesFailed, "Failed on get credit notes")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCreditNote(ctx context.Context, stmt CreditNoteStatement) (note domain.CreditNote, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetCreditNoteFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(creditNotesQuery, whereClause)
+	if err = r.db.GetContext(ctx, &note, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Credit note not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetCreditNoteFailed, "Failed on get credit note")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCreditNoteByID(ctx context.Context, id int64) (note domain.CreditNote, err error) {
+	return r.GetCreditNote(ctx, CreditNoteStatement{ID: id})
+}
+
+func (r *reader) GetAllDebitNotes(ctx context.Context, stmt DebitNoteStatement) (notes []domain.DebitNote, err error) {
+	notes = make([]domain.DebitNote, 0)
+
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDebitNotesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(debitNotesQuery, whereClause+" ORDER BY note_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &notes, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllDebitNotesFailed, "Failed on get debit notes")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetDebitNote(ctx context.Context, stmt DebitNoteStatement) (note domain.DebitNote, err error) {
+	stmt.CompanyID = companyID(ctx)
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetDebitNoteFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(debitNotesQuery, whereClause)
+	if err = r.db.GetContext(ctx, &note, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Debit note not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetDebitNoteFailed, "Failed on get debit note")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetDebitNoteByID(ctx context.Context, id int64) (note domain.DebitNote, err error) {
+	return r.GetDebitNote(ctx, DebitNoteStatement{ID: id})
+}
+
+func (r *reader) GetAllCompanies(ctx context.Context, stmt CompanyStatement) (companies []domain.Company, err error) {
+	companies = make([]domain.Company, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCompaniesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, code, name, created_at FROM companies %s ORDER BY code, id", whereClause)
+	if err = r.db.SelectContext(ctx, &companies, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCompaniesFailed, "Failed on get companies")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCompany(ctx context.Context, stmt CompanyStatement) (company domain.Company, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetCompanyFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, code, name, created_at FROM companies %s", whereClause)
+	if err = r.db.GetContext(ctx, &company, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Company not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetCompanyFailed, "Failed on get company")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetCompanyByID(ctx context.Context, id int64) (company domain.Company, err error) {
+	return r.GetCompany(ctx, CompanyStatement{ID: id})
+}
+
+// GetAllCompaniesByUserID returns the companies a user can switch to, the default company alone for a user
+// who is not a member of any company.
+func (r *reader) GetAllCompaniesByUserID(ctx context.Context, userID uuid.UUID) (companies []domain.Company, err error) {
+	companies = make([]domain.Company, 0)
+
+	query := `
+		SELECT id, code, name, created_at
+		FROM companies
+		WHERE
+			id IN (SELECT company_id FROM company_users WHERE user_id = ?) OR
+			(id = ? AND NOT EXISTS (SELECT 1 FROM company_users WHERE user_id = ?))
+		ORDER BY code, id
+	`
+
+	if err = r.db.SelectContext(ctx, &companies, r.db.Rebind(query), userID, DefaultCompanyID, userID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllCompaniesFailed, "Failed on get companies of user")
+		return
+	}
+
+	return
+}
+
+// HasCompanyAccess tells whether a user may work in a company, see GetAllCompaniesByUserID.
+func (r *reader) HasCompanyAccess(ctx context.Context, userID uuid.UUID, companyID int64) (hasAccess bool, err error) {
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM company_users WHERE company_id = ? AND user_id = ?) OR
+			(? = ? AND NOT EXISTS (SELECT 1 FROM company_users WHERE user_id = ?))
+	`
+
+	if err = r.db.GetContext(ctx, &hasAccess, r.db.Rebind(query), companyID, userID, companyID, DefaultCompanyID, userID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetCompanyFailed, "Failed on check company access")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetAllGroupAccounts(ctx context.Context, stmt GroupAccountStatement) (groupAccounts []domain.GroupAccount, err error) {
+	groupAccounts = make([]domain.GroupAccount, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllGroupAccountsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, code, name, class_id FROM group_accounts %s ORDER BY code, id", whereClause)
+	if err = r.db.SelectContext(ctx, &groupAccounts, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllGroupAccountsFailed, "Failed on get group accounts")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetGroupAccount(ctx context.Context, stmt GroupAccountStatement) (groupAccount domain.GroupAccount, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetGroupAccountFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf("SELECT id, code, name, class_id FROM group_accounts %s", whereClause)
+	if err = r.db.GetContext(ctx, &groupAccount, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Group account not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetGroupAccountFailed, "Failed on get group account")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetGroupAccountByID(ctx context.Context, id int64) (groupAccount domain.GroupAccount, err error) {
+	return r.GetGroupAccount(ctx, GroupAccountStatement{ID: id})
+}
+
+// consolidationBalancesQuery sums the general ledger of every account of the consolidated companies up to a date.
+// Lines tagged with a counterparty that is consolidated as well are summed again apart, they are eliminated.
+// The class type comes from the group account when the account is mapped, from the account class otherwise.
+const consolidationBalancesQuery = `
+	SELECT
+		a.company_id, a.id AS account_id, a.code AS account_code, a.name AS account_name,
+		ga.id AS group_account_id, ga.code AS group_account_code, ga.name AS group_account_name,
+		COALESCE(gcls.type_id, cls.type_id) AS class_type_id, a.normal_balance,
+		SUM(gl.amount) AS amount,
+		SUM(CASE WHEN gl.counterparty_company_id IN (%[1]s) THEN gl.amount ELSE 0 END) AS eliminated_amount
+	FROM general_ledgers gl
+	INNER JOIN journals j ON j.id = gl.journal_id
+	INNER JOIN accounts a ON a.id = gl.account_id
+	INNER JOIN account_groups grp ON grp.id = a.group_id
+	INNER JOIN account_classes cls ON cls.id = grp.class_id
+	LEFT JOIN group_accounts ga ON ga.id = a.group_account_id
+	LEFT JOIN account_classes gcls ON gcls.id = ga.class_id
+	WHERE
+		j.deleted_at IS NULL AND
+		j.company_id IN (%[1]s) AND
+		DATE(j.trans_date) <= DATE(?)
+	GROUP BY a.company_id, a.id, a.code, a.name, a.normal_balance, ga.id, ga.code, ga.name, gcls.type_id, cls.type_id
+`
+
+// getConsolidationRows checks the consolidated companies and sums their balances as of a date onto the group chart.
+func (r *reader) getConsolidationRows(ctx context.Context, companyIDs []int64, asOf time.Time) (ids []int64, rows []domain.ConsolidationRow, err error) {
+	var balances []consolidationBalance
+
+	seen := make(map[int64]bool, len(companyIDs))
+	for _, id := range companyIDs {
+		if seen[id] {
+			continue
+		}
+
+		if _, err = r.GetCompanyByID(ctx, id); err != nil {
+			err = errors.PropagateWithCode(err, errors.GetCode(err), fmt.Sprintf("Failed on get company %d", id))
+			return
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		err = errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "companyIDs", Message: "At least one company is required"}},
+			EcodeGetConsolidationFailed,
+			"At least one company is required",
+		)
+		return
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]interface{}, 0, len(ids)*2+1)
+	for _, id := range append(ids, ids...) {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(consolidationBalancesQuery, placeholders)
+	if err = r.db.SelectContext(ctx, &balances, r.db.Rebind(query), append(args, asOf)...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetConsolidationFailed, "Failed on get company balances")
+		return
+	}
+
+	rows = newConsolidationRows(ids, balances)
+
+	return
+}
+
+// GetConsolidatedTrialBalance consolidates the trial balances of companies as of a date onto the group chart,
+// intercompany lines between them eliminated.
+func (r *reader) GetConsolidatedTrialBalance(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedTrialBalance, err error) {
+	ids, rows, err := r.getConsolidationRows(ctx, companyIDs, asOf)
+	if err != nil {
+		return
+	}
+
+	report = domain.ConsolidatedTrialBalance{AsOf: asOf, CompanyIDs: ids, Rows: rows}
+	for _, row := range rows {
+		if row.Amount > 0 {
+			report.TotalDebit += row.Amount
+		} else {
+			report.TotalCredit -= row.Amount
+		}
+	}
+
+	report.TotalDebit, report.TotalCredit = roundCents(report.TotalDebit), roundCents(report.TotalCredit)
+
+	return
+}
+
+// GetConsolidatedBalanceSheet consolidates the balance sheets of companies as of a date onto the group chart,
+// intercompany lines between them eliminated.
+func (r *reader) GetConsolidatedBalanceSheet(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedBalanceSheet, err error) {
+	ids, rows, err := r.getConsolidationRows(ctx, companyIDs, asOf)
+	if err != nil {
+		return
+	}
+
+	report = newConsolidatedBalanceSheet(rows)
+	report.AsOf, report.CompanyIDs = asOf, ids
+
+	return
+}
+
+// intercompanyTransactionsQuery keeps the transactions the company of the request is on either side of,
+// and adds whether they have been voided.
+const intercompanyTransactionsQuery = `
+	SELECT
+		id, reference, from_company_id, to_company_id, trans_date, memo, amount, from_journal_id, to_journal_id,
+		created_by, created_at, voided
+	FROM (
+		SELECT it.*, fj.deleted_at IS NOT NULL AS voided
+		FROM intercompany_transactions it
+		INNER JOIN journals fj ON fj.id = it.from_journal_id
+		WHERE it.from_company_id = ? OR it.to_company_id = ?
+	) AS intercompany_transactions
+	%s
+`
+
+func (r *reader) GetAllIntercompanyTransactions(ctx context.Context, stmt IntercompanyTransactionStatement) (transactions []domain.IntercompanyTransaction, err error) {
+	transactions = make([]domain.IntercompanyTransaction, 0)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllIntercompanyTransactionsFailed, "Failed on build where clause")
+		return
+	}
+
+	args := append([]interface{}{companyID(ctx), companyID(ctx)}, whereClauseArgs...)
+	query := fmt.Sprintf(intercompanyTransactionsQuery, whereClause+" ORDER BY trans_date DESC, id DESC")
+	if err = r.db.SelectContext(ctx, &transactions, r.db.Rebind(query), args...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllIntercompanyTransactionsFailed, "Failed on get intercompany transactions")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetIntercompanyTransaction(ctx context.Context, stmt IntercompanyTransactionStatement) (transaction domain.IntercompanyTransaction, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetIntercompanyTransactionFailed, "Failed on build where clause")
+		return
+	}
+
+	args := append([]interface{}{companyID(ctx), companyID(ctx)}, whereClauseArgs...)
+	query := fmt.Sprintf(intercompanyTransactionsQuery, whereClause)
+	if err = r.db.GetContext(ctx, &transaction, r.db.Rebind(query), args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Intercompany transaction not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetIntercompanyTransactionFailed, "Failed on get intercompany transaction")
+		return
+	}
+
+	return
+}
+
+func (r *reader) GetIntercompanyTransactionByID(ctx context.Context, id int64) (transaction domain.IntercompanyTransaction, err error) {
+	return r.GetIntercompanyTransaction(ctx, IntercompanyTransactionStatement{ID: id})
+}
+
+// intercompanyBalancesQuery sums the ledger lines each company has tagged with a counterparty company.
+const intercompanyBalancesQuery = `
+	SELECT j.company_id, gl.counterparty_company_id, SUM(gl.amount) AS amount
+	FROM general_ledgers gl
+	INNER JOIN journals j ON j.id = gl.journal_id
+	WHERE
+		j.deleted_at IS NULL AND
+		j.company_id IN (%[1]s) AND
+		gl.counterparty_company_id IN (%[1]s) AND
+		DATE(j.trans_date) <= DATE(?)
+	GROUP BY j.company_id, gl.counterparty_company_id
+`
+
+// GetIntercompanyReconciliation lists the pairs of companies whose intercompany balances as of a date do not net
+// to zero, each pair once with the lower company id first.
+func (r *reader) GetIntercompanyReconciliation(ctx context.Context, companyIDs []int64, asOf time.Time) (balances []domain.IntercompanyBalance, err error) {
+	var rows []domain.IntercompanyBalance
+
+	balances = make([]domain.IntercompanyBalance, 0)
+	if len(companyIDs) == 0 {
+		return
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(companyIDs)), ",")
+	args := make([]interface{}, 0, len(companyIDs)*2+1)
+	for _, id := range append(companyIDs, companyIDs...) {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(intercompanyBalancesQuery, placeholders)
+	if err = r.db.SelectContext(ctx, &rows, r.db.Rebind(query), append(args, asOf)...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetIntercompanyReconciliationFailed, "Failed on get intercompany balances")
+		return
+	}
+
+	balances = newIntercompanyReconciliation(rows)
+
+	return
+}
+
+const auditLogsQuery = `
+	SELECT
+		id, company_id, entity, entity_id, operation, user_id, request_id, before, after, created_at, prev_hash, hash
+	FROM audit_logs
+	%s
+`
+
+// GetAllAuditLogs lists the audit log entries of the company in the order they were written.
+func (r *reader) GetAllAuditLogs(ctx context.Context, stmt AuditLogStatement) (logs []domain.AuditLog, err error) {
+	logs = make([]domain.AuditLog, 0)
+	stmt.CompanyID = companyID(ctx)
+
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAuditLogsFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(auditLogsQuery, whereClause+" ORDER BY id")
+	if err = r.db.SelectContext(ctx, &logs, r.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAuditLogsFailed, "Failed on get audit logs")
+		return
+	}
+
+	return
+}
+
+// VerifyAuditLog walks the whole audit log chain, every company included, and stops at the first entry that does
+// not hash to its stored hash or does not chain onto the entry before it.
+func (r *reader) VerifyAuditLog(ctx context.Context) (verification domain.AuditLogVerification, err error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(auditLogsQuery, "ORDER BY id"))
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeVerifyAuditLogFailed, "Failed on get audit logs")
+		return
+	}
+
+	defer rows.Close()
+
+	prevHash := AuditLogGenesisHash
+	for rows.Next() {
+		var log domain.AuditLog
+		if err = rows.StructScan(&log); err != nil {
+			err = errors.PropagateWithCode(err, EcodeVerifyAuditLogFailed, "Failed on scan audit log")
+			return
+		}
+
+		verification.Checked++
+		if !auditLogChains(prevHash, log) {
+			verification.BrokenAtID = goSql.NullInt64{Int64: log.ID, Valid: true}
+			return
+		}
+
+		prevHash = log.Hash
+	}
+
+	if err = rows.Err(); err != nil {
+		err = errors.PropagateWithCode(err, EcodeVerifyAuditLogFailed, "Failed on read audit logs")
+		return
+	}
+
+	verification.Valid = true
+
+	return
+}
+
+func NewReader(opt *Options) Reader {
+	return &reader{db: opt.SlaveDB}
+}
+
+// GetAllJournalVersions lists the versions of a journal from the one it was posted with to the one it stands at,
+// each with the changes made to the lines of the version before it.
+func (r *reader) GetAllJournalVersions(ctx context.Context, journalID uuid.UUID) (versions []domain.JournalVersion, err error) {
+	type journalVersionRow struct {
+		ID        int64
+		Version   int64
+		TransDate time.Time `db:"trans_date"`
+		Memo      goSql.NullString
+		Amount    float64
+		AmendedBy uuid.UUID `db:"amended_by"`
+		AmendedAt time.Time `db:"amended_at"`
+		Reason    goSql.NullString
+	}
+
+	type journalVersionLineRow struct {
+		VersionID int64 `db:"version_id"`
+		domain.JournalVersionLine
+	}
+
+	var (
+		rows      []journalVersionRow
+		lineRows  []journalVersionLineRow
+		creatorID uuid.UUID
+	)
+
+	journal, err := r.GetJournalByID(ctx, journalID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get journal")
+		return
+	}
+
+	gls, err := r.GetAllGeneralLedgersByJournalID(ctx, journalID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalVersionsFailed, "Failed on get general ledgers")
+		return
+	}
+
+	query := `
+		SELECT id, version, trans_date, memo, amount, amended_by, amended_at, reason
+		FROM journal_versions
+		WHERE journal_id = ?
+		ORDER BY version
+	`
+
+	if err = r.db.SelectContext(ctx, &rows, r.db.Rebind(query), journalID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalVersionsFailed, "Failed on get journal versions")
+		return
+	}
+
+	query = `
+		SELECT l.version_id, l.account_id, l.amount, l.memo, l.external_reference, l.counterparty_company_id
+		FROM journal_version_lines l
+		INNER JOIN journal_versions v ON v.id = l.version_id
+		WHERE v.journal_id = ?
+		ORDER BY l.id
+	`
+
+	if err = r.db.SelectContext(ctx, &lineRows, r.db.Rebind(query), journalID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalVersionsFailed, "Failed on get journal version lines")
+		return
+	}
+
+	linesByVersion := make(map[int64][]domain.JournalVersionLine)
+	for _, line := range lineRows {
+		linesByVersion[line.VersionID] = append(linesByVersion[line.VersionID], line.JournalVersionLine)
+	}
+
+	// an amendment keeps the creator of the lines, so any line tells who posted the journal
+	if len(gls) > 0 {
+		creatorID = gls[0].CreatedBy
+	}
+
+	versions = make([]domain.JournalVersion, 0, len(rows)+1)
+	for _, row := range rows {
+		versions = append(versions, domain.JournalVersion{
+			Version:   row.Version,
+			TransDate: row.TransDate,
+			Memo:      row.Memo,
+			Amount:    row.Amount,
+			Lines:     linesByVersion[row.ID],
+		})
+	}
+
+	current := domain.JournalVersion{
+		Version:   int64(len(rows)) + 1,
+		TransDate: journal.TransDate,
+		Memo:      journal.Memo,
+		Amount:    journal.Amount,
+		Lines:     make([]domain.JournalVersionLine, len(gls)),
+	}
+
+	for i, gl := range gls {
+		current.Lines[i] = domain.JournalVersionLine{
+			AccountID:             gl.AccountID,
+			Amount:                gl.Amount,
+			Memo:                  gl.Memo,
+			ExternalReference:     gl.ExternalReference,
+			CounterpartyCompanyID: gl.CounterpartyCompanyID,
+		}
+	}
+
+	versions = append(versions, current)
+
+	// a stored version is the one an amendment replaced, so the amendment made the version after it
+	for i := range versions {
+		if i == 0 {
+			versions[i].CreatedBy, versions[i].CreatedAt = creatorID, journal.CreatedAt
+			versions[i].Changes = make([]domain.JournalLineChange, 0)
+			continue
+		}
+
+		versions[i].CreatedBy, versions[i].CreatedAt = rows[i-1].AmendedBy, rows[i-1].AmendedAt
+		versions[i].Reason = rows[i-1].Reason
+		versions[i].Changes = newJournalLineChanges(versions[i-1].Lines, versions[i].Lines)
+	}
+
+	return
+}
+
+// GetJournalImportByBatchID reads a recorded batch with its journals and errors, the lines of the journals being
+// kept by the journals posted.
+func (r *reader) GetJournalImportByBatchID(ctx context.Context, batchID string) (journalImport domain.JournalImport, err error) {
+	query := `
+		SELECT id, batch_id, mode, posted_count, error_count, created_by, created_at
+		FROM journal_imports
+		WHERE company_id = ? AND batch_id = ?
+	`
+
+	if err = r.db.GetContext(ctx, &journalImport, r.db.Rebind(query), companyID(ctx), batchID); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Journal import not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetJournalImportFailed, "Failed on get journal import")
+		return
+	}
+
+	journalImport.Journals = make([]domain.JournalImportJournal, 0)
+	query = "SELECT line, reference, trans_date, memo, journal_id FROM journal_import_journals WHERE import_id = ? ORDER BY id"
+	if err = r.db.SelectContext(ctx, &journalImport.Journals, r.db.Rebind(query), journalImport.ID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalImportFailed, "Failed on get journal import journals")
+		return
+	}
+
+	journalImport.Errors = make([]domain.JournalImportError, 0)
+	query = "SELECT line, reference, message FROM journal_import_errors WHERE import_id = ? ORDER BY id"
+	if err = r.db.SelectContext(ctx, &journalImport.Errors, r.db.Rebind(query), journalImport.ID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetJournalImportFailed, "Failed on get journal import errors")
+		return
+	}
+
+	return
+}
+
+// standardAuditFileAccountsQuery sums the ledgers of every account of the company before the start date
+// and up to the end date, accounts with no ledgers kept with zero balances.
+const standardAuditFileAccountsQuery = `
+	SELECT
+		a.id, a.code, a.name, cls.type_id AS class_type_id,
+		COALESCE(SUM(CASE WHEN DATE(j.trans_date) < DATE(?) THEN gl.amount ELSE 0 END), 0) AS opening_balance,
+		COALESCE(SUM(gl.amount), 0) AS closing_balance
+	FROM accounts a
+	INNER JOIN account_groups grp ON grp.id = a.group_id
+	INNER JOIN account_classes cls ON cls.id = grp.class_id
+	LEFT JOIN (
+		general_ledgers gl
+		INNER JOIN journals j ON j.id = gl.journal_id AND j.deleted_at IS NULL AND DATE(j.trans_date) <= DATE(?)
+	) ON gl.account_id = a.id
+	WHERE a.company_id = ?
+	GROUP BY a.id, a.code, a.name, cls.type_id
+	ORDER BY a.code ASC, a.id ASC
+`
+
+// standardAuditFileTaxRatesQuery lists the tax rates of the sales invoice and purchase bill lines
+// posted by the company in the date range.
+const standardAuditFileTaxRatesQuery = `
+	SELECT DISTINCT kind, rate
+	FROM (
+		SELECT 'sales' AS kind, sil.tax_rate AS rate
+		FROM sales_invoice_lines sil
+		INNER JOIN sales_invoices si ON si.id = sil.invoice_id
+		INNER JOIN journals j ON j.id = si.journal_id
+		WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) BETWEEN DATE(?) AND DATE(?)
+		UNION ALL
+		SELECT 'purchase', pbl.tax_rate
+		FROM purchase_bill_lines pbl
+		INNER JOIN purchase_bills pb ON pb.id = pbl.bill_id
+		INNER JOIN journals j ON j.id = pb.journal_id
+		WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) BETWEEN DATE(?) AND DATE(?)
+	) AS tax_rates
+	ORDER BY kind ASC, rate ASC
+`
+
+// GetStandardAuditFile collects the SAF-T content of the company of the request for a date range. Only the customers
+// and vendors the company has invoiced, billed, received from or paid up to the end date are kept.
+func (r *reader) GetStandardAuditFile(ctx context.Context, startDate time.Time, endDate time.Time) (file domain.StandardAuditFile, err error) {
+	var (
+		journals []domain.Journal
+		lines    []domain.GeneralLedger
+	)
+
+	startDate, endDate = truncateDate(startDate), truncateDate(endDate)
+	if endDate.Before(startDate) {
+		err = errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "endDate", Message: "End date must not be before the start date"}},
+			EcodeStandardAuditFileInvalid,
+			"End date must not be before the start date",
+		)
+		return
+	}
+
+	file = domain.StandardAuditFile{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Accounts:  make([]domain.StandardAuditFileAccount, 0),
+		Customers: make([]domain.Customer, 0),
+		Vendors:   make([]domain.Vendor, 0),
+		TaxRates:  make([]domain.StandardAuditFileTaxRate, 0),
+	}
+
+	if file.Company, err = r.GetCompanyByID(ctx, companyID(ctx)); err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &file.Accounts, r.db.Rebind(standardAuditFileAccountsQuery), startDate, endDate, file.Company.ID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get account balances")
+		return
+	}
+
+	journalsQuery := `
+		SELECT id, type_id, number, amount, created_at, trans_date, memo, closing, opening
+		FROM journals
+		WHERE deleted_at IS NULL AND company_id = ? AND DATE(trans_date) BETWEEN DATE(?) AND DATE(?)
+		ORDER BY trans_date ASC, created_at ASC, id ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &journals, r.db.Rebind(journalsQuery), file.Company.ID, startDate, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get journals")
+		return
+	}
+
+	linesQuery := `
+		SELECT
+			gl.id, gl.journal_id, gl.account_id, gl.amount, gl.created_by, gl.memo, gl.external_reference,
+			gl.counterparty_company_id
+		FROM general_ledgers gl
+		INNER JOIN journals j ON j.id = gl.journal_id
+		WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) BETWEEN DATE(?) AND DATE(?)
+		ORDER BY gl.journal_id ASC, gl.amount DESC, gl.id ASC
+	`
+
+	if err = r.db.SelectContext(ctx, &lines, r.db.Rebind(linesQuery), file.Company.ID, startDate, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get general ledgers")
+		return
+	}
+
+	file.Journals = newStandardAuditFileJournals(journals, lines)
+
+	customersWhere := `
+		WHERE id IN (
+			SELECT si.customer_id FROM sales_invoices si INNER JOIN journals j ON j.id = si.journal_id
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) <= DATE(?)
+			UNION
+			SELECT cr.customer_id FROM customer_receipts cr INNER JOIN journals j ON j.id = cr.journal_id
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) <= DATE(?)
+		)
+		ORDER BY code ASC
+	`
+
+	query := fmt.Sprintf(customersQuery, customersWhere)
+	if err = r.db.SelectContext(ctx, &file.Customers, r.db.Rebind(query), file.Company.ID, endDate, file.Company.ID, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get customers")
+		return
+	}
+
+	vendorsWhere := `
+		WHERE id IN (
+			SELECT pb.vendor_id FROM purchase_bills pb INNER JOIN journals j ON j.id = pb.journal_id
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) <= DATE(?)
+			UNION
+			SELECT vp.vendor_id FROM vendor_payments vp INNER JOIN journals j ON j.id = vp.journal_id
+			WHERE j.deleted_at IS NULL AND j.company_id = ? AND DATE(j.trans_date) <= DATE(?)
+		)
+		ORDER BY code ASC
+	`
+
+	query = fmt.Sprintf(vendorsQuery, vendorsWhere)
+	if err = r.db.SelectContext(ctx, &file.Vendors, r.db.Rebind(query), file.Company.ID, endDate, file.Company.ID, endDate); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get vendors")
+		return
+	}
+
+	args := []interface{}{file.Company.ID, startDate, endDate, file.Company.ID, startDate, endDate}
+	if err = r.db.SelectContext(ctx, &file.TaxRates, r.db.Rebind(standardAuditFileTaxRatesQuery), args...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetStandardAuditFileFailed, "Failed on get tax rates")
+		return
+	}
+
+	return
+}
+
+// VerifyLedger runs the ledger checks over the company of the request and reports every issue they find.
+func (r *reader) VerifyLedger(ctx context.Context) (verification domain.LedgerVerification, err error) {
+	verification = domain.LedgerVerification{CompanyID: companyID(ctx), Issues: make([]domain.LedgerIssue, 0)}
+
+	query := "SELECT COUNT(*) FROM journals WHERE deleted_at IS NULL AND company_id = ?"
+	if err = r.db.GetContext(ctx, &verification.CheckedJournals, r.db.Rebind(query), verification.CompanyID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeVerifyLedgerFailed, "Failed on count journals")
+		return
+	}
+
+	for _, check := range ledgerChecks {
+		var issues []domain.LedgerIssue
+
+		if err = r.db.SelectContext(ctx, &issues, r.db.Rebind(check.query), verification.CompanyID); err != nil {
+			err = errors.PropagateWithCode(err, EcodeVerifyLedgerFailed, fmt.Sprintf("Failed on check %s", check.check))
+			return
+		}
+
+		for _, issue := range issues {
+			issue.Check = check.check
+			issue.Message = check.message(issue)
+			verification.Issues = append(verification.Issues, issue)
+		}
+	}
+
+	verification.Valid = len(verification.Issues) == 0
+
+	return
 }
@@ -0,0 +1,24 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/google/uuid"
+)
+
+// newStandardAuditFileJournals hands each journal its lines, keeping the order of the journals.
+func newStandardAuditFileJournals(journals []domain.Journal, lines []domain.GeneralLedger) (result []domain.StandardAuditFileJournal) {
+	linesByJournalID := make(map[uuid.UUID][]domain.GeneralLedger, len(journals))
+	for _, line := range lines {
+		linesByJournalID[line.JournalID] = append(linesByJournalID[line.JournalID], line)
+	}
+
+	result = make([]domain.StandardAuditFileJournal, len(journals))
+	for i, journal := range journals {
+		result[i] = domain.StandardAuditFileJournal{Journal: journal, Lines: linesByJournalID[journal.ID]}
+		if result[i].Lines == nil {
+			result[i].Lines = make([]domain.GeneralLedger, 0)
+		}
+	}
+
+	return
+}
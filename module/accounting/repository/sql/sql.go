@@ -1,14 +1,23 @@
 package sql
 
 import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/auth"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/logger"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
+	"github.com/google/uuid"
 )
 
+// PermissionChecker reports whether a user has been granted a permission on a resource.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (hasPermission bool, err error)
+}
+
 type Options struct {
-	MasterDB sql.DB
-	SlaveDB  sql.DB
-	Logger   logger.Logger
+	MasterDB   sql.DB
+	SlaveDB    sql.DB
+	Logger     logger.Logger
+	Permission PermissionChecker
 }
 
 type SQL interface {
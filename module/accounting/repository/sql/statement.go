@@ -6,37 +6,50 @@ import (
 )
 
 type AccountClassStatement struct {
-	ID int64
+	ID        int64
+	Code      string
+	CompanyID int64
 }
 
 type AccountGroupStatement struct {
 	ID             int64
+	Code           string
 	ParentID       int64
 	ParentIDIsNULL bool
+	CompanyID      int64
 }
 
 type AccountStatement struct {
 	ID             int64
-	AccountClassID int64 `qb:"-"`
-	ClassType      int64 `qb:"-"`
+	Code           string
+	GroupID        int64
+	Name           string
+	AccountClassID int64
+	ClassType      int64
+	GroupAccountID int64
+	CompanyID      int64
 }
 
 type GeneralLedgerPreferenceStatement struct {
-	ID int64
+	ID        int64
+	CompanyID int64
 }
 
 type FiscalYearStatement struct {
 	ID           int64
+	Closed       bool
 	ClosedNotEQ  bool
 	StartDateGTE time.Time
 	StartDateLTE time.Time
 	EndDateGTE   time.Time
 	EndDateLTE   time.Time
+	CompanyID    int64
 }
 
 type BankAccountStatement struct {
 	ID        int64
 	AccountID int64
+	CompanyID int64
 }
 
 type GeneralLedgerStatement struct {
@@ -44,10 +57,203 @@ type GeneralLedgerStatement struct {
 	JournalID uuid.UUID
 }
 
+type JournalNumberFormatStatement struct {
+	TypeID    int64
+	CompanyID int64
+}
+
 type JournalStatement struct {
-	ID uuid.UUID
+	ID              uuid.UUID
+	Number          string
+	Opening         bool
+	DeletedAtIsNULL bool
+	CompanyID       int64
 }
 
 type BankTransactionStatement struct {
 	JournalID uuid.UUID
 }
+
+type JournalDraftStatement struct {
+	ID        uuid.UUID
+	StatusID  int64
+	CreatedBy uuid.UUID
+	JournalID uuid.UUID
+	CompanyID int64
+}
+
+type JournalDraftLineStatement struct {
+	DraftID uuid.UUID
+}
+
+type JournalDraftReviewStatement struct {
+	DraftID uuid.UUID
+}
+
+type ApprovalRuleStatement struct {
+	ID        int64
+	CompanyID int64
+}
+
+type FiscalPeriodStatement struct {
+	ID           int64
+	FiscalYearID int64
+	StatusID     int64
+}
+
+type FiscalPeriodHistoryStatement struct {
+	FiscalPeriodID int64
+}
+
+type FiscalYearHistoryStatement struct {
+	FiscalYearID int64
+	ActionID     int64
+}
+
+type AttachmentStatement struct {
+	ID                uuid.UUID
+	JournalID         uuid.UUID
+	BankTransactionID int64
+	CompanyID         int64
+}
+
+type BudgetStatement struct {
+	ID           int64
+	FiscalYearID int64
+	CompanyID    int64
+}
+
+type BudgetLineStatement struct {
+	BudgetID       int64
+	AccountID      int64
+	FiscalPeriodID int64
+	Dimension      string
+}
+
+type AmortizationScheduleStatement struct {
+	ID        int64
+	StatusID  int64
+	CompanyID int64
+}
+
+type AmortizationEntryStatement struct {
+	ScheduleID int64
+}
+
+type AssetCategoryStatement struct {
+	ID int64
+}
+
+type FixedAssetStatement struct {
+	ID         int64
+	CategoryID int64
+	StatusID   int64
+	CompanyID  int64
+}
+
+type DepreciationRunStatement struct {
+	ID        int64
+	CompanyID int64
+}
+
+type DepreciationEntryStatement struct {
+	RunID   int64
+	AssetID int64
+}
+
+type CustomerStatement struct {
+	ID        int64
+	Code      string
+	CompanyID int64
+}
+
+type SalesInvoiceStatement struct {
+	ID         int64
+	CustomerID int64
+	Number     string
+	CompanyID  int64
+}
+
+type SalesInvoiceLineStatement struct {
+	InvoiceID int64
+}
+
+type CustomerReceiptStatement struct {
+	ID         int64
+	CustomerID int64
+	CompanyID  int64
+}
+
+type ReceiptAllocationStatement struct {
+	ReceiptID int64
+	InvoiceID int64
+}
+
+type VendorStatement struct {
+	ID        int64
+	Code      string
+	CompanyID int64
+}
+
+type PurchaseBillStatement struct {
+	ID        int64
+	VendorID  int64
+	Number    string
+	CompanyID int64
+}
+
+type PurchaseBillLineStatement struct {
+	BillID int64
+}
+
+type VendorPaymentStatement struct {
+	ID        int64
+	VendorID  int64
+	CompanyID int64
+}
+
+type PaymentAllocationStatement struct {
+	PaymentID int64
+	BillID    int64
+}
+
+type CreditNoteStatement struct {
+	ID        int64
+	InvoiceID int64
+	Number    string
+	CompanyID int64
+}
+
+type DebitNoteStatement struct {
+	ID        int64
+	BillID    int64
+	Number    string
+	CompanyID int64
+}
+
+type CompanyStatement struct {
+	ID   int64
+	Code string
+}
+
+type GroupAccountStatement struct {
+	ID      int64
+	Code    string
+	ClassID int64
+}
+
+type IntercompanyTransactionStatement struct {
+	ID            int64
+	Reference     string
+	FromCompanyID int64
+	ToCompanyID   int64
+}
+
+type AuditLogStatement struct {
+	CompanyID    int64
+	Entity       string
+	EntityID     string
+	UserID       uuid.UUID
+	CreatedAtGTE time.Time
+	CreatedAtLTE time.Time
+}
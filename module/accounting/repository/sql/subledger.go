@@ -0,0 +1,148 @@
+package sql
+
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"time"
+)
+
+// agingItem is the amount of one open document of a party, or of one payment not applied yet, as of the aging date.
+type agingItem struct {
+	PartyID   int64     `db:"party_id"`
+	PartyName string    `db:"party_name"`
+	DueDate   time.Time `db:"due_date"`
+	Amount    float64
+}
+
+// newAgingReport buckets the open documents by the days between their due date and asOf, one row per party
+// in the order the parties first appear in documents and then payments.
+func newAgingReport(asOf time.Time, documents []agingItem, payments []agingItem) (report domain.AgingReport) {
+	var rowIndex = make(map[int64]int)
+
+	report.AsOf = asOf
+	report.Rows = make([]domain.AgingRow, 0)
+
+	row := func(item agingItem) *domain.AgingRow {
+		i, ok := rowIndex[item.PartyID]
+		if !ok {
+			i = len(report.Rows)
+			rowIndex[item.PartyID] = i
+			report.Rows = append(report.Rows, domain.AgingRow{PartyID: item.PartyID, PartyName: item.PartyName})
+		}
+
+		return &report.Rows[i]
+	}
+
+	for _, document := range documents {
+		if roundCents(document.Amount) == 0 {
+			continue
+		}
+
+		r := row(document)
+		switch daysPastDue := int(truncateDate(asOf).Sub(truncateDate(document.DueDate)).Hours() / 24); {
+		case daysPastDue <= 0:
+			r.Current += document.Amount
+		case daysPastDue <= 30:
+			r.Days1To30 += document.Amount
+		case daysPastDue <= 60:
+			r.Days31To60 += document.Amount
+		case daysPastDue <= 90:
+			r.Days61To90 += document.Amount
+		default:
+			r.Over90 += document.Amount
+		}
+
+		r.Total += document.Amount
+	}
+
+	for _, payment := range payments {
+		if roundCents(payment.Amount) == 0 {
+			continue
+		}
+
+		row(payment).Unapplied += payment.Amount
+	}
+
+	for i := range report.Rows {
+		r := &report.Rows[i]
+		r.Current, r.Days1To30, r.Days31To60 = roundCents(r.Current), roundCents(r.Days1To30), roundCents(r.Days31To60)
+		r.Days61To90, r.Over90, r.Total, r.Unapplied = roundCents(r.Days61To90), roundCents(r.Over90), roundCents(r.Total), roundCents(r.Unapplied)
+
+		report.Total.Current += r.Current
+		report.Total.Days1To30 += r.Days1To30
+		report.Total.Days31To60 += r.Days31To60
+		report.Total.Days61To90 += r.Days61To90
+		report.Total.Over90 += r.Over90
+		report.Total.Total += r.Total
+		report.Total.Unapplied += r.Unapplied
+	}
+
+	t := &report.Total
+	t.Current, t.Days1To30, t.Days31To60 = roundCents(t.Current), roundCents(t.Days1To30), roundCents(t.Days31To60)
+	t.Days61To90, t.Over90, t.Total, t.Unapplied = roundCents(t.Days61To90), roundCents(t.Over90), roundCents(t.Total), roundCents(t.Unapplied)
+
+	return
+}
+
+// newPartyStatement folds the lines dated before startDate into the opening balance and runs the balance
+// through the rest. lines must be ordered by date.
+func newPartyStatement(partyID int64, startDate time.Time, endDate time.Time, lines []domain.PartyStatementLine) (statement domain.PartyStatement) {
+	statement = domain.PartyStatement{
+		PartyID:   partyID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Lines:     make([]domain.PartyStatementLine, 0),
+	}
+
+	balance := 0.0
+	for _, line := range lines {
+		balance = roundCents(balance + line.Amount)
+		if line.Date.Before(startDate) {
+			statement.OpeningBalance = balance
+			continue
+		}
+
+		line.Balance = balance
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	statement.ClosingBalance = balance
+
+	return
+}
+
+// prorate splits amount over weights in proportion to each weight, rounded to cents, the rounding
+// difference going to the last part with a weight so the parts add up to amount.
+func prorate(amount float64, weights []float64) []float64 {
+	var (
+		total float64
+		last  = -1
+		rest  = roundCents(amount)
+		parts = make([]float64, len(weights))
+	)
+
+	for i, weight := range weights {
+		total += weight
+		if weight != 0 {
+			last = i
+		}
+	}
+
+	if total == 0 {
+		return parts
+	}
+
+	for i, weight := range weights {
+		if i == last {
+			break
+		}
+
+		parts[i] = roundCents(amount * weight / total)
+		rest = roundCents(rest - parts[i])
+	}
+
+	if last >= 0 {
+		parts[last] = rest
+	}
+
+	return parts
+}
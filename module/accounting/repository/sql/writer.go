@@ -6,11 +6,15 @@ import (
 	goErr "errors"
 	"fmt"
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/auth"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/logger"
 	qb "github.com/QuickAmethyst/monosvc/stdlibgo/querybuilder/sql"
 	"github.com/QuickAmethyst/monosvc/stdlibgo/sql"
 	"github.com/google/uuid"
+	"math"
+	"strings"
 	"time"
 )
 
@@ -26,11 +30,16 @@ type Writer interface {
 	StoreAccount(ctx context.Context, account *domain.Account) (err error)
 	UpdateAccountByID(ctx context.Context, id int64, account *domain.Account) (err error)
 	DeleteAccountByID(ctx context.Context, id int64) (err error)
+	UpdateAccountCodeFormatByClassTypeID(ctx context.Context, classTypeID int64, pattern string) (err error)
+
+	ImportChartOfAccounts(ctx context.Context, chart domain.ChartOfAccounts, dryRun bool) (changes []domain.ChartOfAccountsChange, err error)
+	ApplyChartOfAccountsTemplate(ctx context.Context, chart domain.ChartOfAccounts) (changes []domain.ChartOfAccountsChange, err error)
 
 	StoreTransaction(ctx context.Context, userID uuid.UUID, transactions Transaction) (journal *domain.Journal, err error)
 	StoreTransactionTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, transaction Transaction) (journal *domain.Journal, err error)
-	VoidTransactionByID(ctx context.Context, journalID uuid.UUID) (err error)
-	VoidTransactionByIDTx(tx sql.Tx, ctx context.Context, journalID uuid.UUID) (err error)
+	VoidTransactionByID(ctx context.Context, journalID uuid.UUID, userID uuid.UUID) (err error)
+	VoidTransactionByIDTx(tx sql.Tx, ctx context.Context, journalID uuid.UUID, userID uuid.UUID) (err error)
+	AmendJournalByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction Transaction, reason string) (journal *domain.Journal, err error)
 
 	UpdateGeneralLedgerPreferenceByID(ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error)
 	UpdateGeneralLedgerPreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error)
@@ -39,20 +48,94 @@ type Writer interface {
 	UpdateBankAccountByID(ctx context.Context, id int64, bankAccount *domain.BankAccount) (err error)
 	StoreBankDepositTransaction(ctx context.Context, userID uuid.UUID, transaction BankTransaction) (bankTransaction domain.BankTransaction, err error)
 
-	StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear) (err error)
+	StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear, periodMonths int) (err error)
 	CloseFiscalYear(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	ReopenFiscalYear(ctx context.Context, id int64, userID uuid.UUID, reason string) (err error)
+
+	ImportOpeningBalances(ctx context.Context, userID uuid.UUID, rows []TransactionRow) (journal *domain.Journal, err error)
+	ImportJournals(ctx context.Context, userID uuid.UUID, journalImport *domain.JournalImport) (err error)
+
+	UpdateJournalNumberFormatByTypeID(ctx context.Context, typeID int64, userID uuid.UUID, format string) (err error)
+
+	StoreAttachment(ctx context.Context, attachment *domain.Attachment) (err error)
+	DeleteAttachmentByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error)
+
+	GenerateFiscalPeriods(ctx context.Context, fiscalYearID int64, periodMonths int) (periods []domain.FiscalPeriod, err error)
+	UpdateFiscalPeriodStatusByID(ctx context.Context, id int64, userID uuid.UUID, statusID int64, reason string) (err error)
+
+	StoreJournalDraft(ctx context.Context, userID uuid.UUID, transaction Transaction) (draft *domain.JournalDraft, err error)
+	UpdateJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction Transaction) (draft *domain.JournalDraft, err error)
+	SubmitJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error)
+	ApproveJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error)
+	RejectJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error)
+
+	StoreApprovalRule(ctx context.Context, userID uuid.UUID, rule *domain.ApprovalRule) (err error)
+	UpdateApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID, rule *domain.ApprovalRule) (err error)
+	DeleteApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+
+	StoreBudget(ctx context.Context, userID uuid.UUID, budget *domain.Budget) (err error)
+	UpdateBudgetByID(ctx context.Context, id int64, budget *domain.Budget) (err error)
+	DeleteBudgetByID(ctx context.Context, id int64) (err error)
+	StoreBudgetLines(ctx context.Context, budgetID int64, lines []domain.BudgetLine) (err error)
+	GenerateBudgetFromActuals(ctx context.Context, userID uuid.UUID, budget *domain.Budget, sourceFiscalYearID int64, percent float64) (err error)
+
+	StoreAmortizationSchedule(ctx context.Context, userID uuid.UUID, schedule *domain.AmortizationSchedule) (err error)
+	PostDueAmortizationEntries(ctx context.Context, asOf time.Time) (entries []domain.AmortizationEntry, err error)
+	TerminateAmortizationScheduleByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, writeOffRemaining bool) (err error)
+
+	StoreAssetCategory(ctx context.Context, category *domain.AssetCategory) (err error)
+	UpdateAssetCategoryByID(ctx context.Context, id int64, category *domain.AssetCategory) (err error)
+	StoreFixedAsset(ctx context.Context, userID uuid.UUID, asset *domain.FixedAsset) (err error)
+	UpdateFixedAssetByID(ctx context.Context, id int64, asset *domain.FixedAsset) (err error)
+	RunDepreciation(ctx context.Context, userID uuid.UUID, date time.Time) (run domain.DepreciationRun, err error)
+	DisposeFixedAssetByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, proceeds float64, proceedsAccountID int64) (err error)
+
+	StoreCustomer(ctx context.Context, customer *domain.Customer) (err error)
+	UpdateCustomerByID(ctx context.Context, id int64, customer *domain.Customer) (err error)
+	StoreSalesInvoice(ctx context.Context, userID uuid.UUID, invoice *domain.SalesInvoice) (err error)
+	VoidSalesInvoiceByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreCustomerReceipt(ctx context.Context, userID uuid.UUID, receipt *domain.CustomerReceipt) (err error)
+	AllocateCustomerReceipt(ctx context.Context, receiptID int64, allocations []domain.ReceiptAllocation) (err error)
+	StoreVendor(ctx context.Context, vendor *domain.Vendor) (err error)
+	UpdateVendorByID(ctx context.Context, id int64, vendor *domain.Vendor) (err error)
+	StorePurchaseBill(ctx context.Context, userID uuid.UUID, bill *domain.PurchaseBill) (err error)
+	VoidPurchaseBillByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreVendorPayment(ctx context.Context, userID uuid.UUID, payment *domain.VendorPayment) (err error)
+	AllocateVendorPayment(ctx context.Context, paymentID int64, allocations []domain.PaymentAllocation) (err error)
+	StoreCreditNote(ctx context.Context, userID uuid.UUID, note *domain.CreditNote) (err error)
+	VoidCreditNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreDebitNote(ctx context.Context, userID uuid.UUID, note *domain.DebitNote) (err error)
+	VoidDebitNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+
+	StoreCompany(ctx context.Context, userID uuid.UUID, company *domain.Company) (err error)
+	UpdateCompanyByID(ctx context.Context, id int64, company *domain.Company) (err error)
+	AddCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error)
+	RemoveCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error)
+	StoreGroupAccount(ctx context.Context, groupAccount *domain.GroupAccount) (err error)
+	UpdateGroupAccountByID(ctx context.Context, id int64, groupAccount *domain.GroupAccount) (err error)
+	MapAccountToGroupAccount(ctx context.Context, accountID int64, groupAccountID int64) (err error)
+
+	StoreIntercompanyTransaction(ctx context.Context, userID uuid.UUID, transaction *domain.IntercompanyTransaction) (err error)
+	VoidIntercompanyTransactionByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
 }
 
 type writer struct {
-	logger logger.Logger
-	db     sql.DB
-	reader Reader
+	logger     logger.Logger
+	db         sql.DB
+	reader     Reader
+	permission PermissionChecker
 }
 
 func (w *writer) storeBankTransactionTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, bankTransaction domain.BankTransaction) (err error) {
+	// the bank account is locked so two postings to it can not both build on the same last balance
+	if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM bank_accounts WHERE id = ? FOR UPDATE"), bankTransaction.BankAccountID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Failed on lock bank account")
+		return err
+	}
+
 	query := `
 		INSERT INTO bank_transactions (journal_id, bank_account_id, amount, balance, memo, created_by, trans_date) VALUES
-		(?, ?, ?, (SELECT COALESCE((SELECT balance FROM bank_transactions GROUP BY id ORDER BY id DESC LIMIT 1), 0) + ?), ?, ?, ?)
+		(?, ?, ?, (SELECT COALESCE((SELECT balance FROM bank_transactions WHERE bank_account_id = ? ORDER BY id DESC LIMIT 1), 0) + ?), ?, ?, ?)
 		RETURNING id;
 	`
 
@@ -62,6 +145,7 @@ func (w *writer) storeBankTransactionTx(tx sql.Tx, ctx context.Context, userID u
 		bankTransaction.JournalID,
 		bankTransaction.BankAccountID,
 		bankTransaction.Amount,
+		bankTransaction.BankAccountID,
 		bankTransaction.Amount,
 		bankTransaction.Memo,
 		userID,
@@ -76,12 +160,29 @@ func (w *writer) storeBankTransactionTx(tx sql.Tx, ctx context.Context, userID u
 }
 
 func (w *writer) storeBankTransactionJournal(ctx context.Context, userID uuid.UUID, transaction BankTransaction) (bankTransaction domain.BankTransaction, err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		_, err := w.storeBankTransactionJournalTx(tx, ctx, userID, transaction)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store bank transaction")
+		return
+	}
+
+	return
+}
+
+// storeBankTransactionJournalTx credits every row of a deposit against the bank account, or debits every row
+// of a payment against it, within tx, so other postings such as customer receipts can record their own rows
+// along with it.
+func (w *writer) storeBankTransactionJournalTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, transaction BankTransaction) (journal *domain.Journal, err error) {
 	var (
 		totalAmount float64
 		bankAccount domain.BankAccount
 	)
 
-	if transaction.bankTransactionType < Deposit || transaction.bankTransactionType > Deposit {
+	if transaction.bankTransactionType < Deposit || transaction.bankTransactionType > Payment {
 		err = errors.PropagateWithCode(fmt.Errorf("invalid bank transaction type"), EcodeBankTransactionTypeInvalid, "Invalid bank transaction type")
 		return
 	}
@@ -117,47 +218,38 @@ func (w *writer) storeBankTransactionJournal(ctx context.Context, userID uuid.UU
 			return
 		}
 
-		transaction.Data[i].Amount = -transaction.Data[i].Amount
+		if transaction.bankTransactionType == Deposit {
+			transaction.Data[i].Amount = -transaction.Data[i].Amount
+		}
+
 		totalAmount += row.Amount
 	}
 
+	// a payment takes the total out of the bank account
+	if transaction.bankTransactionType == Payment {
+		totalAmount = -totalAmount
+	}
+
 	bankAccount, err = w.reader.GetBankAccountByID(ctx, transaction.BankAccountID)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeGetBankAccountFailed, "Failed on get bank account")
 		return
 	}
 
+	transaction.Transaction.typeID = BankDepositJournalType
+	if transaction.bankTransactionType == Payment {
+		transaction.Transaction.typeID = BankPaymentJournalType
+	}
+
 	// post bank account to gl
 	transaction.Transaction.Data = append(transaction.Transaction.Data, TransactionRow{
 		AccountID: bankAccount.AccountID,
 		Amount:    totalAmount,
 	})
 
-	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		if _, err := w.StoreTransactionTx(tx, ctx, userID, transaction.Transaction); err != nil {
-			err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Failed on store journal")
-			return err
-		}
-
-		err := w.storeBankTransactionTx(tx, ctx, userID, domain.BankTransaction{
-			JournalID:     transaction.journalID,
-			BankAccountID: transaction.BankAccountID,
-			UserID:        userID,
-			Amount:        totalAmount,
-			Memo:          transaction.Memo,
-			TransDate:     transaction.Date,
-		})
-
-		if err != nil {
-			err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Failed on store bank transaction")
-			return err
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Failed on store bank transaction")
+	// the bank transaction is recorded by StoreTransactionTx along with the line on the bank account
+	if journal, err = w.StoreTransactionTx(tx, ctx, userID, transaction.Transaction); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Failed on store journal")
 		return
 	}
 
@@ -175,9 +267,20 @@ func (w *writer) StoreBankDepositTransaction(ctx context.Context, userID uuid.UU
 	return
 }
 
-func (w *writer) VoidTransactionByID(ctx context.Context, journalID uuid.UUID) (err error) {
+func (w *writer) VoidTransactionByID(ctx context.Context, journalID uuid.UUID, userID uuid.UUID) (err error) {
+	// an intercompany journal is only voided together with its mirror in the other company
+	journal, err := w.reader.GetJournalByID(ctx, journalID)
+	if err == nil && journal.TypeID == IntercompanyJournalType {
+		err = errors.PropagateWithCode(
+			goErr.New("intercompany journal"),
+			EcodeVoidIntercompanyJournalProhibited,
+			"Intercompany journal must be voided with its intercompany transaction",
+		)
+		return
+	}
+
 	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		err = w.VoidTransactionByIDTx(tx, ctx, journalID)
+		err = w.VoidTransactionByIDTx(tx, ctx, journalID, userID)
 		if err != nil {
 			err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on void transaction")
 			return err
@@ -194,22 +297,19 @@ func (w *writer) VoidTransactionByID(ctx context.Context, journalID uuid.UUID) (
 	return
 }
 
-func (w *writer) VoidTransactionByIDTx(tx sql.Tx, ctx context.Context, journalID uuid.UUID) (err error) {
-	activeFiscalYear, err := w.reader.GetActiveFiscalYear(ctx)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on get active fiscal year")
-		return
-	}
-
+func (w *writer) VoidTransactionByIDTx(tx sql.Tx, ctx context.Context, journalID uuid.UUID, userID uuid.UUID) (err error) {
 	journal, err := w.reader.GetJournalByID(ctx, journalID)
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on get journal by id")
 		return
 	}
 
-	// check is journal closed
-	if journal.TransDate.Before(activeFiscalYear.StartDate) {
-		err = errors.PropagateWithCode(err, EcodeJournalAlreadyClosed, "Closing closed journal prohibited")
+	// check is journal closed or locked
+	if err = w.validatePostingDate(ctx, userID, journal.TransDate); err != nil {
+		if errors.GetCode(err) == EcodeStoreTransactionProhibited {
+			err = errors.PropagateWithCode(err, EcodeJournalAlreadyClosed, "Closing closed journal prohibited")
+		}
+
 		return
 	}
 
@@ -225,12 +325,75 @@ func (w *writer) VoidTransactionByIDTx(tx sql.Tx, ctx context.Context, journalID
 		return
 	}
 
-	query := "UPDATE journals SET deleted_at = ? WHERE journal_id = ?"
+	// an invoice keeps its journal while receipts or credit notes are allocated to it
+	var hasAllocations bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM receipt_allocations ra
+			INNER JOIN sales_invoices si ON si.id = ra.invoice_id
+			WHERE si.journal_id = ?
+		) OR EXISTS (
+			SELECT 1
+			FROM credit_notes cn
+			INNER JOIN sales_invoices si ON si.id = cn.invoice_id
+			INNER JOIN journals cj ON cj.id = cn.journal_id
+			WHERE si.journal_id = ? AND cj.deleted_at IS NULL
+		)
+	`
+
+	if err = tx.GetContext(ctx, &hasAllocations, tx.Rebind(query), journalID, journalID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on check receipt allocations")
+		return
+	}
+
+	if hasAllocations {
+		err = errors.PropagateWithCode(goErr.New("sales invoice has allocations"), EcodeSalesInvoiceHasAllocations, "Sales invoice has receipts or credit notes allocated to it")
+		return
+	}
+
+	// so does a bill while payments or debit notes are allocated to it
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM payment_allocations pa
+			INNER JOIN purchase_bills pb ON pb.id = pa.bill_id
+			WHERE pb.journal_id = ?
+		) OR EXISTS (
+			SELECT 1
+			FROM debit_notes dn
+			INNER JOIN purchase_bills pb ON pb.id = dn.bill_id
+			INNER JOIN journals dj ON dj.id = dn.journal_id
+			WHERE pb.journal_id = ? AND dj.deleted_at IS NULL
+		)
+	`
+
+	if err = tx.GetContext(ctx, &hasAllocations, tx.Rebind(query), journalID, journalID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on check payment allocations")
+		return
+	}
+
+	if hasAllocations {
+		err = errors.PropagateWithCode(goErr.New("purchase bill has allocations"), EcodePurchaseBillHasAllocations, "Purchase bill has payments or debit notes allocated to it")
+		return
+	}
+
+	before, err := snapshotTx(tx, ctx, AuditEntityJournal, journalID)
+	if err != nil {
+		return
+	}
+
+	query = "UPDATE journals SET deleted_at = ? WHERE id = ?"
 	if _, err = tx.ExecContext(ctx, tx.Rebind(query), time.Now(), journalID); err != nil {
 		err = errors.PropagateWithCode(err, EcodeVoidTransactionByIDFailed, "Failed on void transaction")
 		return
 	}
 
+	if err = auditTx(tx, ctx, userID, AuditEntityJournal, journalID, AuditOperationVoid, before); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -246,12 +409,20 @@ func (w *writer) StoreBankAccount(ctx context.Context, bankAccount *domain.BankA
 		return
 	}
 
-	query := "INSERT INTO bank_accounts (account_id, type_id, bank_number) VALUES (?, ?, ?) RETURNING id"
-	err = w.db.QueryRowContext(
-		ctx,
-		w.db.Rebind(query),
-		bankAccount.AccountID, bankAccount.TypeID, bankAccount.BankNumber,
-	).Scan(&bankAccount.ID)
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := "INSERT INTO bank_accounts (account_id, type_id, bank_number, company_id) VALUES (?, ?, ?, ?) RETURNING id"
+		err := tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			bankAccount.AccountID, bankAccount.TypeID, bankAccount.BankNumber, companyID(ctx),
+		).Scan(&bankAccount.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityBankAccount, bankAccount.ID, AuditOperationCreate, goSql.NullString{})
+	})
 
 	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeStoreBankAccountFailed, "Store bank account failed")
@@ -284,7 +455,20 @@ func (w *writer) UpdateBankAccountByID(ctx context.Context, id int64, bankAccoun
 	}
 
 	bankAccount.ID = id
-	if _, err = w.db.Updates(ctx, "bank_accounts", bankAccount, &BankAccountStatement{ID: id}); err != nil {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityBankAccount, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Updates(ctx, "bank_accounts", bankAccount, &BankAccountStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityBankAccount, id, AuditOperationUpdate, before)
+	})
+
+	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeUpdateBankAccountFailed, "Update bank account by id failed")
 		return
 	}
@@ -293,57 +477,65 @@ func (w *writer) UpdateBankAccountByID(ctx context.Context, id int64, bankAccoun
 }
 
 func (w *writer) CloseFiscalYear(ctx context.Context, id int64, userID uuid.UUID) (err error) {
-	// check if retained earnings account is valid
-	retainedEarningsGLP, err := w.reader.GetGeneralLedgerPreferenceByID(ctx, GeneralLedgerPreferenceStatement{ID: int64(RetainedEarnings)})
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{ID: id})
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
 		return
 	}
 
-	if err = w.reader.ValidatePreferences(ctx, []domain.GeneralLedgerPreference{retainedEarningsGLP}); err != nil {
-		err = errors.PropagateWithCode(err, EcodeCloseFiscalYearFailed, "Validate general ledger preferences failed")
+	if fiscalYear.Closed {
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal year already closed"), EcodeFiscalYearAlreadyClosed, "Fiscal year already closed")
 		return
 	}
 
 	// check if close fiscal year is allowed
 	activeFiscalYear, err := w.reader.GetActiveFiscalYear(ctx)
-	if err != nil && err != sql.ErrNoRows {
+	if err != nil {
 		err = errors.PropagateWithCode(err, EcodeCloseFiscalYearFailed, "Failed on get active fiscal year")
 		return
 	}
 
-	if err != sql.ErrNoRows && activeFiscalYear.ID != id {
+	if activeFiscalYear.ID != id {
 		err = errors.PropagateWithCode(fmt.Errorf("close fiscal year prohibited"), EcodeCloseFiscalYearFailed, "Cannot close fiscal year while there are open fiscal years before")
 		return
 	}
 
-	// get balance amount
-	balance, err := w.reader.GetBalanceSheetAmount(ctx, activeFiscalYear.StartDate, activeFiscalYear.EndDate)
+	closingJournal, err := w.reader.GetClosingJournal(ctx, id)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetBalanceSheetAmountFailed, "Failed on get balance sheet amount")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get closing journal")
 		return
 	}
 
-	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		_, err = w.StoreTransactionTx(tx, ctx, userID, Transaction{
-			Memo: "Close Fiscal Year",
-			Data: []TransactionRow{
-				{retainedEarningsGLP.AccountID.Int64, -balance},
-			},
-		})
+	transaction := Transaction{
+		Date:    closingJournal.TransDate,
+		Memo:    fmt.Sprintf("Closing entries for fiscal year %s - %s", fiscalYear.StartDate.Format("2006-01-02"), fiscalYear.EndDate.Format("2006-01-02")),
+		Data:    make([]TransactionRow, len(closingJournal.Lines)),
+		closing: true,
+	}
+
+	for i, line := range closingJournal.Lines {
+		transaction.Data[i] = TransactionRow{AccountID: line.AccountID, Amount: line.Amount}
+	}
 
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, transaction)
 		if err != nil {
 			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on store transaction")
 			return err
 		}
 
-		activeFiscalYear.Closed = true
-		if err = w.updateFiscalYearByIDTx(tx, ctx, id, &activeFiscalYear); err != nil {
+		fiscalYear.Closed = true
+		if err = w.updateFiscalYearByIDTx(tx, ctx, userID, id, &fiscalYear); err != nil {
 			err = errors.PropagateWithCode(err, EcodeCloseFiscalYearFailed, "Update fiscal year failed")
 			return err
 		}
 
-		return nil
+		history := domain.FiscalYearHistory{FiscalYearID: id, ActionID: domain.CloseFiscalYearAction, CreatedBy: userID}
+		if journal != nil {
+			history.JournalID = uuid.NullUUID{UUID: journal.ID, Valid: true}
+		}
+
+		return w.storeFiscalYearHistoryTx(tx, ctx, &history)
 	})
 
 	if err != nil {
@@ -354,550 +546,5645 @@ func (w *writer) CloseFiscalYear(ctx context.Context, id int64, userID uuid.UUID
 	return
 }
 
-func (w *writer) updateFiscalYearByIDTx(tx sql.Tx, ctx context.Context, id int64, fiscalYear *domain.FiscalYear) (err error) {
-	if _, err = tx.Updates(ctx, "fiscal_years", fiscalYear, &FiscalYearStatement{ID: id}); err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
+func (w *writer) ReopenFiscalYear(ctx context.Context, id int64, userID uuid.UUID, reason string) (err error) {
+	var transaction Transaction
+
+	if reason == "" {
+		err = errors.PropagateWithCode(fmt.Errorf("reason required"), EcodeReopenFiscalYearReasonRequired, "Reason is required to reopen fiscal year")
 		return
 	}
 
-	return
-}
+	if err = w.mustHavePermission(ctx, userID, auth.FiscalYear, auth.WRITE); err != nil {
+		return
+	}
+
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{ID: id})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
+		return
+	}
+
+	if !fiscalYear.Closed {
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal year not closed"), EcodeFiscalYearNotClosed, "Fiscal year is not closed")
+		return
+	}
+
+	// later closed years carry this year's result in their opening balances
+	_, err = w.reader.GetFiscalYear(ctx, FiscalYearStatement{StartDateGTE: fiscalYear.EndDate, Closed: true})
+	if err == nil {
+		err = errors.PropagateWithCode(fmt.Errorf("later fiscal year closed"), EcodeLaterFiscalYearClosed, "Reopen later fiscal years first")
+		return
+	}
+
+	if errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, EcodeReopenFiscalYearFailed, "Failed on get later fiscal year")
+		return
+	}
+
+	closeHistory, err := w.reader.GetFiscalYearHistory(ctx, FiscalYearHistoryStatement{FiscalYearID: id, ActionID: domain.CloseFiscalYearAction})
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, EcodeReopenFiscalYearFailed, "Failed on get fiscal year history")
+		return
+	}
+
+	// reverse the closing journal, years closed without P&L activity have none
+	if err == nil && closeHistory.JournalID.Valid {
+		var gls []domain.GeneralLedger
+
+		gls, err = w.reader.GetAllGeneralLedgersByJournalID(ctx, closeHistory.JournalID.UUID)
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeReopenFiscalYearFailed, "Failed on get closing journal general ledgers")
+			return
+		}
+
+		transaction = Transaction{
+			Date:    fiscalYear.EndDate,
+			Memo:    fmt.Sprintf("Reversal of closing entries for fiscal year %s - %s", fiscalYear.StartDate.Format("2006-01-02"), fiscalYear.EndDate.Format("2006-01-02")),
+			Data:    make([]TransactionRow, len(gls)),
+			closing: true,
+		}
+
+		for i, gl := range gls {
+			transaction.Data[i] = TransactionRow{AccountID: gl.AccountID, Amount: -gl.Amount, CounterpartyCompanyID: gl.CounterpartyCompanyID.Int64}
+		}
+	}
 
-func (w *writer) updateFiscalYearByID(ctx context.Context, id int64, fiscalYear *domain.FiscalYear) (err error) {
 	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		err = w.updateFiscalYearByIDTx(tx, ctx, id, fiscalYear)
+		history := domain.FiscalYearHistory{FiscalYearID: id, ActionID: domain.ReopenFiscalYearAction, CreatedBy: userID}
+
+		if len(transaction.Data) > 0 {
+			journal, err := w.StoreTransactionTx(tx, ctx, userID, transaction)
+			if err != nil {
+				err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on store transaction")
+				return err
+			}
+
+			if journal != nil {
+				history.JournalID = uuid.NullUUID{UUID: journal.ID, Valid: true}
+			}
+		}
+
+		before, err := snapshotTx(tx, ctx, AuditEntityFiscalYear, id)
 		if err != nil {
-			err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
 			return err
 		}
 
-		return nil
+		query := "UPDATE fiscal_years SET closed = FALSE WHERE id = ?"
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), id); err != nil {
+			return err
+		}
+
+		if err := auditTx(tx, ctx, userID, AuditEntityFiscalYear, id, AuditOperationUpdate, before); err != nil {
+			return err
+		}
+
+		if err := history.Reason.Scan(reason); err != nil {
+			return err
+		}
+
+		return w.storeFiscalYearHistoryTx(tx, ctx, &history)
 	})
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
-		return err
+		err = errors.PropagateWithCode(err, EcodeReopenFiscalYearFailed, "Failed on reopen fiscal year")
+		return
 	}
 
 	return
 }
 
-func (w *writer) StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear) (err error) {
+// ImportOpeningBalances posts the opening journal of the first fiscal year, putting any difference to the
+// opening balance equity account. A previous opening journal is replaced until the first period gets locked.
+// It needs the journal posting permission, as it writes the ledger directly.
+func (w *writer) ImportOpeningBalances(ctx context.Context, userID uuid.UUID, rows []TransactionRow) (journal *domain.Journal, err error) {
 	var (
-		intersectedFiscalYear domain.FiscalYear
-		lastEndDate           time.Time
+		fieldErrors errors.ValidationErrors
+		difference  float64
 	)
 
-	if fiscalYear.EndDate.Before(fiscalYear.StartDate) {
-		err = errors.PropagateWithCode(fmt.Errorf("invalid fiscal year end date"), EcodeValidateFiscalYearFailed, "Fiscal year end date must after start date")
+	if err = w.mustHavePermission(ctx, userID, auth.JournalPosting, auth.WRITE); err != nil {
 		return
 	}
 
-	query := "SELECT DATE(MAX(end_date)) FROM fiscal_years"
-	err = w.db.QueryRowContext(ctx, query).Scan(&lastEndDate)
-	if err != nil && err != sql.ErrNoRows {
-		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Failed on get last end date")
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get first fiscal year")
 		return
 	}
 
-	// if there are fiscal year exists, check for start date validity
-	// the start date must equal last end date + one day
-	if err == nil && !fiscalYear.StartDate.Equal(lastEndDate.Add(24*time.Hour)) {
-		err = errors.PropagateWithCode(fmt.Errorf("invalid fiscal year start date"), EcodeValidateFiscalYearFailed, "Fiscal year start date is invalid")
+	if fiscalYear.Closed {
+		err = errors.PropagateWithCode(fmt.Errorf("first fiscal year closed"), EcodeOpeningBalancesLocked, "Opening balances are locked")
 		return
 	}
 
-	args := []interface{}{fiscalYear.StartDate, fiscalYear.StartDate, fiscalYear.EndDate, fiscalYear.EndDate}
-	query = `
-		SELECT id, start_date, end_date, closed
-		FROM fiscal_years
-		WHERE (start_date <= ? AND end_date >= ?) OR (start_date >= ? AND end_date <= ?)
-	`
-
-	err = w.db.GetContext(ctx, &intersectedFiscalYear, w.db.Rebind(query), args...)
-	if err != nil && err != sql.ErrNoRows {
-		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Store fiscal year failed")
+	period, err := w.reader.GetFiscalPeriodByDate(ctx, fiscalYear.StartDate)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on get first fiscal period")
 		return
 	}
 
-	if err == nil {
-		err = errors.PropagateWithCode(fmt.Errorf("intersect with other fiscal year"), EcodeStoreFiscalYearFailed, "Fiscal year intersect with other fiscal year")
+	if err == nil && period.StatusID != domain.OpenPeriodStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("first fiscal period locked"), EcodeOpeningBalancesLocked, "Opening balances are locked")
 		return
 	}
 
-	query = "INSERT INTO fiscal_years (start_date, end_date, closed) VALUES (DATE($1), DATE($2), $3) RETURNING id"
-	err = w.db.QueryRowContext(ctx, w.db.Rebind(query), fiscalYear.StartDate, fiscalYear.EndDate, fiscalYear.Closed).Scan(&fiscalYear.ID)
+	seenAccounts := make(map[int64]bool, len(rows))
+	for i, row := range rows {
+		field := fmt.Sprintf("%d", i)
 
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Store fiscal year failed")
+		if seenAccounts[row.AccountID] {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Duplicate account"})
+			continue
+		}
+
+		seenAccounts[row.AccountID] = true
+
+		accountClass, err := w.reader.GetAccountClassByAccountID(ctx, row.AccountID)
+		if errors.GetCode(err) == EcodeNotFound {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Account not found"})
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on get account class")
+		}
+
+		if !IsBalanceSheetAccount(accountClass.TypeID) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Account must be one of the balance sheet account"})
+			continue
+		}
+
+		difference -= row.Amount
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeImportOpeningBalancesFailed, "Invalid opening balances")
 		return
 	}
 
-	return
-}
+	transaction := Transaction{
+		Date:    fiscalYear.StartDate,
+		Memo:    "Opening balances",
+		Data:    rows,
+		opening: true,
+	}
 
-func (w *writer) UpdateGeneralLedgerPreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error) {
-	if err = w.reader.ValidatePreferences(ctx, preferences); err != nil {
-		err = errors.PropagateWithCode(err, EcodeValidatePreferencesFailed, "Update general ledger preferences failed")
+	if difference != 0 {
+		openingBalanceEquityGLP, err := w.reader.GetGeneralLedgerPreferenceByID(ctx, GeneralLedgerPreferenceStatement{ID: int64(OpeningBalanceEquity)})
+		if err != nil {
+			return nil, errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
+		}
+
+		if !openingBalanceEquityGLP.AccountID.Valid || openingBalanceEquityGLP.AccountID.Int64 == 0 {
+			return nil, errors.PropagateWithCode(fmt.Errorf("opening balance equity account not set"), EcodeOpeningBalanceEquityNotSet, "Opening balance equity account is not set")
+		}
+
+		transaction.Data = append(transaction.Data, TransactionRow{AccountID: openingBalanceEquityGLP.AccountID.Int64, Amount: difference})
+	}
+
+	previousJournal, err := w.reader.GetJournal(ctx, JournalStatement{Opening: true, DeletedAtIsNULL: true})
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on get opening journal")
 		return
 	}
 
-	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		for _, preference := range preferences {
-			err = w.mustUpdateGeneralLedgerPreferenceByIDTx(tx, ctx, preference.ID, &preference)
-			if err != nil {
-				err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Update general ledger preferences failed")
-				return err
+	hasPreviousJournal := err == nil
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) (err error) {
+		if hasPreviousJournal {
+			if err = w.removeOpeningJournalTx(tx, ctx, userID, previousJournal.ID); err != nil {
+				return
 			}
 		}
 
-		return nil
+		journal, err = w.StoreTransactionTx(tx, ctx, userID, transaction)
+		return
 	})
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on update general ledger preferences")
+		err = errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on import opening balances")
+		return
 	}
 
 	return
 }
 
-func (w *writer) UpdateGeneralLedgerPreferenceByID(ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
-	err = w.reader.ValidatePreferences(ctx, []domain.GeneralLedgerPreference{
-		{ID: id, AccountID: preference.AccountID},
-	})
+// removeOpeningJournalTx voids the opening journal, bank accounts get a counter entry to keep their running balance.
+func (w *writer) removeOpeningJournalTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, journalID uuid.UUID) (err error) {
+	bankTransactions, err := w.reader.GetAllBankTransactionsByJournalID(ctx, journalID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on get bank transactions")
+		return
+	}
+
+	for _, bankTransaction := range bankTransactions {
+		bankTransaction.Amount = -bankTransaction.Amount
+		if err = w.storeBankTransactionTx(tx, ctx, userID, bankTransaction); err != nil {
+			return
+		}
+	}
 
+	before, err := snapshotTx(tx, ctx, AuditEntityJournal, journalID)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeValidatePreferencesFailed, "Update general ledger preferences failed")
 		return
 	}
 
-	if err = w.mustUpdateGeneralLedgerPreferenceByID(ctx, id, preference); err != nil {
-		err = errors.PropagateWithCode(goErr.New("update general ledger preference by id failed"), EcodeUpdateGeneralLedgerPreferenceFailed, "creator unknown")
+	query := "UPDATE journals SET deleted_at = ? WHERE id = ?"
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), time.Now(), journalID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeImportOpeningBalancesFailed, "Failed on void opening journal")
+		return
+	}
+
+	if err = auditTx(tx, ctx, userID, AuditEntityJournal, journalID, AuditOperationVoid, before); err != nil {
 		return
 	}
 
 	return
 }
 
-func (w *writer) mustUpdateGeneralLedgerPreferenceByIDTx(tx sql.Tx, ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
-	if _, err = tx.Updates(ctx, "general_ledger_preferences", preference, &GeneralLedgerPreferenceStatement{ID: id}); err != nil {
-		err = errors.PropagateWithCode(goErr.New("update general ledger preference by id failed"), EcodeUpdateGeneralLedgerPreferenceFailed, "creator unknown")
+// StoreAttachment links a document to a journal or a bank transaction. The stored file is shared between
+// attachments with the same SHA-256 digest, so uploading the same receipt twice keeps a single copy.
+func (w *writer) StoreAttachment(ctx context.Context, attachment *domain.Attachment) (err error) {
+	if attachment.JournalID.Valid == attachment.BankTransactionID.Valid {
+		err = errors.PropagateWithCode(
+			goErr.New("attachment target invalid"),
+			EcodeAttachmentTargetInvalid,
+			"Attachment must belong to either a journal or a bank transaction",
+		)
 		return
 	}
 
-	return
-}
+	if attachment.JournalID.Valid {
+		if _, err = w.reader.GetJournalByID(ctx, attachment.JournalID.UUID); err != nil {
+			return
+		}
+	}
 
-func (w *writer) mustUpdateGeneralLedgerPreferenceByID(ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
-	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+	if attachment.BankTransactionID.Valid {
+		if _, err = w.reader.GetBankTransactionByID(ctx, attachment.BankTransactionID.Int64); err != nil {
+			return
+		}
+	}
+
+	attachment.ID = uuid.New()
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := `
+			INSERT INTO attachment_files (id, sha256, content_type, size, storage_key) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (sha256) DO NOTHING
+		`
+
+		_, err := tx.ExecContext(
+			ctx,
+			tx.Rebind(query),
+			uuid.New(), attachment.SHA256, attachment.ContentType, attachment.Size, attachment.StorageKey,
+		)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreAttachmentFailed, "Failed on store attachment file")
+		}
+
+		query = "SELECT id, content_type, storage_key FROM attachment_files WHERE sha256 = ?"
+		err = tx.QueryRowContext(ctx, tx.Rebind(query), attachment.SHA256).Scan(&attachment.FileID, &attachment.ContentType, &attachment.StorageKey)
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreAttachmentFailed, "Failed on get attachment file")
+		}
+
+		query = `
+			INSERT INTO attachments (id, file_id, journal_id, bank_transaction_id, file_name, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			attachment.ID, attachment.FileID, attachment.JournalID, attachment.BankTransactionID, attachment.FileName, attachment.CreatedBy, companyID(ctx),
+		).Scan(&attachment.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreAttachmentFailed, "Failed on store attachment")
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// DeleteAttachmentByID detaches a document. Only the user who uploaded it may remove it, and the stored file
+// is kept because other attachments may share it.
+func (w *writer) DeleteAttachmentByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error) {
+	attachment, err := w.reader.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if attachment.CreatedBy != userID {
+		err = errors.PropagateWithCode(goErr.New("permission denied"), EcodePermissionDenied, "Only the uploader can delete an attachment")
+		return
+	}
+
+	query := "UPDATE attachments SET deleted_at = now() WHERE id = ? AND company_id = ?"
+	if _, err = w.db.ExecContext(ctx, w.db.Rebind(query), id, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteAttachmentFailed, "Failed on delete attachment")
+		return
+	}
+
+	return
+}
+
+func (w *writer) storeFiscalYearHistoryTx(tx sql.Tx, ctx context.Context, history *domain.FiscalYearHistory) (err error) {
+	query := `
+		INSERT INTO fiscal_year_histories (fiscal_year_id, action_id, journal_id, reason, created_by)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		tx.Rebind(query),
+		history.FiscalYearID, history.ActionID, history.JournalID, history.Reason, history.CreatedBy,
+	).Scan(&history.ID, &history.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearHistoryFailed, "Failed on store fiscal year history")
+		return
+	}
+
+	return
+}
+
+func (w *writer) updateFiscalYearByIDTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, id int64, fiscalYear *domain.FiscalYear) (err error) {
+	before, err := snapshotTx(tx, ctx, AuditEntityFiscalYear, id)
+	if err != nil {
+		return
+	}
+
+	if _, err = tx.Updates(ctx, "fiscal_years", fiscalYear, &FiscalYearStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
+		return
+	}
+
+	return auditTx(tx, ctx, userID, AuditEntityFiscalYear, id, AuditOperationUpdate, before)
+}
+
+func (w *writer) updateFiscalYearByID(ctx context.Context, id int64, fiscalYear *domain.FiscalYear) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		err = w.updateFiscalYearByIDTx(tx, ctx, appcontext.GetUserID(ctx), id, fiscalYear)
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateFiscalYearFailed, "Update fiscal year failed")
+		return err
+	}
+
+	return
+}
+
+func (w *writer) StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear, periodMonths int) (err error) {
+	var (
+		intersectedFiscalYear domain.FiscalYear
+		lastEndDate           time.Time
+	)
+
+	if fiscalYear.EndDate.Before(fiscalYear.StartDate) {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid fiscal year end date"), EcodeValidateFiscalYearFailed, "Fiscal year end date must after start date")
+		return
+	}
+
+	query := "SELECT DATE(MAX(end_date)) FROM fiscal_years WHERE company_id = ?"
+	err = w.db.QueryRowContext(ctx, w.db.Rebind(query), companyID(ctx)).Scan(&lastEndDate)
+	if err != nil && err != sql.ErrNoRows {
+		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Failed on get last end date")
+		return
+	}
+
+	// if there are fiscal year exists, check for start date validity
+	// the start date must equal last end date + one day
+	if err == nil && !fiscalYear.StartDate.Equal(lastEndDate.Add(24*time.Hour)) {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid fiscal year start date"), EcodeValidateFiscalYearFailed, "Fiscal year start date is invalid")
+		return
+	}
+
+	args := []interface{}{companyID(ctx), fiscalYear.StartDate, fiscalYear.StartDate, fiscalYear.EndDate, fiscalYear.EndDate}
+	query = `
+		SELECT id, start_date, end_date, closed
+		FROM fiscal_years
+		WHERE company_id = ? AND ((start_date <= ? AND end_date >= ?) OR (start_date >= ? AND end_date <= ?))
+	`
+
+	err = w.db.GetContext(ctx, &intersectedFiscalYear, w.db.Rebind(query), args...)
+	if err != nil && err != sql.ErrNoRows {
+		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Store fiscal year failed")
+		return
+	}
+
+	if err == nil {
+		err = errors.PropagateWithCode(fmt.Errorf("intersect with other fiscal year"), EcodeStoreFiscalYearFailed, "Fiscal year intersect with other fiscal year")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := "INSERT INTO fiscal_years (start_date, end_date, closed, company_id) VALUES (DATE($1), DATE($2), $3, $4) RETURNING id"
+		err := tx.QueryRowContext(ctx, tx.Rebind(query), fiscalYear.StartDate, fiscalYear.EndDate, fiscalYear.Closed, companyID(ctx)).Scan(&fiscalYear.ID)
+		if err != nil {
+			return err
+		}
+
+		err = auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityFiscalYear, fiscalYear.ID, AuditOperationCreate, goSql.NullString{})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.storeFiscalPeriodsTx(tx, ctx, *fiscalYear, periodMonths)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreFiscalYearFailed, "Store fiscal year failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) GenerateFiscalPeriods(ctx context.Context, fiscalYearID int64, periodMonths int) (periods []domain.FiscalPeriod, err error) {
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{ID: fiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
+		return
+	}
+
+	existingPeriods, err := w.reader.GetAllFiscalPeriods(ctx, FiscalPeriodStatement{FiscalYearID: fiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGenerateFiscalPeriodsFailed, "Failed on get fiscal periods")
+		return
+	}
+
+	if len(existingPeriods) > 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal periods already generated"), EcodeFiscalPeriodsAlreadyGenerated, "Fiscal year already has periods")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) (err error) {
+		periods, err = w.storeFiscalPeriodsTx(tx, ctx, fiscalYear, periodMonths)
+		return
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGenerateFiscalPeriodsFailed, "Failed on generate fiscal periods")
+		return
+	}
+
+	return
+}
+
+// storeFiscalPeriodsTx splits the fiscal year into consecutive periods of periodMonths months,
+// monthly when periodMonths is not positive. The last period is cut at the fiscal year end date.
+func (w *writer) storeFiscalPeriodsTx(tx sql.Tx, ctx context.Context, fiscalYear domain.FiscalYear, periodMonths int) (periods []domain.FiscalPeriod, err error) {
+	if periodMonths <= 0 {
+		periodMonths = DefaultFiscalPeriodMonths
+	}
+
+	startDate := fiscalYear.StartDate
+	for i := 1; !startDate.After(fiscalYear.EndDate); i++ {
+		period := domain.FiscalPeriod{
+			FiscalYearID: fiscalYear.ID,
+			StartDate:    startDate,
+			EndDate:      fiscalYear.StartDate.AddDate(0, i*periodMonths, -1),
+			StatusID:     domain.OpenPeriodStatus,
+		}
+
+		if period.EndDate.After(fiscalYear.EndDate) {
+			period.EndDate = fiscalYear.EndDate
+		}
+
+		query := `
+			INSERT INTO fiscal_periods (fiscal_year_id, start_date, end_date, status_id)
+			VALUES (?, DATE(?), DATE(?), ?)
+			RETURNING id
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			period.FiscalYearID, period.StartDate, period.EndDate, period.StatusID,
+		).Scan(&period.ID)
+
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeGenerateFiscalPeriodsFailed, "Failed on store fiscal period")
+			return
+		}
+
+		err = auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityFiscalPeriod, period.ID, AuditOperationCreate, goSql.NullString{})
+		if err != nil {
+			return
+		}
+
+		periods = append(periods, period)
+		startDate = period.EndDate.AddDate(0, 0, 1)
+	}
+
+	return
+}
+
+func (w *writer) UpdateFiscalPeriodStatusByID(ctx context.Context, id int64, userID uuid.UUID, statusID int64, reason string) (err error) {
+	var reasonValue goSql.NullString
+
+	if statusID < domain.OpenPeriodStatus || statusID > domain.HardLockedPeriodStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid fiscal period status"), EcodeFiscalPeriodStatusInvalid, "Invalid fiscal period status")
+		return
+	}
+
+	if err = w.mustHavePermission(ctx, userID, auth.FiscalPeriod, auth.WRITE); err != nil {
+		return
+	}
+
+	period, err := w.reader.GetFiscalPeriodByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal period")
+		return
+	}
+
+	if period.StatusID == statusID {
+		return
+	}
+
+	if reason != "" {
+		if err = reasonValue.Scan(reason); err != nil {
+			err = errors.PropagateWithCode(err, EcodeUpdateFiscalPeriodStatusFailed, "Failed on scan reason value")
+			return
+		}
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityFiscalPeriod, id)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE fiscal_periods fp
+			SET status_id = ?
+			FROM fiscal_years fy
+			WHERE fp.id = ? AND fy.id = fp.fiscal_year_id AND fy.company_id = ?
+		`
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), statusID, id, companyID(ctx)); err != nil {
+			return err
+		}
+
+		if err := auditTx(tx, ctx, userID, AuditEntityFiscalPeriod, id, AuditOperationUpdate, before); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO fiscal_period_histories (fiscal_period_id, from_status_id, to_status_id, reason, created_by)
+			VALUES (?, ?, ?, ?, ?)
+		`
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(query), id, period.StatusID, statusID, reasonValue, userID)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateFiscalPeriodStatusFailed, "Failed on update fiscal period status")
+		return
+	}
+
+	return
+}
+
+// validatePostingDate checks that date falls into an unclosed fiscal year and that its fiscal period,
+// if the fiscal year has periods, accepts postings from userID.
+func (w *writer) validatePostingDate(ctx context.Context, userID uuid.UUID, date time.Time) (err error) {
+	if err = w.validateFiscalYearDate(ctx, date); err != nil {
+		return
+	}
+
+	period, err := w.reader.GetFiscalPeriodByDate(ctx, date)
+	if errors.GetCode(err) == EcodeNotFound {
+		return nil
+	}
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFiscalPeriodFailed, "Failed on get fiscal period")
+		return
+	}
+
+	switch period.StatusID {
+	case domain.HardLockedPeriodStatus:
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal period is hard locked"), EcodeFiscalPeriodLocked, "Fiscal period is locked for posting")
+	case domain.SoftLockedPeriodStatus:
+		if err = w.mustHavePermission(ctx, userID, auth.SoftLockedFiscalPeriod, auth.WRITE); err != nil {
+			err = errors.PropagateWithCode(err, EcodeFiscalPeriodLocked, "Fiscal period is locked for posting")
+		}
+	}
+
+	return
+}
+
+// validateFiscalYearDate checks that date falls into an unclosed fiscal year.
+func (w *writer) validateFiscalYearDate(ctx context.Context, date time.Time) (err error) {
+	// startDate <= transDate <= endDate
+	_, err = w.reader.GetFiscalYear(ctx, FiscalYearStatement{
+		StartDateLTE: date,
+		EndDateGTE:   date,
+		ClosedNotEQ:  true,
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetFiscalYearFailed, "Failed on get fiscal year")
+
+		if errors.GetCode(err) == EcodeNotFound {
+			err = errors.PropagateWithCode(err, EcodeStoreTransactionProhibited, "No active fiscal year for transaction date")
+		}
+
+		return
+	}
+
+	return
+}
+
+func (w *writer) mustHavePermission(ctx context.Context, userID uuid.UUID, resource auth.Resource, permission auth.Permission) (err error) {
+	var hasPermission bool
+
+	if w.permission != nil {
+		hasPermission, err = w.permission.HasPermission(ctx, userID, resource, permission)
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodePermissionDenied, "Failed on check permission")
+			return
+		}
+	}
+
+	if !hasPermission {
+		err = errors.PropagateWithCode(fmt.Errorf("permission denied"), EcodePermissionDenied, "Permission denied")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateGeneralLedgerPreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error) {
+	if err = w.reader.ValidatePreferences(ctx, preferences); err != nil {
+		err = errors.PropagateWithCode(err, EcodeValidatePreferencesFailed, "Update general ledger preferences failed")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		for _, preference := range preferences {
+			err = w.mustUpdateGeneralLedgerPreferenceByIDTx(tx, ctx, preference.ID, &preference)
+			if err != nil {
+				err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Update general ledger preferences failed")
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on update general ledger preferences")
+	}
+
+	return
+}
+
+func (w *writer) UpdateGeneralLedgerPreferenceByID(ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
+	err = w.reader.ValidatePreferences(ctx, []domain.GeneralLedgerPreference{
+		{ID: id, AccountID: preference.AccountID},
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeValidatePreferencesFailed, "Update general ledger preferences failed")
+		return
+	}
+
+	if err = w.mustUpdateGeneralLedgerPreferenceByID(ctx, id, preference); err != nil {
+		err = errors.PropagateWithCode(goErr.New("update general ledger preference by id failed"), EcodeUpdateGeneralLedgerPreferenceFailed, "creator unknown")
+		return
+	}
+
+	return
+}
+
+func (w *writer) mustUpdateGeneralLedgerPreferenceByIDTx(tx sql.Tx, ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
+	before, err := snapshotTx(tx, ctx, AuditEntityGeneralLedgerPreference, id)
+	if err != nil {
+		return
+	}
+
+	if _, err = tx.Updates(ctx, "general_ledger_preferences", preference, &GeneralLedgerPreferenceStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(goErr.New("update general ledger preference by id failed"), EcodeUpdateGeneralLedgerPreferenceFailed, "creator unknown")
+		return
+	}
+
+	return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityGeneralLedgerPreference, id, AuditOperationUpdate, before)
+}
+
+func (w *writer) mustUpdateGeneralLedgerPreferenceByID(ctx context.Context, id int64, preference *domain.GeneralLedgerPreference) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
 		err = w.mustUpdateGeneralLedgerPreferenceByIDTx(tx, ctx, id, preference)
 		if err != nil {
-			err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on update general ledger preference")
-			return err
+			err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on update general ledger preference")
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on commit")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreTransactionTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, transaction Transaction) (journal *domain.Journal, err error) {
+	var (
+		gls              []domain.GeneralLedger
+		bankTransactions []domain.BankTransaction
+		memo             goSql.NullString
+		journalAmount    float64
+		balanceAmount    float64
+	)
+
+	if userID == uuid.Nil {
+		err = errors.PropagateWithCode(goErr.New("creator unknown"), EcodeStoreTransactionCreatedByRequired, "creator unknown")
+		return
+	}
+
+	if transaction.Memo != "" {
+		if err = memo.Scan(transaction.Memo); err != nil {
+			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on scan memo value")
+			return
+		}
+	}
+
+	if transaction.journalID == uuid.Nil {
+		transaction.journalID = uuid.New()
+	}
+
+	now := time.Now()
+
+	if transaction.Date.IsZero() {
+		transaction.Date = now
+	}
+
+	// closing entries are dated at the fiscal year end date, which is usually locked by then,
+	// the fiscal year close and reopen validate the fiscal year themselves.
+	if !transaction.closing {
+		if err = w.validatePostingDate(ctx, userID, transaction.Date); err != nil {
+			return
+		}
+	}
+
+	for _, row := range transaction.Data {
+		var bankAccount domain.BankAccount
+
+		if row.Amount == 0 {
+			continue
+		}
+
+		if _, err = w.reader.GetAccountByID(ctx, row.AccountID); err != nil {
+			if errors.GetCode(err) == EcodeNotFound {
+				err = errors.PropagateWithCode(err, EcodeAccountNotInCompany, fmt.Sprintf("Account %d does not belong to the company", row.AccountID))
+				return
+			}
+
+			err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get account")
+			return
+		}
+
+		if row.CounterpartyCompanyID == companyID(ctx) {
+			err = errors.PropagateWithCode(fmt.Errorf("counterparty is the company itself"), EcodeStoreTransactionFailed, "Counterparty company must be another company")
+			return
+		}
+
+		gl := domain.GeneralLedger{
+			ID:        uuid.New(),
+			JournalID: transaction.journalID,
+			AccountID: row.AccountID,
+			CreatedBy: userID,
+			Amount:    row.Amount,
+		}
+
+		if err = scanGeneralLedgerReferences(&gl, row); err != nil {
+			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on scan general ledger references")
+			return
+		}
+
+		if row.CounterpartyCompanyID != 0 {
+			gl.CounterpartyCompanyID = goSql.NullInt64{Int64: row.CounterpartyCompanyID, Valid: true}
+		}
+
+		gls = append(gls, gl)
+
+		balanceAmount += row.Amount
+		if row.Amount > 0 {
+			journalAmount += row.Amount
+		}
+
+		// a line on the account of a bank account moves the running balance of that bank account
+		bankAccount, err = w.reader.GetBankAccount(ctx, BankAccountStatement{AccountID: row.AccountID})
+		switch {
+		case err == nil:
+			bankTransactions = append(bankTransactions, domain.BankTransaction{
+				JournalID:     transaction.journalID,
+				BankAccountID: bankAccount.ID,
+				UserID:        userID,
+				Amount:        row.Amount,
+				Memo:          transaction.Memo,
+				TransDate:     transaction.Date,
+			})
+		case errors.GetCode(err) != EcodeNotFound:
+			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on check bank transaction")
+			return
+		}
+
+		err = nil
+	}
+
+	// check mapAccountGeneralLedger is empty.
+	// empty is occur when all the transaction amount is zero
+	if len(gls) == 0 {
+		return nil, nil
+	}
+
+	if balanceAmount != 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("transaction not balance"), EcodeTransactionNotBalance, "Transaction not balance")
+		return
+	}
+
+	journal = &domain.Journal{
+		ID:        transaction.journalID,
+		TypeID:    transaction.journalTypeID(),
+		Amount:    journalAmount,
+		TransDate: transaction.Date,
+		Memo:      memo,
+		CreatedAt: now,
+		Closing:   transaction.closing,
+		Opening:   transaction.opening,
+	}
+
+	if err = w.StoreJournalTx(tx, ctx, journal); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreTransactionAtJournalFailed, "Store transaction failed")
+		return
+	}
+
+	if err = w.StoreGeneralLedgersTx(tx, ctx, gls); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreTransactionAtGeneralLedgerFailed, "Store transaction failed")
+		return
+	}
+
+	for _, bankTransaction := range bankTransactions {
+		err = w.storeBankTransactionTx(tx, ctx, userID, bankTransaction)
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Store bank transaction failed")
+			return
+		}
+	}
+
+	if err = auditTx(tx, ctx, userID, AuditEntityJournal, journal.ID, AuditOperationCreate, goSql.NullString{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// StoreTransaction posts a journal right away, skipping the review of a journal draft, so it needs the
+// journal posting permission.
+func (w *writer) StoreTransaction(ctx context.Context, userID uuid.UUID, transaction Transaction) (journal *domain.Journal, err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.JournalPosting, auth.WRITE); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err = w.StoreTransactionTx(tx, ctx, userID, transaction)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store transaction")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreJournalTx(tx sql.Tx, ctx context.Context, journal *domain.Journal) (err error) {
+	now := time.Now()
+
+	if journal.TransDate.IsZero() {
+		journal.TransDate = now
+	}
+
+	if journal.TypeID == 0 {
+		journal.TypeID = GeneralJournalType
+	}
+
+	if err = w.allocateJournalNumberTx(tx, ctx, journal); err != nil {
+		return
+	}
+
+	query := `
+		INSERT INTO journals (id, type_id, number, amount, trans_date, memo, closing, opening, company_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		journal.ID, journal.TypeID, journal.Number, journal.Amount, journal.TransDate, journal.Memo, journal.Closing, journal.Opening, companyID(ctx),
+	).Scan(&journal.ID)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreJournalFailed, "Store journal failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreGeneralLedgersTx(tx sql.Tx, ctx context.Context, gls []domain.GeneralLedger) (err error) {
+	var params []interface{}
+
+	query := `INSERT INTO general_ledgers (id, journal_id, account_id, amount, created_by, memo, external_reference, counterparty_company_id) VALUES `
+
+	for _, gl := range gls {
+		query += "(?,?,?,?,?,?,?,?),"
+		params = append(params, gl.ID, gl.JournalID, gl.AccountID, gl.Amount, gl.CreatedBy, gl.Memo, gl.ExternalReference, gl.CounterpartyCompanyID)
+	}
+
+	query = query[:len(query)-1] // remove trailing ","
+	_, err = tx.ExecContext(ctx, w.db.Rebind(query), params...)
+	return
+}
+
+func scanGeneralLedgerReferences(gl *domain.GeneralLedger, row TransactionRow) (err error) {
+	if row.Memo != "" {
+		if err = gl.Memo.Scan(row.Memo); err != nil {
+			return
+		}
+	}
+
+	if row.ExternalReference != "" {
+		if err = gl.ExternalReference.Scan(row.ExternalReference); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// allocateJournalNumberTx takes the next number of the journal type within the fiscal year of the journal.
+// The sequence row stays locked until tx ends, so a rolled back posting gives its number back.
+func (w *writer) allocateJournalNumberTx(tx sql.Tx, ctx context.Context, journal *domain.Journal) (err error) {
+	var (
+		sequence int64
+		format   string
+	)
+
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{
+		StartDateLTE: journal.TransDate,
+		EndDateGTE:   journal.TransDate,
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeAllocateJournalNumberFailed, "Failed on get fiscal year")
+		return
+	}
+
+	query := "SELECT format FROM journal_number_formats WHERE type_id = ? AND company_id = ?"
+	if err = tx.GetContext(ctx, &format, tx.Rebind(query), journal.TypeID, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeAllocateJournalNumberFailed, "Failed on get journal number format")
+		return
+	}
+
+	query = `
+		INSERT INTO journal_sequences (fiscal_year_id, type_id, last_number) VALUES (?, ?, 1)
+		ON CONFLICT (fiscal_year_id, type_id) DO UPDATE SET last_number = journal_sequences.last_number + 1
+		RETURNING last_number
+	`
+
+	if err = tx.QueryRowContext(ctx, tx.Rebind(query), fiscalYear.ID, journal.TypeID).Scan(&sequence); err != nil {
+		err = errors.PropagateWithCode(err, EcodeAllocateJournalNumberFailed, "Failed on allocate journal sequence")
+		return
+	}
+
+	journal.Number = goSql.NullString{String: FormatJournalNumber(format, journal.TransDate, sequence), Valid: true}
+
+	return
+}
+
+// UpdateJournalNumberFormatByTypeID needs the journal permission, as the format names every journal posted after.
+func (w *writer) UpdateJournalNumberFormatByTypeID(ctx context.Context, typeID int64, userID uuid.UUID, format string) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.Journal, auth.WRITE); err != nil {
+		return
+	}
+
+	if typeID < GeneralJournalType || typeID > IntercompanyJournalType {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid journal type"), EcodeJournalNumberFormatInvalid, "Invalid journal type")
+		return
+	}
+
+	if len(format) > 64 || !ValidJournalNumberFormat(format) {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid journal number format"), EcodeJournalNumberFormatInvalid, "Journal number format must have one sequence placeholder, e.g. {NNNNN}")
+		return
+	}
+
+	query := "UPDATE journal_number_formats SET format = ? WHERE type_id = ? AND company_id = ?"
+	if _, err = w.db.ExecContext(ctx, w.db.Rebind(query), format, typeID, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateJournalNumberFormatFailed, "Failed on update journal number format")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateAccountCodeFormatByClassTypeID(ctx context.Context, classTypeID int64, pattern string) (err error) {
+	if classTypes[classTypeID].ID == 0 {
+		err = errors.PropagateWithCode(goErr.New("invalid class type"), EcodeAccountCodeFormatInvalid, "Class type not valid")
+		return
+	}
+
+	if !ValidAccountCodeFormat(pattern) {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("invalid account code format %s", pattern),
+			EcodeAccountCodeFormatInvalid,
+			"Account code format must be a valid regular expression",
+		)
+		return
+	}
+
+	query := `
+		INSERT INTO account_code_formats (class_type_id, pattern) VALUES (?, ?)
+		ON CONFLICT (class_type_id) DO UPDATE SET pattern = EXCLUDED.pattern
+	`
+
+	if _, err = w.db.ExecContext(ctx, w.db.Rebind(query), classTypeID, pattern); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAccountCodeFormatFailed, "Failed on update account code format")
+		return
+	}
+
+	return
+}
+
+// validateAccountGroup checks that the account is filed under a group of the company.
+func (w *writer) validateAccountGroup(ctx context.Context, account *domain.Account) (err error) {
+	if _, err = w.reader.GetAccountGroupByID(ctx, account.GroupID); err != nil {
+		err = errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "groupID", Message: "Account group not found"}},
+			EcodeNotFound,
+			"Account group not found",
+		)
+	}
+
+	return
+}
+
+// validateAccountCode checks the code format for the account group and that no other account uses the code.
+func (w *writer) validateAccountCode(ctx context.Context, id int64, account *domain.Account) (err error) {
+	if !account.Code.Valid {
+		return
+	}
+
+	if err = w.reader.ValidateAccountCode(ctx, account.GroupID, account.Code.String); err != nil {
+		return
+	}
+
+	accounts, err := w.reader.GetAllAccounts(ctx, AccountStatement{Code: account.Code.String})
+	if err != nil {
+		return
+	}
+
+	for _, existing := range accounts {
+		if existing.ID != id {
+			err = errors.PropagateWithCode(
+				goErr.New("account code already exists"),
+				EcodeAccountCodeAlreadyExists,
+				fmt.Sprintf("Account code %s is already used by %s", account.Code.String, existing.Name),
+			)
+			return
+		}
+	}
+
+	return
+}
+
+func (w *writer) StoreAccount(ctx context.Context, account *domain.Account) (err error) {
+	if err = w.validateAccountGroup(ctx, account); err != nil {
+		return
+	}
+
+	if err = w.validateAccountCode(ctx, 0, account); err != nil {
+		return
+	}
+
+	if err = validateAccountNormalBalance(account); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO accounts (code, name, group_id, inactive, normal_balance, company_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, account.Code, account.Name, account.GroupID, account.Inactive, account.NormalBalance, companyID(ctx)).Scan(&account.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccount, account.ID, AuditOperationCreate, goSql.NullString{})
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAccountFailed, "Store account failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateAccountByID(ctx context.Context, id int64, account *domain.Account) (err error) {
+	if err = w.validateAccountGroup(ctx, account); err != nil {
+		return
+	}
+
+	if err = w.validateAccountCode(ctx, id, account); err != nil {
+		return
+	}
+
+	if err = validateAccountNormalBalance(account); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"code":           account.Code,
+		"name":           account.Name,
+		"group_id":       account.GroupID,
+		"inactive":       account.Inactive,
+		"normal_balance": account.NormalBalance,
+	}
+
+	if err = w.updateAccountByID(ctx, id, dest); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAccountFailed, "Update account by id failed")
+		return
+	}
+
+	return
+}
+
+// validateAccountNormalBalance checks the normal balance override of an account, which is left unset
+// for an account that follows its class type.
+func validateAccountNormalBalance(account *domain.Account) (err error) {
+	if account.NormalBalance.Valid && !ValidNormalBalance(account.NormalBalance.String) {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("invalid normal balance %s", account.NormalBalance.String),
+			EcodeAccountNormalBalanceInvalid,
+			fmt.Sprintf("Normal balance must be %s or %s", domain.DebitNormalBalance, domain.CreditNormalBalance),
+		)
+	}
+
+	return
+}
+
+// updateAccountByID writes the columns of dest to an account of the company and audits the change.
+func (w *writer) updateAccountByID(ctx context.Context, id int64, dest map[string]interface{}) (err error) {
+	return w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityAccount, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Updates(ctx, "accounts", dest, &AccountStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccount, id, AuditOperationUpdate, before)
+	})
+}
+
+func (w *writer) DeleteAccountByID(ctx context.Context, id int64) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityAccount, id)
+		if err != nil {
+			return err
+		}
+
+		query := "DELETE FROM accounts WHERE id = ? AND company_id = ?"
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), id, companyID(ctx)); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccount, id, AuditOperationDelete, before)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteAccountFailed, "Delete account by id failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreAccountGroup(ctx context.Context, accountGroup *domain.AccountGroup) (err error) {
+	if accountGroup.ParentID.Valid && accountGroup.ParentID.Int64 != 0 {
+		var parentAccountGroup domain.AccountGroup
+
+		parentAccountGroup, err = w.reader.GetAccountGroupByID(ctx, accountGroup.ParentID.Int64)
+		if err != nil {
+			err = errors.PropagateWithCode(err, errors.GetCode(err), "Error on get parent account group")
+			return
+		}
+
+		accountGroup.ClassID = parentAccountGroup.ClassID
+	} else if _, err = w.reader.GetAccountClassByID(ctx, accountGroup.ClassID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Error on get account class")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO account_groups (code, parent_id, class_id, name, inactive, company_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, accountGroup.Code, accountGroup.ParentID, accountGroup.ClassID, accountGroup.Name, accountGroup.Inactive, companyID(ctx),
+		).Scan(&accountGroup.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountGroup, accountGroup.ID, AuditOperationCreate, goSql.NullString{})
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAccountGroupFailed, "Insert account group failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateAccountGroupByID(ctx context.Context, id int64, accountGroup *domain.AccountGroup) (err error) {
+	if accountGroup.ParentID.Valid && accountGroup.ParentID.Int64 == id {
+		err = errors.PropagateWithCode(goErr.New("invalid parent id"), EcodeParentIDNotValid, "cannot set parent with the same account group")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		// Serialize group moves so two concurrent updates can not each pass the cycle check
+		// and together link two groups under one another.
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE account_groups IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Failed on lock account groups")
+		}
+
+		var current domain.AccountGroup
+		query := "SELECT id, class_id FROM account_groups WHERE id = ? AND company_id = ?"
+		err := tx.GetContext(ctx, &current, tx.Rebind(query), id, companyID(ctx))
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Failed on get account group")
+		}
+
+		if accountGroup.ParentID.Valid && accountGroup.ParentID.Int64 != 0 {
+			var parent domain.AccountGroup
+			err = tx.GetContext(ctx, &parent, tx.Rebind(query), accountGroup.ParentID.Int64, companyID(ctx))
+			if err != nil {
+				return errors.PropagateWithCode(err, EcodeParentIDNotValid, "Failed on get parent account group")
+			}
+
+			if parent.ClassID != current.ClassID {
+				return errors.PropagateWithCode(goErr.New("parent account group belongs to another class"), EcodeAccountGroupClassMismatch, "Account group can not be moved to another class")
+			}
+
+			var isDescendant bool
+			err = tx.QueryRowContext(ctx, tx.Rebind(`
+				WITH RECURSIVE descendants AS (
+					SELECT id, ARRAY[id] AS path FROM account_groups WHERE parent_id = ?
+					UNION ALL
+					SELECT g.id, d.path || g.id
+					FROM account_groups g
+					JOIN descendants d ON g.parent_id = d.id
+					WHERE NOT g.id = ANY(d.path)
+				)
+				SELECT EXISTS (SELECT 1 FROM descendants WHERE id = ?)
+			`), id, accountGroup.ParentID.Int64).Scan(&isDescendant)
+			if err != nil {
+				return errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Failed on check account group descendants")
+			}
+
+			if isDescendant {
+				return errors.PropagateWithCode(goErr.New("parent account group is a descendant"), EcodeAccountGroupCycle, "Account group can not be moved under one of its own descendants")
+			}
+		}
+
+		if accountGroup.ClassID != 0 && accountGroup.ClassID != current.ClassID {
+			return errors.PropagateWithCode(goErr.New("class id changed"), EcodeAccountGroupClassMismatch, "Account group can not be moved to another class")
+		}
+
+		accountGroup.ClassID = current.ClassID
+
+		before, err := snapshotTx(tx, ctx, AuditEntityAccountGroup, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(`
+			UPDATE account_groups
+			SET code = ?, parent_id = ?, class_id = ?, name = ?, inactive = ?
+			WHERE id = ? AND company_id = ?
+		`), accountGroup.Code, accountGroup.ParentID, accountGroup.ClassID, accountGroup.Name, accountGroup.Inactive, id, companyID(ctx))
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Update account group failed")
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountGroup, id, AuditOperationUpdate, before)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on update account group")
+		return
+	}
+
+	return
+}
+
+func (w *writer) DeleteAccountGroupByID(ctx context.Context, id int64) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityAccountGroup, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.deleteAccountGroupTx(tx, ctx, AccountGroupStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountGroup, id, AuditOperationDelete, before)
+	})
+
+	return
+}
+
+func (w *writer) deleteAccountGroupTx(tx sql.Tx, ctx context.Context, where AccountGroupStatement) (result sql.Result, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteAccountGroupFailed, "Failed on select account group")
+		return
+	}
+
+	deleteQuery := fmt.Sprintf(`
+		DELETE FROM account_groups
+		%s
+	`, whereClause)
+
+	result, err = tx.ExecContext(ctx, tx.Rebind(deleteQuery), whereClauseArgs...)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteAccountGroupFailed, "Delete account group failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) DeleteAccountClassByID(ctx context.Context, id int64) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityAccountClass, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.deleteAccountClassTx(tx, ctx, AccountClassStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountClass, id, AuditOperationDelete, before)
+	})
+
+	return
+}
+
+func (w *writer) deleteAccountClassTx(tx sql.Tx, ctx context.Context, where AccountClassStatement) (result sql.Result, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Failed on delete account class")
+		return
+	}
+
+	deleteQuery := fmt.Sprintf(`
+		DELETE FROM account_classes
+		%s
+	`, whereClause)
+
+	result, err = tx.ExecContext(ctx, tx.Rebind(deleteQuery), whereClauseArgs...)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteAccountClassFailed, "Failed on delete account class")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateAccountClassByID(ctx context.Context, id int64, accountClass *domain.AccountClass) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		before, err := snapshotTx(tx, ctx, AuditEntityAccountClass, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.updateAccountClassTx(tx, ctx, accountClass, AccountClassStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountClass, id, AuditOperationUpdate, before)
+	})
+
+	return
+}
+
+func (w *writer) updateAccountClassTx(tx sql.Tx, ctx context.Context, accountClass *domain.AccountClass, where AccountClassStatement) (result sql.Result, err error) {
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Failed on select account class")
+		return
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE account_classes
+		SET code = ?, name = ?, type_id = ?
+		%s
+	`, whereClause)
+
+	args := append([]interface{}{accountClass.Code, accountClass.Name, accountClass.TypeID}, whereClauseArgs...)
+	result, err = tx.ExecContext(ctx, tx.Rebind(updateQuery), args...)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Update account class failed")
+		return
+	}
+
+	return
+}
+
+var errChartOfAccountsDryRun = goErr.New("chart of accounts dry run")
+
+// validateChartOfAccounts checks names, codes and class types before anything is written. Codes must be unique per
+// kind, group names must be unique and account names must be unique within their group.
+func validateChartOfAccounts(chart domain.ChartOfAccounts, formats []domain.AccountCodeFormat) (err error) {
+	var (
+		fieldErrors errors.ValidationErrors
+		codes       = map[string]map[string]bool{
+			domain.ChartOfAccountsClassKind:   {},
+			domain.ChartOfAccountsGroupKind:   {},
+			domain.ChartOfAccountsAccountKind: {},
+		}
+		classNames = make(map[string]bool)
+		groupNames = make(map[string]bool)
+		patterns   = make(map[int64]string, len(formats))
+	)
+
+	for _, format := range formats {
+		patterns[format.ClassTypeID] = format.Pattern
+	}
+
+	check := func(kind string, field string, code string, name string, names map[string]bool) {
+		if strings.TrimSpace(name) == "" {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: fmt.Sprintf("%s name is required", kind)})
+		} else if names[name] {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: fmt.Sprintf("duplicate %s name %s", kind, name)})
+		}
+
+		names[name] = true
+
+		if code == "" {
+			return
+		}
+
+		if codes[kind][code] {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: fmt.Sprintf("duplicate %s code %s", kind, code)})
+		}
+
+		codes[kind][code] = true
+	}
+
+	var checkGroups func(field string, classTypeID int64, groups []domain.ChartOfAccountsGroup)
+	checkGroups = func(field string, classTypeID int64, groups []domain.ChartOfAccountsGroup) {
+		for i, group := range groups {
+			groupField := fmt.Sprintf("%s.groups[%d]", field, i)
+			check(domain.ChartOfAccountsGroupKind, groupField, group.Code, group.Name, groupNames)
+
+			accountNames := make(map[string]bool, len(group.Accounts))
+			for j, account := range group.Accounts {
+				accountField := fmt.Sprintf("%s.accounts[%d]", groupField, j)
+				check(domain.ChartOfAccountsAccountKind, accountField, account.Code, account.Name, accountNames)
+
+				if account.Code == "" {
+					continue
+				}
+
+				if err := matchAccountCode(patterns[classTypeID], account.Code); err != nil {
+					fieldErrors = append(fieldErrors, errors.FieldError{Field: accountField, Message: err.Error()})
+				}
+			}
+
+			checkGroups(groupField, classTypeID, group.Groups)
+		}
+	}
+
+	if len(chart.Classes) == 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "classes", Message: "chart of accounts is empty"})
+	}
+
+	for i, class := range chart.Classes {
+		field := fmt.Sprintf("classes[%d]", i)
+		check(domain.ChartOfAccountsClassKind, field, class.Code, class.Name, classNames)
+
+		if classTypes[class.TypeID].ID == 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "class type is not valid"})
+		}
+
+		checkGroups(field, class.TypeID, class.Groups)
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeChartOfAccountsInvalid, "Chart of accounts is not valid")
+	}
+
+	return
+}
+
+// chartOfAccountsImport applies a chart of accounts inside a transaction. Existing nodes are matched by code first
+// and by name otherwise; accounts are matched by name only within their target group.
+type chartOfAccountsImport struct {
+	tx             sql.Tx
+	ctx            context.Context
+	classesByCode  map[string]domain.AccountClass
+	classesByName  map[string]domain.AccountClass
+	groupsByCode   map[string]domain.AccountGroup
+	groupsByName   map[string]domain.AccountGroup
+	accountsByCode map[string]domain.Account
+	accountsByName map[int64]map[string]domain.Account
+	changes        []domain.ChartOfAccountsChange
+}
+
+func (i *chartOfAccountsImport) load() (err error) {
+	var (
+		classes  []domain.AccountClass
+		groups   []domain.AccountGroup
+		accounts []domain.Account
+	)
+
+	query := "SELECT id, code, name, type_id, inactive FROM account_classes WHERE company_id = ?"
+	if err = i.tx.SelectContext(i.ctx, &classes, i.tx.Rebind(query), companyID(i.ctx)); err != nil {
+		return
+	}
+
+	query = "SELECT id, code, parent_id, class_id, name, inactive FROM account_groups WHERE company_id = ?"
+	if err = i.tx.SelectContext(i.ctx, &groups, i.tx.Rebind(query), companyID(i.ctx)); err != nil {
+		return
+	}
+
+	query = "SELECT id, code, name, group_id, inactive, group_account_id FROM accounts WHERE company_id = ?"
+	if err = i.tx.SelectContext(i.ctx, &accounts, i.tx.Rebind(query), companyID(i.ctx)); err != nil {
+		return
+	}
+
+	i.classesByCode = make(map[string]domain.AccountClass)
+	i.classesByName = make(map[string]domain.AccountClass, len(classes))
+	for _, class := range classes {
+		if class.Code.Valid {
+			i.classesByCode[class.Code.String] = class
+		}
+
+		i.classesByName[class.Name] = class
+	}
+
+	i.groupsByCode = make(map[string]domain.AccountGroup)
+	i.groupsByName = make(map[string]domain.AccountGroup, len(groups))
+	for _, group := range groups {
+		if group.Code.Valid {
+			i.groupsByCode[group.Code.String] = group
+		}
+
+		i.groupsByName[group.Name] = group
+	}
+
+	i.accountsByCode = make(map[string]domain.Account)
+	i.accountsByName = make(map[int64]map[string]domain.Account)
+	for _, account := range accounts {
+		if account.Code.Valid {
+			i.accountsByCode[account.Code.String] = account
+		}
+
+		if i.accountsByName[account.GroupID] == nil {
+			i.accountsByName[account.GroupID] = make(map[string]domain.Account)
+		}
+
+		i.accountsByName[account.GroupID][account.Name] = account
+	}
+
+	return
+}
+
+func (i *chartOfAccountsImport) record(kind string, action string, name string, fields ...string) {
+	i.changes = append(i.changes, domain.ChartOfAccountsChange{Kind: kind, Action: action, Name: name, Fields: fields})
+}
+
+func (i *chartOfAccountsImport) audit(entity string, id int64, operation string, before goSql.NullString) error {
+	return auditTx(i.tx, i.ctx, appcontext.GetUserID(i.ctx), entity, id, operation, before)
+}
+
+// codeChanged reports whether an imported code replaces the stored one. An empty imported code keeps the stored code.
+func codeChanged(stored goSql.NullString, imported string) bool {
+	return imported != "" && stored.String != imported
+}
+
+func nullableCode(stored goSql.NullString, imported string) goSql.NullString {
+	if imported == "" {
+		return stored
+	}
+
+	return goSql.NullString{String: imported, Valid: true}
+}
+
+func (i *chartOfAccountsImport) applyClass(class domain.ChartOfAccountsClass) (err error) {
+	existing, ok := i.classesByCode[class.Code]
+	if !ok || class.Code == "" {
+		existing, ok = i.classesByName[class.Name]
+	}
+
+	code := nullableCode(existing.Code, class.Code)
+
+	if !ok {
+		err = i.tx.QueryRowContext(
+			i.ctx,
+			i.tx.Rebind("INSERT INTO account_classes (code, name, type_id, inactive, company_id) VALUES (?, ?, ?, ?, ?) RETURNING id"),
+			code, class.Name, class.TypeID, class.Inactive, companyID(i.ctx),
+		).Scan(&existing.ID)
+
+		if err != nil {
+			return
+		}
+
+		if err = i.audit(AuditEntityAccountClass, existing.ID, AuditOperationCreate, goSql.NullString{}); err != nil {
+			return
+		}
+
+		i.record(domain.ChartOfAccountsClassKind, domain.ChartOfAccountsCreateAction, class.Name)
+	} else {
+		var fields []string
+		if codeChanged(existing.Code, class.Code) {
+			fields = append(fields, "code")
+		}
+
+		if existing.Name != class.Name {
+			fields = append(fields, "name")
+		}
+
+		if existing.TypeID != class.TypeID {
+			fields = append(fields, "typeID")
+		}
+
+		if existing.Inactive != class.Inactive {
+			fields = append(fields, "inactive")
+		}
+
+		if len(fields) > 0 {
+			var before goSql.NullString
+			if before, err = snapshotTx(i.tx, i.ctx, AuditEntityAccountClass, existing.ID); err != nil {
+				return
+			}
+
+			query := "UPDATE account_classes SET code = ?, name = ?, type_id = ?, inactive = ? WHERE id = ?"
+			if _, err = i.tx.ExecContext(i.ctx, i.tx.Rebind(query), code, class.Name, class.TypeID, class.Inactive, existing.ID); err != nil {
+				return
+			}
+
+			if err = i.audit(AuditEntityAccountClass, existing.ID, AuditOperationUpdate, before); err != nil {
+				return
+			}
+
+			i.record(domain.ChartOfAccountsClassKind, domain.ChartOfAccountsUpdateAction, class.Name, fields...)
+		}
+	}
+
+	return i.applyGroups(existing.ID, goSql.NullInt64{}, class.Groups)
+}
+
+func (i *chartOfAccountsImport) applyGroups(classID int64, parentID goSql.NullInt64, groups []domain.ChartOfAccountsGroup) (err error) {
+	for _, group := range groups {
+		existing, ok := i.groupsByCode[group.Code]
+		if !ok || group.Code == "" {
+			existing, ok = i.groupsByName[group.Name]
+		}
+
+		code := nullableCode(existing.Code, group.Code)
+
+		if !ok {
+			err = i.tx.QueryRowContext(
+				i.ctx,
+				i.tx.Rebind("INSERT INTO account_groups (code, parent_id, class_id, name, inactive, company_id) VALUES (?, ?, ?, ?, ?, ?) RETURNING id"),
+				code, parentID, classID, group.Name, group.Inactive, companyID(i.ctx),
+			).Scan(&existing.ID)
+
+			if err != nil {
+				return
+			}
+
+			if err = i.audit(AuditEntityAccountGroup, existing.ID, AuditOperationCreate, goSql.NullString{}); err != nil {
+				return
+			}
+
+			i.record(domain.ChartOfAccountsGroupKind, domain.ChartOfAccountsCreateAction, group.Name)
+		} else {
+			var fields []string
+			if codeChanged(existing.Code, group.Code) {
+				fields = append(fields, "code")
+			}
+
+			if existing.Name != group.Name {
+				fields = append(fields, "name")
+			}
+
+			if existing.ClassID != classID {
+				fields = append(fields, "classID")
+			}
+
+			if existing.ParentID.Int64 != parentID.Int64 {
+				fields = append(fields, "parentID")
+			}
+
+			if existing.Inactive != group.Inactive {
+				fields = append(fields, "inactive")
+			}
+
+			if len(fields) > 0 {
+				var before goSql.NullString
+				if before, err = snapshotTx(i.tx, i.ctx, AuditEntityAccountGroup, existing.ID); err != nil {
+					return
+				}
+
+				query := "UPDATE account_groups SET code = ?, name = ?, parent_id = ?, class_id = ?, inactive = ? WHERE id = ?"
+				_, err = i.tx.ExecContext(i.ctx, i.tx.Rebind(query), code, group.Name, parentID, classID, group.Inactive, existing.ID)
+				if err != nil {
+					return
+				}
+
+				if err = i.audit(AuditEntityAccountGroup, existing.ID, AuditOperationUpdate, before); err != nil {
+					return
+				}
+
+				i.record(domain.ChartOfAccountsGroupKind, domain.ChartOfAccountsUpdateAction, group.Name, fields...)
+			}
+		}
+
+		if err = i.applyAccounts(existing.ID, group.Accounts); err != nil {
+			return
+		}
+
+		if err = i.applyGroups(classID, goSql.NullInt64{Int64: existing.ID, Valid: true}, group.Groups); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (i *chartOfAccountsImport) applyAccounts(groupID int64, accounts []domain.ChartOfAccountsAccount) (err error) {
+	for _, account := range accounts {
+		existing, ok := i.accountsByCode[account.Code]
+		if !ok || account.Code == "" {
+			existing, ok = i.accountsByName[groupID][account.Name]
+		}
+
+		code := nullableCode(existing.Code, account.Code)
+
+		if !ok {
+			query := "INSERT INTO accounts (code, name, group_id, inactive, company_id) VALUES (?, ?, ?, ?, ?) RETURNING id"
+			err = i.tx.QueryRowContext(
+				i.ctx,
+				i.tx.Rebind(query),
+				code, account.Name, groupID, account.Inactive, companyID(i.ctx),
+			).Scan(&existing.ID)
+
+			if err != nil {
+				return
+			}
+
+			if err = i.audit(AuditEntityAccount, existing.ID, AuditOperationCreate, goSql.NullString{}); err != nil {
+				return
+			}
+
+			i.record(domain.ChartOfAccountsAccountKind, domain.ChartOfAccountsCreateAction, account.Name)
+			continue
+		}
+
+		var fields []string
+		if codeChanged(existing.Code, account.Code) {
+			fields = append(fields, "code")
+		}
+
+		if existing.Name != account.Name {
+			fields = append(fields, "name")
+		}
+
+		if existing.GroupID != groupID {
+			fields = append(fields, "groupID")
+		}
+
+		if existing.Inactive != account.Inactive {
+			fields = append(fields, "inactive")
+		}
+
+		if len(fields) > 0 {
+			var before goSql.NullString
+			if before, err = snapshotTx(i.tx, i.ctx, AuditEntityAccount, existing.ID); err != nil {
+				return
+			}
+
+			query := "UPDATE accounts SET code = ?, name = ?, group_id = ?, inactive = ? WHERE id = ?"
+			if _, err = i.tx.ExecContext(i.ctx, i.tx.Rebind(query), code, account.Name, groupID, account.Inactive, existing.ID); err != nil {
+				return
+			}
+
+			if err = i.audit(AuditEntityAccount, existing.ID, AuditOperationUpdate, before); err != nil {
+				return
+			}
+
+			i.record(domain.ChartOfAccountsAccountKind, domain.ChartOfAccountsUpdateAction, account.Name, fields...)
+		}
+	}
+
+	return
+}
+
+// ImportChartOfAccounts creates missing classes, groups and accounts and updates existing ones, all in one
+// transaction. Nodes absent from the chart are left untouched. On a dry run the changes are computed and returned
+// but the transaction is rolled back.
+func (w *writer) ImportChartOfAccounts(ctx context.Context, chart domain.ChartOfAccounts, dryRun bool) (changes []domain.ChartOfAccountsChange, err error) {
+	formats, err := w.reader.GetAllAccountCodeFormats(ctx)
+	if err != nil {
+		return
+	}
+
+	if err = validateChartOfAccounts(chart, formats); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		coaImport := chartOfAccountsImport{tx: tx, ctx: ctx}
+		if err := coaImport.load(); err != nil {
+			return errors.PropagateWithCode(err, EcodeImportChartOfAccountsFailed, "Failed on load chart of accounts")
+		}
+
+		for _, class := range chart.Classes {
+			if err := coaImport.applyClass(class); err != nil {
+				return errors.PropagateWithCode(err, EcodeImportChartOfAccountsFailed, fmt.Sprintf("Failed on import class %s", class.Name))
+			}
+		}
+
+		changes = coaImport.changes
+		if dryRun {
+			return errChartOfAccountsDryRun
+		}
+
+		return nil
+	})
+
+	if err == errChartOfAccountsDryRun {
+		err = nil
+	}
+
+	return
+}
+
+// ApplyChartOfAccountsTemplate imports a template into a ledger whose company has no account classes yet.
+func (w *writer) ApplyChartOfAccountsTemplate(ctx context.Context, chart domain.ChartOfAccounts) (changes []domain.ChartOfAccountsChange, err error) {
+	classes, err := w.reader.GetAllAccountClasses(ctx, AccountClassStatement{})
+	if err != nil {
+		return
+	}
+
+	if len(classes) > 0 {
+		err = errors.PropagateWithCode(
+			goErr.New("chart of accounts not empty"),
+			EcodeChartOfAccountsNotEmpty,
+			"Templates can only be applied to an empty chart of accounts",
+		)
+		return
+	}
+
+	return w.ImportChartOfAccounts(ctx, chart, false)
+}
+
+func (w *writer) StoreAccountClass(ctx context.Context, accountClass *domain.AccountClass) (err error) {
+	classType := classTypes[accountClass.TypeID]
+	if classType.ID == 0 {
+		err = errors.PropagateWithCode(err, EcodeStoreAccountClassFailed, "Type not valid")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		err := tx.QueryRowContext(ctx, tx.Rebind(`
+			INSERT INTO account_classes (code, name, type_id, inactive, company_id)
+			VALUES (?, ?, ?, ?, ?)
+			RETURNING id
+		`), accountClass.Code, accountClass.Name, accountClass.TypeID, accountClass.Inactive, companyID(ctx)).Scan(&accountClass.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return auditTx(tx, ctx, appcontext.GetUserID(ctx), AuditEntityAccountClass, accountClass.ID, AuditOperationCreate, goSql.NullString{})
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAccountClassFailed, "Insert account class failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreJournalDraft(ctx context.Context, userID uuid.UUID, transaction Transaction) (draft *domain.JournalDraft, err error) {
+	if userID == uuid.Nil {
+		err = errors.PropagateWithCode(goErr.New("creator unknown"), EcodeStoreTransactionCreatedByRequired, "creator unknown")
+		return
+	}
+
+	draft = &domain.JournalDraft{
+		ID:        uuid.New(),
+		StatusID:  domain.DraftJournalStatus,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+
+	if err = fillJournalDraft(draft, transaction); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreJournalDraftFailed, "Failed on scan memo value")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := `
+			INSERT INTO journal_drafts (id, amount, trans_date, memo, status_id, created_by, created_at, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`
+
+		_, err := tx.ExecContext(
+			ctx,
+			tx.Rebind(query),
+			draft.ID, draft.Amount, draft.TransDate, draft.Memo, draft.StatusID, draft.CreatedBy, draft.CreatedAt, companyID(ctx),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		return w.storeJournalDraftLinesTx(tx, ctx, draft.ID, transaction.Data)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreJournalDraftFailed, "Failed on store journal draft")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction Transaction) (draft *domain.JournalDraft, err error) {
+	prevDraft, err := w.reader.GetJournalDraftByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get journal draft")
+		return
+	}
+
+	if prevDraft.CreatedBy != userID {
+		err = errors.PropagateWithCode(fmt.Errorf("only the creator can edit a draft"), EcodeJournalDraftEditorInvalid, "Only the creator can edit a draft")
+		return
+	}
+
+	// rejected drafts go back to draft once they are corrected
+	if prevDraft.StatusID != domain.DraftJournalStatus && prevDraft.StatusID != domain.RejectedJournalStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("draft is not editable"), EcodeJournalDraftStatusInvalid, "Only draft or rejected journal can be edited")
+		return
+	}
+
+	draft = &prevDraft
+	draft.StatusID = domain.DraftJournalStatus
+	if err = fillJournalDraft(draft, transaction); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateJournalDraftFailed, "Failed on scan memo value")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := "UPDATE journal_drafts SET amount = ?, trans_date = ?, memo = ?, status_id = ? WHERE id = ? AND company_id = ?"
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), draft.Amount, draft.TransDate, draft.Memo, draft.StatusID, id, companyID(ctx)); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM journal_draft_lines WHERE draft_id = ?"), id); err != nil {
+			return err
+		}
+
+		return w.storeJournalDraftLinesTx(tx, ctx, id, transaction.Data)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateJournalDraftFailed, "Failed on update journal draft")
+		return
+	}
+
+	return
+}
+
+func (w *writer) SubmitJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error) {
+	var balanceAmount float64
+
+	draft, err := w.reader.GetJournalDraftByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get journal draft")
+		return
+	}
+
+	if draft.CreatedBy != userID {
+		err = errors.PropagateWithCode(fmt.Errorf("only the creator can submit a draft"), EcodeJournalDraftEditorInvalid, "Only the creator can submit a draft")
+		return
+	}
+
+	if draft.StatusID != domain.DraftJournalStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("draft already submitted"), EcodeJournalDraftStatusInvalid, "Only draft journal can be submitted")
+		return
+	}
+
+	lines, err := w.reader.GetAllJournalDraftLinesByDraftID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeSubmitJournalDraftFailed, "Failed on get journal draft lines")
+		return
+	}
+
+	for _, line := range lines {
+		balanceAmount += line.Amount
+	}
+
+	if len(lines) == 0 || draft.Amount == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("draft is empty"), EcodeSubmitJournalDraftFailed, "Journal draft has no amount")
+		return
+	}
+
+	if balanceAmount != 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("transaction not balance"), EcodeTransactionNotBalance, "Transaction not balance")
+		return
+	}
+
+	// submitted_at is taken from the database clock, the same one that stamps the reviews counted against it
+	query := "UPDATE journal_drafts SET status_id = ?, submitted_at = now() WHERE id = ? AND company_id = ? AND status_id = ?"
+	if _, err = w.db.ExecContext(ctx, w.db.Rebind(query), domain.SubmittedJournalStatus, id, companyID(ctx), domain.DraftJournalStatus); err != nil {
+		err = errors.PropagateWithCode(err, EcodeSubmitJournalDraftFailed, "Failed on submit journal draft")
+		return
+	}
+
+	return
+}
+
+// ApproveJournalDraftByID records the approval of userID and, once the draft has collected
+// the approvals required by the approval rules, posts it to the ledger within the same transaction.
+// The rules and the lines are read under the lock of the draft, so they are the ones that get posted.
+func (w *writer) ApproveJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.JournalApproval, auth.WRITE); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		var (
+			approvals         int64
+			requiredApprovals int64
+			lines             []domain.JournalDraftLine
+		)
+
+		draft, err := w.reviewJournalDraftTx(tx, ctx, id, userID, true, comment)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.GetContext(ctx, &requiredApprovals, tx.Rebind(requiredApprovalsQuery), id); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetRequiredApprovalsFailed, "Failed on get required approvals")
+		}
+
+		query := `
+			SELECT COUNT(DISTINCT r.user_id)
+			FROM journal_draft_reviews r
+			JOIN journal_drafts d ON d.id = r.draft_id
+			WHERE r.draft_id = ? AND r.approved = TRUE AND r.created_at >= d.submitted_at
+		`
+
+		if err = tx.GetContext(ctx, &approvals, tx.Rebind(query), id); err != nil {
+			return errors.PropagateWithCode(err, EcodeApproveJournalDraftFailed, "Failed on count approvals")
+		}
+
+		if approvals < requiredApprovals {
+			return nil
+		}
+
+		query = "SELECT id, draft_id, account_id, amount, memo, external_reference FROM journal_draft_lines WHERE draft_id = ?"
+		if err = tx.SelectContext(ctx, &lines, tx.Rebind(query), id); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetAllJournalDraftLinesFailed, "Failed on get journal draft lines")
+		}
+
+		transaction := Transaction{Date: draft.TransDate, Memo: draft.Memo.String}
+		for _, line := range lines {
+			transaction.Data = append(transaction.Data, TransactionRow{
+				AccountID:         line.AccountID,
+				Amount:            line.Amount,
+				Memo:              line.Memo.String,
+				ExternalReference: line.ExternalReference.String,
+			})
+		}
+
+		journal, err := w.StoreTransactionTx(tx, ctx, draft.CreatedBy, transaction)
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post journal draft")
+		}
+
+		query = "UPDATE journal_drafts SET status_id = ?, journal_id = ? WHERE id = ?"
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), domain.ApprovedJournalStatus, journal.ID, id); err != nil {
+			return errors.PropagateWithCode(err, EcodeApproveJournalDraftFailed, "Failed on update journal draft status")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on approve journal draft")
+		return
+	}
+
+	return
+}
+
+func (w *writer) RejectJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.JournalApproval, auth.WRITE); err != nil {
+		return
+	}
+
+	if comment == "" {
+		err = errors.PropagateWithCode(fmt.Errorf("comment required"), EcodeReviewCommentRequired, "Rejection comment is required")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		if _, err := w.reviewJournalDraftTx(tx, ctx, id, userID, false, comment); err != nil {
+			return err
+		}
+
+		query := "UPDATE journal_drafts SET status_id = ? WHERE id = ?"
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), domain.RejectedJournalStatus, id); err != nil {
+			return errors.PropagateWithCode(err, EcodeRejectJournalDraftFailed, "Failed on update journal draft status")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on reject journal draft")
+		return
+	}
+
+	return
+}
+
+// reviewJournalDraftTx locks the draft and stores the review of userID on it.
+// The creator of a draft can never review it.
+func (w *writer) reviewJournalDraftTx(tx sql.Tx, ctx context.Context, id uuid.UUID, userID uuid.UUID, approved bool, comment string) (draft domain.JournalDraft, err error) {
+	var (
+		reviewed      bool
+		reviewComment goSql.NullString
+	)
+
+	if userID == uuid.Nil {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid user"), EcodeJournalDraftReviewerInvalid, "invalid user")
+		return
+	}
+
+	query := `
+		SELECT id, amount, trans_date, memo, status_id, journal_id, created_by, created_at, submitted_at
+		FROM journal_drafts
+		WHERE id = ? AND company_id = ?
+		FOR UPDATE
+	`
+
+	if err = tx.GetContext(ctx, &draft, tx.Rebind(query), id, companyID(ctx)); err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Journal draft not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetJournalDraftFailed, "Failed on get journal draft")
+		return
+	}
+
+	if draft.StatusID != domain.SubmittedJournalStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("draft not submitted"), EcodeJournalDraftStatusInvalid, "Only submitted journal can be reviewed")
+		return
+	}
+
+	if draft.CreatedBy == userID {
+		err = errors.PropagateWithCode(fmt.Errorf("creator cannot review own draft"), EcodeJournalDraftReviewerInvalid, "Journal must be reviewed by other user than the creator")
+		return
+	}
+
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM journal_draft_reviews r
+			JOIN journal_drafts d ON d.id = r.draft_id
+			WHERE r.draft_id = ? AND r.user_id = ? AND r.created_at >= d.submitted_at
+		)
+	`
+
+	if err = tx.GetContext(ctx, &reviewed, tx.Rebind(query), id, userID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllJournalDraftReviewsFailed, "Failed on get journal draft reviews")
+		return
+	}
+
+	if reviewed {
+		err = errors.PropagateWithCode(fmt.Errorf("draft already reviewed"), EcodeJournalDraftReviewerInvalid, "Journal draft already reviewed by the user")
+		return
+	}
+
+	if comment != "" {
+		if err = reviewComment.Scan(comment); err != nil {
+			err = errors.PropagateWithCode(err, EcodeApproveJournalDraftFailed, "Failed on scan comment value")
+			return
+		}
+	}
+
+	query = "INSERT INTO journal_draft_reviews (draft_id, user_id, approved, comment) VALUES (?, ?, ?, ?)"
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), id, userID, approved, reviewComment); err != nil {
+		err = errors.PropagateWithCode(err, EcodeApproveJournalDraftFailed, "Failed on store journal draft review")
+		return
+	}
+
+	return
+}
+
+func (w *writer) storeJournalDraftLinesTx(tx sql.Tx, ctx context.Context, draftID uuid.UUID, rows []TransactionRow) (err error) {
+	var params []interface{}
+
+	query := "INSERT INTO journal_draft_lines (id, draft_id, account_id, amount, memo, external_reference) VALUES "
+	for _, row := range rows {
+		if row.Amount == 0 {
+			continue
+		}
+
+		line := domain.JournalDraftLine{ID: uuid.New(), DraftID: draftID, AccountID: row.AccountID, Amount: row.Amount}
+
+		if row.Memo != "" {
+			line.Memo = goSql.NullString{String: row.Memo, Valid: true}
+		}
+
+		if row.ExternalReference != "" {
+			line.ExternalReference = goSql.NullString{String: row.ExternalReference, Valid: true}
+		}
+
+		query += "(?,?,?,?,?,?),"
+		params = append(params, line.ID, line.DraftID, line.AccountID, line.Amount, line.Memo, line.ExternalReference)
+	}
+
+	if len(params) == 0 {
+		return
+	}
+
+	query = query[:len(query)-1] // remove trailing ","
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), params...)
+	return
+}
+
+func fillJournalDraft(draft *domain.JournalDraft, transaction Transaction) (err error) {
+	draft.Amount = 0
+	draft.TransDate = transaction.Date
+	draft.Memo = goSql.NullString{}
+
+	if draft.TransDate.IsZero() {
+		draft.TransDate = time.Now()
+	}
+
+	if transaction.Memo != "" {
+		if err = draft.Memo.Scan(transaction.Memo); err != nil {
+			return
+		}
+	}
+
+	for _, row := range transaction.Data {
+		if row.Amount > 0 {
+			draft.Amount += row.Amount
+		}
+	}
+
+	return
+}
+
+// validateApprovalRuleClass checks that a rule limited to an account class names a class of the company.
+func (w *writer) validateApprovalRuleClass(ctx context.Context, rule *domain.ApprovalRule) (err error) {
+	if !rule.AccountClassID.Valid {
+		return
+	}
+
+	if _, err = w.reader.GetAccountClassByID(ctx, rule.AccountClassID.Int64); err != nil {
+		err = errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "accountClassID", Message: "Account class not found"}},
+			EcodeNotFound,
+			"Account class not found",
+		)
+	}
+
+	return
+}
+
+// StoreApprovalRule needs the approval rule permission, as the rules decide how many reviewers a draft needs.
+func (w *writer) StoreApprovalRule(ctx context.Context, userID uuid.UUID, rule *domain.ApprovalRule) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.ApprovalRule, auth.WRITE); err != nil {
+		return
+	}
+
+	if err = w.validateApprovalRuleClass(ctx, rule); err != nil {
+		return
+	}
+
+	query := "INSERT INTO approval_rules (account_class_id, min_amount, required_approvals, company_id) VALUES (?, ?, ?, ?) RETURNING id"
+	err = w.db.QueryRowContext(ctx, w.db.Rebind(query), rule.AccountClassID, rule.MinAmount, rule.RequiredApprovals, companyID(ctx)).Scan(&rule.ID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreApprovalRuleFailed, "Store approval rule failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID, rule *domain.ApprovalRule) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.ApprovalRule, auth.WRITE); err != nil {
+		return
+	}
+
+	if err = w.validateApprovalRuleClass(ctx, rule); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"account_class_id":   rule.AccountClassID,
+		"min_amount":         rule.MinAmount,
+		"required_approvals": rule.RequiredApprovals,
+	}
+
+	rule.ID = id
+	if _, err = w.db.Updates(ctx, "approval_rules", dest, &ApprovalRuleStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateApprovalRuleFailed, "Update approval rule by id failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) DeleteApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	if err = w.mustHavePermission(ctx, userID, auth.ApprovalRule, auth.WRITE); err != nil {
+		return
+	}
+
+	if _, err = w.db.Delete(ctx, "approval_rules", &ApprovalRuleStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteApprovalRuleFailed, "Delete approval rule by id failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreBudget(ctx context.Context, userID uuid.UUID, budget *domain.Budget) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		return w.storeBudgetTx(tx, ctx, userID, budget)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store budget")
+		return
+	}
+
+	return
+}
+
+func (w *writer) storeBudgetTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, budget *domain.Budget) (err error) {
+	if strings.TrimSpace(budget.Name) == "" {
+		err = errors.PropagateWithCode(goErr.New("budget name is required"), EcodeStoreBudgetFailed, "Budget name is required")
+		return
+	}
+
+	if _, err = w.reader.GetFiscalYear(ctx, FiscalYearStatement{ID: budget.FiscalYearID}); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
+		return
+	}
+
+	query := `
+		INSERT INTO budgets (fiscal_year_id, name, description, created_by, company_id)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	budget.Name = strings.TrimSpace(budget.Name)
+	budget.CreatedBy = userID
+	err = tx.QueryRowContext(ctx, tx.Rebind(query), budget.FiscalYearID, budget.Name, budget.Description, userID, companyID(ctx)).Scan(&budget.ID, &budget.CreatedAt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreBudgetFailed, "Store budget failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateBudgetByID(ctx context.Context, id int64, budget *domain.Budget) (err error) {
+	if strings.TrimSpace(budget.Name) == "" {
+		err = errors.PropagateWithCode(goErr.New("budget name is required"), EcodeUpdateBudgetFailed, "Budget name is required")
+		return
+	}
+
+	dest := map[string]interface{}{
+		"name":        strings.TrimSpace(budget.Name),
+		"description": budget.Description,
+	}
+
+	if _, err = w.db.Updates(ctx, "budgets", dest, &BudgetStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateBudgetFailed, "Update budget by id failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) DeleteBudgetByID(ctx context.Context, id int64) (err error) {
+	if _, err = w.db.Delete(ctx, "budgets", &BudgetStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeDeleteBudgetFailed, "Delete budget by id failed")
+		return
+	}
+
+	return
+}
+
+// StoreBudgetLines sets the amount of every given account, period and dimension of a budget,
+// lines of the budget not given are kept.
+func (w *writer) StoreBudgetLines(ctx context.Context, budgetID int64, lines []domain.BudgetLine) (err error) {
+	budget, err := w.reader.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get budget")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		return w.storeBudgetLinesTx(tx, ctx, budget, lines)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store budget lines")
+		return
+	}
+
+	return
+}
+
+func (w *writer) storeBudgetLinesTx(tx sql.Tx, ctx context.Context, budget domain.Budget, lines []domain.BudgetLine) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	periods, err := w.reader.GetAllFiscalPeriods(ctx, FiscalPeriodStatement{FiscalYearID: budget.FiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal periods")
+		return
+	}
+
+	periodIDs := make(map[int64]bool, len(periods))
+	for _, period := range periods {
+		periodIDs[period.ID] = true
+	}
+
+	for i, line := range lines {
+		field := fmt.Sprintf("%d", i)
+
+		if !periodIDs[line.FiscalPeriodID] {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Fiscal period does not belong to the budget fiscal year"})
+		}
+
+		if len(strings.TrimSpace(line.Dimension)) > 255 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "Dimension must be at most 255 characters"})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeBudgetLineInvalid, "Invalid budget lines")
+		return
+	}
+
+	query := tx.Rebind(`
+		INSERT INTO budget_lines (budget_id, account_id, fiscal_period_id, dimension, amount)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (budget_id, account_id, fiscal_period_id, dimension) DO UPDATE SET amount = EXCLUDED.amount
+	`)
+
+	for _, line := range lines {
+		_, err = tx.ExecContext(ctx, query, budget.ID, line.AccountID, line.FiscalPeriodID, strings.TrimSpace(line.Dimension), line.Amount)
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeStoreBudgetLinesFailed, "Store budget line failed")
+			return
+		}
+	}
+
+	return
+}
+
+// GenerateBudgetFromActuals stores a new budget filled with the actuals of the source fiscal year grown by percent.
+// Periods are matched by their position in the fiscal year, the source defaults to the fiscal year right before.
+func (w *writer) GenerateBudgetFromActuals(ctx context.Context, userID uuid.UUID, budget *domain.Budget, sourceFiscalYearID int64, percent float64) (err error) {
+	fiscalYear, err := w.reader.GetFiscalYear(ctx, FiscalYearStatement{ID: budget.FiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal year")
+		return
+	}
+
+	if sourceFiscalYearID == 0 {
+		query := "SELECT id FROM fiscal_years WHERE company_id = ? AND end_date < ? ORDER BY end_date DESC LIMIT 1"
+		if err = w.db.GetContext(ctx, &sourceFiscalYearID, w.db.Rebind(query), companyID(ctx), fiscalYear.StartDate); err != nil {
+			if err == goSql.ErrNoRows {
+				err = errors.PropagateWithCode(err, EcodeNotFound, "There is no fiscal year before the budget fiscal year")
+				return
+			}
+
+			err = errors.PropagateWithCode(err, EcodeGenerateBudgetFailed, "Failed on get previous fiscal year")
+			return
+		}
+	}
+
+	periods, err := w.reader.GetAllFiscalPeriods(ctx, FiscalPeriodStatement{FiscalYearID: fiscalYear.ID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get fiscal periods")
+		return
+	}
+
+	sourcePeriods, err := w.reader.GetAllFiscalPeriods(ctx, FiscalPeriodStatement{FiscalYearID: sourceFiscalYearID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get source fiscal periods")
+		return
+	}
+
+	if len(periods) == 0 || len(periods) != len(sourcePeriods) {
+		err = errors.PropagateWithCode(
+			goErr.New("fiscal periods mismatch"),
+			EcodeGenerateBudgetFailed,
+			"Both fiscal years must have the same number of generated fiscal periods",
+		)
+		return
+	}
+
+	periodIDs := make(map[int64]int64, len(sourcePeriods))
+	for i, period := range sourcePeriods {
+		periodIDs[period.ID] = periods[i].ID
+	}
+
+	actuals, err := w.reader.GetAllActualsByFiscalPeriod(ctx, sourceFiscalYearID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get actuals")
+		return
+	}
+
+	lines := make([]domain.BudgetLine, len(actuals))
+	for i, actual := range actuals {
+		lines[i] = domain.BudgetLine{
+			AccountID:      actual.AccountID,
+			FiscalPeriodID: periodIDs[actual.FiscalPeriodID],
+			Amount:         math.Round(actual.Amount*(1+percent/100)*100) / 100,
+		}
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		if err := w.storeBudgetTx(tx, ctx, userID, budget); err != nil {
+			return err
+		}
+
+		return w.storeBudgetLinesTx(tx, ctx, *budget, lines)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on generate budget")
+		return
+	}
+
+	return
+}
+
+func (w *writer) StoreAmortizationSchedule(ctx context.Context, userID uuid.UUID, schedule *domain.AmortizationSchedule) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	schedule.Name = strings.TrimSpace(schedule.Name)
+	schedule.StartDate, schedule.EndDate = truncateDate(schedule.StartDate), truncateDate(schedule.EndDate)
+
+	if schedule.MethodID == 0 {
+		schedule.MethodID = domain.MonthlyAmortizationMethod
+	}
+
+	if schedule.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if schedule.TotalAmount <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "totalAmount", Message: "Total amount must be greater than zero"})
+	}
+
+	if schedule.EndDate.Before(schedule.StartDate) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "endDate", Message: "End date must not be before the start date"})
+	}
+
+	if schedule.MethodID != domain.MonthlyAmortizationMethod && schedule.MethodID != domain.DailyAmortizationMethod {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "methodID", Message: "Method not valid"})
+	}
+
+	sourceClass, err := w.reader.GetAccountClassByAccountID(ctx, schedule.SourceAccountID)
+	if err != nil || !IsBalanceSheetAccount(sourceClass.TypeID) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "sourceAccountID", Message: "Source account must be one of the balance sheet account"})
+	}
+
+	targetClass, err := w.reader.GetAccountClassByAccountID(ctx, schedule.TargetAccountID)
+	if err != nil || targetClass.TypeID < IncomeClassType {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "targetAccountID", Message: "Target account must be one of the profit and loss account"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeAmortizationScheduleInvalid, "Invalid amortization schedule")
+		return
+	}
+
+	query := `
+		INSERT INTO amortization_schedules (name, total_amount, start_date, end_date, source_account_id, target_account_id, method_id, created_by, company_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, status_id, created_at
+	`
+
+	err = w.db.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		schedule.Name, schedule.TotalAmount, schedule.StartDate, schedule.EndDate,
+		schedule.SourceAccountID, schedule.TargetAccountID, schedule.MethodID, userID, companyID(ctx),
+	).Scan(&schedule.ID, &schedule.StatusID, &schedule.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAmortizationScheduleFailed, "Store amortization schedule failed")
+		return
+	}
+
+	schedule.CreatedBy = userID
+
+	return
+}
+
+// lockAmortizationScheduleTx locks a schedule until tx ends and reads it with its posted entries,
+// so the scheduled run and a termination never post the same period twice.
+func (w *writer) lockAmortizationScheduleTx(tx sql.Tx, ctx context.Context, id int64) (schedule domain.AmortizationSchedule, entries []domain.AmortizationEntry, err error) {
+	if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM amortization_schedules WHERE id = ? AND company_id = ? FOR UPDATE"), id, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAmortizationScheduleFailed, "Failed on lock amortization schedule")
+		return
+	}
+
+	query := fmt.Sprintf(amortizationSchedulesQuery, "WHERE id = ? AND company_id = ?")
+	if err = tx.GetContext(ctx, &schedule, tx.Rebind(query), id, companyID(ctx)); err != nil {
+		if err == goSql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Amortization schedule not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetAmortizationScheduleFailed, "Failed on get amortization schedule")
+		return
+	}
+
+	query = fmt.Sprintf(amortizationEntriesQuery, "WHERE schedule_id = ?")
+	if err = tx.SelectContext(ctx, &entries, tx.Rebind(query), id); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationEntriesFailed, "Failed on get amortization entries")
+		return
+	}
+
+	return
+}
+
+// postAmortizationEntryTx posts the journal of an entry and records it. An expense or COGS target is debited
+// against the source account, an income target is credited against it.
+func (w *writer) postAmortizationEntryTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, schedule domain.AmortizationSchedule, entry *domain.AmortizationEntry) (err error) {
+	targetClass, err := w.reader.GetAccountClassByAccountID(ctx, schedule.TargetAccountID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get target account class")
+		return
+	}
+
+	amount := entry.Amount
+	if targetClass.TypeID == IncomeClassType {
+		amount = -amount
+	}
+
+	journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+		Date: entry.PeriodEnd,
+		Memo: fmt.Sprintf("Amortization of %s, %s to %s", schedule.Name, entry.PeriodStart.Format("2006-01-02"), entry.PeriodEnd.Format("2006-01-02")),
+		Data: []TransactionRow{
+			{AccountID: schedule.TargetAccountID, Amount: amount},
+			{AccountID: schedule.SourceAccountID, Amount: -amount},
+		},
+		typeID: AmortizationJournalType,
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post amortization journal")
+		return
+	}
+
+	entry.ScheduleID = schedule.ID
+	entry.JournalID = journal.ID
+
+	query := `
+		INSERT INTO amortization_entries (schedule_id, period_start, period_end, amount, journal_id, catch_up)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		tx.Rebind(query),
+		entry.ScheduleID, entry.PeriodStart, entry.PeriodEnd, entry.Amount, entry.JournalID, entry.CatchUp,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodePostAmortizationEntriesFailed, "Store amortization entry failed")
+		return
+	}
+
+	return
+}
+
+// PostDueAmortizationEntries posts, for every active schedule, the plan lines ending on or before asOf that
+// have no entry yet, oldest first, one journal per line. A schedule stops at its first line that fails to post,
+// for example on a locked period, and the failure is reported once every schedule has been tried.
+// Without a company in ctx, as for the background job, the schedules of every company are posted,
+// each within its own company.
+func (w *writer) PostDueAmortizationEntries(ctx context.Context, asOf time.Time) (entries []domain.AmortizationEntry, err error) {
+	var (
+		failures  errors.ValidationErrors
+		schedules []domain.AmortizationSchedule
+	)
+
+	entries = make([]domain.AmortizationEntry, 0)
+
+	stmt := AmortizationScheduleStatement{StatusID: domain.ActiveAmortizationStatus, CompanyID: appcontext.GetCompanyID(ctx)}
+	whereClause, whereClauseArgs, err := qb.NewWhereClause(stmt)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationSchedulesFailed, "Failed on build where clause")
+		return
+	}
+
+	query := fmt.Sprintf(amortizationSchedulesQuery, whereClause+" ORDER BY company_id ASC, start_date ASC, id ASC")
+	if err = w.db.SelectContext(ctx, &schedules, w.db.Rebind(query), whereClauseArgs...); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAmortizationSchedulesFailed, "Failed on get active amortization schedules")
+		return
+	}
+
+	for _, schedule := range schedules {
+		ctx := appcontext.SetCompanyID(ctx, schedule.CompanyID)
+
+		for {
+			var (
+				entry  domain.AmortizationEntry
+				posted bool
+			)
+
+			postErr := w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+				schedule, postedEntries, err := w.lockAmortizationScheduleTx(tx, ctx, schedule.ID)
+				if err != nil || schedule.StatusID != domain.ActiveAmortizationStatus {
+					return err
+				}
+
+				postedPeriods := make(map[time.Time]bool, len(postedEntries))
+				for _, postedEntry := range postedEntries {
+					postedPeriods[truncateDate(postedEntry.PeriodEnd)] = true
+				}
+
+				plan := AmortizationPlan(schedule)
+				for i, line := range plan {
+					if postedPeriods[line.PeriodEnd] {
+						continue
+					}
+
+					if line.PeriodEnd.After(asOf) {
+						return nil
+					}
+
+					entry = domain.AmortizationEntry{PeriodStart: line.PeriodStart, PeriodEnd: line.PeriodEnd, Amount: line.Amount}
+
+					// the last line settles what is left, the earlier lines may have been posted under another split
+					if i == len(plan)-1 {
+						entry.Amount = roundCents(schedule.TotalAmount - schedule.PostedAmount)
+					}
+
+					if err = w.postAmortizationEntryTx(tx, ctx, schedule.CreatedBy, schedule, &entry); err != nil {
+						return err
+					}
+
+					posted = true
+					if i < len(plan)-1 {
+						return nil
+					}
+
+					break
+				}
+
+				_, err = tx.ExecContext(
+					ctx,
+					tx.Rebind("UPDATE amortization_schedules SET status_id = ? WHERE id = ?"),
+					domain.CompletedAmortizationStatus, schedule.ID,
+				)
+
+				if err != nil {
+					return errors.PropagateWithCode(err, EcodePostAmortizationEntriesFailed, "Failed on complete amortization schedule")
+				}
+
+				return nil
+			})
+
+			if postErr != nil {
+				failures = append(failures, errors.FieldError{Field: fmt.Sprintf("%d", schedule.ID), Message: postErr.Error()})
+				break
+			}
+
+			if !posted {
+				break
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(failures) > 0 {
+		err = errors.PropagateWithCode(failures, EcodePostAmortizationEntriesFailed, "Failed on post amortization entries of some schedules")
+		return
+	}
+
+	return
+}
+
+// TerminateAmortizationScheduleByID stops a schedule at date with one catch-up entry for what the plan amortizes
+// by then and has not been posted yet, or for the whole remaining amount when writeOffRemaining is set.
+func (w *writer) TerminateAmortizationScheduleByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, writeOffRemaining bool) (err error) {
+	date = truncateDate(date)
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		schedule, entries, err := w.lockAmortizationScheduleTx(tx, ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if schedule.StatusID != domain.ActiveAmortizationStatus {
+			return errors.PropagateWithCode(goErr.New("schedule not active"), EcodeAmortizationScheduleNotActive, "Amortization schedule is not active")
+		}
+
+		if date.Before(schedule.StartDate) || !date.Before(schedule.EndDate) {
+			return errors.PropagateWithCode(
+				goErr.New("invalid termination date"),
+				EcodeAmortizationScheduleInvalid,
+				"Termination date must be on or after the start date and before the end date",
+			)
+		}
+
+		amount := amortizedThrough(AmortizationPlan(schedule), date) - schedule.PostedAmount
+		if writeOffRemaining {
+			amount = schedule.TotalAmount - schedule.PostedAmount
+		}
+
+		if amount = roundCents(amount); amount != 0 {
+			entry := domain.AmortizationEntry{PeriodStart: schedule.StartDate, PeriodEnd: date, Amount: amount, CatchUp: true}
+			for _, posted := range entries {
+				if !posted.PeriodEnd.Before(entry.PeriodStart) {
+					entry.PeriodStart = posted.PeriodEnd.AddDate(0, 0, 1)
+				}
+			}
+
+			if entry.PeriodStart.After(date) {
+				entry.PeriodStart = date
+			}
+
+			if err = w.postAmortizationEntryTx(tx, ctx, userID, schedule, &entry); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			tx.Rebind("UPDATE amortization_schedules SET status_id = ?, terminated_at = ? WHERE id = ?"),
+			domain.TerminatedAmortizationStatus, date, schedule.ID,
+		)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeTerminateAmortizationScheduleFailed, "Failed on terminate amortization schedule")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on terminate amortization schedule")
+		return
+	}
+
+	return
+}
+
+func validDepreciationMethod(methodID int64) bool {
+	return methodID == domain.StraightLineDepreciationMethod || methodID == domain.DecliningBalanceDepreciationMethod
+}
+
+func (w *writer) validateAssetCategory(ctx context.Context, category *domain.AssetCategory) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	category.Name = strings.TrimSpace(category.Name)
+
+	if category.MethodID == 0 {
+		category.MethodID = domain.StraightLineDepreciationMethod
+	}
+
+	if category.DecliningFactor == 0 {
+		category.DecliningFactor = 2
+	}
+
+	if category.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if category.UsefulLifeMonths <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "usefulLifeMonths", Message: "Useful life must be greater than zero"})
+	}
+
+	if !validDepreciationMethod(category.MethodID) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "methodID", Message: "Method not valid"})
+	}
+
+	if category.DecliningFactor < 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "decliningFactor", Message: "Declining factor must be greater than zero"})
+	}
+
+	assetClass, err := w.reader.GetAccountClassByAccountID(ctx, category.AssetAccountID)
+	if err != nil || assetClass.TypeID != AssetClassType {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "assetAccountID", Message: "Asset account must be one of the asset account"})
+	}
+
+	accumulatedClass, err := w.reader.GetAccountClassByAccountID(ctx, category.AccumulatedDepreciationAccountID)
+	if err != nil || accumulatedClass.TypeID != AssetClassType {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "accumulatedDepreciationAccountID", Message: "Accumulated depreciation account must be one of the asset account"})
+	}
+
+	expenseClass, err := w.reader.GetAccountClassByAccountID(ctx, category.DepreciationExpenseAccountID)
+	if err != nil || expenseClass.TypeID < COGSClassType {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "depreciationExpenseAccountID", Message: "Depreciation expense account must be one of the expense account"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeAssetCategoryInvalid, "Invalid asset category")
+	}
+
+	return nil
+}
+
+func (w *writer) StoreAssetCategory(ctx context.Context, category *domain.AssetCategory) (err error) {
+	if err = w.validateAssetCategory(ctx, category); err != nil {
+		return
+	}
+
+	query := `
+		INSERT INTO asset_categories (
+			name, asset_account_id, accumulated_depreciation_account_id, depreciation_expense_account_id,
+			useful_life_months, method_id, declining_factor
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err = w.db.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		category.Name, category.AssetAccountID, category.AccumulatedDepreciationAccountID, category.DepreciationExpenseAccountID,
+		category.UsefulLifeMonths, category.MethodID, category.DecliningFactor,
+	).Scan(&category.ID)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreAssetCategoryFailed, "Store asset category failed")
+		return
+	}
+
+	return
+}
+
+// UpdateAssetCategoryByID changes the accounts and defaults of a category. Assets already registered keep
+// their own life and method, and post to the new accounts from their next depreciation run or disposal.
+func (w *writer) UpdateAssetCategoryByID(ctx context.Context, id int64, category *domain.AssetCategory) (err error) {
+	if err = w.validateAssetCategory(ctx, category); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"name":                                category.Name,
+		"asset_account_id":                    category.AssetAccountID,
+		"accumulated_depreciation_account_id": category.AccumulatedDepreciationAccountID,
+		"depreciation_expense_account_id":     category.DepreciationExpenseAccountID,
+		"useful_life_months":                  category.UsefulLifeMonths,
+		"method_id":                           category.MethodID,
+		"declining_factor":                    category.DecliningFactor,
+	}
+
+	if _, err = w.db.Updates(ctx, "asset_categories", dest, &AssetCategoryStatement{ID: id}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateAssetCategoryFailed, "Update asset category by id failed")
+		return
+	}
+
+	category.ID = id
+
+	return
+}
+
+func validateFixedAsset(asset *domain.FixedAsset) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	if asset.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if asset.AcquisitionCost <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "acquisitionCost", Message: "Acquisition cost must be greater than zero"})
+	}
+
+	if asset.SalvageValue < 0 || asset.SalvageValue > asset.AcquisitionCost {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "salvageValue", Message: "Salvage value must be between zero and the acquisition cost"})
+	}
+
+	if asset.InServiceDate.IsZero() {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "inServiceDate", Message: "In service date is required"})
+	}
+
+	if asset.UsefulLifeMonths <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "usefulLifeMonths", Message: "Useful life must be greater than zero"})
+	}
+
+	if !validDepreciationMethod(asset.MethodID) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "methodID", Message: "Method not valid"})
+	}
+
+	if asset.DecliningFactor <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "decliningFactor", Message: "Declining factor must be greater than zero"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeFixedAssetInvalid, "Invalid fixed asset")
+	}
+
+	return nil
+}
+
+// StoreFixedAsset registers an asset, taking the useful life, method and declining factor of its category when not given.
+func (w *writer) StoreFixedAsset(ctx context.Context, userID uuid.UUID, asset *domain.FixedAsset) (err error) {
+	category, err := w.reader.GetAssetCategoryByID(ctx, asset.CategoryID)
+	if err != nil {
+		if errors.GetCode(err) == EcodeNotFound {
+			err = errors.PropagateWithCode(
+				errors.ValidationErrors{{Field: "categoryID", Message: "Category not found"}},
+				EcodeFixedAssetInvalid,
+				"Invalid fixed asset",
+			)
+			return
+		}
+
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get asset category")
+		return
+	}
+
+	asset.Name = strings.TrimSpace(asset.Name)
+	asset.InServiceDate = truncateDate(asset.InServiceDate)
+
+	if asset.UsefulLifeMonths == 0 {
+		asset.UsefulLifeMonths = category.UsefulLifeMonths
+	}
+
+	if asset.MethodID == 0 {
+		asset.MethodID = category.MethodID
+	}
+
+	if asset.DecliningFactor == 0 {
+		asset.DecliningFactor = category.DecliningFactor
+	}
+
+	if err = validateFixedAsset(asset); err != nil {
+		return
+	}
+
+	query := `
+		INSERT INTO fixed_assets (
+			name, category_id, acquisition_cost, in_service_date, salvage_value, useful_life_months, method_id,
+			declining_factor, created_by, company_id
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, status_id, created_at
+	`
+
+	err = w.db.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		asset.Name, asset.CategoryID, asset.AcquisitionCost, asset.InServiceDate, asset.SalvageValue,
+		asset.UsefulLifeMonths, asset.MethodID, asset.DecliningFactor, userID, companyID(ctx),
+	).Scan(&asset.ID, &asset.StatusID, &asset.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreFixedAssetFailed, "Store fixed asset failed")
+		return
+	}
+
+	asset.CreatedBy = userID
+
+	return
+}
+
+// UpdateFixedAssetByID changes an active asset. Depreciation already posted stays, the next run catches up,
+// or reverses, the difference the new cost, salvage value, life or method makes.
+func (w *writer) UpdateFixedAssetByID(ctx context.Context, id int64, asset *domain.FixedAsset) (err error) {
+	asset.Name = strings.TrimSpace(asset.Name)
+	asset.InServiceDate = truncateDate(asset.InServiceDate)
+
+	if err = validateFixedAsset(asset); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		var statusID int64
+
+		if err := tx.GetContext(ctx, &statusID, tx.Rebind("SELECT status_id FROM fixed_assets WHERE id = ? AND company_id = ? FOR UPDATE"), id, companyID(ctx)); err != nil {
+			if err == goSql.ErrNoRows {
+				return errors.PropagateWithCode(err, EcodeNotFound, "Fixed asset not found")
+			}
+
+			return errors.PropagateWithCode(err, EcodeUpdateFixedAssetFailed, "Failed on lock fixed asset")
+		}
+
+		if statusID != domain.ActiveFixedAssetStatus {
+			return errors.PropagateWithCode(goErr.New("fixed asset not active"), EcodeFixedAssetNotActive, "Fixed asset is not active")
+		}
+
+		query := `
+			UPDATE fixed_assets
+			SET name = ?, acquisition_cost = ?, in_service_date = ?, salvage_value = ?, useful_life_months = ?,
+				method_id = ?, declining_factor = ?
+			WHERE id = ?
+		`
+
+		_, err := tx.ExecContext(
+			ctx,
+			tx.Rebind(query),
+			asset.Name, asset.AcquisitionCost, asset.InServiceDate, asset.SalvageValue, asset.UsefulLifeMonths,
+			asset.MethodID, asset.DecliningFactor, id,
+		)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeUpdateFixedAssetFailed, "Failed on update fixed asset")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Update fixed asset by id failed")
+		return
+	}
+
+	asset.ID = id
+
+	return
+}
+
+// getAllAssetCategoriesTx reads every category keyed by id.
+func (w *writer) getAllAssetCategoriesTx(tx sql.Tx, ctx context.Context) (categories map[int64]domain.AssetCategory, err error) {
+	var rows []domain.AssetCategory
+
+	if err = tx.SelectContext(ctx, &rows, fmt.Sprintf(assetCategoriesQuery, "")); err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetAllAssetCategoriesFailed, "Failed on get asset categories")
+		return
+	}
+
+	categories = make(map[int64]domain.AssetCategory, len(rows))
+	for _, category := range rows {
+		categories[category.ID] = category
+	}
+
+	return
+}
+
+// RunDepreciation posts, in one journal dated at the end of the month holding date, the depreciation every active
+// asset accumulates by then less what earlier runs posted. A month runs once, and never before a month already run,
+// unless the journal of the later run is voided first.
+func (w *writer) RunDepreciation(ctx context.Context, userID uuid.UUID, date time.Time) (run domain.DepreciationRun, err error) {
+	run.PeriodEnd = endOfMonth(truncateDate(date))
+	run.CreatedBy = userID
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		var alreadyRun bool
+
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE depreciation_runs IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return errors.PropagateWithCode(err, EcodeRunDepreciationFailed, "Failed on lock depreciation runs")
+		}
+
+		query := `
+			SELECT EXISTS (
+				SELECT 1
+				FROM depreciation_runs dr
+				INNER JOIN journals j ON j.id = dr.journal_id
+				WHERE dr.period_end >= ? AND j.deleted_at IS NULL AND j.company_id = ?
+			)
+		`
+
+		if err := tx.GetContext(ctx, &alreadyRun, tx.Rebind(query), run.PeriodEnd, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeRunDepreciationFailed, "Failed on check depreciation runs")
+		}
+
+		if alreadyRun {
+			return errors.PropagateWithCode(
+				goErr.New("depreciation already run"),
+				EcodeDepreciationAlreadyRun,
+				"Depreciation has already been run for this or a later month",
+			)
+		}
+
+		categories, err := w.getAllAssetCategoriesTx(tx, ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM fixed_assets WHERE company_id = ? AND status_id = ? FOR UPDATE"), companyID(ctx), domain.ActiveFixedAssetStatus); err != nil {
+			return errors.PropagateWithCode(err, EcodeRunDepreciationFailed, "Failed on lock fixed assets")
+		}
+
+		var assets []domain.FixedAsset
+
+		query = fmt.Sprintf(fixedAssetsQuery, "WHERE company_id = ? AND status_id = ? AND in_service_date <= ? ORDER BY id ASC")
+		if err = tx.SelectContext(ctx, &assets, tx.Rebind(query), companyID(ctx), domain.ActiveFixedAssetStatus, run.PeriodEnd); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetAllFixedAssetsFailed, "Failed on get fixed assets")
+		}
+
+		var rows []TransactionRow
+
+		for _, asset := range assets {
+			amount := roundCents(DepreciationThrough(asset, run.PeriodEnd) - asset.AccumulatedDepreciation)
+			if amount == 0 {
+				continue
+			}
+
+			category := categories[asset.CategoryID]
+			rows = append(
+				rows,
+				TransactionRow{AccountID: category.DepreciationExpenseAccountID, Amount: amount, Memo: asset.Name},
+				TransactionRow{AccountID: category.AccumulatedDepreciationAccountID, Amount: -amount, Memo: asset.Name},
+			)
+
+			run.Entries = append(run.Entries, domain.DepreciationEntry{AssetID: asset.ID, Amount: amount})
+		}
+
+		if len(run.Entries) == 0 {
+			return errors.PropagateWithCode(goErr.New("nothing to depreciate"), EcodeNothingToDepreciate, "There is nothing to depreciate for this month")
+		}
+
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   run.PeriodEnd,
+			Memo:   fmt.Sprintf("Depreciation for %s", run.PeriodEnd.Format("January 2006")),
+			Data:   rows,
+			typeID: FixedAssetJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post depreciation journal")
+		}
+
+		run.JournalID = journal.ID
+
+		query = `
+			INSERT INTO depreciation_runs (period_end, journal_id, created_by)
+			VALUES (?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		if err = tx.QueryRowContext(ctx, tx.Rebind(query), run.PeriodEnd, run.JournalID, userID).Scan(&run.ID, &run.CreatedAt); err != nil {
+			return errors.PropagateWithCode(err, EcodeRunDepreciationFailed, "Store depreciation run failed")
+		}
+
+		for i := range run.Entries {
+			run.Entries[i].RunID = run.ID
+
+			query = "INSERT INTO depreciation_entries (run_id, asset_id, amount) VALUES (?, ?, ?) RETURNING id"
+			if err = tx.QueryRowContext(ctx, tx.Rebind(query), run.ID, run.Entries[i].AssetID, run.Entries[i].Amount).Scan(&run.Entries[i].ID); err != nil {
+				return errors.PropagateWithCode(err, EcodeRunDepreciationFailed, "Store depreciation entry failed")
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on run depreciation")
+		return
+	}
+
+	return
+}
+
+// DisposeFixedAssetByID takes an active asset off the books at date. The journal catches up the depreciation through
+// the month before date, clears the accumulated depreciation and the cost, debits the proceeds to proceedsAccountID,
+// and books the difference to the disposal gain or loss account of the general ledger preferences.
+func (w *writer) DisposeFixedAssetByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, proceeds float64, proceedsAccountID int64) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	date = truncateDate(date)
+	proceeds = roundCents(proceeds)
+
+	if proceeds < 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "proceeds", Message: "Proceeds must not be negative"})
+	}
+
+	if proceeds > 0 && proceedsAccountID == 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "proceedsAccountID", Message: "Proceeds account is required"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeFixedAssetInvalid, "Invalid fixed asset disposal")
+		return
+	}
+
+	gainLossGLP, err := w.reader.GetGeneralLedgerPreferenceByID(ctx, GeneralLedgerPreferenceStatement{ID: int64(FixedAssetDisposalGainLoss)})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		var (
+			asset    domain.FixedAsset
+			category domain.AssetCategory
+		)
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind("SELECT id FROM fixed_assets WHERE id = ? AND company_id = ? FOR UPDATE"), id, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeDisposeFixedAssetFailed, "Failed on lock fixed asset")
+		}
+
+		if err := tx.GetContext(ctx, &asset, tx.Rebind(fmt.Sprintf(fixedAssetsQuery, "WHERE id = ? AND company_id = ?")), id, companyID(ctx)); err != nil {
+			if err == goSql.ErrNoRows {
+				return errors.PropagateWithCode(err, EcodeNotFound, "Fixed asset not found")
+			}
+
+			return errors.PropagateWithCode(err, EcodeGetFixedAssetFailed, "Failed on get fixed asset")
+		}
+
+		if asset.StatusID != domain.ActiveFixedAssetStatus {
+			return errors.PropagateWithCode(goErr.New("fixed asset not active"), EcodeFixedAssetNotActive, "Fixed asset is not active")
+		}
+
+		if date.Before(asset.InServiceDate) {
+			return errors.PropagateWithCode(
+				errors.ValidationErrors{{Field: "date", Message: "Disposal date must not be before the in service date"}},
+				EcodeFixedAssetInvalid,
+				"Invalid fixed asset disposal",
+			)
+		}
+
+		if err := tx.GetContext(ctx, &category, tx.Rebind(fmt.Sprintf(assetCategoriesQuery, "WHERE id = ?")), asset.CategoryID); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetAssetCategoryFailed, "Failed on get asset category")
+		}
+
+		var rows []TransactionRow
+
+		accumulated := asset.AccumulatedDepreciation
+		if catchUp := roundCents(DepreciationThrough(asset, date.AddDate(0, 0, -date.Day())) - accumulated); catchUp > 0 {
+			rows = append(
+				rows,
+				TransactionRow{AccountID: category.DepreciationExpenseAccountID, Amount: catchUp, Memo: asset.Name},
+				TransactionRow{AccountID: category.AccumulatedDepreciationAccountID, Amount: -catchUp, Memo: asset.Name},
+			)
+
+			accumulated = roundCents(accumulated + catchUp)
+		}
+
+		if accumulated != 0 {
+			rows = append(rows, TransactionRow{AccountID: category.AccumulatedDepreciationAccountID, Amount: accumulated, Memo: asset.Name})
+		}
+
+		if proceeds > 0 {
+			rows = append(rows, TransactionRow{AccountID: proceedsAccountID, Amount: proceeds, Memo: asset.Name})
+		}
+
+		rows = append(rows, TransactionRow{AccountID: category.AssetAccountID, Amount: -asset.AcquisitionCost, Memo: asset.Name})
+
+		if loss := roundCents(asset.AcquisitionCost - accumulated - proceeds); loss != 0 {
+			if !gainLossGLP.AccountID.Valid || gainLossGLP.AccountID.Int64 == 0 {
+				return errors.PropagateWithCode(
+					goErr.New("fixed asset disposal gain or loss account not set"),
+					EcodeFixedAssetDisposalGainLossNotSet,
+					"Fixed asset disposal gain or loss account is not set",
+				)
+			}
+
+			rows = append(rows, TransactionRow{AccountID: gainLossGLP.AccountID.Int64, Amount: loss, Memo: asset.Name})
+		}
+
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   date,
+			Memo:   fmt.Sprintf("Disposal of %s", asset.Name),
+			Data:   rows,
+			typeID: FixedAssetJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post disposal journal")
+		}
+
+		query := `
+			UPDATE fixed_assets
+			SET status_id = ?, disposed_at = ?, disposal_proceeds = ?, disposal_journal_id = ?
+			WHERE id = ?
+		`
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), domain.DisposedFixedAssetStatus, date, proceeds, journal.ID, id); err != nil {
+			return errors.PropagateWithCode(err, EcodeDisposeFixedAssetFailed, "Failed on update fixed asset")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on dispose fixed asset")
+		return
+	}
+
+	return
+}
+
+func validateCustomer(customer *domain.Customer) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	customer.Code, customer.Name = strings.TrimSpace(customer.Code), strings.TrimSpace(customer.Name)
+
+	if customer.Code == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "code", Message: "Code is required"})
+	}
+
+	if customer.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if customer.PaymentTermsDays < 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "paymentTermsDays", Message: "Payment terms must not be negative"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeCustomerInvalid, "Invalid customer")
+	}
+
+	return nil
+}
+
+func (w *writer) StoreCustomer(ctx context.Context, customer *domain.Customer) (err error) {
+	if err = validateCustomer(customer); err != nil {
+		return
+	}
+
+	query := `
+		INSERT INTO customers (code, name, email, phone, address, tax_number, payment_terms_days, inactive, company_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	err = w.db.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		customer.Code, customer.Name, customer.Email, customer.Phone, customer.Address, customer.TaxNumber,
+		customer.PaymentTermsDays, customer.Inactive, companyID(ctx),
+	).Scan(&customer.ID, &customer.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreCustomerFailed, "Store customer failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateCustomerByID(ctx context.Context, id int64, customer *domain.Customer) (err error) {
+	if err = validateCustomer(customer); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"code":               customer.Code,
+		"name":               customer.Name,
+		"email":              customer.Email,
+		"phone":              customer.Phone,
+		"address":            customer.Address,
+		"tax_number":         customer.TaxNumber,
+		"payment_terms_days": customer.PaymentTermsDays,
+		"inactive":           customer.Inactive,
+	}
+
+	if _, err = w.db.Updates(ctx, "customers", dest, &CustomerStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateCustomerFailed, "Update customer by id failed")
+		return
+	}
+
+	customer.ID = id
+
+	return
+}
+
+// getPreferenceAccountID reads the account of a general ledger preference, notSetCode when no account is set.
+func (w *writer) getPreferenceAccountID(ctx context.Context, id GeneralLedgerPreferenceID, notSetCode errors.ErrorCode, notSetMessage string) (accountID int64, err error) {
+	preference, err := w.reader.GetGeneralLedgerPreferenceByID(ctx, GeneralLedgerPreferenceStatement{ID: int64(id)})
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeGetGeneralLedgerPreferenceFailed, "Failed on get general ledger preference")
+		return
+	}
+
+	if !preference.AccountID.Valid || preference.AccountID.Int64 == 0 {
+		err = errors.PropagateWithCode(goErr.New(strings.ToLower(notSetMessage)), notSetCode, notSetMessage)
+		return
+	}
+
+	return preference.AccountID.Int64, nil
+}
+
+// StoreSalesInvoice prices the lines, dues the invoice after the payment terms of the customer unless a due date
+// is given, and posts it to the accounts receivable control account in a sales invoice journal.
+func (w *writer) StoreSalesInvoice(ctx context.Context, userID uuid.UUID, invoice *domain.SalesInvoice) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	customer, err := w.reader.GetCustomerByID(ctx, invoice.CustomerID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get customer")
+		return
+	}
+
+	if err != nil || customer.Inactive {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "customerID", Message: "Customer not found or inactive"})
+	}
+
+	if invoice.InvoiceDate.IsZero() {
+		invoice.InvoiceDate = time.Now()
+	}
+
+	invoice.InvoiceDate = truncateDate(invoice.InvoiceDate)
+	if invoice.DueDate.IsZero() {
+		invoice.DueDate = invoice.InvoiceDate.AddDate(0, 0, int(customer.PaymentTermsDays))
+	}
+
+	invoice.DueDate = truncateDate(invoice.DueDate)
+	if invoice.DueDate.Before(invoice.InvoiceDate) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "dueDate", Message: "Due date must not be before the invoice date"})
+	}
+
+	if len(invoice.Lines) == 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "lines", Message: "Invoice needs at least one line"})
+	}
+
+	invoice.Subtotal, invoice.TaxAmount = 0, 0
+
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		field := fmt.Sprintf("lines.%d", i)
+
+		if line.Quantity == 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".quantity", Message: "Quantity must not be zero"})
+		}
+
+		if line.TaxRate < 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".taxRate", Message: "Tax rate must not be negative"})
+		}
+
+		accountClass, err := w.reader.GetAccountClassByAccountID(ctx, line.AccountID)
+		if err != nil || accountClass.TypeID != IncomeClassType {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".accountID", Message: "Account must be one of the income account"})
+		}
+
+		line.Description = strings.TrimSpace(line.Description)
+		line.Amount = roundCents(line.Quantity * line.UnitPrice)
+		line.TaxAmount = roundCents(line.Amount * line.TaxRate / 100)
+
+		invoice.Subtotal += line.Amount
+		invoice.TaxAmount += line.TaxAmount
+	}
+
+	invoice.Subtotal, invoice.TaxAmount = roundCents(invoice.Subtotal), roundCents(invoice.TaxAmount)
+	invoice.Total = roundCents(invoice.Subtotal + invoice.TaxAmount)
+
+	if len(invoice.Lines) > 0 && invoice.Total <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "lines", Message: "Invoice total must be greater than zero"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeSalesInvoiceInvalid, "Invalid sales invoice")
+		return
+	}
+
+	receivableAccountID, err := w.getPreferenceAccountID(ctx, AccountsReceivable, EcodeAccountsReceivableNotSet, "Accounts receivable account is not set")
+	if err != nil {
+		return
+	}
+
+	rows := []TransactionRow{{AccountID: receivableAccountID, Amount: invoice.Total, Memo: customer.Name}}
+	for _, line := range invoice.Lines {
+		rows = append(rows, TransactionRow{AccountID: line.AccountID, Amount: -line.Amount, Memo: line.Description})
+	}
+
+	if invoice.TaxAmount != 0 {
+		taxAccountID, err := w.getPreferenceAccountID(ctx, SalesTaxPayable, EcodeSalesTaxPayableNotSet, "Sales tax payable account is not set")
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, TransactionRow{AccountID: taxAccountID, Amount: -invoice.TaxAmount, Memo: customer.Name})
+	}
+
+	memo := fmt.Sprintf("Sales invoice to %s", customer.Name)
+	if invoice.Memo.Valid && invoice.Memo.String != "" {
+		memo = invoice.Memo.String
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   invoice.InvoiceDate,
+			Memo:   memo,
+			Data:   rows,
+			typeID: SalesInvoiceJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post sales invoice journal")
+		}
+
+		invoice.JournalID, invoice.Number = journal.ID, journal.Number.String
+
+		query := `
+			INSERT INTO sales_invoices (number, customer_id, invoice_date, due_date, memo, subtotal, tax_amount, total, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			invoice.Number, invoice.CustomerID, invoice.InvoiceDate, invoice.DueDate, invoice.Memo,
+			invoice.Subtotal, invoice.TaxAmount, invoice.Total, invoice.JournalID, userID, companyID(ctx),
+		).Scan(&invoice.ID, &invoice.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreSalesInvoiceFailed, "Store sales invoice failed")
+		}
+
+		for i := range invoice.Lines {
+			line := &invoice.Lines[i]
+			line.InvoiceID = invoice.ID
+
+			query = `
+				INSERT INTO sales_invoice_lines (invoice_id, account_id, description, quantity, unit_price, tax_rate, amount, tax_amount)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				RETURNING id
+			`
+
+			err = tx.QueryRowContext(
+				ctx,
+				tx.Rebind(query),
+				line.InvoiceID, line.AccountID, line.Description, line.Quantity, line.UnitPrice, line.TaxRate, line.Amount, line.TaxAmount,
+			).Scan(&line.ID)
+
+			if err != nil {
+				return errors.PropagateWithCode(err, EcodeStoreSalesInvoiceFailed, "Store sales invoice line failed")
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store sales invoice")
+		return
+	}
+
+	invoice.CreatedBy = userID
+
+	return
+}
+
+// VoidSalesInvoiceByID voids the journal of an invoice, which takes it off the receivables.
+// An invoice with receipts allocated to it cannot be voided.
+func (w *writer) VoidSalesInvoiceByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	invoice, err := w.reader.GetSalesInvoiceByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get sales invoice")
+		return
+	}
+
+	if invoice.Voided {
+		return
+	}
+
+	if err = w.VoidTransactionByID(ctx, invoice.JournalID, userID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void sales invoice")
+		return
+	}
+
+	return
+}
+
+// StoreCustomerReceipt deposits a receipt into its bank account against the accounts receivable control account
+// through the bank deposit path and allocates it to the invoices given, all in one transaction.
+func (w *writer) StoreCustomerReceipt(ctx context.Context, userID uuid.UUID, receipt *domain.CustomerReceipt) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	receipt.Amount = roundCents(receipt.Amount)
+
+	customer, err := w.reader.GetCustomerByID(ctx, receipt.CustomerID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get customer")
+		return
+	}
+
+	if err != nil {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "customerID", Message: "Customer not found"})
+	}
+
+	if receipt.Amount <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "amount", Message: "Amount must be greater than zero"})
+	}
+
+	if receipt.ReceiptDate.IsZero() {
+		receipt.ReceiptDate = time.Now()
+	}
+
+	receipt.ReceiptDate = truncateDate(receipt.ReceiptDate)
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeReceiptAllocationInvalid, "Invalid customer receipt")
+		return
+	}
+
+	receivableAccountID, err := w.getPreferenceAccountID(ctx, AccountsReceivable, EcodeAccountsReceivableNotSet, "Accounts receivable account is not set")
+	if err != nil {
+		return
+	}
+
+	memo := fmt.Sprintf("Receipt from %s", customer.Name)
+	if receipt.Memo.Valid && receipt.Memo.String != "" {
+		memo = receipt.Memo.String
+	}
+
+	allocations := receipt.Allocations
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err := w.storeBankTransactionJournalTx(tx, ctx, userID, BankTransaction{
+			BankAccountID:       receipt.BankAccountID,
+			bankTransactionType: Deposit,
+			Transaction: Transaction{
+				Date: receipt.ReceiptDate,
+				Memo: memo,
+				Data: []TransactionRow{{AccountID: receivableAccountID, Amount: receipt.Amount, Memo: customer.Name}},
+			},
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post customer receipt")
+		}
+
+		receipt.JournalID = journal.ID
+
+		query := `
+			INSERT INTO customer_receipts (customer_id, bank_account_id, receipt_date, amount, memo, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			receipt.CustomerID, receipt.BankAccountID, receipt.ReceiptDate, receipt.Amount, receipt.Memo, receipt.JournalID, userID, companyID(ctx),
+		).Scan(&receipt.ID, &receipt.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreCustomerReceiptFailed, "Store customer receipt failed")
+		}
+
+		receipt.Allocations, err = w.allocateCustomerReceiptTx(tx, ctx, receipt.ID, allocations)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store customer receipt")
+		return
+	}
+
+	receipt.CreatedBy = userID
+	for _, allocation := range receipt.Allocations {
+		receipt.AllocatedAmount += allocation.Amount
+	}
+
+	return
+}
+
+// allocateCustomerReceiptTx applies a receipt to invoices of its customer. An invoice allocated again
+// from the same receipt adds up. Neither the invoices nor the receipt may end up over allocated.
+func (w *writer) allocateCustomerReceiptTx(tx sql.Tx, ctx context.Context, receiptID int64, allocations []domain.ReceiptAllocation) (stored []domain.ReceiptAllocation, err error) {
+	var (
+		receipt     domain.CustomerReceipt
+		fieldErrors errors.ValidationErrors
+	)
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM customer_receipts WHERE id = ? AND company_id = ? FOR UPDATE"), receiptID, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeAllocateCustomerReceiptFailed, "Failed on lock customer receipt")
+		return
+	}
+
+	if err = tx.GetContext(ctx, &receipt, tx.Rebind(fmt.Sprintf(customerReceiptsQuery, "WHERE id = ? AND company_id = ?")), receiptID, companyID(ctx)); err != nil {
+		if err == goSql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Customer receipt not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetCustomerReceiptFailed, "Failed on get customer receipt")
+		return
+	}
+
+	unallocated := roundCents(receipt.Amount - receipt.AllocatedAmount)
+
+	for i, allocation := range allocations {
+		var invoice domain.SalesInvoice
+
+		field := fmt.Sprintf("allocations.%d", i)
+		allocation.ReceiptID, allocation.Amount = receiptID, roundCents(allocation.Amount)
+
+		if allocation.Amount <= 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount must be greater than zero"})
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM sales_invoices WHERE id = ? AND company_id = ? FOR UPDATE"), allocation.InvoiceID, companyID(ctx)); err != nil {
+			err = errors.PropagateWithCode(err, EcodeAllocateCustomerReceiptFailed, "Failed on lock sales invoice")
+			return
+		}
+
+		err = tx.GetContext(ctx, &invoice, tx.Rebind(fmt.Sprintf(salesInvoicesQuery, "WHERE id = ? AND company_id = ?")), allocation.InvoiceID, companyID(ctx))
+		if err == goSql.ErrNoRows || (err == nil && (invoice.CustomerID != receipt.CustomerID || invoice.Voided)) {
+			err = nil
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".invoiceID", Message: "Invoice not found for the customer of the receipt"})
+			continue
+		}
+
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeGetSalesInvoiceFailed, "Failed on get sales invoice")
+			return
+		}
+
+		if allocation.Amount > roundCents(invoice.Total-invoice.PaidAmount-invoice.CreditedAmount) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount exceeds what is left on the invoice"})
+			continue
+		}
+
+		if allocation.Amount > unallocated {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount exceeds what is left on the receipt"})
+			continue
+		}
+
+		query := `
+			INSERT INTO receipt_allocations (receipt_id, invoice_id, amount)
+			VALUES (?, ?, ?)
+			ON CONFLICT (receipt_id, invoice_id) DO UPDATE SET amount = receipt_allocations.amount + EXCLUDED.amount
+			RETURNING id
+		`
+
+		if err = tx.QueryRowContext(ctx, tx.Rebind(query), allocation.ReceiptID, allocation.InvoiceID, allocation.Amount).Scan(&allocation.ID); err != nil {
+			err = errors.PropagateWithCode(err, EcodeAllocateCustomerReceiptFailed, "Store receipt allocation failed")
+			return
+		}
+
+		unallocated = roundCents(unallocated - allocation.Amount)
+		stored = append(stored, allocation)
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeReceiptAllocationInvalid, "Invalid receipt allocations")
+		return
+	}
+
+	return
+}
+
+func (w *writer) AllocateCustomerReceipt(ctx context.Context, receiptID int64, allocations []domain.ReceiptAllocation) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		_, err := w.allocateCustomerReceiptTx(tx, ctx, receiptID, allocations)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on allocate customer receipt")
+		return
+	}
+
+	return
+}
+
+func validateVendor(vendor *domain.Vendor) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	vendor.Code, vendor.Name = strings.TrimSpace(vendor.Code), strings.TrimSpace(vendor.Name)
+
+	if vendor.Code == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "code", Message: "Code is required"})
+	}
+
+	if vendor.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if vendor.PaymentTermsDays < 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "paymentTermsDays", Message: "Payment terms must not be negative"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeVendorInvalid, "Invalid vendor")
+	}
+
+	return nil
+}
+
+func (w *writer) StoreVendor(ctx context.Context, vendor *domain.Vendor) (err error) {
+	if err = validateVendor(vendor); err != nil {
+		return
+	}
+
+	query := `
+		INSERT INTO vendors (code, name, email, phone, address, tax_number, payment_terms_days, inactive, company_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	err = w.db.QueryRowContext(
+		ctx,
+		w.db.Rebind(query),
+		vendor.Code, vendor.Name, vendor.Email, vendor.Phone, vendor.Address, vendor.TaxNumber,
+		vendor.PaymentTermsDays, vendor.Inactive, companyID(ctx),
+	).Scan(&vendor.ID, &vendor.CreatedAt)
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreVendorFailed, "Store vendor failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) UpdateVendorByID(ctx context.Context, id int64, vendor *domain.Vendor) (err error) {
+	if err = validateVendor(vendor); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"code":               vendor.Code,
+		"name":               vendor.Name,
+		"email":              vendor.Email,
+		"phone":              vendor.Phone,
+		"address":            vendor.Address,
+		"tax_number":         vendor.TaxNumber,
+		"payment_terms_days": vendor.PaymentTermsDays,
+		"inactive":           vendor.Inactive,
+	}
+
+	if _, err = w.db.Updates(ctx, "vendors", dest, &VendorStatement{ID: id, CompanyID: companyID(ctx)}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateVendorFailed, "Update vendor by id failed")
+		return
+	}
+
+	vendor.ID = id
+
+	return
+}
+
+// StorePurchaseBill prices the lines, dues the bill after the payment terms of the vendor unless a due date
+// is given, and posts it to the accounts payable control account in a purchase bill journal.
+func (w *writer) StorePurchaseBill(ctx context.Context, userID uuid.UUID, bill *domain.PurchaseBill) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	vendor, err := w.reader.GetVendorByID(ctx, bill.VendorID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get vendor")
+		return
+	}
+
+	if err != nil || vendor.Inactive {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "vendorID", Message: "Vendor not found or inactive"})
+	}
+
+	if bill.BillDate.IsZero() {
+		bill.BillDate = time.Now()
+	}
+
+	bill.BillDate = truncateDate(bill.BillDate)
+	if bill.DueDate.IsZero() {
+		bill.DueDate = bill.BillDate.AddDate(0, 0, int(vendor.PaymentTermsDays))
+	}
+
+	bill.DueDate = truncateDate(bill.DueDate)
+	if bill.DueDate.Before(bill.BillDate) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "dueDate", Message: "Due date must not be before the bill date"})
+	}
+
+	if len(bill.Lines) == 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "lines", Message: "Bill needs at least one line"})
+	}
+
+	bill.VendorReference = strings.TrimSpace(bill.VendorReference)
+	bill.Subtotal, bill.TaxAmount = 0, 0
+
+	for i := range bill.Lines {
+		line := &bill.Lines[i]
+		field := fmt.Sprintf("lines.%d", i)
+
+		if line.Quantity == 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".quantity", Message: "Quantity must not be zero"})
+		}
+
+		if line.TaxRate < 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".taxRate", Message: "Tax rate must not be negative"})
+		}
+
+		accountClass, err := w.reader.GetAccountClassByAccountID(ctx, line.AccountID)
+		if err != nil || (accountClass.TypeID != AssetClassType && accountClass.TypeID < COGSClassType) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".accountID", Message: "Account must be one of the asset, cost of goods sold or expense account"})
+		}
+
+		line.Description = strings.TrimSpace(line.Description)
+		line.Amount = roundCents(line.Quantity * line.UnitPrice)
+		line.TaxAmount = roundCents(line.Amount * line.TaxRate / 100)
+
+		bill.Subtotal += line.Amount
+		bill.TaxAmount += line.TaxAmount
+	}
+
+	bill.Subtotal, bill.TaxAmount = roundCents(bill.Subtotal), roundCents(bill.TaxAmount)
+	bill.Total = roundCents(bill.Subtotal + bill.TaxAmount)
+
+	if len(bill.Lines) > 0 && bill.Total <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "lines", Message: "Bill total must be greater than zero"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodePurchaseBillInvalid, "Invalid purchase bill")
+		return
+	}
+
+	payableAccountID, err := w.getPreferenceAccountID(ctx, AccountsPayable, EcodeAccountsPayableNotSet, "Accounts payable account is not set")
+	if err != nil {
+		return
+	}
+
+	rows := []TransactionRow{{AccountID: payableAccountID, Amount: -bill.Total, Memo: vendor.Name, ExternalReference: bill.VendorReference}}
+	for _, line := range bill.Lines {
+		rows = append(rows, TransactionRow{AccountID: line.AccountID, Amount: line.Amount, Memo: line.Description})
+	}
+
+	if bill.TaxAmount != 0 {
+		taxAccountID, err := w.getPreferenceAccountID(ctx, PurchaseTaxRecoverable, EcodePurchaseTaxRecoverableNotSet, "Purchase tax recoverable account is not set")
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, TransactionRow{AccountID: taxAccountID, Amount: bill.TaxAmount, Memo: vendor.Name})
+	}
+
+	memo := fmt.Sprintf("Purchase bill from %s", vendor.Name)
+	if bill.Memo.Valid && bill.Memo.String != "" {
+		memo = bill.Memo.String
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   bill.BillDate,
+			Memo:   memo,
+			Data:   rows,
+			typeID: PurchaseBillJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post purchase bill journal")
+		}
+
+		bill.JournalID, bill.Number = journal.ID, journal.Number.String
+
+		query := `
+			INSERT INTO purchase_bills (number, vendor_id, vendor_reference, bill_date, due_date, memo, subtotal, tax_amount, total, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			bill.Number, bill.VendorID, bill.VendorReference, bill.BillDate, bill.DueDate, bill.Memo,
+			bill.Subtotal, bill.TaxAmount, bill.Total, bill.JournalID, userID, companyID(ctx),
+		).Scan(&bill.ID, &bill.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStorePurchaseBillFailed, "Store purchase bill failed")
+		}
+
+		for i := range bill.Lines {
+			line := &bill.Lines[i]
+			line.BillID = bill.ID
+
+			query = `
+				INSERT INTO purchase_bill_lines (bill_id, account_id, description, quantity, unit_price, tax_rate, amount, tax_amount)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				RETURNING id
+			`
+
+			err = tx.QueryRowContext(
+				ctx,
+				tx.Rebind(query),
+				line.BillID, line.AccountID, line.Description, line.Quantity, line.UnitPrice, line.TaxRate, line.Amount, line.TaxAmount,
+			).Scan(&line.ID)
+
+			if err != nil {
+				return errors.PropagateWithCode(err, EcodeStorePurchaseBillFailed, "Store purchase bill line failed")
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store purchase bill")
+		return
+	}
+
+	bill.CreatedBy = userID
+
+	return
+}
+
+// VoidPurchaseBillByID voids the journal of a bill, which takes it off the payables.
+// A bill with payments allocated to it cannot be voided.
+func (w *writer) VoidPurchaseBillByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	bill, err := w.reader.GetPurchaseBillByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get purchase bill")
+		return
+	}
+
+	if bill.Voided {
+		return
+	}
+
+	if err = w.VoidTransactionByID(ctx, bill.JournalID, userID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void purchase bill")
+		return
+	}
+
+	return
+}
+
+// StoreVendorPayment pays a payment out of its bank account against the accounts payable control account
+// through the bank payment path and allocates it to the bills given, all in one transaction.
+func (w *writer) StoreVendorPayment(ctx context.Context, userID uuid.UUID, payment *domain.VendorPayment) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	payment.Amount = roundCents(payment.Amount)
+
+	vendor, err := w.reader.GetVendorByID(ctx, payment.VendorID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get vendor")
+		return
+	}
+
+	if err != nil {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "vendorID", Message: "Vendor not found"})
+	}
+
+	if payment.Amount <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "amount", Message: "Amount must be greater than zero"})
+	}
+
+	if payment.PaymentDate.IsZero() {
+		payment.PaymentDate = time.Now()
+	}
+
+	payment.PaymentDate = truncateDate(payment.PaymentDate)
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodePaymentAllocationInvalid, "Invalid vendor payment")
+		return
+	}
+
+	payableAccountID, err := w.getPreferenceAccountID(ctx, AccountsPayable, EcodeAccountsPayableNotSet, "Accounts payable account is not set")
+	if err != nil {
+		return
+	}
+
+	memo := fmt.Sprintf("Payment to %s", vendor.Name)
+	if payment.Memo.Valid && payment.Memo.String != "" {
+		memo = payment.Memo.String
+	}
+
+	allocations := payment.Allocations
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		journal, err := w.storeBankTransactionJournalTx(tx, ctx, userID, BankTransaction{
+			BankAccountID:       payment.BankAccountID,
+			bankTransactionType: Payment,
+			Transaction: Transaction{
+				Date: payment.PaymentDate,
+				Memo: memo,
+				Data: []TransactionRow{{AccountID: payableAccountID, Amount: payment.Amount, Memo: vendor.Name}},
+			},
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post vendor payment")
+		}
+
+		payment.JournalID = journal.ID
+
+		query := `
+			INSERT INTO vendor_payments (vendor_id, bank_account_id, payment_date, amount, memo, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			payment.VendorID, payment.BankAccountID, payment.PaymentDate, payment.Amount, payment.Memo, payment.JournalID, userID, companyID(ctx),
+		).Scan(&payment.ID, &payment.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreVendorPaymentFailed, "Store vendor payment failed")
+		}
+
+		payment.Allocations, err = w.allocateVendorPaymentTx(tx, ctx, payment.ID, allocations)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store vendor payment")
+		return
+	}
+
+	payment.CreatedBy = userID
+	for _, allocation := range payment.Allocations {
+		payment.AllocatedAmount += allocation.Amount
+	}
+
+	return
+}
+
+// allocateVendorPaymentTx applies a payment to bills of its vendor. A bill allocated again
+// from the same payment adds up. Neither the bills nor the payment may end up over allocated.
+func (w *writer) allocateVendorPaymentTx(tx sql.Tx, ctx context.Context, paymentID int64, allocations []domain.PaymentAllocation) (stored []domain.PaymentAllocation, err error) {
+	var (
+		payment     domain.VendorPayment
+		fieldErrors errors.ValidationErrors
+	)
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM vendor_payments WHERE id = ? AND company_id = ? FOR UPDATE"), paymentID, companyID(ctx)); err != nil {
+		err = errors.PropagateWithCode(err, EcodeAllocateVendorPaymentFailed, "Failed on lock vendor payment")
+		return
+	}
+
+	if err = tx.GetContext(ctx, &payment, tx.Rebind(fmt.Sprintf(vendorPaymentsQuery, "WHERE id = ? AND company_id = ?")), paymentID, companyID(ctx)); err != nil {
+		if err == goSql.ErrNoRows {
+			err = errors.PropagateWithCode(err, EcodeNotFound, "Vendor payment not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, EcodeGetVendorPaymentFailed, "Failed on get vendor payment")
+		return
+	}
+
+	unallocated := roundCents(payment.Amount - payment.AllocatedAmount)
+
+	for i, allocation := range allocations {
+		var bill domain.PurchaseBill
+
+		field := fmt.Sprintf("allocations.%d", i)
+		allocation.PaymentID, allocation.Amount = paymentID, roundCents(allocation.Amount)
+
+		if allocation.Amount <= 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount must be greater than zero"})
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind("SELECT id FROM purchase_bills WHERE id = ? AND company_id = ? FOR UPDATE"), allocation.BillID, companyID(ctx)); err != nil {
+			err = errors.PropagateWithCode(err, EcodeAllocateVendorPaymentFailed, "Failed on lock purchase bill")
+			return
+		}
+
+		err = tx.GetContext(ctx, &bill, tx.Rebind(fmt.Sprintf(purchaseBillsQuery, "WHERE id = ? AND company_id = ?")), allocation.BillID, companyID(ctx))
+		if err == goSql.ErrNoRows || (err == nil && (bill.VendorID != payment.VendorID || bill.Voided)) {
+			err = nil
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".billID", Message: "Bill not found for the vendor of the payment"})
+			continue
+		}
+
+		if err != nil {
+			err = errors.PropagateWithCode(err, EcodeGetPurchaseBillFailed, "Failed on get purchase bill")
+			return
+		}
+
+		if allocation.Amount > roundCents(bill.Total-bill.PaidAmount-bill.DebitedAmount) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount exceeds what is left on the bill"})
+			continue
+		}
+
+		if allocation.Amount > unallocated {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field + ".amount", Message: "Amount exceeds what is left on the payment"})
+			continue
+		}
+
+		query := `
+			INSERT INTO payment_allocations (payment_id, bill_id, amount)
+			VALUES (?, ?, ?)
+			ON CONFLICT (payment_id, bill_id) DO UPDATE SET amount = payment_allocations.amount + EXCLUDED.amount
+			RETURNING id
+		`
+
+		if err = tx.QueryRowContext(ctx, tx.Rebind(query), allocation.PaymentID, allocation.BillID, allocation.Amount).Scan(&allocation.ID); err != nil {
+			err = errors.PropagateWithCode(err, EcodeAllocateVendorPaymentFailed, "Store payment allocation failed")
+			return
+		}
+
+		unallocated = roundCents(unallocated - allocation.Amount)
+		stored = append(stored, allocation)
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodePaymentAllocationInvalid, "Invalid payment allocations")
+		return
+	}
+
+	return
+}
+
+func (w *writer) AllocateVendorPayment(ctx context.Context, paymentID int64, allocations []domain.PaymentAllocation) (err error) {
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		_, err := w.allocateVendorPaymentTx(tx, ctx, paymentID, allocations)
+		return err
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on allocate vendor payment")
+		return
+	}
+
+	return
+}
+
+// StoreCreditNote credits part or all of what is left open on a sales invoice. The income lines and the sales tax
+// of the invoice are reversed in proportion to the note total against the accounts receivable control account,
+// in a credit note journal whose number becomes the note number.
+func (w *writer) StoreCreditNote(ctx context.Context, userID uuid.UUID, note *domain.CreditNote) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	note.Total = roundCents(note.Total)
+
+	invoice, err := w.reader.GetSalesInvoiceByID(ctx, note.InvoiceID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get sales invoice")
+		return
+	}
+
+	if err != nil || invoice.Voided {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "invoiceID", Message: "Invoice not found or voided"})
+	}
+
+	if note.Total <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "total", Message: "Total must be greater than zero"})
+	}
+
+	if note.NoteDate.IsZero() {
+		note.NoteDate = time.Now()
+	}
+
+	note.NoteDate = truncateDate(note.NoteDate)
+	if err == nil && note.NoteDate.Before(invoice.InvoiceDate) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "noteDate", Message: "Note date must not be before the invoice date"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeCreditNoteInvalid, "Invalid credit note")
+		return
+	}
+
+	lines, err := w.reader.GetAllSalesInvoiceLines(ctx, SalesInvoiceLineStatement{InvoiceID: invoice.ID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get sales invoice lines")
+		return
+	}
+
+	receivableAccountID, err := w.getPreferenceAccountID(ctx, AccountsReceivable, EcodeAccountsReceivableNotSet, "Accounts receivable account is not set")
+	if err != nil {
+		return
+	}
+
+	var taxAccountID int64
+	if invoice.TaxAmount != 0 {
+		taxAccountID, err = w.getPreferenceAccountID(ctx, SalesTaxPayable, EcodeSalesTaxPayableNotSet, "Sales tax payable account is not set")
+		if err != nil {
+			return
+		}
+	}
+
+	memo := fmt.Sprintf("Credit note for %s", invoice.Number)
+	if note.Memo.Valid && note.Memo.String != "" {
+		memo = note.Memo.String
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, tx.Rebind("SELECT id FROM sales_invoices WHERE id = ? AND company_id = ? FOR UPDATE"), invoice.ID, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreCreditNoteFailed, "Failed on lock sales invoice")
+		}
+
+		if err := tx.GetContext(ctx, &invoice, tx.Rebind(fmt.Sprintf(salesInvoicesQuery, "WHERE id = ? AND company_id = ?")), invoice.ID, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetSalesInvoiceFailed, "Failed on get sales invoice")
+		}
+
+		if note.Total > roundCents(invoice.Total-invoice.PaidAmount-invoice.CreditedAmount) {
+			return errors.PropagateWithCode(
+				errors.ValidationErrors{{Field: "total", Message: "Total exceeds what is left on the invoice"}},
+				EcodeCreditNoteInvalid,
+				"Invalid credit note",
+			)
+		}
+
+		note.TaxAmount = roundCents(invoice.TaxAmount * note.Total / invoice.Total)
+		note.Subtotal = roundCents(note.Total - note.TaxAmount)
+
+		weights := make([]float64, len(lines))
+		for i, line := range lines {
+			weights[i] = line.Amount
+		}
+
+		rows := []TransactionRow{{AccountID: receivableAccountID, Amount: -note.Total, Memo: invoice.Number}}
+		for i, amount := range prorate(note.Subtotal, weights) {
+			rows = append(rows, TransactionRow{AccountID: lines[i].AccountID, Amount: amount, Memo: lines[i].Description})
+		}
+
+		if note.TaxAmount != 0 {
+			rows = append(rows, TransactionRow{AccountID: taxAccountID, Amount: note.TaxAmount, Memo: invoice.Number})
+		}
+
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   note.NoteDate,
+			Memo:   memo,
+			Data:   rows,
+			typeID: CreditNoteJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post credit note journal")
+		}
+
+		note.JournalID, note.Number = journal.ID, journal.Number.String
+
+		query := `
+			INSERT INTO credit_notes (number, invoice_id, note_date, memo, subtotal, tax_amount, total, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			note.Number, note.InvoiceID, note.NoteDate, note.Memo, note.Subtotal, note.TaxAmount, note.Total, note.JournalID, userID, companyID(ctx),
+		).Scan(&note.ID, &note.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreCreditNoteFailed, "Store credit note failed")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store credit note")
+		return
+	}
+
+	note.CreatedBy = userID
+
+	return
+}
+
+// VoidCreditNoteByID voids the journal of a credit note, which puts its total back on the invoice.
+func (w *writer) VoidCreditNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	note, err := w.reader.GetCreditNoteByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get credit note")
+		return
+	}
+
+	if note.Voided {
+		return
+	}
+
+	if err = w.VoidTransactionByID(ctx, note.JournalID, userID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void credit note")
+		return
+	}
+
+	return
+}
+
+// StoreDebitNote debits part or all of what is left open on a purchase bill. The lines and the purchase tax
+// of the bill are reversed in proportion to the note total against the accounts payable control account,
+// in a debit note journal whose number becomes the note number.
+func (w *writer) StoreDebitNote(ctx context.Context, userID uuid.UUID, note *domain.DebitNote) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	note.Total = roundCents(note.Total)
+
+	bill, err := w.reader.GetPurchaseBillByID(ctx, note.BillID)
+	if err != nil && errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get purchase bill")
+		return
+	}
+
+	if err != nil || bill.Voided {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "billID", Message: "Bill not found or voided"})
+	}
+
+	if note.Total <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "total", Message: "Total must be greater than zero"})
+	}
+
+	if note.NoteDate.IsZero() {
+		note.NoteDate = time.Now()
+	}
+
+	note.NoteDate = truncateDate(note.NoteDate)
+	if err == nil && note.NoteDate.Before(bill.BillDate) {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "noteDate", Message: "Note date must not be before the bill date"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeDebitNoteInvalid, "Invalid debit note")
+		return
+	}
+
+	lines, err := w.reader.GetAllPurchaseBillLines(ctx, PurchaseBillLineStatement{BillID: bill.ID})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get purchase bill lines")
+		return
+	}
+
+	payableAccountID, err := w.getPreferenceAccountID(ctx, AccountsPayable, EcodeAccountsPayableNotSet, "Accounts payable account is not set")
+	if err != nil {
+		return
+	}
+
+	var taxAccountID int64
+	if bill.TaxAmount != 0 {
+		taxAccountID, err = w.getPreferenceAccountID(ctx, PurchaseTaxRecoverable, EcodePurchaseTaxRecoverableNotSet, "Purchase tax recoverable account is not set")
+		if err != nil {
+			return
+		}
+	}
+
+	memo := fmt.Sprintf("Debit note for %s", bill.Number)
+	if note.Memo.Valid && note.Memo.String != "" {
+		memo = note.Memo.String
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, tx.Rebind("SELECT id FROM purchase_bills WHERE id = ? AND company_id = ? FOR UPDATE"), bill.ID, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreDebitNoteFailed, "Failed on lock purchase bill")
+		}
+
+		if err := tx.GetContext(ctx, &bill, tx.Rebind(fmt.Sprintf(purchaseBillsQuery, "WHERE id = ? AND company_id = ?")), bill.ID, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeGetPurchaseBillFailed, "Failed on get purchase bill")
+		}
+
+		if note.Total > roundCents(bill.Total-bill.PaidAmount-bill.DebitedAmount) {
+			return errors.PropagateWithCode(
+				errors.ValidationErrors{{Field: "total", Message: "Total exceeds what is left on the bill"}},
+				EcodeDebitNoteInvalid,
+				"Invalid debit note",
+			)
+		}
+
+		note.TaxAmount = roundCents(bill.TaxAmount * note.Total / bill.Total)
+		note.Subtotal = roundCents(note.Total - note.TaxAmount)
+
+		weights := make([]float64, len(lines))
+		for i, line := range lines {
+			weights[i] = line.Amount
+		}
+
+		rows := []TransactionRow{{AccountID: payableAccountID, Amount: note.Total, Memo: bill.Number}}
+		for i, amount := range prorate(note.Subtotal, weights) {
+			rows = append(rows, TransactionRow{AccountID: lines[i].AccountID, Amount: -amount, Memo: lines[i].Description})
+		}
+
+		if note.TaxAmount != 0 {
+			rows = append(rows, TransactionRow{AccountID: taxAccountID, Amount: -note.TaxAmount, Memo: bill.Number})
+		}
+
+		journal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   note.NoteDate,
+			Memo:   memo,
+			Data:   rows,
+			typeID: DebitNoteJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post debit note journal")
+		}
+
+		note.JournalID, note.Number = journal.ID, journal.Number.String
+
+		query := `
+			INSERT INTO debit_notes (number, bill_id, note_date, memo, subtotal, tax_amount, total, journal_id, created_by, company_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			note.Number, note.BillID, note.NoteDate, note.Memo, note.Subtotal, note.TaxAmount, note.Total, note.JournalID, userID, companyID(ctx),
+		).Scan(&note.ID, &note.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreDebitNoteFailed, "Store debit note failed")
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateGeneralLedgerPreferenceFailed, "Failed on commit")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store debit note")
 		return
 	}
 
+	note.CreatedBy = userID
+
 	return
 }
 
-func (w *writer) StoreTransactionTx(tx sql.Tx, ctx context.Context, userID uuid.UUID, transaction Transaction) (journal *domain.Journal, err error) {
-	var (
-		gls              []domain.GeneralLedger
-		bankTransactions []domain.BankTransaction
-		memo             goSql.NullString
-		journalAmount    float64
-		balanceAmount    float64
-	)
+// VoidDebitNoteByID voids the journal of a debit note, which puts its total back on the bill.
+func (w *writer) VoidDebitNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	note, err := w.reader.GetDebitNoteByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get debit note")
+		return
+	}
 
-	if userID == uuid.Nil {
-		err = errors.PropagateWithCode(goErr.New("creator unknown"), EcodeStoreTransactionCreatedByRequired, "creator unknown")
+	if note.Voided {
 		return
 	}
 
-	if transaction.Memo != "" {
-		if err = memo.Scan(transaction.Memo); err != nil {
-			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on scan memo value")
-			return
+	if err = w.VoidTransactionByID(ctx, note.JournalID, userID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void debit note")
+		return
+	}
+
+	return
+}
+
+func validateCompany(company *domain.Company) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	company.Code, company.Name = strings.TrimSpace(company.Code), strings.TrimSpace(company.Name)
+
+	if company.Code == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "code", Message: "Code is required"})
+	}
+
+	if company.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeCompanyInvalid, "Invalid company")
+	}
+
+	return nil
+}
+
+// StoreCompany creates a company with the general ledger preferences of the default company left unset,
+// and makes its creator a member of it.
+func (w *writer) StoreCompany(ctx context.Context, userID uuid.UUID, company *domain.Company) (err error) {
+	if err = validateCompany(company); err != nil {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := "INSERT INTO companies (code, name) VALUES (?, ?) RETURNING id, created_at"
+		if err := tx.QueryRowContext(ctx, tx.Rebind(query), company.Code, company.Name).Scan(&company.ID, &company.CreatedAt); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO general_ledger_preferences (company_id, id)
+			SELECT ?, id FROM general_ledger_preferences WHERE company_id = ?
+		`
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), company.ID, DefaultCompanyID); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO journal_number_formats (company_id, type_id, format)
+			SELECT ?, type_id, format FROM journal_number_formats WHERE company_id = ?
+		`
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), company.ID, DefaultCompanyID); err != nil {
+			return err
 		}
+
+		return w.addCompanyUserTx(tx, ctx, company.ID, userID)
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreCompanyFailed, "Store company failed")
+		return
 	}
 
-	if transaction.journalID == uuid.Nil {
-		transaction.journalID = uuid.New()
+	return
+}
+
+func (w *writer) UpdateCompanyByID(ctx context.Context, id int64, company *domain.Company) (err error) {
+	if err = validateCompany(company); err != nil {
+		return
 	}
 
-	now := time.Now()
+	dest := map[string]interface{}{
+		"code": company.Code,
+		"name": company.Name,
+	}
 
-	if transaction.Date.IsZero() {
-		transaction.Date = now
+	if _, err = w.db.Updates(ctx, "companies", dest, &CompanyStatement{ID: id}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreCompanyFailed, "Update company by id failed")
+		return
 	}
 
-	// startDate <= transDate <= endDate
-	_, err = w.reader.GetFiscalYear(ctx, FiscalYearStatement{
-		StartDateLTE: transaction.Date,
-		EndDateGTE:   transaction.Date,
-		ClosedNotEQ:  true,
+	company.ID = id
+
+	return
+}
+
+// addCompanyUserTx makes a user a member of a company. A user without any membership works in the default
+// company, so that membership is written down first to keep it once the user joins another company.
+func (w *writer) addCompanyUserTx(tx sql.Tx, ctx context.Context, companyID int64, userID uuid.UUID) (err error) {
+	query := `
+		INSERT INTO company_users (company_id, user_id)
+		SELECT ?, ? WHERE NOT EXISTS (SELECT 1 FROM company_users WHERE user_id = ?)
+	`
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), DefaultCompanyID, userID, userID); err != nil {
+		return
+	}
+
+	query = "INSERT INTO company_users (company_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING"
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), companyID, userID)
+
+	return
+}
+
+func (w *writer) AddCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error) {
+	if _, err = w.reader.GetCompanyByID(ctx, companyID); err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get company")
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		return w.addCompanyUserTx(tx, ctx, companyID, userID)
 	})
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeGetFiscalYearFailed, "Failed on get fiscal year")
+		err = errors.PropagateWithCode(err, EcodeStoreCompanyUserFailed, "Add company user failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) RemoveCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error) {
+	query := "DELETE FROM company_users WHERE company_id = ? AND user_id = ?"
+	if _, err = w.db.ExecContext(ctx, w.db.Rebind(query), companyID, userID); err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreCompanyUserFailed, "Remove company user failed")
+		return
+	}
+
+	return
+}
+
+func (w *writer) validateGroupAccount(ctx context.Context, groupAccount *domain.GroupAccount) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	groupAccount.Code, groupAccount.Name = strings.TrimSpace(groupAccount.Code), strings.TrimSpace(groupAccount.Name)
+
+	if groupAccount.Code == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "code", Message: "Code is required"})
+	}
+
+	if groupAccount.Name == "" {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "name", Message: "Name is required"})
+	}
+
+	// the group chart is laid on the account classes of the default company
+	if _, err = w.reader.GetAccountClassByID(appcontext.SetCompanyID(ctx, DefaultCompanyID), groupAccount.ClassID); err != nil {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "classID", Message: "Account class not found"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errors.PropagateWithCode(fieldErrors, EcodeGroupAccountInvalid, "Invalid group account")
+	}
+
+	return nil
+}
+
+func (w *writer) StoreGroupAccount(ctx context.Context, groupAccount *domain.GroupAccount) (err error) {
+	if err = w.validateGroupAccount(ctx, groupAccount); err != nil {
+		return
+	}
+
+	query := "INSERT INTO group_accounts (code, name, class_id) VALUES (?, ?, ?) RETURNING id"
+	err = w.db.QueryRowContext(ctx, w.db.Rebind(query), groupAccount.Code, groupAccount.Name, groupAccount.ClassID).Scan(&groupAccount.ID)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeStoreGroupAccountFailed, "Store group account failed")
+		return
+	}
 
+	return
+}
+
+func (w *writer) UpdateGroupAccountByID(ctx context.Context, id int64, groupAccount *domain.GroupAccount) (err error) {
+	if err = w.validateGroupAccount(ctx, groupAccount); err != nil {
+		return
+	}
+
+	dest := map[string]interface{}{
+		"code":     groupAccount.Code,
+		"name":     groupAccount.Name,
+		"class_id": groupAccount.ClassID,
+	}
+
+	if _, err = w.db.Updates(ctx, "group_accounts", dest, &GroupAccountStatement{ID: id}); err != nil {
+		err = errors.PropagateWithCode(err, EcodeUpdateGroupAccountFailed, "Update group account by id failed")
+		return
+	}
+
+	groupAccount.ID = id
+
+	return
+}
+
+// MapAccountToGroupAccount maps an account of the company onto the group chart, a zero group account unmaps it.
+func (w *writer) MapAccountToGroupAccount(ctx context.Context, accountID int64, groupAccountID int64) (err error) {
+	var groupAccount goSql.NullInt64
+
+	if _, err = w.reader.GetAccountByID(ctx, accountID); err != nil {
 		if errors.GetCode(err) == EcodeNotFound {
-			err = errors.PropagateWithCode(err, EcodeStoreTransactionProhibited, "No active fiscal year for transaction date")
+			err = errors.PropagateWithCode(err, EcodeAccountNotInCompany, "Account does not belong to the company")
+			return
 		}
 
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get account")
 		return
 	}
 
-	for _, row := range transaction.Data {
-		var isBankTransaction bool
-
-		if row.Amount == 0 {
-			continue
+	if groupAccountID != 0 {
+		if _, err = w.reader.GetGroupAccountByID(ctx, groupAccountID); err != nil {
+			err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get group account")
+			return
 		}
 
-		gls = append(gls, domain.GeneralLedger{
-			ID:        uuid.New(),
-			JournalID: transaction.journalID,
-			AccountID: row.AccountID,
-			CreatedBy: userID,
-			Amount:    row.Amount,
-		})
+		groupAccount = goSql.NullInt64{Int64: groupAccountID, Valid: true}
+	}
 
-		balanceAmount += row.Amount
-		if row.Amount > 0 {
-			journalAmount += row.Amount
+	dest := map[string]interface{}{"group_account_id": groupAccount}
+	if err = w.updateAccountByID(ctx, accountID, dest); err != nil {
+		err = errors.PropagateWithCode(err, EcodeMapAccountToGroupAccountFailed, "Map account to group account failed")
+		return
+	}
+
+	return
+}
+
+// sumIntercompanyTransactionLines checks the lines of one side of an intercompany transaction and adds them up.
+func sumIntercompanyTransactionLines(field string, lines []domain.IntercompanyTransactionLine) (total float64, fieldErrors errors.ValidationErrors) {
+	if len(lines) == 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "At least one line is required"})
+		return
+	}
+
+	for i, line := range lines {
+		if line.AccountID == 0 {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: fmt.Sprintf("%s.%d.accountID", field, i), Message: "Account is required"})
 		}
 
-		isBankTransaction, err = w.reader.IsBankAccount(ctx, row.AccountID)
-		if err != nil {
-			err = errors.PropagateWithCode(err, EcodeStoreTransactionFailed, "Failed on check bank transaction")
+		total += line.Amount
+	}
+
+	return roundCents(total), fieldErrors
+}
+
+// StoreIntercompanyTransaction posts a transaction the company of the request pays on behalf of another company.
+// The paying company debits the intercompany receivable from the other company against its lines, the other company
+// credits the intercompany payable to the paying company against the mirror lines. Both journals are posted in one
+// database transaction and carry the reference of the intercompany transaction.
+func (w *writer) StoreIntercompanyTransaction(ctx context.Context, userID uuid.UUID, transaction *domain.IntercompanyTransaction) (err error) {
+	var fieldErrors errors.ValidationErrors
+
+	transaction.FromCompanyID = companyID(ctx)
+	transaction.Amount = roundCents(transaction.Amount)
+
+	if transaction.ToCompanyID == transaction.FromCompanyID {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "toCompanyID", Message: "Company must be another company"})
+	} else if _, err = w.reader.GetCompanyByID(ctx, transaction.ToCompanyID); err != nil {
+		if errors.GetCode(err) != EcodeNotFound {
+			err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get company")
 			return
 		}
 
-		if isBankTransaction {
-			bankTransactions = append(bankTransactions, domain.BankTransaction{
-				JournalID:     transaction.journalID,
-				BankAccountID: row.AccountID,
-				UserID:        userID,
-				Amount:        row.Amount,
-				TransDate:     transaction.Date,
-			})
-		}
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "toCompanyID", Message: "Company not found"})
 	}
 
-	// check mapAccountGeneralLedger is empty.
-	// empty is occur when all the transaction amount is zero
-	if len(gls) == 0 {
-		return nil, nil
+	if transaction.Amount <= 0 {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "amount", Message: "Amount must be greater than zero"})
 	}
 
-	if balanceAmount != 0 {
-		err = errors.PropagateWithCode(fmt.Errorf("transaction not balance"), EcodeTransactionNotBalance, "Transaction not balance")
+	fromTotal, lineErrors := sumIntercompanyTransactionLines("fromLines", transaction.FromLines)
+	fieldErrors = append(fieldErrors, lineErrors...)
+	if len(lineErrors) == 0 && fromTotal != -transaction.Amount {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "fromLines", Message: "Lines must credit the amount in total"})
+	}
+
+	toTotal, lineErrors := sumIntercompanyTransactionLines("toLines", transaction.ToLines)
+	fieldErrors = append(fieldErrors, lineErrors...)
+	if len(lineErrors) == 0 && toTotal != transaction.Amount {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: "toLines", Message: "Lines must debit the amount in total"})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, EcodeIntercompanyTransactionInvalid, "Invalid intercompany transaction")
 		return
 	}
 
-	journal = &domain.Journal{
-		ID:        transaction.journalID,
-		Amount:    journalAmount,
-		TransDate: transaction.Date,
-		Memo:      memo,
-		CreatedAt: now,
+	if transaction.TransDate.IsZero() {
+		transaction.TransDate = time.Now()
 	}
 
-	if err = w.StoreJournalTx(tx, ctx, journal); err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreTransactionAtJournalFailed, "Store transaction failed")
+	transaction.TransDate = truncateDate(transaction.TransDate)
+	toCtx := appcontext.SetCompanyID(ctx, transaction.ToCompanyID)
+
+	receivableAccountID, err := w.getPreferenceAccountID(ctx, IntercompanyReceivable, EcodeIntercompanyReceivableNotSet, "Intercompany receivable account is not set")
+	if err != nil {
 		return
 	}
 
-	if err = w.StoreGeneralLedgersTx(tx, ctx, gls); err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreTransactionAtGeneralLedgerFailed, "Store transaction failed")
+	payableAccountID, err := w.getPreferenceAccountID(toCtx, IntercompanyPayable, EcodeIntercompanyPayableNotSet, "Intercompany payable account of the other company is not set")
+	if err != nil {
 		return
 	}
 
-	for _, bankTransaction := range bankTransactions {
-		err = w.storeBankTransactionTx(tx, ctx, userID, bankTransaction)
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := "SELECT nextval('intercompany_transactions_id_seq')"
+		if err := tx.GetContext(ctx, &transaction.ID, query); err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreIntercompanyTransactionFailed, "Failed on allocate intercompany transaction id")
+		}
+
+		transaction.Reference = fmt.Sprintf("IC-%08d", transaction.ID)
+
+		memo := fmt.Sprintf("Intercompany transaction %s", transaction.Reference)
+		if transaction.Memo.Valid && transaction.Memo.String != "" {
+			memo = transaction.Memo.String
+		}
+
+		fromRows := []TransactionRow{{
+			AccountID:             receivableAccountID,
+			Amount:                transaction.Amount,
+			Memo:                  transaction.Reference,
+			ExternalReference:     transaction.Reference,
+			CounterpartyCompanyID: transaction.ToCompanyID,
+		}}
+
+		for _, line := range transaction.FromLines {
+			fromRows = append(fromRows, TransactionRow{AccountID: line.AccountID, Amount: line.Amount, Memo: line.Memo, ExternalReference: transaction.Reference})
+		}
+
+		fromJournal, err := w.StoreTransactionTx(tx, ctx, userID, Transaction{
+			Date:   transaction.TransDate,
+			Memo:   memo,
+			Data:   fromRows,
+			typeID: IntercompanyJournalType,
+		})
+
 		if err != nil {
-			err = errors.PropagateWithCode(err, EcodeStoreBankTransactionFailed, "Store bank transaction failed")
-			return
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post intercompany journal of the paying company")
+		}
+
+		toRows := make([]TransactionRow, 0, len(transaction.ToLines)+1)
+		for _, line := range transaction.ToLines {
+			toRows = append(toRows, TransactionRow{AccountID: line.AccountID, Amount: line.Amount, Memo: line.Memo, ExternalReference: transaction.Reference})
 		}
+
+		toRows = append(toRows, TransactionRow{
+			AccountID:             payableAccountID,
+			Amount:                -transaction.Amount,
+			Memo:                  transaction.Reference,
+			ExternalReference:     transaction.Reference,
+			CounterpartyCompanyID: transaction.FromCompanyID,
+		})
+
+		toJournal, err := w.StoreTransactionTx(tx, toCtx, userID, Transaction{
+			Date:   transaction.TransDate,
+			Memo:   memo,
+			Data:   toRows,
+			typeID: IntercompanyJournalType,
+		})
+
+		if err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on post intercompany journal of the other company")
+		}
+
+		transaction.FromJournalID, transaction.ToJournalID = fromJournal.ID, toJournal.ID
+
+		query = `
+			INSERT INTO intercompany_transactions (
+				id, reference, from_company_id, to_company_id, trans_date, memo, amount, from_journal_id, to_journal_id, created_by
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING created_at
+		`
+
+		err = tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			transaction.ID, transaction.Reference, transaction.FromCompanyID, transaction.ToCompanyID, transaction.TransDate,
+			transaction.Memo, transaction.Amount, transaction.FromJournalID, transaction.ToJournalID, userID,
+		).Scan(&transaction.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeStoreIntercompanyTransactionFailed, "Store intercompany transaction failed")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store intercompany transaction")
+		return
 	}
 
+	transaction.CreatedBy = userID
+
 	return
 }
 
-func (w *writer) StoreTransaction(ctx context.Context, userID uuid.UUID, transaction Transaction) (journal *domain.Journal, err error) {
-	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
-		journal, err = w.StoreTransactionTx(tx, ctx, userID, transaction)
-		if err != nil {
-			return err
+// VoidIntercompanyTransactionByID voids the journals of both companies of an intercompany transaction together.
+func (w *writer) VoidIntercompanyTransactionByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	transaction, err := w.reader.GetIntercompanyTransactionByID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get intercompany transaction")
+		return
+	}
+
+	if transaction.Voided {
+		return
+	}
+
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		fromCtx := appcontext.SetCompanyID(ctx, transaction.FromCompanyID)
+		if err := w.VoidTransactionByIDTx(tx, fromCtx, transaction.FromJournalID, userID); err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void intercompany journal of the paying company")
+		}
+
+		toCtx := appcontext.SetCompanyID(ctx, transaction.ToCompanyID)
+		if err := w.VoidTransactionByIDTx(tx, toCtx, transaction.ToJournalID, userID); err != nil {
+			return errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void intercompany journal of the other company")
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on store transaction")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on void intercompany transaction")
 		return
 	}
 
 	return
 }
 
-func (w *writer) StoreJournalTx(tx sql.Tx, ctx context.Context, journal *domain.Journal) (err error) {
-	now := time.Now()
+func NewWriter(opt *Options, reader Reader) Writer {
+	return &writer{opt.Logger, opt.MasterDB, reader, opt.Permission}
+}
 
-	if journal.TransDate.IsZero() {
-		journal.TransDate = now
+// validateOpenPostingDate checks that date falls into an unclosed fiscal year and, if the fiscal year has periods,
+// into an open fiscal period.
+func (w *writer) validateOpenPostingDate(ctx context.Context, date time.Time) (err error) {
+	if err = w.validateFiscalYearDate(ctx, date); err != nil {
+		return
 	}
 
-	query := "INSERT INTO journals (id, amount, trans_date, memo) VALUES (?, ?, ?, ?) RETURNING id"
-	err = tx.QueryRowContext(
-		ctx,
-		w.db.Rebind(query),
-		journal.ID, journal.Amount, journal.TransDate, journal.Memo,
-	).Scan(&journal.ID)
+	period, err := w.reader.GetFiscalPeriodByDate(ctx, date)
+	if errors.GetCode(err) == EcodeNotFound {
+		return nil
+	}
 
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreJournalFailed, "Store journal failed")
+		err = errors.PropagateWithCode(err, EcodeGetFiscalPeriodFailed, "Failed on get fiscal period")
+		return
+	}
+
+	if period.StatusID != domain.OpenPeriodStatus {
+		err = errors.PropagateWithCode(fmt.Errorf("fiscal period is locked"), EcodeFiscalPeriodLocked, "Fiscal period is not open")
 		return
 	}
 
 	return
 }
 
-func (w *writer) StoreGeneralLedgersTx(tx sql.Tx, ctx context.Context, gls []domain.GeneralLedger) (err error) {
-	var params []interface{}
-
-	query := `INSERT INTO general_ledgers (id, journal_id, account_id, amount, created_by) VALUES `
+// AmendJournalByID replaces the lines of a general journal dated in an open fiscal period, and keeps the journal as
+// it was as a version of it. Journals of a bank account can not be amended. The creator of the journal amends it,
+// any other user needs the permission to write journals.
+func (w *writer) AmendJournalByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction Transaction, reason string) (journal *domain.Journal, err error) {
+	var (
+		gls           []domain.GeneralLedger
+		memo          goSql.NullString
+		reasonValue   goSql.NullString
+		journalAmount float64
+		balanceAmount float64
+	)
 
-	for _, gl := range gls {
-		query += "(?,?,?,?,?),"
-		params = append(params, gl.ID, gl.JournalID, gl.AccountID, gl.Amount, gl.CreatedBy)
+	if userID == uuid.Nil {
+		err = errors.PropagateWithCode(goErr.New("creator unknown"), EcodeStoreTransactionCreatedByRequired, "creator unknown")
+		return
 	}
 
-	query = query[:len(query)-1] // remove trailing ","
-	_, err = tx.ExecContext(ctx, w.db.Rebind(query), params...)
-	return
-}
+	prevJournal, err := w.reader.GetJournal(ctx, JournalStatement{ID: id, DeletedAtIsNULL: true})
+	if err != nil {
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get journal")
+		return
+	}
 
-func (w *writer) StoreAccount(ctx context.Context, account *domain.Account) (err error) {
-	err = w.db.QueryRowContext(ctx, `
-		INSERT INTO accounts (name, group_id, inactive)
-		VALUES ($1, $2, $3)
-		RETURNING id
-	`, account.Name, account.GroupID, account.Inactive).Scan(&account.ID)
+	if prevJournal.TypeID != GeneralJournalType || prevJournal.Closing || prevJournal.Opening {
+		err = errors.PropagateWithCode(goErr.New("not a general journal"), EcodeAmendJournalProhibited, "Only general journals can be amended")
+		return
+	}
 
+	bankTransactions, err := w.reader.GetAllBankTransactionsByJournalID(ctx, id)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreAccountFailed, "Store account failed")
+		err = errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on get bank transactions")
 		return
 	}
 
-	return
-}
-
-func (w *writer) UpdateAccountByID(ctx context.Context, id int64, account *domain.Account) (err error) {
-	dest := map[string]interface{}{
-		"name":     account.Name,
-		"group_id": account.GroupID,
-		"inactive": account.Inactive,
+	if len(bankTransactions) > 0 {
+		err = errors.PropagateWithCode(goErr.New("bank journal"), EcodeAmendBankJournalProhibited, "Amend bank transaction is prohibited")
+		return
 	}
 
-	if _, err = w.db.Updates(ctx, "accounts", dest, &AccountStatement{ID: id}); err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountFailed, "Update account by id failed")
+	prevGLs, err := w.reader.GetAllGeneralLedgersByJournalID(ctx, id)
+	if err != nil {
+		err = errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on get general ledgers")
 		return
 	}
 
-	return
-}
+	// the lines keep their creator, so the journal stays with whoever posted it
+	creatorID := userID
+	if len(prevGLs) > 0 {
+		creatorID = prevGLs[0].CreatedBy
+	}
 
-func (w *writer) DeleteAccountByID(ctx context.Context, id int64) (err error) {
-	if _, err = w.db.Delete(ctx, "accounts", &AccountStatement{ID: id}); err != nil {
-		err = errors.PropagateWithCode(err, EcodeDeleteAccountFailed, "Delete account by id failed")
+	if creatorID != userID {
+		if err = w.mustHavePermission(ctx, userID, auth.Journal, auth.WRITE); err != nil {
+			err = errors.PropagateWithCode(err, EcodePermissionDenied, "Only the creator of the journal can amend it")
+			return
+		}
+	}
+
+	// a journal posted from an approved draft is posted as the maker of the draft, who must not be able to
+	// rewrite the approved lines without another review
+	_, err = w.reader.GetJournalDraft(ctx, JournalDraftStatement{JournalID: id})
+	switch {
+	case err == nil:
+		if err = w.mustHavePermission(ctx, userID, auth.Journal, auth.WRITE); err != nil {
+			err = errors.PropagateWithCode(err, EcodePermissionDenied, "Only a user with the permission to write journals can amend an approved journal")
+			return
+		}
+	case errors.GetCode(err) != EcodeNotFound:
+		err = errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on get journal draft")
 		return
 	}
 
-	return
-}
+	err = nil
 
-func (w *writer) StoreAccountGroup(ctx context.Context, accountGroup *domain.AccountGroup) (err error) {
-	if accountGroup.ParentID.Valid && accountGroup.ParentID.Int64 != 0 {
-		var parentAccountGroup domain.AccountGroup
+	if transaction.Date.IsZero() {
+		transaction.Date = prevJournal.TransDate
+	}
 
-		parentAccountGroup, err = w.reader.GetAccountGroupByID(ctx, accountGroup.ParentID.Int64)
-		if err != nil {
-			err = errors.PropagateWithCode(err, errors.GetCode(err), "Error on get parent account group")
+	// both where the journal is and where it goes must be open
+	for _, date := range []time.Time{prevJournal.TransDate, transaction.Date} {
+		if err = w.validateOpenPostingDate(ctx, date); err != nil {
 			return
 		}
-
-		accountGroup.ClassID = parentAccountGroup.ClassID
 	}
 
-	err = w.db.QueryRowContext(ctx, `
-		INSERT INTO account_groups (parent_id, class_id, name, inactive)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id
-	`, accountGroup.ParentID, accountGroup.ClassID, accountGroup.Name, accountGroup.Inactive,
-	).Scan(&accountGroup.ID)
+	if transaction.Memo != "" {
+		memo = goSql.NullString{String: transaction.Memo, Valid: true}
+	}
 
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreAccountGroupFailed, "Insert account group failed")
-		return
+	if reason != "" {
+		reasonValue = goSql.NullString{String: reason, Valid: true}
 	}
 
-	return
-}
+	for _, row := range transaction.Data {
+		var isBankAccount bool
 
-func (w *writer) UpdateAccountGroupByID(ctx context.Context, id int64, accountGroup *domain.AccountGroup) (err error) {
-	if accountGroup.ParentID.Valid && accountGroup.ParentID.Int64 != 0 {
-		if accountGroup.ParentID.Int64 == id {
-			err = errors.PropagateWithCode(goErr.New("invalid parent id"), EcodeParentIDNotValid, "cannot set parent with the same account group")
+		if row.Amount == 0 {
+			continue
+		}
+
+		if _, err = w.reader.GetAccountByID(ctx, row.AccountID); err != nil {
+			if errors.GetCode(err) == EcodeNotFound {
+				err = errors.PropagateWithCode(err, EcodeAccountNotInCompany, fmt.Sprintf("Account %d does not belong to the company", row.AccountID))
+				return
+			}
+
+			err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get account")
 			return
 		}
 
-		var parentAccountGroup domain.AccountGroup
-		parentAccountGroup, err = w.reader.GetAccountGroupByID(ctx, accountGroup.ParentID.Int64)
+		if row.CounterpartyCompanyID == companyID(ctx) {
+			err = errors.PropagateWithCode(fmt.Errorf("counterparty is the company itself"), EcodeAmendJournalFailed, "Counterparty company must be another company")
+			return
+		}
+
+		isBankAccount, err = w.reader.IsBankAccount(ctx, row.AccountID)
 		if err != nil {
-			err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on get parent account group")
+			err = errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on check bank account")
 			return
 		}
 
-		accountGroup.ClassID = parentAccountGroup.ClassID
+		if isBankAccount {
+			err = errors.PropagateWithCode(goErr.New("bank account"), EcodeAmendBankJournalProhibited, "Bank account is prohibited")
+			return
+		}
+
+		gl := domain.GeneralLedger{
+			ID:        uuid.New(),
+			JournalID: id,
+			AccountID: row.AccountID,
+			CreatedBy: creatorID,
+			Amount:    row.Amount,
+		}
+
+		if err = scanGeneralLedgerReferences(&gl, row); err != nil {
+			err = errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on scan general ledger references")
+			return
+		}
+
+		if row.CounterpartyCompanyID != 0 {
+			gl.CounterpartyCompanyID = goSql.NullInt64{Int64: row.CounterpartyCompanyID, Valid: true}
+		}
+
+		gls = append(gls, gl)
+
+		balanceAmount += row.Amount
+		if row.Amount > 0 {
+			journalAmount += row.Amount
+		}
 	}
 
-	whereClause, whereClauseArgs, err := qb.NewWhereClause(AccountClassStatement{ID: id})
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Failed on select account group")
+	if len(gls) == 0 {
+		err = errors.PropagateWithCode(goErr.New("no lines"), EcodeAmendJournalFailed, "Amended journal must have lines")
 		return
 	}
 
-	updateQuery := fmt.Sprintf(`
-		UPDATE account_groups
-		SET parent_id = ?, class_id = ?, name = ?, inactive = ?
-		%s
-	`, whereClause)
+	if balanceAmount != 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("transaction not balance"), EcodeTransactionNotBalance, "Transaction not balance")
+		return
+	}
 
-	args := append(
-		[]interface{}{accountGroup.ParentID, accountGroup.ClassID, accountGroup.Name, accountGroup.Inactive},
-		whereClauseArgs...,
-	)
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		var (
+			active    bool
+			versionID int64
+		)
+
+		// the journal stays locked, so amendments made at the same time take their versions one after another
+		query := "SELECT deleted_at IS NULL FROM journals WHERE id = ? AND company_id = ? FOR UPDATE"
+		if err := tx.GetContext(ctx, &active, tx.Rebind(query), id, companyID(ctx)); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on lock journal")
+		}
+
+		if !active {
+			return errors.PropagateWithCode(goErr.New("journal voided"), EcodeAmendJournalProhibited, "Voided journal can not be amended")
+		}
+
+		before, err := snapshotTx(tx, ctx, AuditEntityJournal, id)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO journal_versions (journal_id, version, trans_date, memo, amount, amended_by, reason)
+			SELECT j.id, COALESCE((SELECT MAX(v.version) FROM journal_versions v WHERE v.journal_id = j.id), 0) + 1, j.trans_date, j.memo, j.amount, ?, ?
+			FROM journals j
+			WHERE j.id = ?
+			RETURNING id
+		`
+
+		if err = tx.QueryRowContext(ctx, tx.Rebind(query), userID, reasonValue, id).Scan(&versionID); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on store journal version")
+		}
+
+		query = `
+			INSERT INTO journal_version_lines (version_id, account_id, amount, memo, external_reference, counterparty_company_id)
+			SELECT ?, account_id, amount, memo, external_reference, counterparty_company_id
+			FROM general_ledgers
+			WHERE journal_id = ?
+		`
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), versionID, id); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on store journal version lines")
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind("DELETE FROM general_ledgers WHERE journal_id = ?"), id); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on remove general ledgers")
+		}
+
+		if err = w.StoreGeneralLedgersTx(tx, ctx, gls); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on store general ledgers")
+		}
+
+		query = "UPDATE journals SET trans_date = ?, memo = ?, amount = ? WHERE id = ?"
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), transaction.Date, memo, journalAmount, id); err != nil {
+			return errors.PropagateWithCode(err, EcodeAmendJournalFailed, "Failed on update journal")
+		}
+
+		return auditTx(tx, ctx, userID, AuditEntityJournal, id, AuditOperationUpdate, before)
+	})
 
-	_, err = w.db.ExecContext(ctx, w.db.Rebind(updateQuery), args...)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountGroupFailed, "Update account group failed")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on amend journal")
 		return
 	}
 
-	return
-}
+	prevJournal.TransDate = transaction.Date
+	prevJournal.Memo = memo
+	prevJournal.Amount = journalAmount
 
-func (w *writer) DeleteAccountGroupByID(ctx context.Context, id int64) (err error) {
-	_, err = w.deleteAccountGroup(ctx, AccountGroupStatement{ID: id})
-	return
+	return &prevJournal, nil
 }
 
-func (w *writer) deleteAccountGroup(ctx context.Context, where AccountGroupStatement) (result sql.Result, err error) {
-	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeDeleteAccountGroupFailed, "Failed on select account class")
+// ImportJournals validates every journal of a batch up front, and posts in one transaction either all of them or
+// only the valid ones depending on the mode. A batch imported before is read back instead of posted again, while a
+// batch with nothing posted is not recorded, so it can be uploaded again once corrected. Like StoreTransaction it
+// skips the review of journal drafts and needs the journal posting permission.
+func (w *writer) ImportJournals(ctx context.Context, userID uuid.UUID, journalImport *domain.JournalImport) (err error) {
+	if userID == uuid.Nil {
+		err = errors.PropagateWithCode(goErr.New("creator unknown"), EcodeStoreTransactionCreatedByRequired, "creator unknown")
 		return
 	}
 
-	deleteQuery := fmt.Sprintf(`
-		DELETE FROM account_classes
-		%s
-	`, whereClause)
-
-	result, err = w.db.ExecContext(ctx, w.db.Rebind(deleteQuery), whereClauseArgs...)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeDeleteAccountGroupFailed, "Update account class failed")
+	if err = w.mustHavePermission(ctx, userID, auth.JournalPosting, auth.WRITE); err != nil {
 		return
 	}
 
-	return
-}
+	if journalImport.BatchID == "" {
+		err = errors.PropagateWithCode(goErr.New("batch id required"), EcodeJournalImportInvalid, "Batch ID is required")
+		return
+	}
 
-func (w *writer) DeleteAccountClassByID(ctx context.Context, id int64) (err error) {
-	_, err = w.deleteAccountClass(ctx, AccountClassStatement{ID: id})
-	return
-}
+	if journalImport.Mode != domain.JournalImportAllOrNothingMode && journalImport.Mode != domain.JournalImportValidOnlyMode {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("unknown mode %s", journalImport.Mode),
+			EcodeJournalImportInvalid,
+			fmt.Sprintf("Mode must be %s or %s", domain.JournalImportAllOrNothingMode, domain.JournalImportValidOnlyMode),
+		)
+		return
+	}
 
-func (w *writer) deleteAccountClass(ctx context.Context, where AccountClassStatement) (result sql.Result, err error) {
-	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Failed on delete account class")
+	prevImport, err := w.reader.GetJournalImportByBatchID(ctx, journalImport.BatchID)
+	if err == nil {
+		*journalImport = prevImport
+		journalImport.Replayed = true
 		return
 	}
 
-	deleteQuery := fmt.Sprintf(`
-		DELETE FROM account_classes
-		%s
-	`, whereClause)
+	if errors.GetCode(err) != EcodeNotFound {
+		err = errors.PropagateWithCode(err, EcodeImportJournalsFailed, "Failed on get journal import")
+		return
+	}
 
-	result, err = w.db.ExecContext(ctx, w.db.Rebind(deleteQuery), whereClauseArgs...)
+	invalid, err := w.validateJournalImport(ctx, journalImport)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeDeleteAccountClassFailed, "Failed on delete account class")
 		return
 	}
 
-	return
-}
-
-func (w *writer) UpdateAccountClassByID(ctx context.Context, id int64, accountClass *domain.AccountClass) (err error) {
-	_, err = w.updateAccountClass(ctx, accountClass, AccountClassStatement{ID: id})
-	return
-}
+	journalImport.ErrorCount = int64(len(journalImport.Errors))
+	journalImport.PostedCount = int64(len(journalImport.Journals) - len(invalid))
 
-func (w *writer) updateAccountClass(ctx context.Context, accountClass *domain.AccountClass, where AccountClassStatement) (result sql.Result, err error) {
-	whereClause, whereClauseArgs, err := qb.NewWhereClause(where)
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Failed on select account class")
-		return
+	if journalImport.PostedCount == 0 || (journalImport.Mode == domain.JournalImportAllOrNothingMode && len(invalid) > 0) {
+		journalImport.PostedCount = 0
+		return nil
 	}
 
-	updateQuery := fmt.Sprintf(`
-		UPDATE account_classes
-		SET name = ?, type_id = ?
-		%s
-	`, whereClause)
+	err = w.db.Transaction(ctx, nil, func(tx sql.Tx) error {
+		query := `
+			INSERT INTO journal_imports (company_id, batch_id, mode, posted_count, error_count, created_by)
+			VALUES (?, ?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`
+
+		err := tx.QueryRowContext(
+			ctx,
+			tx.Rebind(query),
+			companyID(ctx), journalImport.BatchID, journalImport.Mode, journalImport.PostedCount, journalImport.ErrorCount, userID,
+		).Scan(&journalImport.ID, &journalImport.CreatedAt)
+
+		if err != nil {
+			return errors.PropagateWithCode(err, EcodeImportJournalsFailed, "Failed on store journal import")
+		}
+
+		for i, journal := range journalImport.Journals {
+			if !invalid[journal.Reference] {
+				var posted *domain.Journal
+
+				rows := make([]TransactionRow, len(journal.Lines))
+				for j, line := range journal.Lines {
+					rows[j] = TransactionRow{AccountID: line.AccountID, Amount: line.Amount, Memo: line.Memo, ExternalReference: line.ExternalReference}
+					if rows[j].ExternalReference == "" {
+						rows[j].ExternalReference = journal.Reference
+					}
+				}
+
+				posted, err = w.StoreTransactionTx(tx, ctx, userID, Transaction{Date: journal.TransDate, Memo: journal.Memo, Data: rows})
+				if err != nil {
+					return errors.PropagateWithCode(err, errors.GetCode(err), fmt.Sprintf("Failed on post journal %s", journal.Reference))
+				}
+
+				journalImport.Journals[i].JournalID = uuid.NullUUID{UUID: posted.ID, Valid: true}
+			}
+
+			query = `
+				INSERT INTO journal_import_journals (import_id, line, reference, trans_date, memo, journal_id)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`
+
+			_, err = tx.ExecContext(
+				ctx,
+				tx.Rebind(query),
+				journalImport.ID, journal.Row, journal.Reference, journal.TransDate, journal.Memo, journalImport.Journals[i].JournalID,
+			)
+
+			if err != nil {
+				return errors.PropagateWithCode(err, EcodeImportJournalsFailed, "Failed on store journal import journal")
+			}
+		}
+
+		for _, importError := range journalImport.Errors {
+			query = "INSERT INTO journal_import_errors (import_id, line, reference, message) VALUES (?, ?, ?, ?)"
+			if _, err = tx.ExecContext(ctx, tx.Rebind(query), journalImport.ID, importError.Row, importError.Reference, importError.Message); err != nil {
+				return errors.PropagateWithCode(err, EcodeImportJournalsFailed, "Failed on store journal import error")
+			}
+		}
+
+		return nil
+	})
 
-	args := append([]interface{}{accountClass.Name, accountClass.TypeID}, whereClauseArgs...)
-	result, err = w.db.ExecContext(ctx, w.db.Rebind(updateQuery), args...)
 	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeUpdateAccountClassFailed, "Update account class failed")
+		err = errors.PropagateWithCode(err, errors.GetCode(err), "Failed on import journals")
 		return
 	}
 
+	journalImport.CreatedBy = userID
+
 	return
 }
 
-func (w *writer) StoreAccountClass(ctx context.Context, accountClass *domain.AccountClass) (err error) {
-	classType := classTypes[accountClass.TypeID]
-	if classType.ID == 0 {
-		err = errors.PropagateWithCode(err, EcodeStoreAccountClassFailed, "Type not valid")
-		return
+// validateJournalImport reports in the errors of journalImport every journal dated outside an open fiscal period,
+// posting to an unknown or inactive account or not balancing, and returns the references of the journals reported,
+// including those the file already had errors for.
+func (w *writer) validateJournalImport(ctx context.Context, journalImport *domain.JournalImport) (invalid map[string]bool, err error) {
+	var (
+		accounts = make(map[int64]domain.Account)
+		dates    = make(map[time.Time]string)
+		seen     = make(map[string]bool)
+	)
+
+	invalid = make(map[string]bool)
+	for _, importError := range journalImport.Errors {
+		invalid[importError.Reference] = true
 	}
 
-	err = w.db.QueryRowContext(ctx, w.db.Rebind(`
-		INSERT INTO account_classes (name, type_id, inactive)
-		VALUES (?, ?, ?)
-		RETURNING id
-	`), accountClass.Name, accountClass.TypeID, accountClass.Inactive).Scan(&accountClass.ID)
+	for _, journal := range journalImport.Journals {
+		var (
+			balanceAmount float64
+			lineCount     int
+		)
 
-	if err != nil {
-		err = errors.PropagateWithCode(err, EcodeStoreAccountClassFailed, "Insert account class failed")
-		return
+		report := func(row int64, message string) {
+			journalImport.Errors = append(journalImport.Errors, domain.JournalImportError{Row: row, Reference: journal.Reference, Message: message})
+			invalid[journal.Reference] = true
+		}
+
+		if journal.Reference == "" {
+			report(journal.Row, "Reference is required")
+		} else if seen[journal.Reference] {
+			report(journal.Row, fmt.Sprintf("Reference %s is used by another journal", journal.Reference))
+		}
+
+		seen[journal.Reference] = true
+
+		// a journal reported by the file already has its date reported when it has none
+		if journal.TransDate.IsZero() {
+			if !invalid[journal.Reference] {
+				report(journal.Row, "Date is required")
+			}
+		} else {
+			message, ok := dates[journal.TransDate]
+			if !ok {
+				dateErr := w.validateOpenPostingDate(ctx, journal.TransDate)
+
+				switch errors.GetCode(dateErr) {
+				case EcodeStoreTransactionProhibited:
+					message = "No active fiscal year for the date"
+				case EcodeFiscalPeriodLocked:
+					message = "Fiscal period of the date is not open"
+				default:
+					if dateErr != nil {
+						err = errors.PropagateWithCode(dateErr, EcodeImportJournalsFailed, "Failed on validate journal date")
+						return
+					}
+				}
+
+				dates[journal.TransDate] = message
+			}
+
+			if message != "" {
+				report(journal.Row, message)
+			}
+		}
+
+		for _, line := range journal.Lines {
+			if line.Amount == 0 {
+				continue
+			}
+
+			account, ok := accounts[line.AccountID]
+			if !ok {
+				account, err = w.reader.GetAccountByID(ctx, line.AccountID)
+				if errors.RootCause(err) == goSql.ErrNoRows {
+					report(line.Row, fmt.Sprintf("Account %d not found", line.AccountID))
+					err = nil
+					continue
+				}
+
+				if err != nil {
+					err = errors.PropagateWithCode(err, EcodeImportJournalsFailed, "Failed on get account")
+					return
+				}
+
+				accounts[line.AccountID] = account
+			}
+
+			if account.Inactive {
+				report(line.Row, fmt.Sprintf("Account %d is inactive", line.AccountID))
+			}
+
+			balanceAmount += line.Amount
+			lineCount++
+		}
+
+		if lineCount == 0 {
+			report(journal.Row, "Journal has no amount")
+		} else if balanceAmount != 0 {
+			report(journal.Row, "Journal does not balance")
+		}
 	}
 
 	return
 }
-
-func NewWriter(opt *Options, reader Reader) Writer {
-	return &writer{opt.Logger, opt.MasterDB, reader}
-}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllAmortizationSchedules(ctx context.Context, stmt sql.AmortizationScheduleStatement) (schedules []domain.AmortizationSchedule, err error) {
+	return r.AccountingSQL.GetAllAmortizationSchedules(ctx, stmt)
+}
+
+func (r *reader) GetAmortizationScheduleByID(ctx context.Context, id int64) (schedule domain.AmortizationSchedule, err error) {
+	return r.AccountingSQL.GetAmortizationScheduleByID(ctx, id)
+}
+
+func (r *reader) GetAllAmortizationEntries(ctx context.Context, stmt sql.AmortizationEntryStatement) (entries []domain.AmortizationEntry, err error) {
+	return r.AccountingSQL.GetAllAmortizationEntries(ctx, stmt)
+}
+
+func (r *reader) GetAmortizationPlan(schedule domain.AmortizationSchedule) (plan []domain.AmortizationPlanLine) {
+	return sql.AmortizationPlan(schedule)
+}
+
+func (w *writer) StoreAmortizationSchedule(ctx context.Context, userID uuid.UUID, schedule *domain.AmortizationSchedule) (err error) {
+	return w.AccountingSQL.StoreAmortizationSchedule(ctx, userID, schedule)
+}
+
+func (w *writer) PostDueAmortizationEntries(ctx context.Context, asOf time.Time) (entries []domain.AmortizationEntry, err error) {
+	return w.AccountingSQL.PostDueAmortizationEntries(ctx, asOf)
+}
+
+func (w *writer) TerminateAmortizationScheduleByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, writeOffRemaining bool) (err error) {
+	return w.AccountingSQL.TerminateAmortizationScheduleByID(ctx, id, userID, date, writeOffRemaining)
+}
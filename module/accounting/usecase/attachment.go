@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
+	"github.com/google/uuid"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	MaxAttachmentSize       = 10 << 20
+	AttachmentURLTTL        = 15 * time.Minute
+	AttachmentDownloadPath  = "/attachments/"
+	attachmentStoragePrefix = "attachments"
+)
+
+// attachmentContentTypes lists the accepted document types. Types with a sniffable signature are checked
+// against the uploaded bytes so a renamed executable cannot pass as a receipt.
+var attachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"text/csv":        false,
+	"text/plain":      false,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": false,
+}
+
+func validateAttachmentContent(contentType string, content []byte) (err error) {
+	sniffable, ok := attachmentContentTypes[contentType]
+	if !ok {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("content type %s not allowed", contentType),
+			sql.EcodeAttachmentContentTypeInvalid,
+			fmt.Sprintf("Content type %s is not allowed", contentType),
+		)
+		return
+	}
+
+	if sniffable {
+		if detected, _, _ := mime.ParseMediaType(http.DetectContentType(content)); detected != contentType {
+			err = errors.PropagateWithCode(
+				fmt.Errorf("content is %s, declared %s", detected, contentType),
+				sql.EcodeAttachmentContentTypeInvalid,
+				"File content does not match its content type",
+			)
+			return
+		}
+	}
+
+	return
+}
+
+func (w *writer) StoreAttachment(ctx context.Context, userID uuid.UUID, attachment *domain.Attachment, file io.Reader) (err error) {
+	content, err := io.ReadAll(io.LimitReader(file, MaxAttachmentSize+1))
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeStoreAttachmentFailed, "Failed on read attachment")
+		return
+	}
+
+	if len(content) > MaxAttachmentSize {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("attachment exceeds %d bytes", MaxAttachmentSize),
+			sql.EcodeAttachmentTooLarge,
+			fmt.Sprintf("Attachment must not exceed %d MB", MaxAttachmentSize>>20),
+		)
+		return
+	}
+
+	if mediaType, _, parseErr := mime.ParseMediaType(attachment.ContentType); parseErr == nil {
+		attachment.ContentType = mediaType
+	}
+
+	if err = validateAttachmentContent(attachment.ContentType, content); err != nil {
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	attachment.SHA256 = hex.EncodeToString(sum[:])
+	attachment.Size = int64(len(content))
+	attachment.FileName = filepath.Base(strings.TrimSpace(attachment.FileName))
+	attachment.CreatedBy = userID
+	attachment.StorageKey = fmt.Sprintf("%s/%s/%s", attachmentStoragePrefix, attachment.SHA256[:2], attachment.SHA256)
+
+	// Identical content is stored once; only upload when no file with the same digest exists yet.
+	if _, err = w.AccountingSQL.GetAttachmentFileBySHA256(ctx, attachment.SHA256); err != nil {
+		if errors.GetCode(err) != sql.EcodeNotFound {
+			return
+		}
+
+		if err = w.Storage.Put(ctx, attachment.StorageKey, bytes.NewReader(content), attachment.Size, attachment.ContentType); err != nil {
+			err = errors.PropagateWithCode(err, sql.EcodeStoreAttachmentFailed, "Failed on upload attachment")
+			return
+		}
+	}
+
+	return w.AccountingSQL.StoreAttachment(ctx, attachment)
+}
+
+func (w *writer) DeleteAttachmentByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.DeleteAttachmentByID(ctx, id, userID)
+}
+
+func (r *reader) GetAllAttachments(ctx context.Context, stmt sql.AttachmentStatement) (attachments []domain.Attachment, err error) {
+	return r.AccountingSQL.GetAllAttachments(ctx, stmt)
+}
+
+func (r *reader) GetAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error) {
+	return r.AccountingSQL.GetAttachmentByID(ctx, id)
+}
+
+// GetAttachmentDownloadURL returns a relative link that downloads the attachment without a bearer token
+// until it expires.
+func (r *reader) GetAttachmentDownloadURL(attachment domain.Attachment) string {
+	return r.Signer.SignURL(AttachmentDownloadPath+attachment.ID.String(), AttachmentURLTTL)
+}
+
+// OpenAttachment verifies a signed download link and opens the attachment content.
+func (r *reader) OpenAttachment(ctx context.Context, id uuid.UUID, query url.Values) (attachment domain.Attachment, body io.ReadCloser, err error) {
+	if err = r.Signer.Verify(AttachmentDownloadPath+id.String(), query); err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeAttachmentURLInvalid, "Download link is invalid or has expired")
+		return
+	}
+
+	// the link names the attachment on its own, a browser following it sends no company
+	if attachment, err = r.AccountingSQL.GetDownloadableAttachmentByID(ctx, id); err != nil {
+		return
+	}
+
+	if body, err = r.Storage.Get(ctx, attachment.StorageKey); err != nil {
+		if err == storage.ErrNotFound {
+			err = errors.PropagateWithCode(err, sql.EcodeNotFound, "Attachment file not found")
+			return
+		}
+
+		err = errors.PropagateWithCode(err, sql.EcodeGetAttachmentFailed, "Failed on open attachment")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+)
+
+func (r *reader) GetAllAuditLogs(ctx context.Context, stmt sql.AuditLogStatement) (logs []domain.AuditLog, err error) {
+	return r.AccountingSQL.GetAllAuditLogs(ctx, stmt)
+}
+
+func (r *reader) VerifyAuditLog(ctx context.Context) (verification domain.AuditLogVerification, err error) {
+	return r.AccountingSQL.VerifyAuditLog(ctx)
+}
+
+func (r *reader) VerifyLedger(ctx context.Context) (verification domain.LedgerVerification, err error) {
+	return r.AccountingSQL.VerifyLedger(ctx)
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/google/uuid"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func (r *reader) GetAllBudgets(ctx context.Context, stmt sql.BudgetStatement) (budgets []domain.Budget, err error) {
+	return r.AccountingSQL.GetAllBudgets(ctx, stmt)
+}
+
+func (r *reader) GetBudgetByID(ctx context.Context, id int64) (budget domain.Budget, err error) {
+	return r.AccountingSQL.GetBudgetByID(ctx, id)
+}
+
+func (r *reader) GetAllBudgetLines(ctx context.Context, stmt sql.BudgetLineStatement) (lines []domain.BudgetLine, err error) {
+	return r.AccountingSQL.GetAllBudgetLines(ctx, stmt)
+}
+
+func (r *reader) GetBudgetVsActual(ctx context.Context, budgetID int64, fromPeriodID int64, toPeriodID int64) (report domain.BudgetVsActual, err error) {
+	return r.AccountingSQL.GetBudgetVsActual(ctx, budgetID, fromPeriodID, toPeriodID)
+}
+
+func (w *writer) StoreBudget(ctx context.Context, userID uuid.UUID, budget *domain.Budget) (err error) {
+	return w.AccountingSQL.StoreBudget(ctx, userID, budget)
+}
+
+func (w *writer) UpdateBudgetByID(ctx context.Context, id int64, budget *domain.Budget) (err error) {
+	return w.AccountingSQL.UpdateBudgetByID(ctx, id, budget)
+}
+
+func (w *writer) DeleteBudgetByID(ctx context.Context, id int64) (err error) {
+	return w.AccountingSQL.DeleteBudgetByID(ctx, id)
+}
+
+func (w *writer) StoreBudgetLines(ctx context.Context, budgetID int64, lines []domain.BudgetLine) (err error) {
+	return w.AccountingSQL.StoreBudgetLines(ctx, budgetID, lines)
+}
+
+func (w *writer) GenerateBudgetFromActuals(ctx context.Context, userID uuid.UUID, budget *domain.Budget, sourceFiscalYearID int64, percent float64) (err error) {
+	return w.AccountingSQL.GenerateBudgetFromActuals(ctx, userID, budget, sourceFiscalYearID, percent)
+}
+
+// ImportBudgetLinesCSV stores the budget lines of a CSV with a header row. An account is given by account_id or
+// account_code, the period by its 1-based position in the budget fiscal year, the amount by a signed amount column
+// or by debit and credit columns. The dimension column is optional.
+func (w *writer) ImportBudgetLinesCSV(ctx context.Context, budgetID int64, file io.Reader) (lines []domain.BudgetLine, err error) {
+	budget, err := w.AccountingSQL.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		return
+	}
+
+	periods, err := w.AccountingSQL.GetAllFiscalPeriods(ctx, sql.FiscalPeriodStatement{FiscalYearID: budget.FiscalYearID})
+	if err != nil {
+		return
+	}
+
+	lines, err = w.parseBudgetLinesCSV(ctx, file, periods)
+	if err != nil {
+		return
+	}
+
+	if err = w.AccountingSQL.StoreBudgetLines(ctx, budget.ID, lines); err != nil {
+		return
+	}
+
+	return w.AccountingSQL.GetAllBudgetLines(ctx, sql.BudgetLineStatement{BudgetID: budget.ID})
+}
+
+func (w *writer) parseBudgetLinesCSV(ctx context.Context, file io.Reader, periods []domain.FiscalPeriod) (lines []domain.BudgetLine, err error) {
+	var fieldErrors errors.ValidationErrors
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeParseCSVFailed, "Failed on read csv")
+		return
+	}
+
+	if len(records) == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("empty csv"), sql.EcodeParseCSVFailed, "CSV is empty")
+		return
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	_, hasAccountID := columns["account_id"]
+	_, hasAccountCode := columns["account_code"]
+	_, hasPeriod := columns["period"]
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	_, hasCredit := columns["credit"]
+
+	if (!hasAccountID && !hasAccountCode) || !hasPeriod || (!hasAmount && !hasDebit && !hasCredit) {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("invalid csv header"),
+			sql.EcodeParseCSVFailed,
+			"CSV header must have account_id or account_code, period and amount or debit and credit columns",
+		)
+		return
+	}
+
+	value := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	number := func(record []string, column string) (float64, error) {
+		if v := value(record, column); v != "" {
+			return strconv.ParseFloat(v, 64)
+		}
+
+		return 0, nil
+	}
+
+	accountIDs := make(map[string]int64)
+
+	for i, record := range records[1:] {
+		line := fmt.Sprintf("%d", i+2)
+
+		var accountID int64
+		if code := value(record, "account_code"); code != "" {
+			if _, ok := accountIDs[code]; !ok {
+				account, getErr := w.AccountingSQL.GetAccount(ctx, sql.AccountStatement{Code: code})
+				if getErr != nil {
+					fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: fmt.Sprintf("Account %s not found", code)})
+					continue
+				}
+
+				accountIDs[code] = account.ID
+			}
+
+			accountID = accountIDs[code]
+		} else {
+			id, parseErr := strconv.ParseInt(value(record, "account_id"), 10, 64)
+			if parseErr != nil {
+				fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: "Invalid account_id"})
+				continue
+			}
+
+			accountID = id
+		}
+
+		period, parseErr := strconv.Atoi(value(record, "period"))
+		if parseErr != nil || period < 1 || period > len(periods) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: fmt.Sprintf("Period must be between 1 and %d", len(periods))})
+			continue
+		}
+
+		amount, parseErr := number(record, "amount")
+		debit, debitErr := number(record, "debit")
+		credit, creditErr := number(record, "credit")
+
+		if parseErr != nil || debitErr != nil || creditErr != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: "Invalid amount"})
+			continue
+		}
+
+		lines = append(lines, domain.BudgetLine{
+			AccountID:      accountID,
+			FiscalPeriodID: periods[period-1].ID,
+			Dimension:      value(record, "dimension"),
+			Amount:         amount + debit - credit,
+		})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, sql.EcodeParseCSVFailed, "Invalid csv rows")
+		return
+	}
+
+	return
+}
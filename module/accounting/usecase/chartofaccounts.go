@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ChartOfAccountsJSONFormat = "json"
+	ChartOfAccountsCSVFormat  = "csv"
+
+	// chartOfAccountsGroupSeparator joins nested group names in the CSV group_path column.
+	chartOfAccountsGroupSeparator = " > "
+)
+
+var chartOfAccountsCSVHeader = []string{"class", "class_type_id", "group_path", "account", "code", "inactive"}
+
+//go:embed templates/*.json
+var chartOfAccountsTemplateFS embed.FS
+
+func (r *reader) ExportChartOfAccounts(ctx context.Context, format string, w io.Writer) (err error) {
+	chart, err := r.AccountingSQL.GetChartOfAccounts(ctx)
+	if err != nil {
+		return
+	}
+
+	switch format {
+	case ChartOfAccountsJSONFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(chart)
+	case ChartOfAccountsCSVFormat:
+		err = writeChartOfAccountsCSV(w, chart)
+	default:
+		return chartOfAccountsFormatError(format)
+	}
+
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeExportChartOfAccountsFailed, "Failed on export chart of accounts")
+	}
+
+	return
+}
+
+func (r *reader) GetAllChartOfAccountsTemplates() (templates []domain.ChartOfAccountsTemplate, err error) {
+	files, err := chartOfAccountsTemplateFS.ReadDir("templates")
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeChartOfAccountsTemplateNotFound, "Failed on read chart of accounts templates")
+		return
+	}
+
+	for _, file := range files {
+		var template domain.ChartOfAccountsTemplate
+		if template, err = readChartOfAccountsTemplate(strings.TrimSuffix(file.Name(), ".json")); err != nil {
+			return
+		}
+
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	return
+}
+
+func (w *writer) ImportChartOfAccounts(ctx context.Context, format string, file io.Reader, dryRun bool) (changes []domain.ChartOfAccountsChange, err error) {
+	var chart domain.ChartOfAccounts
+
+	switch format {
+	case ChartOfAccountsJSONFormat:
+		if err = json.NewDecoder(file).Decode(&chart); err != nil {
+			err = errors.PropagateWithCode(err, sql.EcodeChartOfAccountsInvalid, "Failed on parse chart of accounts json")
+			return
+		}
+	case ChartOfAccountsCSVFormat:
+		if chart, err = parseChartOfAccountsCSV(file); err != nil {
+			return
+		}
+	default:
+		err = chartOfAccountsFormatError(format)
+		return
+	}
+
+	return w.AccountingSQL.ImportChartOfAccounts(ctx, chart, dryRun)
+}
+
+func (w *writer) ApplyChartOfAccountsTemplate(ctx context.Context, name string) (changes []domain.ChartOfAccountsChange, err error) {
+	template, err := readChartOfAccountsTemplate(name)
+	if err != nil {
+		return
+	}
+
+	return w.AccountingSQL.ApplyChartOfAccountsTemplate(ctx, template.ChartOfAccounts)
+}
+
+func readChartOfAccountsTemplate(name string) (template domain.ChartOfAccountsTemplate, err error) {
+	content, err := chartOfAccountsTemplateFS.ReadFile(path.Join("templates", path.Base(name)+".json"))
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeChartOfAccountsTemplateNotFound, fmt.Sprintf("Chart of accounts template %s not found", name))
+		return
+	}
+
+	if err = json.Unmarshal(content, &template); err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeChartOfAccountsTemplateNotFound, fmt.Sprintf("Chart of accounts template %s is broken", name))
+		return
+	}
+
+	return
+}
+
+func chartOfAccountsFormatError(format string) error {
+	return errors.PropagateWithCode(
+		fmt.Errorf("unknown format %s", format),
+		sql.EcodeChartOfAccountsInvalid,
+		fmt.Sprintf("Format must be %s or %s", ChartOfAccountsJSONFormat, ChartOfAccountsCSVFormat),
+	)
+}
+
+// writeChartOfAccountsCSV writes one row per class, group and account. Group rows leave the account column
+// empty and class rows leave both group_path and account empty; code and inactive belong to the deepest node of the row.
+func writeChartOfAccountsCSV(w io.Writer, chart domain.ChartOfAccounts) (err error) {
+	csvWriter := csv.NewWriter(w)
+	if err = csvWriter.Write(chartOfAccountsCSVHeader); err != nil {
+		return
+	}
+
+	var writeGroups func(class domain.ChartOfAccountsClass, parents []string, groups []domain.ChartOfAccountsGroup) error
+	writeGroups = func(class domain.ChartOfAccountsClass, parents []string, groups []domain.ChartOfAccountsGroup) error {
+		typeID := strconv.FormatInt(class.TypeID, 10)
+
+		for _, group := range groups {
+			groupPath := append(append([]string{}, parents...), group.Name)
+			joined := strings.Join(groupPath, chartOfAccountsGroupSeparator)
+
+			if err := csvWriter.Write([]string{class.Name, typeID, joined, "", group.Code, strconv.FormatBool(group.Inactive)}); err != nil {
+				return err
+			}
+
+			for _, account := range group.Accounts {
+				if err := csvWriter.Write([]string{class.Name, typeID, joined, account.Name, account.Code, strconv.FormatBool(account.Inactive)}); err != nil {
+					return err
+				}
+			}
+
+			if err := writeGroups(class, groupPath, group.Groups); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, class := range chart.Classes {
+		err = csvWriter.Write([]string{class.Name, strconv.FormatInt(class.TypeID, 10), "", "", class.Code, strconv.FormatBool(class.Inactive)})
+		if err != nil {
+			return
+		}
+
+		if err = writeGroups(class, nil, class.Groups); err != nil {
+			return
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// parseChartOfAccountsCSV builds a tree from rows written by writeChartOfAccountsCSV. Class and group rows are
+// optional: classes and groups are created from the first row that mentions them.
+func parseChartOfAccountsCSV(file io.Reader) (chart domain.ChartOfAccounts, err error) {
+	var fieldErrors errors.ValidationErrors
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeParseCSVFailed, "Failed on read csv")
+		return
+	}
+
+	if len(records) == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("empty csv"), sql.EcodeParseCSVFailed, "CSV is empty")
+		return
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range chartOfAccountsCSVHeader[:4] {
+		if _, ok := columns[name]; !ok {
+			err = errors.PropagateWithCode(
+				fmt.Errorf("invalid csv header"),
+				sql.EcodeParseCSVFailed,
+				fmt.Sprintf("CSV header must have %s columns", strings.Join(chartOfAccountsCSVHeader, ", ")),
+			)
+			return
+		}
+	}
+
+	value := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	classIndex := make(map[string]int)
+	for line, record := range records[1:] {
+		field := fmt.Sprintf("line %d", line+2)
+
+		className := value(record, "class")
+		classTypeID, parseErr := strconv.ParseInt(value(record, "class_type_id"), 10, 64)
+		if parseErr != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "class_type_id must be a number"})
+			continue
+		}
+
+		inactive := false
+		if raw := value(record, "inactive"); raw != "" {
+			if inactive, parseErr = strconv.ParseBool(raw); parseErr != nil {
+				fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "inactive must be true or false"})
+				continue
+			}
+		}
+
+		ci, ok := classIndex[className]
+		if !ok {
+			ci = len(chart.Classes)
+			classIndex[className] = ci
+			chart.Classes = append(chart.Classes, domain.ChartOfAccountsClass{Name: className, TypeID: classTypeID})
+		}
+
+		class := &chart.Classes[ci]
+		if class.TypeID != classTypeID {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: fmt.Sprintf("class %s has conflicting class_type_id", className)})
+			continue
+		}
+
+		groupPath := value(record, "group_path")
+		accountName := value(record, "account")
+		code := value(record, "code")
+
+		if groupPath == "" {
+			if accountName != "" {
+				fieldErrors = append(fieldErrors, errors.FieldError{Field: field, Message: "account must belong to a group"})
+				continue
+			}
+
+			class.Code = code
+			class.Inactive = inactive
+			continue
+		}
+
+		groups := &class.Groups
+		var group *domain.ChartOfAccountsGroup
+		for _, name := range strings.Split(groupPath, strings.TrimSpace(chartOfAccountsGroupSeparator)) {
+			name = strings.TrimSpace(name)
+
+			group = nil
+			for i := range *groups {
+				if (*groups)[i].Name == name {
+					group = &(*groups)[i]
+					break
+				}
+			}
+
+			if group == nil {
+				*groups = append(*groups, domain.ChartOfAccountsGroup{Name: name})
+				group = &(*groups)[len(*groups)-1]
+			}
+
+			groups = &group.Groups
+		}
+
+		if accountName == "" {
+			group.Code = code
+			group.Inactive = inactive
+			continue
+		}
+
+		group.Accounts = append(group.Accounts, domain.ChartOfAccountsAccount{Code: code, Name: accountName, Inactive: inactive})
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, sql.EcodeParseCSVFailed, "CSV contains invalid rows")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllCompanies(ctx context.Context, stmt sql.CompanyStatement) (companies []domain.Company, err error) {
+	return r.AccountingSQL.GetAllCompanies(ctx, stmt)
+}
+
+func (r *reader) GetCompanyByID(ctx context.Context, id int64) (company domain.Company, err error) {
+	return r.AccountingSQL.GetCompanyByID(ctx, id)
+}
+
+func (r *reader) GetAllCompaniesByUserID(ctx context.Context, userID uuid.UUID) (companies []domain.Company, err error) {
+	return r.AccountingSQL.GetAllCompaniesByUserID(ctx, userID)
+}
+
+func (r *reader) HasCompanyAccess(ctx context.Context, userID uuid.UUID, companyID int64) (hasAccess bool, err error) {
+	return r.AccountingSQL.HasCompanyAccess(ctx, userID, companyID)
+}
+
+func (r *reader) GetAllGroupAccounts(ctx context.Context, stmt sql.GroupAccountStatement) (groupAccounts []domain.GroupAccount, err error) {
+	return r.AccountingSQL.GetAllGroupAccounts(ctx, stmt)
+}
+
+func (r *reader) GetGroupAccountByID(ctx context.Context, id int64) (groupAccount domain.GroupAccount, err error) {
+	return r.AccountingSQL.GetGroupAccountByID(ctx, id)
+}
+
+func (r *reader) GetConsolidatedTrialBalance(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedTrialBalance, err error) {
+	return r.AccountingSQL.GetConsolidatedTrialBalance(ctx, companyIDs, asOf)
+}
+
+func (r *reader) GetConsolidatedBalanceSheet(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedBalanceSheet, err error) {
+	return r.AccountingSQL.GetConsolidatedBalanceSheet(ctx, companyIDs, asOf)
+}
+
+func (w *writer) StoreCompany(ctx context.Context, userID uuid.UUID, company *domain.Company) (err error) {
+	return w.AccountingSQL.StoreCompany(ctx, userID, company)
+}
+
+func (w *writer) UpdateCompanyByID(ctx context.Context, id int64, company *domain.Company) (err error) {
+	return w.AccountingSQL.UpdateCompanyByID(ctx, id, company)
+}
+
+func (w *writer) AddCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.AddCompanyUser(ctx, companyID, userID)
+}
+
+func (w *writer) RemoveCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.RemoveCompanyUser(ctx, companyID, userID)
+}
+
+func (w *writer) StoreGroupAccount(ctx context.Context, groupAccount *domain.GroupAccount) (err error) {
+	return w.AccountingSQL.StoreGroupAccount(ctx, groupAccount)
+}
+
+func (w *writer) UpdateGroupAccountByID(ctx context.Context, id int64, groupAccount *domain.GroupAccount) (err error) {
+	return w.AccountingSQL.UpdateGroupAccountByID(ctx, id, groupAccount)
+}
+
+func (w *writer) MapAccountToGroupAccount(ctx context.Context, accountID int64, groupAccountID int64) (err error) {
+	return w.AccountingSQL.MapAccountToGroupAccount(ctx, accountID, groupAccountID)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseTransactionRowsCSV reads transaction rows from a CSV with a header row. The account_id column is required,
+// the amount is taken from a signed amount column or from debit and credit columns.
+func parseTransactionRowsCSV(file io.Reader) (rows []sql.TransactionRow, err error) {
+	var fieldErrors errors.ValidationErrors
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeParseCSVFailed, "Failed on read csv")
+		return
+	}
+
+	if len(records) == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("empty csv"), sql.EcodeParseCSVFailed, "CSV is empty")
+		return
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	_, hasAccountID := columns["account_id"]
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	_, hasCredit := columns["credit"]
+
+	if !hasAccountID || (!hasAmount && !hasDebit && !hasCredit) {
+		err = errors.PropagateWithCode(fmt.Errorf("invalid csv header"), sql.EcodeParseCSVFailed, "CSV header must have account_id and amount or debit and credit columns")
+		return
+	}
+
+	value := func(record []string, column string) (number float64, err error) {
+		i, ok := columns[column]
+		if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+			return 0, nil
+		}
+
+		return strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+	}
+
+	for i, record := range records[1:] {
+		var row sql.TransactionRow
+
+		line := fmt.Sprintf("%d", i+2)
+
+		accountID, parseErr := strconv.ParseInt(strings.TrimSpace(record[columns["account_id"]]), 10, 64)
+		if parseErr != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: "Invalid account_id"})
+			continue
+		}
+
+		amount, parseErr := value(record, "amount")
+		debit, debitErr := value(record, "debit")
+		credit, creditErr := value(record, "credit")
+
+		if parseErr != nil || debitErr != nil || creditErr != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: line, Message: "Invalid amount"})
+			continue
+		}
+
+		row.AccountID = accountID
+		row.Amount = amount + debit - credit
+		rows = append(rows, row)
+	}
+
+	if len(fieldErrors) > 0 {
+		err = errors.PropagateWithCode(fieldErrors, sql.EcodeParseCSVFailed, "Invalid csv rows")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllAssetCategories(ctx context.Context, stmt sql.AssetCategoryStatement) (categories []domain.AssetCategory, err error) {
+	return r.AccountingSQL.GetAllAssetCategories(ctx, stmt)
+}
+
+func (r *reader) GetAssetCategoryByID(ctx context.Context, id int64) (category domain.AssetCategory, err error) {
+	return r.AccountingSQL.GetAssetCategoryByID(ctx, id)
+}
+
+func (r *reader) GetAllFixedAssets(ctx context.Context, stmt sql.FixedAssetStatement) (assets []domain.FixedAsset, err error) {
+	return r.AccountingSQL.GetAllFixedAssets(ctx, stmt)
+}
+
+func (r *reader) GetFixedAssetByID(ctx context.Context, id int64) (asset domain.FixedAsset, err error) {
+	return r.AccountingSQL.GetFixedAssetByID(ctx, id)
+}
+
+func (r *reader) GetAllDepreciationRuns(ctx context.Context, stmt sql.DepreciationRunStatement) (runs []domain.DepreciationRun, err error) {
+	return r.AccountingSQL.GetAllDepreciationRuns(ctx, stmt)
+}
+
+func (r *reader) GetAllDepreciationEntries(ctx context.Context, stmt sql.DepreciationEntryStatement) (entries []domain.DepreciationEntry, err error) {
+	return r.AccountingSQL.GetAllDepreciationEntries(ctx, stmt)
+}
+
+func (r *reader) GetFixedAssetRegister(ctx context.Context, asOf time.Time) (rows []domain.FixedAssetRegisterRow, err error) {
+	return r.AccountingSQL.GetFixedAssetRegister(ctx, asOf)
+}
+
+func (w *writer) StoreAssetCategory(ctx context.Context, category *domain.AssetCategory) (err error) {
+	return w.AccountingSQL.StoreAssetCategory(ctx, category)
+}
+
+func (w *writer) UpdateAssetCategoryByID(ctx context.Context, id int64, category *domain.AssetCategory) (err error) {
+	return w.AccountingSQL.UpdateAssetCategoryByID(ctx, id, category)
+}
+
+func (w *writer) StoreFixedAsset(ctx context.Context, userID uuid.UUID, asset *domain.FixedAsset) (err error) {
+	return w.AccountingSQL.StoreFixedAsset(ctx, userID, asset)
+}
+
+func (w *writer) UpdateFixedAssetByID(ctx context.Context, id int64, asset *domain.FixedAsset) (err error) {
+	return w.AccountingSQL.UpdateFixedAssetByID(ctx, id, asset)
+}
+
+func (w *writer) RunDepreciation(ctx context.Context, userID uuid.UUID, date time.Time) (run domain.DepreciationRun, err error) {
+	return w.AccountingSQL.RunDepreciation(ctx, userID, date)
+}
+
+func (w *writer) DisposeFixedAssetByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, proceeds float64, proceedsAccountID int64) (err error) {
+	return w.AccountingSQL.DisposeFixedAssetByID(ctx, id, userID, date, proceeds, proceedsAccountID)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllIntercompanyTransactions(ctx context.Context, stmt sql.IntercompanyTransactionStatement) (transactions []domain.IntercompanyTransaction, err error) {
+	return r.AccountingSQL.GetAllIntercompanyTransactions(ctx, stmt)
+}
+
+func (r *reader) GetIntercompanyTransactionByID(ctx context.Context, id int64) (transaction domain.IntercompanyTransaction, err error) {
+	return r.AccountingSQL.GetIntercompanyTransactionByID(ctx, id)
+}
+
+func (r *reader) GetIntercompanyReconciliation(ctx context.Context, companyIDs []int64, asOf time.Time) (balances []domain.IntercompanyBalance, err error) {
+	return r.AccountingSQL.GetIntercompanyReconciliation(ctx, companyIDs, asOf)
+}
+
+func (w *writer) StoreIntercompanyTransaction(ctx context.Context, userID uuid.UUID, transaction *domain.IntercompanyTransaction) (err error) {
+	return w.AccountingSQL.StoreIntercompanyTransaction(ctx, userID, transaction)
+}
+
+func (w *writer) VoidIntercompanyTransactionByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.VoidIntercompanyTransactionByID(ctx, id, userID)
+}
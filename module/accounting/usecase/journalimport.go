@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"github.com/google/uuid"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	JournalImportJSONFormat = "json"
+	JournalImportCSVFormat  = "csv"
+)
+
+// journalImportFile is the JSON format of a batch, the amount of a line is taken from a signed amount or from debit
+// and credit.
+type journalImportFile struct {
+	Journals []struct {
+		Reference string `json:"reference"`
+		Date      string `json:"date"`
+		Memo      string `json:"memo"`
+		Lines     []struct {
+			AccountID         int64   `json:"accountID"`
+			Amount            float64 `json:"amount"`
+			Debit             float64 `json:"debit"`
+			Credit            float64 `json:"credit"`
+			Memo              string  `json:"memo"`
+			ExternalReference string  `json:"externalReference"`
+		} `json:"lines"`
+	} `json:"journals"`
+}
+
+func (w *writer) ImportJournals(ctx context.Context, userID uuid.UUID, batchID string, format string, mode string, file io.Reader) (journalImport domain.JournalImport, err error) {
+	switch format {
+	case JournalImportJSONFormat:
+		journalImport, err = parseJournalImportJSON(file)
+	case JournalImportCSVFormat:
+		journalImport, err = parseJournalImportCSV(file)
+	default:
+		err = errors.PropagateWithCode(
+			fmt.Errorf("unknown format %s", format),
+			sql.EcodeJournalImportInvalid,
+			fmt.Sprintf("Format must be %s or %s", JournalImportJSONFormat, JournalImportCSVFormat),
+		)
+	}
+
+	if err != nil {
+		return
+	}
+
+	journalImport.BatchID = batchID
+	journalImport.Mode = mode
+
+	err = w.AccountingSQL.ImportJournals(ctx, userID, &journalImport)
+
+	return
+}
+
+func (r *reader) GetJournalImportByBatchID(ctx context.Context, batchID string) (journalImport domain.JournalImport, err error) {
+	return r.AccountingSQL.GetJournalImportByBatchID(ctx, batchID)
+}
+
+// parseJournalImportDate takes a date as 2006-01-02 or as RFC 3339.
+func parseJournalImportDate(value string) (date time.Time, err error) {
+	value = strings.TrimSpace(value)
+	if date, err = time.Parse("2006-01-02", value); err == nil {
+		return
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseJournalImportJSON(file io.Reader) (journalImport domain.JournalImport, err error) {
+	var content journalImportFile
+
+	if err = json.NewDecoder(file).Decode(&content); err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeJournalImportInvalid, "Failed on parse journal import json")
+		return
+	}
+
+	for i, item := range content.Journals {
+		row := int64(i + 1)
+		journal := domain.JournalImportJournal{Row: row, Reference: strings.TrimSpace(item.Reference), Memo: item.Memo}
+
+		if item.Date == "" {
+			journalImport.Errors = append(journalImport.Errors, domain.JournalImportError{Row: row, Reference: journal.Reference, Message: "Date is required"})
+		} else {
+			date, parseErr := parseJournalImportDate(item.Date)
+			if parseErr != nil {
+				journalImport.Errors = append(journalImport.Errors, domain.JournalImportError{Row: row, Reference: journal.Reference, Message: "Invalid date"})
+			}
+
+			journal.TransDate = date
+		}
+
+		for _, line := range item.Lines {
+			journal.Lines = append(journal.Lines, domain.JournalImportLine{
+				Row:               row,
+				AccountID:         line.AccountID,
+				Amount:            line.Amount + line.Debit - line.Credit,
+				Memo:              line.Memo,
+				ExternalReference: line.ExternalReference,
+			})
+		}
+
+		journalImport.Journals = append(journalImport.Journals, journal)
+	}
+
+	return
+}
+
+// parseJournalImportCSV reads a batch from a CSV with a header row and a row per line. Lines are grouped into
+// journals by the reference column, the date and memo of a journal are taken from its first line. The amount is
+// taken from a signed amount column or from debit and credit columns, line_memo and external_reference are optional.
+func parseJournalImportCSV(file io.Reader) (journalImport domain.JournalImport, err error) {
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err = errors.PropagateWithCode(err, sql.EcodeParseCSVFailed, "Failed on read csv")
+		return
+	}
+
+	if len(records) == 0 {
+		err = errors.PropagateWithCode(fmt.Errorf("empty csv"), sql.EcodeParseCSVFailed, "CSV is empty")
+		return
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	_, hasReference := columns["reference"]
+	_, hasDate := columns["date"]
+	_, hasAccountID := columns["account_id"]
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	_, hasCredit := columns["credit"]
+
+	if !hasReference || !hasDate || !hasAccountID || (!hasAmount && !hasDebit && !hasCredit) {
+		err = errors.PropagateWithCode(
+			fmt.Errorf("invalid csv header"),
+			sql.EcodeParseCSVFailed,
+			"CSV header must have reference, date, account_id and amount or debit and credit columns",
+		)
+		return
+	}
+
+	text := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	number := func(record []string, column string) (float64, error) {
+		if value := text(record, column); value != "" {
+			return strconv.ParseFloat(value, 64)
+		}
+
+		return 0, nil
+	}
+
+	journalIndex := make(map[string]int)
+
+	for i, record := range records[1:] {
+		row := int64(i + 2)
+		reference := text(record, "reference")
+
+		report := func(message string) {
+			journalImport.Errors = append(journalImport.Errors, domain.JournalImportError{Row: row, Reference: reference, Message: message})
+		}
+
+		index, ok := journalIndex[reference]
+		if !ok || reference == "" {
+			journal := domain.JournalImportJournal{Row: row, Reference: reference, Memo: text(record, "memo")}
+
+			if value := text(record, "date"); value == "" {
+				report("Date is required")
+			} else {
+				date, parseErr := parseJournalImportDate(value)
+				if parseErr != nil {
+					report("Invalid date")
+				}
+
+				journal.TransDate = date
+			}
+
+			index = len(journalImport.Journals)
+			journalIndex[reference] = index
+			journalImport.Journals = append(journalImport.Journals, journal)
+		} else if value := text(record, "date"); value != "" {
+			date, parseErr := parseJournalImportDate(value)
+			if parseErr != nil || !date.Equal(journalImport.Journals[index].TransDate) {
+				report("Date differs from the first line of the journal")
+			}
+		}
+
+		accountID, parseErr := strconv.ParseInt(text(record, "account_id"), 10, 64)
+		if parseErr != nil {
+			report("Invalid account_id")
+			continue
+		}
+
+		amount, parseErr := number(record, "amount")
+		debit, debitErr := number(record, "debit")
+		credit, creditErr := number(record, "credit")
+
+		if parseErr != nil || debitErr != nil || creditErr != nil {
+			report("Invalid amount")
+			continue
+		}
+
+		journalImport.Journals[index].Lines = append(journalImport.Journals[index].Lines, domain.JournalImportLine{
+			Row:               row,
+			AccountID:         accountID,
+			Amount:            amount + debit - credit,
+			Memo:              text(record, "line_memo"),
+			ExternalReference: text(record, "external_reference"),
+		})
+	}
+
+	return
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+)
+
+func (r *reader) GetAllCreditNotes(ctx context.Context, stmt sql.CreditNoteStatement) (notes []domain.CreditNote, err error) {
+	return r.AccountingSQL.GetAllCreditNotes(ctx, stmt)
+}
+
+func (r *reader) GetCreditNoteByID(ctx context.Context, id int64) (note domain.CreditNote, err error) {
+	return r.AccountingSQL.GetCreditNoteByID(ctx, id)
+}
+
+func (r *reader) GetAllDebitNotes(ctx context.Context, stmt sql.DebitNoteStatement) (notes []domain.DebitNote, err error) {
+	return r.AccountingSQL.GetAllDebitNotes(ctx, stmt)
+}
+
+func (r *reader) GetDebitNoteByID(ctx context.Context, id int64) (note domain.DebitNote, err error) {
+	return r.AccountingSQL.GetDebitNoteByID(ctx, id)
+}
+
+func (w *writer) StoreCreditNote(ctx context.Context, userID uuid.UUID, note *domain.CreditNote) (err error) {
+	return w.AccountingSQL.StoreCreditNote(ctx, userID, note)
+}
+
+func (w *writer) VoidCreditNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.VoidCreditNoteByID(ctx, id, userID)
+}
+
+func (w *writer) StoreDebitNote(ctx context.Context, userID uuid.UUID, note *domain.DebitNote) (err error) {
+	return w.AccountingSQL.StoreDebitNote(ctx, userID, note)
+}
+
+func (w *writer) VoidDebitNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.VoidDebitNoteByID(ctx, id, userID)
+}
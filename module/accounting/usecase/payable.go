@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllVendors(ctx context.Context, stmt sql.VendorStatement) (vendors []domain.Vendor, err error) {
+	return r.AccountingSQL.GetAllVendors(ctx, stmt)
+}
+
+func (r *reader) GetVendorByID(ctx context.Context, id int64) (vendor domain.Vendor, err error) {
+	return r.AccountingSQL.GetVendorByID(ctx, id)
+}
+
+func (r *reader) GetAllPurchaseBills(ctx context.Context, stmt sql.PurchaseBillStatement) (bills []domain.PurchaseBill, err error) {
+	return r.AccountingSQL.GetAllPurchaseBills(ctx, stmt)
+}
+
+func (r *reader) GetPurchaseBillByID(ctx context.Context, id int64) (bill domain.PurchaseBill, err error) {
+	return r.AccountingSQL.GetPurchaseBillByID(ctx, id)
+}
+
+func (r *reader) GetAllPurchaseBillLines(ctx context.Context, stmt sql.PurchaseBillLineStatement) (lines []domain.PurchaseBillLine, err error) {
+	return r.AccountingSQL.GetAllPurchaseBillLines(ctx, stmt)
+}
+
+func (r *reader) GetAllVendorPayments(ctx context.Context, stmt sql.VendorPaymentStatement) (payments []domain.VendorPayment, err error) {
+	return r.AccountingSQL.GetAllVendorPayments(ctx, stmt)
+}
+
+func (r *reader) GetVendorPaymentByID(ctx context.Context, id int64) (payment domain.VendorPayment, err error) {
+	return r.AccountingSQL.GetVendorPaymentByID(ctx, id)
+}
+
+func (r *reader) GetAllPaymentAllocations(ctx context.Context, stmt sql.PaymentAllocationStatement) (allocations []domain.PaymentAllocation, err error) {
+	return r.AccountingSQL.GetAllPaymentAllocations(ctx, stmt)
+}
+
+func (r *reader) GetAllPurchaseBillsDue(ctx context.Context, through time.Time) (bills []domain.PurchaseBill, err error) {
+	return r.AccountingSQL.GetAllPurchaseBillsDue(ctx, through)
+}
+
+func (r *reader) GetPayableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error) {
+	return r.AccountingSQL.GetPayableAging(ctx, asOf)
+}
+
+func (r *reader) GetVendorAccountStatement(ctx context.Context, vendorID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error) {
+	return r.AccountingSQL.GetVendorAccountStatement(ctx, vendorID, startDate, endDate)
+}
+
+func (w *writer) StoreVendor(ctx context.Context, vendor *domain.Vendor) (err error) {
+	return w.AccountingSQL.StoreVendor(ctx, vendor)
+}
+
+func (w *writer) UpdateVendorByID(ctx context.Context, id int64, vendor *domain.Vendor) (err error) {
+	return w.AccountingSQL.UpdateVendorByID(ctx, id, vendor)
+}
+
+func (w *writer) StorePurchaseBill(ctx context.Context, userID uuid.UUID, bill *domain.PurchaseBill) (err error) {
+	return w.AccountingSQL.StorePurchaseBill(ctx, userID, bill)
+}
+
+func (w *writer) VoidPurchaseBillByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.VoidPurchaseBillByID(ctx, id, userID)
+}
+
+func (w *writer) StoreVendorPayment(ctx context.Context, userID uuid.UUID, payment *domain.VendorPayment) (err error) {
+	return w.AccountingSQL.StoreVendorPayment(ctx, userID, payment)
+}
+
+func (w *writer) AllocateVendorPayment(ctx context.Context, paymentID int64, allocations []domain.PaymentAllocation) (err error) {
+	return w.AccountingSQL.AllocateVendorPayment(ctx, paymentID, allocations)
+}
@@ -5,6 +5,11 @@ import (
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
 	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
 	qb "github.com/QuickAmethyst/monosvc/stdlibgo/querybuilder/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
+	"github.com/google/uuid"
+	"io"
+	"net/url"
+	"time"
 )
 
 type Reader interface {
@@ -15,6 +20,8 @@ type Reader interface {
 
 	GetAllAccountTypes(ctx context.Context) (result []domain.AccountClassType)
 	GetAccountClassTypeByID(ctx context.Context, id int64) (accountClassType domain.AccountClassType)
+	GetAllGeneralLedgerPreferenceDefinitions(ctx context.Context) (result []domain.GeneralLedgerPreferenceDefinition)
+	GetGeneralLedgerPreferenceDefinitionByID(ctx context.Context, id int64) (definition domain.GeneralLedgerPreferenceDefinition)
 
 	GetAllAccountGroups(ctx context.Context, stmt sql.AccountGroupStatement) (result []domain.AccountGroup, err error)
 	GetAllTopLevelAccountGroup(ctx context.Context, stmt sql.AccountGroupStatement) (result []domain.AccountGroup, err error)
@@ -24,19 +31,164 @@ type Reader interface {
 	GetAllAccounts(ctx context.Context, stmt sql.AccountStatement) (result []domain.Account, err error)
 	GetAccount(ctx context.Context, stmt sql.AccountStatement) (account domain.Account, err error)
 	GetAccountByID(ctx context.Context, id int64) (account domain.Account, err error)
+	SearchAccounts(ctx context.Context, query string, includeInactive bool, limit int) (accounts []domain.Account, err error)
+	GetAllAccountCodeFormats(ctx context.Context) (formats []domain.AccountCodeFormat, err error)
 	GetAccountBalanceByID(ctx context.Context, id int64) (balance float64, err error)
+	GetAccountNormalBalanceByID(ctx context.Context, id int64) (normalBalance string, err error)
 
 	GetAllGeneralLedgerPreferences(ctx context.Context, stmt sql.GeneralLedgerPreferenceStatement) (preferences []domain.GeneralLedgerPreference, err error)
 
 	GetFiscalYearList(ctx context.Context, stmt sql.FiscalYearStatement, p qb.Paging) (result []domain.FiscalYear, paging qb.Paging, err error)
+	GetFiscalYear(ctx context.Context, stmt sql.FiscalYearStatement) (fiscalYear domain.FiscalYear, err error)
+	GetAllFiscalPeriods(ctx context.Context, stmt sql.FiscalPeriodStatement) (periods []domain.FiscalPeriod, err error)
+	GetFiscalPeriodByID(ctx context.Context, id int64) (period domain.FiscalPeriod, err error)
+	GetAllFiscalPeriodHistoriesByPeriodID(ctx context.Context, periodID int64) (histories []domain.FiscalPeriodHistory, err error)
+	GetClosingJournal(ctx context.Context, fiscalYearID int64) (closingJournal domain.ClosingJournal, err error)
+	GetAllFiscalYearHistoriesByFiscalYearID(ctx context.Context, fiscalYearID int64) (histories []domain.FiscalYearHistory, err error)
 
 	GetAllBankAccountTypes(ctx context.Context) (bankAccountTypes []domain.BankAccountType)
 	GetBankAccountList(ctx context.Context, stmt sql.BankAccountStatement, p qb.Paging) (result []domain.BankAccount, paging qb.Paging, err error)
 	GetBankAccount(ctx context.Context, stmt sql.BankAccountStatement) (bankAccount domain.BankAccount, err error)
+
+	ExportChartOfAccounts(ctx context.Context, format string, w io.Writer) (err error)
+	GetAccountTree(ctx context.Context, withBalances bool) (classes []domain.AccountTreeClass, err error)
+	GetAllChartOfAccountsTemplates() (templates []domain.ChartOfAccountsTemplate, err error)
+	ExportStandardAuditFile(ctx context.Context, startDate time.Time, endDate time.Time, w io.Writer) (err error)
+
+	GetAllAttachments(ctx context.Context, stmt sql.AttachmentStatement) (attachments []domain.Attachment, err error)
+	GetAttachmentByID(ctx context.Context, id uuid.UUID) (attachment domain.Attachment, err error)
+	GetAttachmentDownloadURL(attachment domain.Attachment) string
+	OpenAttachment(ctx context.Context, id uuid.UUID, query url.Values) (attachment domain.Attachment, body io.ReadCloser, err error)
+
+	GetJournal(ctx context.Context, stmt sql.JournalStatement) (journal domain.Journal, err error)
+	GetJournalByID(ctx context.Context, id uuid.UUID) (journal domain.Journal, err error)
+	GetAllJournalVersions(ctx context.Context, journalID uuid.UUID) (versions []domain.JournalVersion, err error)
+	GetJournalImportByBatchID(ctx context.Context, batchID string) (journalImport domain.JournalImport, err error)
+	SearchGeneralLedgers(ctx context.Context, stmt sql.GeneralLedgerStatement, search string, p qb.Paging) (gls []domain.GeneralLedger, paging qb.Paging, err error)
+	GetAllJournalNumberFormats(ctx context.Context) (formats []domain.JournalNumberFormat, err error)
+	GetJournalNumberFormat(ctx context.Context, stmt sql.JournalNumberFormatStatement) (format domain.JournalNumberFormat, err error)
+
+	GetJournalDraftList(ctx context.Context, stmt sql.JournalDraftStatement, p qb.Paging) (result []domain.JournalDraft, paging qb.Paging, err error)
+	GetJournalDraftByID(ctx context.Context, id uuid.UUID) (draft domain.JournalDraft, err error)
+	GetAllJournalDraftLinesByDraftID(ctx context.Context, draftID uuid.UUID) (lines []domain.JournalDraftLine, err error)
+	GetAllJournalDraftReviewsByDraftID(ctx context.Context, draftID uuid.UUID) (reviews []domain.JournalDraftReview, err error)
+	GetRequiredApprovalsByDraftID(ctx context.Context, draftID uuid.UUID) (requiredApprovals int64, err error)
+
+	GetAllApprovalRules(ctx context.Context, stmt sql.ApprovalRuleStatement) (rules []domain.ApprovalRule, err error)
+
+	GetAllBudgets(ctx context.Context, stmt sql.BudgetStatement) (budgets []domain.Budget, err error)
+	GetBudgetByID(ctx context.Context, id int64) (budget domain.Budget, err error)
+	GetAllBudgetLines(ctx context.Context, stmt sql.BudgetLineStatement) (lines []domain.BudgetLine, err error)
+	GetBudgetVsActual(ctx context.Context, budgetID int64, fromPeriodID int64, toPeriodID int64) (report domain.BudgetVsActual, err error)
+
+	GetAllAmortizationSchedules(ctx context.Context, stmt sql.AmortizationScheduleStatement) (schedules []domain.AmortizationSchedule, err error)
+	GetAmortizationScheduleByID(ctx context.Context, id int64) (schedule domain.AmortizationSchedule, err error)
+	GetAllAmortizationEntries(ctx context.Context, stmt sql.AmortizationEntryStatement) (entries []domain.AmortizationEntry, err error)
+	GetAmortizationPlan(schedule domain.AmortizationSchedule) (plan []domain.AmortizationPlanLine)
+
+	GetAllAssetCategories(ctx context.Context, stmt sql.AssetCategoryStatement) (categories []domain.AssetCategory, err error)
+	GetAssetCategoryByID(ctx context.Context, id int64) (category domain.AssetCategory, err error)
+	GetAllFixedAssets(ctx context.Context, stmt sql.FixedAssetStatement) (assets []domain.FixedAsset, err error)
+	GetFixedAssetByID(ctx context.Context, id int64) (asset domain.FixedAsset, err error)
+	GetAllDepreciationRuns(ctx context.Context, stmt sql.DepreciationRunStatement) (runs []domain.DepreciationRun, err error)
+	GetAllDepreciationEntries(ctx context.Context, stmt sql.DepreciationEntryStatement) (entries []domain.DepreciationEntry, err error)
+	GetFixedAssetRegister(ctx context.Context, asOf time.Time) (rows []domain.FixedAssetRegisterRow, err error)
+
+	GetAllCustomers(ctx context.Context, stmt sql.CustomerStatement) (customers []domain.Customer, err error)
+	GetCustomerByID(ctx context.Context, id int64) (customer domain.Customer, err error)
+	GetAllSalesInvoices(ctx context.Context, stmt sql.SalesInvoiceStatement) (invoices []domain.SalesInvoice, err error)
+	GetSalesInvoiceByID(ctx context.Context, id int64) (invoice domain.SalesInvoice, err error)
+	GetAllSalesInvoiceLines(ctx context.Context, stmt sql.SalesInvoiceLineStatement) (lines []domain.SalesInvoiceLine, err error)
+	GetAllCustomerReceipts(ctx context.Context, stmt sql.CustomerReceiptStatement) (receipts []domain.CustomerReceipt, err error)
+	GetCustomerReceiptByID(ctx context.Context, id int64) (receipt domain.CustomerReceipt, err error)
+	GetAllReceiptAllocations(ctx context.Context, stmt sql.ReceiptAllocationStatement) (allocations []domain.ReceiptAllocation, err error)
+	GetReceivableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error)
+	GetCustomerAccountStatement(ctx context.Context, customerID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error)
+	GetAllVendors(ctx context.Context, stmt sql.VendorStatement) (vendors []domain.Vendor, err error)
+	GetVendorByID(ctx context.Context, id int64) (vendor domain.Vendor, err error)
+	GetAllPurchaseBills(ctx context.Context, stmt sql.PurchaseBillStatement) (bills []domain.PurchaseBill, err error)
+	GetPurchaseBillByID(ctx context.Context, id int64) (bill domain.PurchaseBill, err error)
+	GetAllPurchaseBillLines(ctx context.Context, stmt sql.PurchaseBillLineStatement) (lines []domain.PurchaseBillLine, err error)
+	GetAllVendorPayments(ctx context.Context, stmt sql.VendorPaymentStatement) (payments []domain.VendorPayment, err error)
+	GetVendorPaymentByID(ctx context.Context, id int64) (payment domain.VendorPayment, err error)
+	GetAllPaymentAllocations(ctx context.Context, stmt sql.PaymentAllocationStatement) (allocations []domain.PaymentAllocation, err error)
+	GetAllPurchaseBillsDue(ctx context.Context, through time.Time) (bills []domain.PurchaseBill, err error)
+	GetPayableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error)
+	GetVendorAccountStatement(ctx context.Context, vendorID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error)
+	GetAllCreditNotes(ctx context.Context, stmt sql.CreditNoteStatement) (notes []domain.CreditNote, err error)
+	GetCreditNoteByID(ctx context.Context, id int64) (note domain.CreditNote, err error)
+	GetAllDebitNotes(ctx context.Context, stmt sql.DebitNoteStatement) (notes []domain.DebitNote, err error)
+	GetDebitNoteByID(ctx context.Context, id int64) (note domain.DebitNote, err error)
+	GetAllCompanies(ctx context.Context, stmt sql.CompanyStatement) (companies []domain.Company, err error)
+	GetCompanyByID(ctx context.Context, id int64) (company domain.Company, err error)
+	GetAllCompaniesByUserID(ctx context.Context, userID uuid.UUID) (companies []domain.Company, err error)
+	HasCompanyAccess(ctx context.Context, userID uuid.UUID, companyID int64) (hasAccess bool, err error)
+	GetAllGroupAccounts(ctx context.Context, stmt sql.GroupAccountStatement) (groupAccounts []domain.GroupAccount, err error)
+	GetGroupAccountByID(ctx context.Context, id int64) (groupAccount domain.GroupAccount, err error)
+	GetConsolidatedTrialBalance(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedTrialBalance, err error)
+	GetConsolidatedBalanceSheet(ctx context.Context, companyIDs []int64, asOf time.Time) (report domain.ConsolidatedBalanceSheet, err error)
+	GetAllIntercompanyTransactions(ctx context.Context, stmt sql.IntercompanyTransactionStatement) (transactions []domain.IntercompanyTransaction, err error)
+	GetIntercompanyTransactionByID(ctx context.Context, id int64) (transaction domain.IntercompanyTransaction, err error)
+	GetIntercompanyReconciliation(ctx context.Context, companyIDs []int64, asOf time.Time) (balances []domain.IntercompanyBalance, err error)
+	GetAllAuditLogs(ctx context.Context, stmt sql.AuditLogStatement) (logs []domain.AuditLog, err error)
+	VerifyAuditLog(ctx context.Context) (verification domain.AuditLogVerification, err error)
+	VerifyLedger(ctx context.Context) (verification domain.LedgerVerification, err error)
 }
 
 type reader struct {
 	AccountingSQL sql.SQL
+	Storage       storage.Storage
+	Signer        storage.Signer
+
+	StandardAuditFile StandardAuditFileOptions
+}
+
+func (r *reader) GetJournal(ctx context.Context, stmt sql.JournalStatement) (journal domain.Journal, err error) {
+	return r.AccountingSQL.GetJournal(ctx, stmt)
+}
+
+func (r *reader) GetJournalByID(ctx context.Context, id uuid.UUID) (journal domain.Journal, err error) {
+	return r.AccountingSQL.GetJournalByID(ctx, id)
+}
+
+func (r *reader) GetAllJournalVersions(ctx context.Context, journalID uuid.UUID) (versions []domain.JournalVersion, err error) {
+	return r.AccountingSQL.GetAllJournalVersions(ctx, journalID)
+}
+
+func (r *reader) SearchGeneralLedgers(ctx context.Context, stmt sql.GeneralLedgerStatement, search string, p qb.Paging) (gls []domain.GeneralLedger, paging qb.Paging, err error) {
+	return r.AccountingSQL.SearchGeneralLedgers(ctx, stmt, search, p)
+}
+
+func (r *reader) GetAllJournalNumberFormats(ctx context.Context) (formats []domain.JournalNumberFormat, err error) {
+	return r.AccountingSQL.GetAllJournalNumberFormats(ctx)
+}
+
+func (r *reader) GetJournalNumberFormat(ctx context.Context, stmt sql.JournalNumberFormatStatement) (format domain.JournalNumberFormat, err error) {
+	return r.AccountingSQL.GetJournalNumberFormat(ctx, stmt)
+}
+
+func (r *reader) GetJournalDraftList(ctx context.Context, stmt sql.JournalDraftStatement, p qb.Paging) (result []domain.JournalDraft, paging qb.Paging, err error) {
+	return r.AccountingSQL.GetJournalDraftList(ctx, stmt, p)
+}
+
+func (r *reader) GetJournalDraftByID(ctx context.Context, id uuid.UUID) (draft domain.JournalDraft, err error) {
+	return r.AccountingSQL.GetJournalDraftByID(ctx, id)
+}
+
+func (r *reader) GetAllJournalDraftLinesByDraftID(ctx context.Context, draftID uuid.UUID) (lines []domain.JournalDraftLine, err error) {
+	return r.AccountingSQL.GetAllJournalDraftLinesByDraftID(ctx, draftID)
+}
+
+func (r *reader) GetAllJournalDraftReviewsByDraftID(ctx context.Context, draftID uuid.UUID) (reviews []domain.JournalDraftReview, err error) {
+	return r.AccountingSQL.GetAllJournalDraftReviewsByDraftID(ctx, draftID)
+}
+
+func (r *reader) GetRequiredApprovalsByDraftID(ctx context.Context, draftID uuid.UUID) (requiredApprovals int64, err error) {
+	return r.AccountingSQL.GetRequiredApprovalsByDraftID(ctx, draftID)
+}
+
+func (r *reader) GetAllApprovalRules(ctx context.Context, stmt sql.ApprovalRuleStatement) (rules []domain.ApprovalRule, err error) {
+	return r.AccountingSQL.GetAllApprovalRules(ctx, stmt)
 }
 
 func (r *reader) GetAccountClassBalanceByID(ctx context.Context, id int64) (balance float64, err error) {
@@ -47,6 +199,10 @@ func (r *reader) GetAccountBalanceByID(ctx context.Context, id int64) (balance f
 	return r.AccountingSQL.GetAccountBalanceByID(ctx, id)
 }
 
+func (r *reader) GetAccountNormalBalanceByID(ctx context.Context, id int64) (normalBalance string, err error) {
+	return r.AccountingSQL.GetAccountNormalBalanceByID(ctx, id)
+}
+
 func (r *reader) GetAllBankAccountTypes(ctx context.Context) (bankAccountTypes []domain.BankAccountType) {
 	return r.AccountingSQL.GetAllBankAccountTypes(ctx)
 }
@@ -63,6 +219,30 @@ func (r *reader) GetFiscalYearList(ctx context.Context, stmt sql.FiscalYearState
 	return r.AccountingSQL.GetFiscalYearList(ctx, stmt, p)
 }
 
+func (r *reader) GetFiscalYear(ctx context.Context, stmt sql.FiscalYearStatement) (fiscalYear domain.FiscalYear, err error) {
+	return r.AccountingSQL.GetFiscalYear(ctx, stmt)
+}
+
+func (r *reader) GetAllFiscalPeriods(ctx context.Context, stmt sql.FiscalPeriodStatement) (periods []domain.FiscalPeriod, err error) {
+	return r.AccountingSQL.GetAllFiscalPeriods(ctx, stmt)
+}
+
+func (r *reader) GetFiscalPeriodByID(ctx context.Context, id int64) (period domain.FiscalPeriod, err error) {
+	return r.AccountingSQL.GetFiscalPeriodByID(ctx, id)
+}
+
+func (r *reader) GetAllFiscalPeriodHistoriesByPeriodID(ctx context.Context, periodID int64) (histories []domain.FiscalPeriodHistory, err error) {
+	return r.AccountingSQL.GetAllFiscalPeriodHistoriesByPeriodID(ctx, periodID)
+}
+
+func (r *reader) GetClosingJournal(ctx context.Context, fiscalYearID int64) (closingJournal domain.ClosingJournal, err error) {
+	return r.AccountingSQL.GetClosingJournal(ctx, fiscalYearID)
+}
+
+func (r *reader) GetAllFiscalYearHistoriesByFiscalYearID(ctx context.Context, fiscalYearID int64) (histories []domain.FiscalYearHistory, err error) {
+	return r.AccountingSQL.GetAllFiscalYearHistoriesByFiscalYearID(ctx, fiscalYearID)
+}
+
 func (r *reader) GetAllGeneralLedgerPreferences(ctx context.Context, stmt sql.GeneralLedgerPreferenceStatement) (preferences []domain.GeneralLedgerPreference, err error) {
 	return r.AccountingSQL.GetAllGeneralLedgerPreferences(ctx, stmt)
 }
@@ -79,6 +259,18 @@ func (r *reader) GetAccountByID(ctx context.Context, id int64) (account domain.A
 	return r.AccountingSQL.GetAccountByID(ctx, id)
 }
 
+func (r *reader) SearchAccounts(ctx context.Context, query string, includeInactive bool, limit int) (accounts []domain.Account, err error) {
+	return r.AccountingSQL.SearchAccounts(ctx, query, includeInactive, limit)
+}
+
+func (r *reader) GetAllAccountCodeFormats(ctx context.Context) (formats []domain.AccountCodeFormat, err error) {
+	return r.AccountingSQL.GetAllAccountCodeFormats(ctx)
+}
+
+func (r *reader) GetAccountTree(ctx context.Context, withBalances bool) (classes []domain.AccountTreeClass, err error) {
+	return r.AccountingSQL.GetAccountTree(ctx, withBalances)
+}
+
 func (r *reader) GetAllTopLevelAccountGroup(ctx context.Context, stmt sql.AccountGroupStatement) (result []domain.AccountGroup, err error) {
 	stmt.ParentIDIsNULL = true
 	return r.GetAllAccountGroups(ctx, stmt)
@@ -104,6 +296,14 @@ func (r *reader) GetAccountClassTypeByID(ctx context.Context, id int64) (account
 	return r.AccountingSQL.GetAccountClassTypeByID(ctx, id)
 }
 
+func (r *reader) GetAllGeneralLedgerPreferenceDefinitions(ctx context.Context) (result []domain.GeneralLedgerPreferenceDefinition) {
+	return r.AccountingSQL.GetAllGeneralLedgerPreferenceDefinitions(ctx)
+}
+
+func (r *reader) GetGeneralLedgerPreferenceDefinitionByID(ctx context.Context, id int64) (definition domain.GeneralLedgerPreferenceDefinition) {
+	return r.AccountingSQL.GetGeneralLedgerPreferenceDefinitionByID(ctx, id)
+}
+
 func (r *reader) GetAllAccountClasses(ctx context.Context, stmt sql.AccountClassStatement) (result []domain.AccountClass, err error) {
 	return r.AccountingSQL.GetAllAccountClasses(ctx, stmt)
 }
@@ -117,5 +317,5 @@ func (r *reader) GetAccountClassByID(ctx context.Context, id int64) (accountClas
 }
 
 func NewReader(opt *Options) Reader {
-	return &reader{opt.AccountingSQL}
+	return &reader{opt.AccountingSQL, opt.Storage, opt.Signer, opt.StandardAuditFile}
 }
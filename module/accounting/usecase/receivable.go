@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (r *reader) GetAllCustomers(ctx context.Context, stmt sql.CustomerStatement) (customers []domain.Customer, err error) {
+	return r.AccountingSQL.GetAllCustomers(ctx, stmt)
+}
+
+func (r *reader) GetCustomerByID(ctx context.Context, id int64) (customer domain.Customer, err error) {
+	return r.AccountingSQL.GetCustomerByID(ctx, id)
+}
+
+func (r *reader) GetAllSalesInvoices(ctx context.Context, stmt sql.SalesInvoiceStatement) (invoices []domain.SalesInvoice, err error) {
+	return r.AccountingSQL.GetAllSalesInvoices(ctx, stmt)
+}
+
+func (r *reader) GetSalesInvoiceByID(ctx context.Context, id int64) (invoice domain.SalesInvoice, err error) {
+	return r.AccountingSQL.GetSalesInvoiceByID(ctx, id)
+}
+
+func (r *reader) GetAllSalesInvoiceLines(ctx context.Context, stmt sql.SalesInvoiceLineStatement) (lines []domain.SalesInvoiceLine, err error) {
+	return r.AccountingSQL.GetAllSalesInvoiceLines(ctx, stmt)
+}
+
+func (r *reader) GetAllCustomerReceipts(ctx context.Context, stmt sql.CustomerReceiptStatement) (receipts []domain.CustomerReceipt, err error) {
+	return r.AccountingSQL.GetAllCustomerReceipts(ctx, stmt)
+}
+
+func (r *reader) GetCustomerReceiptByID(ctx context.Context, id int64) (receipt domain.CustomerReceipt, err error) {
+	return r.AccountingSQL.GetCustomerReceiptByID(ctx, id)
+}
+
+func (r *reader) GetAllReceiptAllocations(ctx context.Context, stmt sql.ReceiptAllocationStatement) (allocations []domain.ReceiptAllocation, err error) {
+	return r.AccountingSQL.GetAllReceiptAllocations(ctx, stmt)
+}
+
+func (r *reader) GetReceivableAging(ctx context.Context, asOf time.Time) (report domain.AgingReport, err error) {
+	return r.AccountingSQL.GetReceivableAging(ctx, asOf)
+}
+
+func (r *reader) GetCustomerAccountStatement(ctx context.Context, customerID int64, startDate time.Time, endDate time.Time) (statement domain.PartyStatement, err error) {
+	return r.AccountingSQL.GetCustomerAccountStatement(ctx, customerID, startDate, endDate)
+}
+
+func (w *writer) StoreCustomer(ctx context.Context, customer *domain.Customer) (err error) {
+	return w.AccountingSQL.StoreCustomer(ctx, customer)
+}
+
+func (w *writer) UpdateCustomerByID(ctx context.Context, id int64, customer *domain.Customer) (err error) {
+	return w.AccountingSQL.UpdateCustomerByID(ctx, id, customer)
+}
+
+func (w *writer) StoreSalesInvoice(ctx context.Context, userID uuid.UUID, invoice *domain.SalesInvoice) (err error) {
+	return w.AccountingSQL.StoreSalesInvoice(ctx, userID, invoice)
+}
+
+func (w *writer) VoidSalesInvoiceByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.VoidSalesInvoiceByID(ctx, id, userID)
+}
+
+func (w *writer) StoreCustomerReceipt(ctx context.Context, userID uuid.UUID, receipt *domain.CustomerReceipt) (err error) {
+	return w.AccountingSQL.StoreCustomerReceipt(ctx, userID, receipt)
+}
+
+func (w *writer) AllocateCustomerReceipt(ctx context.Context, receiptID int64, allocations []domain.ReceiptAllocation) (err error) {
+	return w.AccountingSQL.AllocateCustomerReceipt(ctx, receiptID, allocations)
+}
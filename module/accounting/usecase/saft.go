@@ -0,0 +1,555 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/errors"
+	"io"
+	"math"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	StandardAuditFileDownloadPath = "/standard-audit-file"
+	StandardAuditFileVersion      = "2.00"
+	StandardAuditFileNamespace    = "urn:OECD:StandardAuditFile-Tax:2.00"
+
+	standardAuditFileSoftwareCompanyName = "QuickAmethyst"
+	standardAuditFileSoftwareID          = "monosvc"
+	standardAuditFileSoftwareVersion     = "1.0"
+
+	// standardAuditFileAccountingBasis is A, the general ledger accounting of the company.
+	standardAuditFileAccountingBasis = "A"
+	standardAuditFileAccountType     = "GL"
+	standardAuditFileTaxType         = "VAT"
+
+	// xmllintValidationErrorCode is the exit code of xmllint for a document that does not validate.
+	xmllintValidationErrorCode = 3
+)
+
+// StandardAuditFileOptions fills in the SAF-T header fields the ledger does not keep.
+type StandardAuditFileOptions struct {
+	// CurrencyCode is the ISO 4217 code of the amounts of the ledger.
+	CurrencyCode string
+	// CountryCode is the ISO 3166-1 alpha-2 code of the tax authority, left out of the header when empty.
+	CountryCode string
+	// SchemaPath is the published SAF-T schema every export is validated against before it is handed out,
+	// no export is made without it.
+	SchemaPath string
+	// Validator is the xmllint binary that validates an export against the schema, xmllint on the PATH when empty.
+	Validator string
+}
+
+var (
+	currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryCodePattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+var journalTypeNames = map[int64]string{
+	sql.GeneralJournalType:      "General",
+	sql.BankDepositJournalType:  "Bank deposit",
+	sql.ClosingJournalType:      "Closing",
+	sql.OpeningJournalType:      "Opening",
+	sql.AmortizationJournalType: "Amortization",
+	sql.FixedAssetJournalType:   "Fixed asset",
+	sql.SalesInvoiceJournalType: "Sales invoice",
+	sql.PurchaseBillJournalType: "Purchase bill",
+	sql.BankPaymentJournalType:  "Bank payment",
+	sql.CreditNoteJournalType:   "Credit note",
+	sql.DebitNoteJournalType:    "Debit note",
+	sql.IntercompanyJournalType: "Intercompany",
+}
+
+type standardAuditFileDate time.Time
+
+func (d standardAuditFileDate) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format("2006-01-02")), nil
+}
+
+type standardAuditFileAmount float64
+
+func (a standardAuditFileAmount) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(roundStandardAuditFileAmount(float64(a)), 'f', 2, 64)), nil
+}
+
+func roundStandardAuditFileAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+type standardAuditFile struct {
+	XMLName              xml.Name `xml:"urn:OECD:StandardAuditFile-Tax:2.00 AuditFile"`
+	Header               standardAuditFileHeader
+	MasterFiles          standardAuditFileMasterFiles
+	GeneralLedgerEntries standardAuditFileEntries
+}
+
+type standardAuditFileHeader struct {
+	AuditFileVersion     string
+	AuditFileCountry     string `xml:",omitempty"`
+	AuditFileDateCreated standardAuditFileDate
+	SoftwareCompanyName  string
+	SoftwareID           string
+	SoftwareVersion      string
+	Company              standardAuditFileCompany
+	DefaultCurrencyCode  string
+	SelectionCriteria    standardAuditFileSelectionCriteria
+	TaxAccountingBasis   string
+}
+
+type standardAuditFileCompany struct {
+	RegistrationNumber string
+	Name               string
+}
+
+type standardAuditFileSelectionCriteria struct {
+	SelectionStartDate standardAuditFileDate
+	SelectionEndDate   standardAuditFileDate
+}
+
+type standardAuditFileMasterFiles struct {
+	GeneralLedgerAccounts *standardAuditFileAccounts
+	Customers             *standardAuditFileCustomers
+	Suppliers             *standardAuditFileSuppliers
+	TaxTable              *standardAuditFileTaxTable
+}
+
+type standardAuditFileAccounts struct {
+	Account []standardAuditFileAccount
+}
+
+// standardAuditFileAccount carries each balance as either a debit or a credit, never both.
+type standardAuditFileAccount struct {
+	AccountID            string
+	AccountDescription   string
+	AccountType          string
+	OpeningDebitBalance  *standardAuditFileAmount `xml:",omitempty"`
+	OpeningCreditBalance *standardAuditFileAmount `xml:",omitempty"`
+	ClosingDebitBalance  *standardAuditFileAmount `xml:",omitempty"`
+	ClosingCreditBalance *standardAuditFileAmount `xml:",omitempty"`
+}
+
+type standardAuditFileParty struct {
+	Name            string
+	Address         *standardAuditFileAddress         `xml:",omitempty"`
+	TaxRegistration *standardAuditFileTaxRegistration `xml:",omitempty"`
+}
+
+// standardAuditFileAddress keeps the free text address of a party as its street name.
+type standardAuditFileAddress struct {
+	StreetName string
+}
+
+type standardAuditFileTaxRegistration struct {
+	TaxRegistrationNumber string
+}
+
+type standardAuditFileCustomers struct {
+	Customer []standardAuditFileCustomer
+}
+
+type standardAuditFileCustomer struct {
+	standardAuditFileParty
+	CustomerID string
+}
+
+type standardAuditFileSuppliers struct {
+	Supplier []standardAuditFileSupplier
+}
+
+type standardAuditFileSupplier struct {
+	standardAuditFileParty
+	SupplierID string
+}
+
+type standardAuditFileTaxTable struct {
+	TaxTableEntry []standardAuditFileTaxTableEntry
+}
+
+type standardAuditFileTaxTableEntry struct {
+	TaxType        string
+	Description    string
+	TaxCodeDetails []standardAuditFileTaxCodeDetails
+}
+
+type standardAuditFileTaxCodeDetails struct {
+	TaxCode       string
+	Description   string
+	TaxPercentage standardAuditFileAmount
+}
+
+type standardAuditFileEntries struct {
+	NumberOfEntries int
+	TotalDebit      standardAuditFileAmount
+	TotalCredit     standardAuditFileAmount
+	Journal         []standardAuditFileJournal
+}
+
+type standardAuditFileJournal struct {
+	JournalID   string
+	Description string
+	Type        string
+	Transaction []standardAuditFileTransaction
+}
+
+type standardAuditFileTransaction struct {
+	TransactionID   string
+	Period          int
+	PeriodYear      int
+	TransactionDate standardAuditFileDate
+	Description     string
+	SystemEntryDate standardAuditFileDate
+	GLPostingDate   standardAuditFileDate
+	Line            []standardAuditFileLine
+}
+
+type standardAuditFileLine struct {
+	RecordID         string
+	AccountID        string
+	SourceDocumentID string `xml:",omitempty"`
+	Description      string
+	DebitAmount      *standardAuditFileAmountStructure `xml:",omitempty"`
+	CreditAmount     *standardAuditFileAmountStructure `xml:",omitempty"`
+}
+
+type standardAuditFileAmountStructure struct {
+	Amount standardAuditFileAmount
+}
+
+// ExportStandardAuditFile writes the SAF-T file of the company of the request for a date range. The file is
+// checked before anything is written, so a failed export leaves w untouched.
+func (r *reader) ExportStandardAuditFile(ctx context.Context, startDate time.Time, endDate time.Time, w io.Writer) (err error) {
+	file, err := r.AccountingSQL.GetStandardAuditFile(ctx, startDate, endDate)
+	if err != nil {
+		return
+	}
+
+	if r.StandardAuditFile.SchemaPath == "" {
+		return errors.PropagateWithCode(
+			fmt.Errorf("standard audit file schema not configured"),
+			sql.EcodeStandardAuditFileSchemaNotConfigured,
+			"Standard audit file schema is not configured",
+		)
+	}
+
+	document := newStandardAuditFile(file, r.StandardAuditFile, time.Now())
+	if err = validateStandardAuditFile(document); err != nil {
+		return
+	}
+
+	var body bytes.Buffer
+	if _, err = io.WriteString(&body, xml.Header); err != nil {
+		return errors.PropagateWithCode(err, sql.EcodeExportStandardAuditFileFailed, "Failed on export standard audit file")
+	}
+
+	encoder := xml.NewEncoder(&body)
+	encoder.Indent("", "  ")
+	if err = encoder.Encode(document); err != nil {
+		return errors.PropagateWithCode(err, sql.EcodeExportStandardAuditFileFailed, "Failed on export standard audit file")
+	}
+
+	if err = validateStandardAuditFileSchema(ctx, r.StandardAuditFile, body.Bytes()); err != nil {
+		return
+	}
+
+	if _, err = body.WriteTo(w); err != nil {
+		return errors.PropagateWithCode(err, sql.EcodeExportStandardAuditFileFailed, "Failed on export standard audit file")
+	}
+
+	return
+}
+
+// validateStandardAuditFileSchema runs the export through xmllint against the published schema. A file xmllint
+// rejects is invalid, while xmllint failing otherwise, such as on a schema it can not load, fails the export.
+func validateStandardAuditFileSchema(ctx context.Context, opt StandardAuditFileOptions, file []byte) (err error) {
+	validator := opt.Validator
+	if validator == "" {
+		validator = "xmllint"
+	}
+
+	cmd := exec.CommandContext(ctx, validator, "--noout", "--schema", opt.SchemaPath, "-")
+	cmd.Stdin = bytes.NewReader(file)
+
+	output, err := cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == xmllintValidationErrorCode {
+		return errors.PropagateWithCode(
+			errors.ValidationErrors{{Field: "schema", Message: string(bytes.TrimSpace(output))}},
+			sql.EcodeStandardAuditFileInvalid,
+			"Standard audit file does not validate against its schema",
+		)
+	}
+
+	if err != nil {
+		return errors.PropagateWithCode(
+			fmt.Errorf("%w: %s", err, bytes.TrimSpace(output)),
+			sql.EcodeExportStandardAuditFileFailed,
+			"Failed on validate standard audit file",
+		)
+	}
+
+	return
+}
+
+// newStandardAuditFile lays out the SAF-T content with one journal per journal type. Accounts are identified by
+// their code, or by their id when they have none, and the period of a transaction is its calendar month.
+func newStandardAuditFile(file domain.StandardAuditFile, opt StandardAuditFileOptions, createdAt time.Time) (document standardAuditFile) {
+	document.Header = standardAuditFileHeader{
+		AuditFileVersion:     StandardAuditFileVersion,
+		AuditFileCountry:     opt.CountryCode,
+		AuditFileDateCreated: standardAuditFileDate(createdAt),
+		SoftwareCompanyName:  standardAuditFileSoftwareCompanyName,
+		SoftwareID:           standardAuditFileSoftwareID,
+		SoftwareVersion:      standardAuditFileSoftwareVersion,
+		Company:              standardAuditFileCompany{RegistrationNumber: file.Company.Code, Name: file.Company.Name},
+		DefaultCurrencyCode:  opt.CurrencyCode,
+		SelectionCriteria: standardAuditFileSelectionCriteria{
+			SelectionStartDate: standardAuditFileDate(file.StartDate),
+			SelectionEndDate:   standardAuditFileDate(file.EndDate),
+		},
+		TaxAccountingBasis: standardAuditFileAccountingBasis,
+	}
+
+	accountIDs := make(map[int64]string, len(file.Accounts))
+	if len(file.Accounts) > 0 {
+		document.MasterFiles.GeneralLedgerAccounts = &standardAuditFileAccounts{}
+	}
+
+	for _, account := range file.Accounts {
+		accountID := strconv.FormatInt(account.ID, 10)
+		if account.Code.Valid && account.Code.String != "" {
+			accountID = account.Code.String
+		}
+
+		accountIDs[account.ID] = accountID
+		saftAccount := standardAuditFileAccount{
+			AccountID:          accountID,
+			AccountDescription: account.Name,
+			AccountType:        standardAuditFileAccountType,
+		}
+
+		saftAccount.OpeningDebitBalance, saftAccount.OpeningCreditBalance = newStandardAuditFileBalance(account.OpeningBalance)
+		saftAccount.ClosingDebitBalance, saftAccount.ClosingCreditBalance = newStandardAuditFileBalance(account.ClosingBalance)
+		document.MasterFiles.GeneralLedgerAccounts.Account = append(document.MasterFiles.GeneralLedgerAccounts.Account, saftAccount)
+	}
+
+	if len(file.Customers) > 0 {
+		document.MasterFiles.Customers = &standardAuditFileCustomers{}
+	}
+
+	for _, customer := range file.Customers {
+		document.MasterFiles.Customers.Customer = append(document.MasterFiles.Customers.Customer, standardAuditFileCustomer{
+			standardAuditFileParty: newStandardAuditFileParty(customer.Name, customer.Address.String, customer.TaxNumber.String),
+			CustomerID:             customer.Code,
+		})
+	}
+
+	if len(file.Vendors) > 0 {
+		document.MasterFiles.Suppliers = &standardAuditFileSuppliers{}
+	}
+
+	for _, vendor := range file.Vendors {
+		document.MasterFiles.Suppliers.Supplier = append(document.MasterFiles.Suppliers.Supplier, standardAuditFileSupplier{
+			standardAuditFileParty: newStandardAuditFileParty(vendor.Name, vendor.Address.String, vendor.TaxNumber.String),
+			SupplierID:             vendor.Code,
+		})
+	}
+
+	if len(file.TaxRates) > 0 {
+		entry := standardAuditFileTaxTableEntry{TaxType: standardAuditFileTaxType, Description: "Value added tax"}
+		for _, rate := range file.TaxRates {
+			entry.TaxCodeDetails = append(entry.TaxCodeDetails, newStandardAuditFileTaxCode(rate))
+		}
+
+		document.MasterFiles.TaxTable = &standardAuditFileTaxTable{TaxTableEntry: []standardAuditFileTaxTableEntry{entry}}
+	}
+
+	document.GeneralLedgerEntries = newStandardAuditFileEntries(file.Journals, accountIDs)
+
+	return
+}
+
+func newStandardAuditFileBalance(amount float64) (debit *standardAuditFileAmount, credit *standardAuditFileAmount) {
+	if amount < 0 {
+		balance := standardAuditFileAmount(-amount)
+		return nil, &balance
+	}
+
+	balance := standardAuditFileAmount(amount)
+	return &balance, nil
+}
+
+func newStandardAuditFileParty(name string, address string, taxNumber string) (party standardAuditFileParty) {
+	party.Name = name
+	if address != "" {
+		party.Address = &standardAuditFileAddress{StreetName: address}
+	}
+
+	if taxNumber != "" {
+		party.TaxRegistration = &standardAuditFileTaxRegistration{TaxRegistrationNumber: taxNumber}
+	}
+
+	return
+}
+
+// newStandardAuditFileTaxCode names a rate by its kind and percentage, S10 for a sales tax of 10%.
+func newStandardAuditFileTaxCode(rate domain.StandardAuditFileTaxRate) standardAuditFileTaxCodeDetails {
+	prefix, description := "S", "Sales tax"
+	if rate.Kind == domain.PurchaseTaxRateKind {
+		prefix, description = "P", "Purchase tax"
+	}
+
+	percentage := strconv.FormatFloat(rate.Rate, 'f', -1, 64)
+
+	return standardAuditFileTaxCodeDetails{
+		TaxCode:       prefix + percentage,
+		Description:   fmt.Sprintf("%s %s%%", description, percentage),
+		TaxPercentage: standardAuditFileAmount(rate.Rate),
+	}
+}
+
+func newStandardAuditFileEntries(journals []domain.StandardAuditFileJournal, accountIDs map[int64]string) (entries standardAuditFileEntries) {
+	var (
+		typeIDs      = make([]int64, 0)
+		transactions = make(map[int64][]standardAuditFileTransaction)
+	)
+
+	for _, journal := range journals {
+		typeName := journalTypeNames[journal.TypeID]
+		transaction := standardAuditFileTransaction{
+			TransactionID:   journal.ID.String(),
+			Period:          int(journal.TransDate.Month()),
+			PeriodYear:      journal.TransDate.Year(),
+			TransactionDate: standardAuditFileDate(journal.TransDate),
+			Description:     typeName,
+			SystemEntryDate: standardAuditFileDate(journal.CreatedAt),
+			GLPostingDate:   standardAuditFileDate(journal.TransDate),
+		}
+
+		if journal.Number.Valid && journal.Number.String != "" {
+			transaction.TransactionID = journal.Number.String
+		}
+
+		if journal.Memo.Valid && journal.Memo.String != "" {
+			transaction.Description = journal.Memo.String
+		}
+
+		for _, line := range journal.Lines {
+			saftLine := standardAuditFileLine{
+				RecordID:         line.ID.String(),
+				AccountID:        accountIDs[line.AccountID],
+				SourceDocumentID: line.ExternalReference.String,
+				Description:      transaction.Description,
+			}
+
+			if line.Memo.Valid && line.Memo.String != "" {
+				saftLine.Description = line.Memo.String
+			}
+
+			if line.Amount < 0 {
+				saftLine.CreditAmount = &standardAuditFileAmountStructure{Amount: standardAuditFileAmount(-line.Amount)}
+				entries.TotalCredit += standardAuditFileAmount(roundStandardAuditFileAmount(-line.Amount))
+			} else {
+				saftLine.DebitAmount = &standardAuditFileAmountStructure{Amount: standardAuditFileAmount(line.Amount)}
+				entries.TotalDebit += standardAuditFileAmount(roundStandardAuditFileAmount(line.Amount))
+			}
+
+			transaction.Line = append(transaction.Line, saftLine)
+		}
+
+		if _, ok := transactions[journal.TypeID]; !ok {
+			typeIDs = append(typeIDs, journal.TypeID)
+		}
+
+		transactions[journal.TypeID] = append(transactions[journal.TypeID], transaction)
+		entries.NumberOfEntries++
+	}
+
+	sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	for _, typeID := range typeIDs {
+		entries.Journal = append(entries.Journal, standardAuditFileJournal{
+			JournalID:   strconv.FormatInt(typeID, 10),
+			Description: journalTypeNames[typeID],
+			Type:        journalTypeNames[typeID],
+			Transaction: transactions[typeID],
+		})
+	}
+
+	return
+}
+
+// validateStandardAuditFile checks what the SAF-T schema requires of the content: the header codes, a name and id
+// for every party, and balanced transactions whose lines are on accounts of the master files.
+func validateStandardAuditFile(document standardAuditFile) (err error) {
+	var validationErrors errors.ValidationErrors
+
+	if !currencyCodePattern.MatchString(document.Header.DefaultCurrencyCode) {
+		validationErrors = append(validationErrors, errors.FieldError{Field: "currencyCode", Message: "Currency code must be an ISO 4217 code"})
+	}
+
+	if document.Header.AuditFileCountry != "" && !countryCodePattern.MatchString(document.Header.AuditFileCountry) {
+		validationErrors = append(validationErrors, errors.FieldError{Field: "countryCode", Message: "Country code must be an ISO 3166-1 alpha-2 code"})
+	}
+
+	if document.Header.Company.RegistrationNumber == "" || document.Header.Company.Name == "" {
+		validationErrors = append(validationErrors, errors.FieldError{Field: "company", Message: "Company code and name are required"})
+	}
+
+	if document.MasterFiles.Customers != nil {
+		for _, customer := range document.MasterFiles.Customers.Customer {
+			if customer.CustomerID == "" || customer.Name == "" {
+				validationErrors = append(validationErrors, errors.FieldError{Field: "customers", Message: fmt.Sprintf("Customer %s must have a code and a name", customer.CustomerID)})
+			}
+		}
+	}
+
+	if document.MasterFiles.Suppliers != nil {
+		for _, supplier := range document.MasterFiles.Suppliers.Supplier {
+			if supplier.SupplierID == "" || supplier.Name == "" {
+				validationErrors = append(validationErrors, errors.FieldError{Field: "vendors", Message: fmt.Sprintf("Vendor %s must have a code and a name", supplier.SupplierID)})
+			}
+		}
+	}
+
+	for _, journal := range document.GeneralLedgerEntries.Journal {
+		for _, transaction := range journal.Transaction {
+			var debit, credit float64
+
+			if len(transaction.Line) == 0 {
+				validationErrors = append(validationErrors, errors.FieldError{Field: "journals", Message: fmt.Sprintf("Transaction %s has no lines", transaction.TransactionID)})
+			}
+
+			for _, line := range transaction.Line {
+				if line.AccountID == "" {
+					validationErrors = append(validationErrors, errors.FieldError{Field: "journals", Message: fmt.Sprintf("Transaction %s has a line on an unknown account", transaction.TransactionID)})
+				}
+
+				if line.DebitAmount != nil {
+					debit += roundStandardAuditFileAmount(float64(line.DebitAmount.Amount))
+				} else {
+					credit += roundStandardAuditFileAmount(float64(line.CreditAmount.Amount))
+				}
+			}
+
+			if roundStandardAuditFileAmount(debit) != roundStandardAuditFileAmount(credit) {
+				validationErrors = append(validationErrors, errors.FieldError{Field: "journals", Message: fmt.Sprintf("Transaction %s is not balanced", transaction.TransactionID)})
+			}
+		}
+	}
+
+	if roundStandardAuditFileAmount(float64(document.GeneralLedgerEntries.TotalDebit)) != roundStandardAuditFileAmount(float64(document.GeneralLedgerEntries.TotalCredit)) {
+		validationErrors = append(validationErrors, errors.FieldError{Field: "journals", Message: "Total debit must equal total credit"})
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.PropagateWithCode(validationErrors, sql.EcodeStandardAuditFileInvalid, "Standard audit file is invalid")
+	}
+
+	return
+}
@@ -1,9 +1,16 @@
 package usecase
 
-import "github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+import (
+	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
+)
 
 type Options struct {
 	AccountingSQL sql.SQL
+	Storage       storage.Storage
+	Signer        storage.Signer
+
+	StandardAuditFile StandardAuditFileOptions
 }
 
 type Usecase interface {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"github.com/QuickAmethyst/monosvc/module/accounting/domain"
 	"github.com/QuickAmethyst/monosvc/module/accounting/repository/sql"
+	"github.com/QuickAmethyst/monosvc/stdlibgo/storage"
 	"github.com/google/uuid"
+	"io"
+	"time"
 )
 
 type Writer interface {
@@ -19,8 +22,13 @@ type Writer interface {
 	StoreAccount(ctx context.Context, account *domain.Account) (err error)
 	UpdateAccountByID(ctx context.Context, id int64, account *domain.Account) (err error)
 	DeleteAccountByID(ctx context.Context, id int64) (err error)
+	UpdateAccountCodeFormatByClassTypeID(ctx context.Context, classTypeID int64, pattern string) (err error)
+
+	ImportChartOfAccounts(ctx context.Context, format string, file io.Reader, dryRun bool) (changes []domain.ChartOfAccountsChange, err error)
+	ApplyChartOfAccountsTemplate(ctx context.Context, name string) (changes []domain.ChartOfAccountsChange, err error)
 
 	StoreTransaction(ctx context.Context, userID uuid.UUID, transaction sql.Transaction) (journal *domain.Journal, err error)
+	AmendJournalByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction sql.Transaction, reason string) (journal *domain.Journal, err error)
 
 	UpdateGeneralLedgerPreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error)
 
@@ -28,12 +36,112 @@ type Writer interface {
 	UpdateBankAccountByID(ctx context.Context, id int64, bankAccount *domain.BankAccount) (err error)
 	StoreBankDepositTransaction(ctx context.Context, userID uuid.UUID, transaction sql.BankTransaction) (bankTransaction domain.BankTransaction, err error)
 
-	StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear) (err error)
+	StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear, periodMonths int) (err error)
 	CloseFiscalYear(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	ReopenFiscalYear(ctx context.Context, id int64, userID uuid.UUID, reason string) (err error)
+
+	ImportOpeningBalances(ctx context.Context, userID uuid.UUID, rows []sql.TransactionRow) (journal *domain.Journal, err error)
+	ImportOpeningBalancesCSV(ctx context.Context, userID uuid.UUID, file io.Reader) (journal *domain.Journal, err error)
+	ImportJournals(ctx context.Context, userID uuid.UUID, batchID string, format string, mode string, file io.Reader) (journalImport domain.JournalImport, err error)
+
+	UpdateJournalNumberFormatByTypeID(ctx context.Context, typeID int64, userID uuid.UUID, format string) (err error)
+
+	StoreAttachment(ctx context.Context, userID uuid.UUID, attachment *domain.Attachment, file io.Reader) (err error)
+	DeleteAttachmentByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error)
+
+	GenerateFiscalPeriods(ctx context.Context, fiscalYearID int64, periodMonths int) (periods []domain.FiscalPeriod, err error)
+	UpdateFiscalPeriodStatusByID(ctx context.Context, id int64, userID uuid.UUID, statusID int64, reason string) (err error)
+
+	StoreJournalDraft(ctx context.Context, userID uuid.UUID, transaction sql.Transaction) (draft *domain.JournalDraft, err error)
+	UpdateJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction sql.Transaction) (draft *domain.JournalDraft, err error)
+	SubmitJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error)
+	ApproveJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error)
+	RejectJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error)
+
+	StoreApprovalRule(ctx context.Context, userID uuid.UUID, rule *domain.ApprovalRule) (err error)
+	UpdateApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID, rule *domain.ApprovalRule) (err error)
+	DeleteApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+
+	StoreBudget(ctx context.Context, userID uuid.UUID, budget *domain.Budget) (err error)
+	UpdateBudgetByID(ctx context.Context, id int64, budget *domain.Budget) (err error)
+	DeleteBudgetByID(ctx context.Context, id int64) (err error)
+	StoreBudgetLines(ctx context.Context, budgetID int64, lines []domain.BudgetLine) (err error)
+	ImportBudgetLinesCSV(ctx context.Context, budgetID int64, file io.Reader) (lines []domain.BudgetLine, err error)
+	GenerateBudgetFromActuals(ctx context.Context, userID uuid.UUID, budget *domain.Budget, sourceFiscalYearID int64, percent float64) (err error)
+
+	StoreAmortizationSchedule(ctx context.Context, userID uuid.UUID, schedule *domain.AmortizationSchedule) (err error)
+	PostDueAmortizationEntries(ctx context.Context, asOf time.Time) (entries []domain.AmortizationEntry, err error)
+	TerminateAmortizationScheduleByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, writeOffRemaining bool) (err error)
+
+	StoreAssetCategory(ctx context.Context, category *domain.AssetCategory) (err error)
+	UpdateAssetCategoryByID(ctx context.Context, id int64, category *domain.AssetCategory) (err error)
+	StoreFixedAsset(ctx context.Context, userID uuid.UUID, asset *domain.FixedAsset) (err error)
+	UpdateFixedAssetByID(ctx context.Context, id int64, asset *domain.FixedAsset) (err error)
+	RunDepreciation(ctx context.Context, userID uuid.UUID, date time.Time) (run domain.DepreciationRun, err error)
+	DisposeFixedAssetByID(ctx context.Context, id int64, userID uuid.UUID, date time.Time, proceeds float64, proceedsAccountID int64) (err error)
+
+	StoreCustomer(ctx context.Context, customer *domain.Customer) (err error)
+	UpdateCustomerByID(ctx context.Context, id int64, customer *domain.Customer) (err error)
+	StoreSalesInvoice(ctx context.Context, userID uuid.UUID, invoice *domain.SalesInvoice) (err error)
+	VoidSalesInvoiceByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreCustomerReceipt(ctx context.Context, userID uuid.UUID, receipt *domain.CustomerReceipt) (err error)
+	AllocateCustomerReceipt(ctx context.Context, receiptID int64, allocations []domain.ReceiptAllocation) (err error)
+	StoreVendor(ctx context.Context, vendor *domain.Vendor) (err error)
+	UpdateVendorByID(ctx context.Context, id int64, vendor *domain.Vendor) (err error)
+	StorePurchaseBill(ctx context.Context, userID uuid.UUID, bill *domain.PurchaseBill) (err error)
+	VoidPurchaseBillByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreVendorPayment(ctx context.Context, userID uuid.UUID, payment *domain.VendorPayment) (err error)
+	AllocateVendorPayment(ctx context.Context, paymentID int64, allocations []domain.PaymentAllocation) (err error)
+	StoreCreditNote(ctx context.Context, userID uuid.UUID, note *domain.CreditNote) (err error)
+	VoidCreditNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreDebitNote(ctx context.Context, userID uuid.UUID, note *domain.DebitNote) (err error)
+	VoidDebitNoteByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
+	StoreCompany(ctx context.Context, userID uuid.UUID, company *domain.Company) (err error)
+	UpdateCompanyByID(ctx context.Context, id int64, company *domain.Company) (err error)
+	AddCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error)
+	RemoveCompanyUser(ctx context.Context, companyID int64, userID uuid.UUID) (err error)
+	StoreGroupAccount(ctx context.Context, groupAccount *domain.GroupAccount) (err error)
+	UpdateGroupAccountByID(ctx context.Context, id int64, groupAccount *domain.GroupAccount) (err error)
+	MapAccountToGroupAccount(ctx context.Context, accountID int64, groupAccountID int64) (err error)
+	StoreIntercompanyTransaction(ctx context.Context, userID uuid.UUID, transaction *domain.IntercompanyTransaction) (err error)
+	VoidIntercompanyTransactionByID(ctx context.Context, id int64, userID uuid.UUID) (err error)
 }
 
 type writer struct {
 	AccountingSQL sql.SQL
+	Storage       storage.Storage
+}
+
+func (w *writer) StoreJournalDraft(ctx context.Context, userID uuid.UUID, transaction sql.Transaction) (draft *domain.JournalDraft, err error) {
+	return w.AccountingSQL.StoreJournalDraft(ctx, userID, transaction)
+}
+
+func (w *writer) UpdateJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction sql.Transaction) (draft *domain.JournalDraft, err error) {
+	return w.AccountingSQL.UpdateJournalDraftByID(ctx, id, userID, transaction)
+}
+
+func (w *writer) SubmitJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.SubmitJournalDraftByID(ctx, id, userID)
+}
+
+func (w *writer) ApproveJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error) {
+	return w.AccountingSQL.ApproveJournalDraftByID(ctx, id, userID, comment)
+}
+
+func (w *writer) RejectJournalDraftByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, comment string) (err error) {
+	return w.AccountingSQL.RejectJournalDraftByID(ctx, id, userID, comment)
+}
+
+func (w *writer) StoreApprovalRule(ctx context.Context, userID uuid.UUID, rule *domain.ApprovalRule) (err error) {
+	return w.AccountingSQL.StoreApprovalRule(ctx, userID, rule)
+}
+
+func (w *writer) UpdateApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID, rule *domain.ApprovalRule) (err error) {
+	return w.AccountingSQL.UpdateApprovalRuleByID(ctx, id, userID, rule)
+}
+
+func (w *writer) DeleteApprovalRuleByID(ctx context.Context, id int64, userID uuid.UUID) (err error) {
+	return w.AccountingSQL.DeleteApprovalRuleByID(ctx, id, userID)
 }
 
 func (w *writer) StoreBankDepositTransaction(ctx context.Context, userID uuid.UUID, transaction sql.BankTransaction) (bankTransaction domain.BankTransaction, err error) {
@@ -52,8 +160,33 @@ func (w *writer) CloseFiscalYear(ctx context.Context, id int64, userID uuid.UUID
 	return w.AccountingSQL.CloseFiscalYear(ctx, id, userID)
 }
 
-func (w *writer) StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear) (err error) {
-	return w.AccountingSQL.StoreFiscalYear(ctx, fiscalYear)
+func (w *writer) ReopenFiscalYear(ctx context.Context, id int64, userID uuid.UUID, reason string) (err error) {
+	return w.AccountingSQL.ReopenFiscalYear(ctx, id, userID, reason)
+}
+
+func (w *writer) ImportOpeningBalances(ctx context.Context, userID uuid.UUID, rows []sql.TransactionRow) (journal *domain.Journal, err error) {
+	return w.AccountingSQL.ImportOpeningBalances(ctx, userID, rows)
+}
+
+func (w *writer) ImportOpeningBalancesCSV(ctx context.Context, userID uuid.UUID, file io.Reader) (journal *domain.Journal, err error) {
+	rows, err := parseTransactionRowsCSV(file)
+	if err != nil {
+		return
+	}
+
+	return w.AccountingSQL.ImportOpeningBalances(ctx, userID, rows)
+}
+
+func (w *writer) StoreFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear, periodMonths int) (err error) {
+	return w.AccountingSQL.StoreFiscalYear(ctx, fiscalYear, periodMonths)
+}
+
+func (w *writer) GenerateFiscalPeriods(ctx context.Context, fiscalYearID int64, periodMonths int) (periods []domain.FiscalPeriod, err error) {
+	return w.AccountingSQL.GenerateFiscalPeriods(ctx, fiscalYearID, periodMonths)
+}
+
+func (w *writer) UpdateFiscalPeriodStatusByID(ctx context.Context, id int64, userID uuid.UUID, statusID int64, reason string) (err error) {
+	return w.AccountingSQL.UpdateFiscalPeriodStatusByID(ctx, id, userID, statusID, reason)
 }
 
 func (w *writer) UpdateGeneralLedgerPreferences(ctx context.Context, preferences []domain.GeneralLedgerPreference) (err error) {
@@ -64,6 +197,10 @@ func (w *writer) StoreTransaction(ctx context.Context, userID uuid.UUID, transac
 	return w.AccountingSQL.StoreTransaction(ctx, userID, transaction)
 }
 
+func (w *writer) AmendJournalByID(ctx context.Context, id uuid.UUID, userID uuid.UUID, transaction sql.Transaction, reason string) (journal *domain.Journal, err error) {
+	return w.AccountingSQL.AmendJournalByID(ctx, id, userID, transaction, reason)
+}
+
 func (w *writer) StoreAccount(ctx context.Context, account *domain.Account) (err error) {
 	return w.AccountingSQL.StoreAccount(ctx, account)
 }
@@ -76,6 +213,10 @@ func (w *writer) DeleteAccountByID(ctx context.Context, id int64) (err error) {
 	return w.AccountingSQL.DeleteAccountByID(ctx, id)
 }
 
+func (w *writer) UpdateAccountCodeFormatByClassTypeID(ctx context.Context, classTypeID int64, pattern string) (err error) {
+	return w.AccountingSQL.UpdateAccountCodeFormatByClassTypeID(ctx, classTypeID, pattern)
+}
+
 func (w *writer) StoreAccountGroup(ctx context.Context, accountClassGroup *domain.AccountGroup) (err error) {
 	return w.AccountingSQL.StoreAccountGroup(ctx, accountClassGroup)
 }
@@ -101,5 +242,9 @@ func (w *writer) StoreAccountClass(ctx context.Context, accountClasses *domain.A
 }
 
 func NewWriter(opt *Options) Writer {
-	return &writer{opt.AccountingSQL}
+	return &writer{opt.AccountingSQL, opt.Storage}
+}
+
+func (w *writer) UpdateJournalNumberFormatByTypeID(ctx context.Context, typeID int64, userID uuid.UUID, format string) (err error) {
+	return w.AccountingSQL.UpdateJournalNumberFormatByTypeID(ctx, typeID, userID, format)
 }
@@ -6,7 +6,12 @@ import (
 )
 
 func GetRequestID(ctx context.Context) string {
-	return ctx.Value(RequestIDKey).(string)
+	v, ok := ctx.Value(RequestIDKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return v
 }
 
 func SetRequestID(ctx context.Context, val string) context.Context {
@@ -38,3 +43,16 @@ func GetUserID(ctx context.Context) uuid.UUID {
 func SetUserID(ctx context.Context, val uuid.UUID) context.Context {
 	return context.WithValue(ctx, UserID, val)
 }
+
+func GetCompanyID(ctx context.Context) int64 {
+	v, ok := ctx.Value(CompanyID).(int64)
+	if !ok {
+		return 0
+	}
+
+	return v
+}
+
+func SetCompanyID(ctx context.Context, val int64) context.Context {
+	return context.WithValue(ctx, CompanyID, val)
+}
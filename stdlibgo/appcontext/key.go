@@ -4,4 +4,5 @@ const (
 	RequestIDKey = iota
 	BearerToken
 	UserID
+	CompanyID
 )
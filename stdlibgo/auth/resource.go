@@ -4,4 +4,11 @@ type Resource = int64
 
 const (
 	UOM Resource = iota + 1
+	FiscalPeriod
+	SoftLockedFiscalPeriod
+	FiscalYear
+	Journal
+	ApprovalRule
+	JournalPosting
+	JournalApproval
 )
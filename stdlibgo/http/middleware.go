@@ -4,6 +4,7 @@ import (
 	"github.com/QuickAmethyst/monosvc/stdlibgo/appcontext"
 	"github.com/google/uuid"
 	netHttp "net/http"
+	"strconv"
 )
 
 func appendHeaderToContext(handler netHttp.HandlerFunc) netHttp.HandlerFunc {
@@ -18,6 +19,10 @@ func appendHeaderToContext(handler netHttp.HandlerFunc) netHttp.HandlerFunc {
 		ctx = appcontext.SetRequestID(ctx, requestID)
 		ctx = appcontext.SetBearerToken(ctx, request.Header.Get("Authorization"))
 
+		if companyID, err := strconv.ParseInt(request.Header.Get("X-Company-ID"), 10, 64); err == nil {
+			ctx = appcontext.SetCompanyID(ctx, companyID)
+		}
+
 		handler(writer, request.WithContext(ctx))
 	}
 }
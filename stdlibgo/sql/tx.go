@@ -35,7 +35,7 @@ func (t *tx) QueryRowContext(ctx context.Context, query string, args ...interfac
 }
 
 func (t *tx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return t.GetContext(ctx, dest, query, args...)
+	return t.tx.GetContext(ctx, dest, query, args...)
 }
 
 func (t *tx) Updates(ctx context.Context, tableName string, dest interface{}, whereStruct interface{}) (sql.Result, error) {
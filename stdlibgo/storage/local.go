@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LocalOptions struct {
+	Root string
+}
+
+type local struct {
+	root string
+}
+
+func (l *local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", errors.New("storage: invalid key")
+	}
+
+	return filepath.Join(l.root, clean), nil
+}
+
+func (l *local) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) (err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	// Write to a temporary file first so a reader never sees a partially written object.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *local) Get(_ context.Context, key string) (body io.ReadCloser, err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+
+	body, err = os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = ErrNotFound
+	}
+
+	return
+}
+
+func (l *local) Delete(_ context.Context, key string) (err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+
+	if err = os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+
+	return
+}
+
+func NewLocal(opt LocalOptions) (Storage, error) {
+	if opt.Root == "" {
+		return nil, errors.New("storage: local root is required")
+	}
+
+	if err := os.MkdirAll(opt.Root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &local{root: opt.Root}, nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Options configures an S3-compatible backend. Requests use path-style addressing so the same
+// configuration works against AWS S3 and local stand-ins such as MinIO.
+type S3Options struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Timeout         time.Duration
+}
+
+type s3 struct {
+	endpoint *url.URL
+	opt      S3Options
+	client   *http.Client
+}
+
+func (s *s3) objectURL(key string) string {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opt.Bucket + "/" + strings.TrimPrefix(key, "/")
+	u.RawPath = uriEncode(u.Path)
+
+	return u.String()
+}
+
+func (s *s3) do(ctx context.Context, method string, key string, body io.Reader, size int64, contentType string) (res *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), body)
+	if err != nil {
+		return
+	}
+
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(req, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request.
+func (s *s3) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.opt.Region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + unsignedPayload + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opt.SecretAccessKey), date)
+	key = hmacSHA256(key, s.opt.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.opt.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func (s *s3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (err error) {
+	res, err := s.do(ctx, http.MethodPut, key, body, size, contentType)
+	if err != nil {
+		return
+	}
+
+	defer res.Body.Close()
+
+	return responseError(res)
+}
+
+func (s *s3) Get(ctx context.Context, key string) (body io.ReadCloser, err error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil, 0, "")
+	if err != nil {
+		return
+	}
+
+	if err = responseError(res); err != nil {
+		res.Body.Close()
+		return
+	}
+
+	return res.Body, nil
+}
+
+func (s *s3) Delete(ctx context.Context, key string) (err error) {
+	res, err := s.do(ctx, http.MethodDelete, key, nil, 0, "")
+	if err != nil {
+		return
+	}
+
+	defer res.Body.Close()
+
+	if err = responseError(res); errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+
+	return
+}
+
+func responseError(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return fmt.Errorf("storage: s3 responded %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// uriEncode escapes a path as required by the SigV4 canonical request, leaving slashes intact.
+func uriEncode(path string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
+
+func NewS3(opt S3Options) (Storage, error) {
+	if opt.Endpoint == "" || opt.Bucket == "" {
+		return nil, errors.New("storage: s3 endpoint and bucket are required")
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Region == "" {
+		opt.Region = "us-east-1"
+	}
+
+	if opt.Timeout == 0 {
+		opt.Timeout = 30 * time.Second
+	}
+
+	return &s3{endpoint: endpoint, opt: opt, client: &http.Client{Timeout: opt.Timeout}}, nil
+}
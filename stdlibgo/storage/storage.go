@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotFound = errors.New("storage: object not found")
+
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (err error)
+	Get(ctx context.Context, key string) (body io.ReadCloser, err error)
+	Delete(ctx context.Context, key string) (err error)
+}
+
+type Options struct {
+	Driver string
+	Local  LocalOptions
+	S3     S3Options
+}
+
+func New(opt Options) (Storage, error) {
+	switch opt.Driver {
+	case DriverLocal, "":
+		return NewLocal(opt.Local)
+	case DriverS3:
+		return NewS3(opt.S3)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", opt.Driver)
+	}
+}